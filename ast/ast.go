@@ -1,5 +1,7 @@
 package ast
 
+import "go-script/token"
+
 type Node interface{}
 
 type Statement interface {
@@ -40,6 +42,36 @@ type ReturnStatement struct {
 
 func (rs *ReturnStatement) statementNode() {}
 
+// ThrowStatement raises an exception
+//
+// Example: "throw \"invalid input\";" → ThrowStatement{Value: StringLiteral{"invalid input"}}
+type ThrowStatement struct {
+	Value Expression
+}
+
+func (ts *ThrowStatement) statementNode() {}
+
+// TryStatement runs Block and handles exceptions thrown inside it
+// Catch and Finally are optional, but at least one of them is present.
+// CatchParam is empty for "catch { ... }" without a binding.
+//
+// Example: "try { risky(); } catch (err) { print(err); } finally { cleanup(); }"
+//
+//	TryStatement{
+//	  Block: BlockStatement{risky()},
+//	  CatchParam: "err",
+//	  Catch: BlockStatement{print(err)},
+//	  Finally: BlockStatement{cleanup()},
+//	}
+type TryStatement struct {
+	Block      *BlockStatement
+	CatchParam string
+	Catch      *BlockStatement
+	Finally    *BlockStatement
+}
+
+func (ts *TryStatement) statementNode() {}
+
 type ExpressionStatement struct {
 	Expression Expression
 }
@@ -67,8 +99,27 @@ type WhileStatement struct {
 
 func (ws *WhileStatement) statementNode() {}
 
+// ForOfStatement loops over the values produced by an iterable
+// Kind is "var" or "let" when the loop declares its variable, "" when it
+// assigns to an existing one. Await is set for "for await", which awaits
+// every value of an async iterable.
+//
+// Example: "for (let item of items) { print(item); }"
+//
+//	ForOfStatement{Kind: "let", Name: "item", Iterable: Identifier{"items"}, Body: BlockStatement{...}}
+type ForOfStatement struct {
+	Kind     string
+	Name     string
+	Iterable Expression
+	Body     *BlockStatement
+	Await    bool
+}
+
+func (fs *ForOfStatement) statementNode() {}
+
 type Identifier struct {
 	Name string
+	Pos  token.Position
 }
 
 func (i *Identifier) expressionNode() {}
@@ -85,12 +136,28 @@ type StringLiteral struct {
 
 func (sl *StringLiteral) expressionNode() {}
 
+// RegExpLiteral is a regular expression literal; each evaluation creates a
+// new RegExp object
+//
+// Example: "/ab+c/gi" → RegExpLiteral{Pattern: "ab+c", Flags: "gi"}
+type RegExpLiteral struct {
+	Pattern string
+	Flags   string
+}
+
+func (rl *RegExpLiteral) expressionNode() {}
+
 type BooleanLiteral struct {
 	Value bool
 }
 
 func (bl *BooleanLiteral) expressionNode() {}
 
+// NullLiteral represents the null keyword
+type NullLiteral struct{}
+
+func (nl *NullLiteral) expressionNode() {}
+
 type PrefixExpression struct {
 	Operator string     // The operator: "-" (negation) or "!" (logical NOT)
 	Right    Expression // The operand expression
@@ -116,6 +183,9 @@ func (ae *AssignExpression) expressionNode() {}
 type FunctionLiteral struct {
 	Parameters []string
 	Body       *BlockStatement
+	Generator  bool // function* () { ... }: calling it returns a generator object
+	Async      bool // async function () { ... }: calling it returns a promise
+	Arrow      bool // (a) => ...: uses the this of the enclosing code
 }
 
 func (fl *FunctionLiteral) expressionNode() {}
@@ -123,12 +193,43 @@ func (fl *FunctionLiteral) expressionNode() {}
 type CallExpression struct {
 	Function  Expression
 	Arguments []Expression
+	Optional  bool           // f?.(): skip the call when f is null or undefined
+	Pos       token.Position // The opening parenthesis
 }
 
 func (ce *CallExpression) expressionNode() {}
 
+// PropertyKind tells the evaluator how to install an object literal property
+type PropertyKind string
+
+const (
+	PropertyInit   PropertyKind = "init" // key: value, shorthand and methods
+	PropertyGetter PropertyKind = "get"
+	PropertySetter PropertyKind = "set"
+)
+
+// ObjectProperty is a single entry of an object literal
+//
+// Examples:
+//
+//	"name: "John""   → ObjectProperty{Kind: PropertyInit, Key: "name", Value: StringLiteral{"John"}}
+//	"name"           → ObjectProperty{Kind: PropertyInit, Key: "name", Value: Identifier{"name"}, Shorthand: true}
+//	"[key]: 1"       → ObjectProperty{Kind: PropertyInit, Computed: Identifier{"key"}, Value: NumberLiteral{1}}
+//	"greet() {...}"  → ObjectProperty{Kind: PropertyInit, Key: "greet", Value: FunctionLiteral{...}, Method: true}
+//	"get size() {}"  → ObjectProperty{Kind: PropertyGetter, Key: "size", Value: FunctionLiteral{...}}
+type ObjectProperty struct {
+	Kind      PropertyKind
+	Key       string     // Static property name (numeric keys are stored in canonical form)
+	Computed  Expression // Key expression for [expr] keys, nil otherwise
+	Value     Expression
+	Shorthand bool // {name} is short for {name: name}
+	Method    bool // Defined with method syntax, so it may use super
+}
+
+// ObjectLiteral holds its properties in source order, which is the
+// order their values are evaluated in
 type ObjectLiteral struct {
-	Pairs map[string]Expression
+	Properties []*ObjectProperty
 }
 
 func (ol *ObjectLiteral) expressionNode() {}
@@ -142,13 +243,273 @@ func (al *ArrayLiteral) expressionNode() {}
 type PropertyAccess struct {
 	Object   Expression
 	Property string
+	Optional bool           // obj?.name
+	Pos      token.Position // The property name
 }
 
 func (pa *PropertyAccess) expressionNode() {}
 
 type IndexExpression struct {
-	Left  Expression // The array or object being indexed
-	Index Expression // The index value
+	Left     Expression     // The array or object being indexed
+	Index    Expression     // The index value
+	Optional bool           // obj?.[key]
+	Pos      token.Position // The opening bracket
 }
 
 func (ie *IndexExpression) expressionNode() {}
+
+// YieldExpression pauses a generator and hands a value to its caller
+// Delegate is set for yield*, which yields every value of another iterable.
+//
+// Examples:
+//
+//	"yield x"        → YieldExpression{Argument: Identifier{"x"}}
+//	"yield* other()" → YieldExpression{Argument: CallExpression{...}, Delegate: true}
+//	"var input = yield;" → YieldExpression{} (the value passed to next() becomes input)
+type YieldExpression struct {
+	Argument Expression // nil for a bare "yield"
+	Delegate bool
+}
+
+func (ye *YieldExpression) expressionNode() {}
+
+// AwaitExpression pauses an async function until a promise settles
+// At the top level of a script it blocks the script instead.
+//
+// Example: "var res = await fetch(url);" → AwaitExpression{Argument: CallExpression{...}}
+type AwaitExpression struct {
+	Argument Expression
+}
+
+func (ae *AwaitExpression) expressionNode() {}
+
+// ConditionalExpression represents the ternary operator
+//
+// Example: "age >= 18 ? \"adult\" : \"minor\""
+//
+//	ConditionalExpression{
+//	  Condition: InfixExpression{Identifier{"age"}, ">=", NumberLiteral{18}},
+//	  Consequence: StringLiteral{"adult"},
+//	  Alternative: StringLiteral{"minor"},
+//	}
+type ConditionalExpression struct {
+	Condition   Expression
+	Consequence Expression
+	Alternative Expression
+}
+
+func (ce *ConditionalExpression) expressionNode() {}
+
+// SequenceExpression represents the comma operator: every expression is
+// evaluated from left to right and the last value is the result
+//
+// Example: "(x = 1, y = 2, x + y)"
+//
+//	SequenceExpression{Expressions: [AssignExpression{x}, AssignExpression{y}, InfixExpression{+}]}
+type SequenceExpression struct {
+	Expressions []Expression
+}
+
+func (se *SequenceExpression) expressionNode() {}
+
+// OptionalChain wraps a member/call chain that contains at least one ?. link.
+// When an optional link meets null or undefined, the rest of the chain is
+// skipped and the whole OptionalChain evaluates to undefined.
+//
+// Example: "user?.address.street"
+//
+//	OptionalChain{
+//	  Expression: PropertyAccess{
+//	    Object: PropertyAccess{Object: Identifier{"user"}, Property: "address", Optional: true},
+//	    Property: "street",
+//	  },
+//	}
+//
+//	user is undefined → undefined (".street" is never evaluated)
+type OptionalChain struct {
+	Expression Expression
+}
+
+func (oc *OptionalChain) expressionNode() {}
+
+// MemberAssignExpression assigns to an object property rather than a variable.
+// Target is either a PropertyAccess or an IndexExpression.
+//
+// Examples:
+//
+//	"this.x = 5"    → MemberAssignExpression{Target: PropertyAccess{...}, Value: NumberLiteral{5}}
+//	"obj[key] = v"  → MemberAssignExpression{Target: IndexExpression{...}, Value: Identifier{"v"}}
+type MemberAssignExpression struct {
+	Target Expression
+	Value  Expression
+}
+
+func (ma *MemberAssignExpression) expressionNode() {}
+
+// ThisExpression is the `this` keyword
+type ThisExpression struct {
+	Pos token.Position
+}
+
+func (te *ThisExpression) expressionNode() {}
+
+// SuperExpression is the `super` keyword. It only makes sense as the callee of
+// a call (super(...)) or as the object of a property access (super.method).
+type SuperExpression struct {
+	Pos token.Position
+}
+
+func (se *SuperExpression) expressionNode() {}
+
+// NewExpression creates an instance of a class
+//
+// Example: "new Point(1, 2)" → NewExpression{Callee: Identifier{"Point"}, Arguments: [...]}
+type NewExpression struct {
+	Callee    Expression
+	Arguments []Expression
+	Pos       token.Position // The new keyword
+}
+
+func (ne *NewExpression) expressionNode() {}
+
+// ClassMemberKind tells the evaluator how to install a class member
+type ClassMemberKind string
+
+const (
+	ClassMethod      ClassMemberKind = "method"
+	ClassGetter      ClassMemberKind = "get"
+	ClassSetter      ClassMemberKind = "set"
+	ClassField       ClassMemberKind = "field"
+	ClassStaticBlock ClassMemberKind = "static"
+)
+
+// ClassMember is a single entry in a class body
+//
+// Examples:
+//
+//	"area() { ... }"     → ClassMember{Kind: ClassMethod, Key: "area", Value: FunctionLiteral{...}}
+//	"static count = 0;"  → ClassMember{Kind: ClassField, Key: "count", Static: true, Value: NumberLiteral{0}}
+//	"get x() { ... }"    → ClassMember{Kind: ClassGetter, Key: "x", Value: FunctionLiteral{...}}
+//	"#secret = 1;"       → ClassMember{Kind: ClassField, Key: "#secret", Value: NumberLiteral{1}}
+//	"static { ... }"     → ClassMember{Kind: ClassStaticBlock, Static: true, Body: BlockStatement{...}}
+type ClassMember struct {
+	Kind     ClassMemberKind
+	Key      string     // Property name; private names keep their leading '#'
+	Computed Expression // Key expression for [expr] names, e.g. [Symbol.iterator]() { ... }
+	Static   bool       // Installed on the class itself rather than on instances
+	Value    Expression // FunctionLiteral for methods/accessors, initializer for fields (may be nil)
+	Body     *BlockStatement
+}
+
+// ClassLiteral is a class definition, used both for declarations and expressions
+//
+// Example:
+//
+//	"class Dog extends Animal { constructor(name) { super(name); } bark() { ... } }"
+//	→ ClassLiteral{
+//	    Name: "Dog",
+//	    SuperClass: Identifier{"Animal"},
+//	    Constructor: FunctionLiteral{...},
+//	    Members: [ClassMember{Kind: ClassMethod, Key: "bark", ...}]
+//	  }
+type ClassLiteral struct {
+	Name        string
+	SuperClass  Expression       // nil when there is no extends clause
+	ExtendsPos  token.Position   // The parent class expression
+	Constructor *FunctionLiteral // nil when the class has no explicit constructor
+	Members     []*ClassMember
+}
+
+func (cl *ClassLiteral) expressionNode() {}
+
+// ClassDeclaration binds a class to its name in the current scope
+//
+// Example: "class Point { ... }" → ClassDeclaration{Class: ClassLiteral{Name: "Point", ...}}
+type ClassDeclaration struct {
+	Class *ClassLiteral
+}
+
+func (cd *ClassDeclaration) statementNode() {}
+
+// ImportSpecifier binds one export of another module to a local name
+//
+// Example: "import { a as b } from \"./m.js\"" → ImportSpecifier{Imported: "a", Local: "b"}
+type ImportSpecifier struct {
+	Imported string
+	Local    string
+}
+
+// ImportDeclaration binds exports of another module in the current module
+// Default and Namespace are empty when the declaration doesn't use them;
+// "import \"./m.js\";" only runs the module.
+//
+// Example: "import greet, { a, b as c } from \"./m.js\";"
+//
+//	ImportDeclaration{
+//	  Source: "./m.js",
+//	  Default: "greet",
+//	  Specifiers: [{Imported: "a", Local: "a"}, {Imported: "b", Local: "c"}],
+//	}
+//
+// Example: "import * as m from \"./m.js\";" → ImportDeclaration{Source: "./m.js", Namespace: "m"}
+type ImportDeclaration struct {
+	Source     string
+	Default    string
+	Namespace  string
+	Specifiers []*ImportSpecifier
+}
+
+func (id *ImportDeclaration) statementNode() {}
+
+// ExportSpecifier makes a binding available to other modules under a name
+//
+// Example: "export { total as sum };" → ExportSpecifier{Local: "total", Exported: "sum"}
+type ExportSpecifier struct {
+	Local    string
+	Exported string
+}
+
+// ExportDeclaration exports the bindings of a declaration, a list of
+// bindings, or bindings of another module (a re-export, when Source is set)
+//
+// Examples:
+//
+//	"export var x = 1;"                    → ExportDeclaration{Declaration: VarStatement{x}}
+//	"export { a, b as c };"                → ExportDeclaration{Specifiers: [{a, a}, {b, c}]}
+//	"export { a } from \"./m.js\";"        → ExportDeclaration{Specifiers: [{a, a}], Source: "./m.js"}
+//	"export * from \"./m.js\";"            → ExportDeclaration{Source: "./m.js", All: true}
+//	"export * as m from \"./m.js\";"       → ExportDeclaration{Source: "./m.js", All: true, Namespace: "m"}
+type ExportDeclaration struct {
+	Declaration Statement // var, let or class
+	Specifiers  []*ExportSpecifier
+	Source      string
+	All         bool
+	Namespace   string
+}
+
+func (ed *ExportDeclaration) statementNode() {}
+
+// ExportDefaultDeclaration exports a value as the module's default export
+// A named class is also declared in the module; anything else is evaluated
+// once and only reachable through the default export.
+//
+// Examples:
+//
+//	"export default 42;"              → ExportDefaultDeclaration{Value: NumberLiteral{42}}
+//	"export default class Point {}"   → ExportDefaultDeclaration{Declaration: ClassDeclaration{Point}}
+type ExportDefaultDeclaration struct {
+	Value       Expression
+	Declaration Statement
+}
+
+func (ed *ExportDefaultDeclaration) statementNode() {}
+
+// ImportExpression loads a module at run time and evaluates to a promise
+// for its namespace object
+//
+// Example: "import(\"./m.js\")" → ImportExpression{Source: StringLiteral{"./m.js"}}
+type ImportExpression struct {
+	Source Expression
+}
+
+func (ie *ImportExpression) expressionNode() {}
@@ -284,37 +284,37 @@ func TestCallExpressionCreation(t *testing.T) {
 
 func TestObjectLiteralCreation(t *testing.T) {
 	obj := &ObjectLiteral{
-		Pairs: map[string]Expression{
-			"name": &StringLiteral{Value: "John"},
-			"age":  &NumberLiteral{Value: 30},
+		Properties: []*ObjectProperty{
+			{Kind: PropertyInit, Key: "name", Value: &StringLiteral{Value: "John"}},
+			{Kind: PropertyInit, Key: "age", Value: &NumberLiteral{Value: 30}},
 		},
 	}
 
-	if len(obj.Pairs) != 2 {
-		t.Errorf("ObjectLiteral should have 2 pairs, got %d", len(obj.Pairs))
+	if len(obj.Properties) != 2 {
+		t.Errorf("ObjectLiteral should have 2 properties, got %d", len(obj.Properties))
 	}
 
-	nameVal, ok := obj.Pairs["name"]
-	if !ok {
-		t.Error("ObjectLiteral should have 'name' key")
+	nameProp := obj.Properties[0]
+	if nameProp.Key != "name" {
+		t.Errorf("First property should be 'name', got '%s'", nameProp.Key)
 	}
 
-	strLit, ok := nameVal.(*StringLiteral)
+	strLit, ok := nameProp.Value.(*StringLiteral)
 	if !ok {
-		t.Errorf("'name' value should be *StringLiteral, got %T", nameVal)
+		t.Errorf("'name' value should be *StringLiteral, got %T", nameProp.Value)
 	}
 	if strLit.Value != "John" {
 		t.Errorf("'name' value should be 'John', got '%s'", strLit.Value)
 	}
 
-	ageVal, ok := obj.Pairs["age"]
-	if !ok {
-		t.Error("ObjectLiteral should have 'age' key")
+	ageProp := obj.Properties[1]
+	if ageProp.Key != "age" {
+		t.Errorf("Second property should be 'age', got '%s'", ageProp.Key)
 	}
 
-	numLit, ok := ageVal.(*NumberLiteral)
+	numLit, ok := ageProp.Value.(*NumberLiteral)
 	if !ok {
-		t.Errorf("'age' value should be *NumberLiteral, got %T", ageVal)
+		t.Errorf("'age' value should be *NumberLiteral, got %T", ageProp.Value)
 	}
 	if numLit.Value != 30 {
 		t.Errorf("'age' value should be 30, got %f", numLit.Value)
@@ -423,6 +423,63 @@ func TestIndexExpressionCreation(t *testing.T) {
 	}
 }
 
+func TestClassLiteralCreation(t *testing.T) {
+	class := &ClassLiteral{
+		Name:       "Dog",
+		SuperClass: &Identifier{Name: "Animal"},
+		Constructor: &FunctionLiteral{
+			Parameters: []string{"name"},
+			Body:       &BlockStatement{},
+		},
+		Members: []*ClassMember{
+			{Kind: ClassMethod, Key: "bark", Value: &FunctionLiteral{Body: &BlockStatement{}}},
+			{Kind: ClassField, Key: "#legs", Value: &NumberLiteral{Value: 4}},
+			{Kind: ClassStaticBlock, Static: true, Body: &BlockStatement{}},
+		},
+	}
+
+	if class.Name != "Dog" {
+		t.Errorf("ClassLiteral name should be 'Dog', got '%s'", class.Name)
+	}
+
+	parent, ok := class.SuperClass.(*Identifier)
+	if !ok || parent.Name != "Animal" {
+		t.Errorf("SuperClass should be Identifier 'Animal', got %v", class.SuperClass)
+	}
+
+	if len(class.Constructor.Parameters) != 1 {
+		t.Errorf("Constructor should have 1 parameter, got %d", len(class.Constructor.Parameters))
+	}
+
+	if len(class.Members) != 3 {
+		t.Fatalf("ClassLiteral should have 3 members, got %d", len(class.Members))
+	}
+
+	if class.Members[1].Kind != ClassField || class.Members[1].Key != "#legs" {
+		t.Errorf("Second member should be field '#legs', got %s %q", class.Members[1].Kind, class.Members[1].Key)
+	}
+
+	if !class.Members[2].Static || class.Members[2].Body == nil {
+		t.Error("Third member should be a static block with a body")
+	}
+}
+
+func TestNewExpressionCreation(t *testing.T) {
+	exp := &NewExpression{
+		Callee:    &Identifier{Name: "Point"},
+		Arguments: []Expression{&NumberLiteral{Value: 1}, &NumberLiteral{Value: 2}},
+	}
+
+	callee, ok := exp.Callee.(*Identifier)
+	if !ok || callee.Name != "Point" {
+		t.Errorf("Callee should be Identifier 'Point', got %v", exp.Callee)
+	}
+
+	if len(exp.Arguments) != 2 {
+		t.Errorf("NewExpression should have 2 arguments, got %d", len(exp.Arguments))
+	}
+}
+
 func TestInterfaceImplementation(t *testing.T) {
 	var _ Statement = (*VarStatement)(nil)
 	var _ Statement = (*ReturnStatement)(nil)
@@ -430,6 +487,13 @@ func TestInterfaceImplementation(t *testing.T) {
 	var _ Statement = (*BlockStatement)(nil)
 	var _ Statement = (*IfStatement)(nil)
 	var _ Statement = (*WhileStatement)(nil)
+	var _ Statement = (*ClassDeclaration)(nil)
+	var _ Statement = (*ForOfStatement)(nil)
+	var _ Statement = (*ThrowStatement)(nil)
+	var _ Statement = (*TryStatement)(nil)
+	var _ Statement = (*ImportDeclaration)(nil)
+	var _ Statement = (*ExportDeclaration)(nil)
+	var _ Statement = (*ExportDefaultDeclaration)(nil)
 
 	var _ Expression = (*Identifier)(nil)
 	var _ Expression = (*NumberLiteral)(nil)
@@ -443,6 +507,18 @@ func TestInterfaceImplementation(t *testing.T) {
 	var _ Expression = (*ObjectLiteral)(nil)
 	var _ Expression = (*PropertyAccess)(nil)
 	var _ Expression = (*IndexExpression)(nil)
+	var _ Expression = (*MemberAssignExpression)(nil)
+	var _ Expression = (*ThisExpression)(nil)
+	var _ Expression = (*SuperExpression)(nil)
+	var _ Expression = (*NewExpression)(nil)
+	var _ Expression = (*ClassLiteral)(nil)
+	var _ Expression = (*NullLiteral)(nil)
+	var _ Expression = (*OptionalChain)(nil)
+	var _ Expression = (*ConditionalExpression)(nil)
+	var _ Expression = (*SequenceExpression)(nil)
+	var _ Expression = (*YieldExpression)(nil)
+	var _ Expression = (*AwaitExpression)(nil)
+	var _ Expression = (*ImportExpression)(nil)
 }
 
 func TestComplexAST(t *testing.T) {
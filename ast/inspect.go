@@ -0,0 +1,135 @@
+package ast
+
+// Inspect traverses the tree rooted at node depth-first, calling f for every
+// node before its children. If f returns false, the children of that node
+// are skipped.
+//
+// Class members (*ClassMember) and object literal properties
+// (*ObjectProperty) are visited as nodes of their own.
+//
+// Example: collect every identifier in a program
+//
+//	ast.Inspect(program, func(n ast.Node) bool {
+//	    if id, ok := n.(*ast.Identifier); ok {
+//	        names = append(names, id.Name)
+//	    }
+//	    return true
+//	})
+func Inspect(node Node, f func(Node) bool) {
+	if node == nil || !f(node) {
+		return
+	}
+
+	switch n := node.(type) {
+	case *Program:
+		inspectStatements(n.Statements, f)
+	case *VarStatement:
+		Inspect(n.Value, f)
+	case *ReturnStatement:
+		Inspect(n.Value, f)
+	case *ThrowStatement:
+		Inspect(n.Value, f)
+	case *TryStatement:
+		inspectBlock(n.Block, f)
+		inspectBlock(n.Catch, f)
+		inspectBlock(n.Finally, f)
+	case *ExpressionStatement:
+		Inspect(n.Expression, f)
+	case *BlockStatement:
+		inspectStatements(n.Statements, f)
+	case *IfStatement:
+		Inspect(n.Condition, f)
+		inspectBlock(n.Consequence, f)
+		Inspect(n.Alternative, f)
+	case *WhileStatement:
+		Inspect(n.Condition, f)
+		inspectBlock(n.Body, f)
+	case *ForOfStatement:
+		Inspect(n.Iterable, f)
+		inspectBlock(n.Body, f)
+	case *PrefixExpression:
+		Inspect(n.Right, f)
+	case *InfixExpression:
+		Inspect(n.Left, f)
+		Inspect(n.Right, f)
+	case *AssignExpression:
+		Inspect(n.Value, f)
+	case *FunctionLiteral:
+		inspectBlock(n.Body, f)
+	case *CallExpression:
+		Inspect(n.Function, f)
+		inspectExpressions(n.Arguments, f)
+	case *ObjectLiteral:
+		for _, property := range n.Properties {
+			Inspect(property, f)
+		}
+	case *ObjectProperty:
+		Inspect(n.Computed, f)
+		Inspect(n.Value, f)
+	case *ArrayLiteral:
+		inspectExpressions(n.Elements, f)
+	case *PropertyAccess:
+		Inspect(n.Object, f)
+	case *IndexExpression:
+		Inspect(n.Left, f)
+		Inspect(n.Index, f)
+	case *YieldExpression:
+		Inspect(n.Argument, f)
+	case *AwaitExpression:
+		Inspect(n.Argument, f)
+	case *ConditionalExpression:
+		Inspect(n.Condition, f)
+		Inspect(n.Consequence, f)
+		Inspect(n.Alternative, f)
+	case *SequenceExpression:
+		inspectExpressions(n.Expressions, f)
+	case *OptionalChain:
+		Inspect(n.Expression, f)
+	case *MemberAssignExpression:
+		Inspect(n.Target, f)
+		Inspect(n.Value, f)
+	case *NewExpression:
+		Inspect(n.Callee, f)
+		inspectExpressions(n.Arguments, f)
+	case *ClassLiteral:
+		Inspect(n.SuperClass, f)
+		if n.Constructor != nil {
+			Inspect(n.Constructor, f)
+		}
+		for _, member := range n.Members {
+			Inspect(member, f)
+		}
+	case *ClassMember:
+		Inspect(n.Computed, f)
+		Inspect(n.Value, f)
+		inspectBlock(n.Body, f)
+	case *ClassDeclaration:
+		Inspect(n.Class, f)
+	case *ExportDeclaration:
+		Inspect(n.Declaration, f)
+	case *ExportDefaultDeclaration:
+		Inspect(n.Value, f)
+		Inspect(n.Declaration, f)
+	case *ImportExpression:
+		Inspect(n.Source, f)
+	}
+}
+
+// inspectBlock inspects a block that may be missing, like an absent finally
+func inspectBlock(block *BlockStatement, f func(Node) bool) {
+	if block != nil {
+		Inspect(block, f)
+	}
+}
+
+func inspectStatements(statements []Statement, f func(Node) bool) {
+	for _, statement := range statements {
+		Inspect(statement, f)
+	}
+}
+
+func inspectExpressions(expressions []Expression, f func(Node) bool) {
+	for _, expression := range expressions {
+		Inspect(expression, f)
+	}
+}
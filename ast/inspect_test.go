@@ -0,0 +1,63 @@
+package ast
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInspect(t *testing.T) {
+	// if (a) { f(b); } else { return c.d; }, with a missing finally block
+	program := &Program{
+		Statements: []Statement{
+			&IfStatement{
+				Condition: &Identifier{Name: "a"},
+				Consequence: &BlockStatement{Statements: []Statement{
+					&ExpressionStatement{Expression: &CallExpression{
+						Function:  &Identifier{Name: "f"},
+						Arguments: []Expression{&Identifier{Name: "b"}},
+					}},
+				}},
+				Alternative: &BlockStatement{Statements: []Statement{
+					&ReturnStatement{Value: &PropertyAccess{Object: &Identifier{Name: "c"}, Property: "d"}},
+				}},
+			},
+			&TryStatement{Block: &BlockStatement{}},
+		},
+	}
+
+	var names []string
+	Inspect(program, func(n Node) bool {
+		if id, ok := n.(*Identifier); ok {
+			names = append(names, id.Name)
+		}
+		return true
+	})
+
+	if expected := []string{"a", "f", "b", "c"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("Expected identifiers %v in source order, got %v", expected, names)
+	}
+}
+
+func TestInspectSkipsChildren(t *testing.T) {
+	class := &ClassLiteral{
+		Members: []*ClassMember{
+			{Kind: ClassField, Key: "x", Value: &Identifier{Name: "inside"}},
+		},
+	}
+	program := &Program{Statements: []Statement{
+		&ExpressionStatement{Expression: &ArrayLiteral{Elements: []Expression{class, &Identifier{Name: "outside"}}}},
+	}}
+
+	var names []string
+	Inspect(program, func(n Node) bool {
+		if id, ok := n.(*Identifier); ok {
+			names = append(names, id.Name)
+		}
+		_, isClass := n.(*ClassLiteral)
+		return !isClass
+	})
+
+	if expected := []string{"outside"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("Expected the class body to be skipped, got %v", names)
+	}
+}
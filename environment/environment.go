@@ -3,6 +3,7 @@ package environment
 import (
 	"go-script/evaluator/builtins"
 	"go-script/internal"
+	"math"
 )
 
 // Environment stores variables and handles scoping
@@ -12,28 +13,102 @@ import (
 //
 //	Function scope can access global variables through the parent chain
 type Environment struct {
-	store map[string]internal.Value // Variables in this scope
-	outer *Environment     // Parent scope (nil for global scope)
+	store   map[string]internal.Value // Variables in this scope
+	outer   *Environment              // Parent scope (nil for global scope)
+	imports map[string]importBinding  // Live bindings to variables of other scopes (module imports)
+	loop    *internal.EventLoop       // Event loop of the realm, shared by every scope in it
+	strict  bool                      // Code in this scope runs in strict mode
+}
+
+// importBinding points at a variable in another scope
+type importBinding struct {
+	env  *Environment
+	name string
 }
 
 // Global environment with built-in functions such as JSON namespace
 func NewGlobalEnvironment() *Environment {
 	env := New(nil)
 
-	jsonObj := make(internal.Object)
+	// undefined is a global binding, not a keyword; Go's nil stands for it
+	env.Set("undefined", nil)
+	env.Set("NaN", math.NaN())
+	env.Set("Infinity", math.Inf(1))
+
+	jsonObj := internal.NewObject()
 	for name, builtin := range builtins.GetJSON() {
-		jsonObj[name] = builtin
+		jsonObj.Set(name, builtin)
 	}
 	env.Set("JSON", jsonObj)
 
+	mathObj := internal.NewObject()
+	for name, member := range builtins.GetMath() {
+		if constant, ok := member.(float64); ok {
+			// Constants like Math.PI can't be changed, deleted or listed
+			mathObj.Define(name, &internal.Property{Value: constant, NonWritable: true, NonEnumerable: true, NonConfigurable: true})
+			continue
+		}
+		mathObj.Set(name, member)
+	}
+	env.Set("Math", mathObj)
+	env.Set("console", builtins.GetConsole())
+
+	env.Set("Object", builtins.GetObject())
+	env.Set("Symbol", builtins.GetSymbol())
+	env.Set("Promise", builtins.GetPromise(env.loop))
+	env.Set("RegExp", builtins.GetRegExp())
+	env.Set("Array", builtins.GetArray())
+	env.Set("Number", builtins.GetNumber())
+	env.Set("Boolean", builtins.GetBoolean())
+	env.Set("Date", builtins.GetDate())
+	for name, constructor := range builtins.GetCollections() {
+		env.Set(name, constructor)
+	}
+	for name, constructor := range builtins.GetErrors() {
+		env.Set(name, constructor)
+	}
+	for name, builtin := range builtins.GetGlobalFunctions(env.loop) {
+		env.Set(name, builtin)
+	}
+	for name, builtin := range builtins.GetTimers(env.loop) {
+		env.Set(name, builtin)
+	}
+
 	return env
 }
 
 func New(outer *Environment) *Environment {
-	return &Environment{
+	env := &Environment{
 		store: make(map[string]internal.Value),
 		outer: outer,
 	}
+	if outer != nil {
+		env.loop = outer.loop
+		env.strict = outer.strict
+	} else {
+		env.loop = internal.NewEventLoop()
+	}
+	return env
+}
+
+// EventLoop returns the event loop of the realm the scope belongs to: the
+// one its promises, timers and pending operations run on
+func (e *Environment) EventLoop() *internal.EventLoop {
+	return e.loop
+}
+
+// Strict reports whether code running in the scope is strict mode code:
+// a failed assignment to a property throws a TypeError there instead of
+// doing nothing. Scopes created inside a strict one are strict too.
+func (e *Environment) Strict() bool {
+	return e.strict
+}
+
+// SetStrict switches strict mode on or off for the scope, as a "use strict"
+// directive, a module or a class body does. Scopes created afterwards
+// inherit it.
+func (e *Environment) SetStrict(strict bool) {
+	e.strict = strict
 }
 
 // Get retrieves a variable value from this scope or any parent scope
@@ -44,12 +119,42 @@ func New(outer *Environment) *Environment {
 //  2. If not found, check parent scope
 //  3. Continue up the chain until found or reach global scope
 func (e *Environment) Get(name string) (internal.Value, bool) {
-	val, ok := e.store[name]
-	if !ok && e.outer != nil {
+	if val, ok := e.getOwn(name); ok {
+		return val, true
+	}
+	if e.outer != nil {
 		// Not in this scope, check parent scope
 		return e.outer.Get(name)
 	}
-	return val, ok
+	return nil, false
+}
+
+// getOwn looks a variable up in this scope only, following import bindings
+// An import whose target isn't initialized yet reads as undefined.
+func (e *Environment) getOwn(name string) (internal.Value, bool) {
+	if val, ok := e.store[name]; ok {
+		return val, true
+	}
+	if binding, ok := e.imports[name]; ok {
+		val, _ := binding.env.getOwn(binding.name)
+		return val, true
+	}
+	return nil, false
+}
+
+// Import creates a live binding: name in this scope reads the variable
+// target in the scope from, and sees every later change to it.
+// Assigning to an imported name throws a TypeError.
+//
+// Example: module main does "import { count } from './counter.js'"
+//
+//	mainEnv.Import("count", counterEnv, "count")
+//	counter.js runs count = count + 1 → main reads the new count
+func (e *Environment) Import(name string, from *Environment, target string) {
+	if e.imports == nil {
+		e.imports = make(map[string]importBinding)
+	}
+	e.imports[name] = importBinding{env: from, name: target}
 }
 
 func (e *Environment) Set(name string, val internal.Value) internal.Value {
@@ -71,6 +176,9 @@ func (e *Environment) Update(name string, val internal.Value) internal.Value {
 		e.store[name] = val
 		return val
 	}
+	if _, ok := e.imports[name]; ok {
+		internal.ThrowError("TypeError", "Assignment to constant variable.")
+	}
 
 	// Check if variable exists in parent scopes
 	if e.outer != nil {
@@ -1,6 +1,7 @@
 package environment
 
 import (
+	"go-script/internal"
 	"testing"
 )
 
@@ -77,3 +78,36 @@ func TestEnvironmentUpdate(t *testing.T) {
 		t.Error("Update should modify variable in outer scope")
 	}
 }
+
+func TestEnvironmentImport(t *testing.T) {
+	exporter := New(nil)
+	importer := New(nil)
+
+	importer.Import("count", exporter, "total")
+
+	// Declared but not initialized yet: reads as undefined
+	val, ok := importer.Get("count")
+	if !ok || val != nil {
+		t.Errorf("Expected an uninitialized import to read as undefined, got %v (found=%v)", val, ok)
+	}
+
+	exporter.Set("total", 1.0)
+	if val, _ := importer.Get("count"); val != 1.0 {
+		t.Errorf("Expected 1, got %v", val)
+	}
+
+	// Live binding: later updates are visible
+	exporter.Update("total", 2.0)
+	inner := New(importer)
+	if val, _ := inner.Get("count"); val != 2.0 {
+		t.Errorf("Expected the import to see the update, got %v", val)
+	}
+
+	exception := internal.Catch(func() { inner.Update("count", 3.0) })
+	if exception == nil || !internal.IsError(exception.Value) || internal.ErrorString(exception.Value) != "TypeError: Assignment to constant variable." {
+		t.Errorf("Expected assigning to an import to throw a TypeError, got %v", exception)
+	}
+	if val, _ := exporter.Get("total"); val != 2.0 {
+		t.Errorf("Expected the exporter's variable to be unchanged, got %v", val)
+	}
+}
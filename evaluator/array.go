@@ -1,116 +1,529 @@
 package evaluator
 
 import (
-	"go-script/environment"
 	"go-script/evaluator/builtins/array"
 	"go-script/internal"
+	"math"
+	"sort"
+	"strconv"
+	"unicode/utf16"
 )
 
+// GetArrayProperty returns the length and methods of arrays
+// Methods are bound to the array; those that take a callback call it with
+// the element, its index and the array, visiting only the elements present
+// when the method was called.
+//
+// Methods that change the array (push, pop, shift, unshift, splice, sort,
+// reverse, fill) work in place, and throw a TypeError when Object.freeze,
+// seal or preventExtensions forbids the change. toSorted, toReversed and
+// with return a changed copy instead.
+//
+// Examples:
+//
+//	[1, 2, 3].map(x => x * 2)            → [2, 4, 6]
+//	[1, 2, 3].reduce((a, b) => a + b)    → 6
+//	[3, 1, 2].sort()                     → [1, 2, 3]
+//	[1, [2, [3]]].flat(Infinity)         → [1, 2, 3]
+//	["a", "b"].join("-")                 → "a-b"
 func GetArrayProperty(arr *array.ArrayReference, property string) Value {
 	switch property {
 	case "length":
 		return arr.Length()
 	case "push":
-		return createPushMethod(arr)
+		return arrayMethod(property, func(args []interface{}) Value {
+			return arr.Push(toValues(args)...)
+		})
+	case "pop":
+		return arrayMethod(property, func(args []interface{}) Value {
+			elements := *arr.Elements
+			if len(elements) == 0 {
+				return nil
+			}
+			arr.CheckDeletable()
+			last := elements[len(elements)-1]
+			*arr.Elements = elements[:len(elements)-1]
+			return last
+		})
+	case "shift":
+		return arrayMethod(property, func(args []interface{}) Value {
+			elements := *arr.Elements
+			if len(elements) == 0 {
+				return nil
+			}
+			arr.CheckDeletable()
+			arr.CheckWritable()
+			first := elements[0]
+			*arr.Elements = append(Array{}, elements[1:]...)
+			return first
+		})
+	case "unshift":
+		return arrayMethod(property, func(args []interface{}) Value {
+			if len(args) > 0 {
+				arr.CheckExtensible()
+				arr.CheckWritable()
+			}
+			*arr.Elements = append(toValues(args), *arr.Elements...)
+			return arr.Length()
+		})
+	case "splice":
+		return arrayMethod(property, func(args []interface{}) Value {
+			return splice(arr, args)
+		})
+	case "fill":
+		return arrayMethod(property, func(args []interface{}) Value {
+			elements := *arr.Elements
+			start := relativeIndex(argAt(args, 1), len(elements), 0)
+			end := relativeIndex(argAt(args, 2), len(elements), len(elements))
+			if start < end {
+				arr.CheckWritable()
+			}
+			for i := start; i < end; i++ {
+				elements[i] = argAt(args, 0)
+			}
+			return arr
+		})
+	case "reverse":
+		return arrayMethod(property, func(args []interface{}) Value {
+			arr.CheckWritable()
+			reverse(*arr.Elements)
+			return arr
+		})
+	case "sort":
+		return arrayMethod(property, func(args []interface{}) Value {
+			arr.CheckWritable()
+			sortElements(*arr.Elements, argAt(args, 0))
+			return arr
+		})
+	case "toReversed":
+		return arrayMethod(property, func(args []interface{}) Value {
+			elements := append(Array{}, *arr.Elements...)
+			reverse(elements)
+			return array.NewArrayReference(elements)
+		})
+	case "toSorted":
+		return arrayMethod(property, func(args []interface{}) Value {
+			elements := append(Array{}, *arr.Elements...)
+			sortElements(elements, argAt(args, 0))
+			return array.NewArrayReference(elements)
+		})
+	case "with":
+		return arrayMethod(property, func(args []interface{}) Value {
+			elements := append(Array{}, *arr.Elements...)
+			index := internal.ToIntegerOrInfinity(argAt(args, 0))
+			if index < 0 {
+				index += float64(len(elements))
+			}
+			if index < 0 || index >= float64(len(elements)) {
+				internal.ThrowError("RangeError", "Invalid index : "+internal.Inspect(internal.ToIntegerOrInfinity(argAt(args, 0))))
+			}
+			elements[int(index)] = argAt(args, 1)
+			return array.NewArrayReference(elements)
+		})
+	case "at":
+		return arrayMethod(property, func(args []interface{}) Value {
+			index := internal.ToIntegerOrInfinity(argAt(args, 0))
+			if index < 0 {
+				index += arr.Length()
+			}
+			if index < 0 || index >= arr.Length() {
+				return nil
+			}
+			return arr.Get(int(index))
+		})
+	case "slice":
+		return arrayMethod(property, func(args []interface{}) Value {
+			elements := *arr.Elements
+			start := relativeIndex(argAt(args, 0), len(elements), 0)
+			end := relativeIndex(argAt(args, 1), len(elements), len(elements))
+			if start >= end {
+				return array.NewArrayReference(Array{})
+			}
+			return array.NewArrayReference(append(Array{}, elements[start:end]...))
+		})
+	case "concat":
+		return arrayMethod(property, func(args []interface{}) Value {
+			elements := append(Array{}, *arr.Elements...)
+			for _, arg := range args {
+				if other, ok := arg.(*array.ArrayReference); ok {
+					elements = append(elements, *other.Elements...)
+				} else {
+					elements = append(elements, arg)
+				}
+			}
+			return array.NewArrayReference(elements)
+		})
+	case "join":
+		return arrayMethod(property, func(args []interface{}) Value {
+			separator := ","
+			if argAt(args, 0) != nil {
+				separator = internal.ToString(argAt(args, 0))
+			}
+			return arr.Join(separator)
+		})
+	case "toString":
+		return arrayMethod(property, func(args []interface{}) Value {
+			return arr.Join(",")
+		})
+	case "flat":
+		return arrayMethod(property, func(args []interface{}) Value {
+			depth := 1.0
+			if argAt(args, 0) != nil {
+				depth = internal.ToIntegerOrInfinity(argAt(args, 0))
+			}
+			return array.NewArrayReference(flatten(*arr.Elements, depth))
+		})
+	case "indexOf":
+		return arrayMethod(property, func(args []interface{}) Value {
+			elements := *arr.Elements
+			for i := relativeIndex(argAt(args, 1), len(elements), 0); i < len(elements); i++ {
+				if strictEquals(elements[i], argAt(args, 0)) {
+					return float64(i)
+				}
+			}
+			return -1.0
+		})
+	case "lastIndexOf":
+		return arrayMethod(property, func(args []interface{}) Value {
+			elements := *arr.Elements
+			from := len(elements) - 1
+			if len(args) > 1 {
+				from = relativeIndex(argAt(args, 1), len(elements), 0)
+				from = min(from, len(elements)-1)
+			}
+			for i := from; i >= 0; i-- {
+				if strictEquals(elements[i], argAt(args, 0)) {
+					return float64(i)
+				}
+			}
+			return -1.0
+		})
+	case "includes":
+		return arrayMethod(property, func(args []interface{}) Value {
+			elements := *arr.Elements
+			for i := relativeIndex(argAt(args, 1), len(elements), 0); i < len(elements); i++ {
+				if sameValueZero(elements[i], argAt(args, 0)) {
+					return true
+				}
+			}
+			return false
+		})
+	case "keys":
+		return arrayMethod(property, func(args []interface{}) Value {
+			return arrayIterator(arr, func(i int) Value { return float64(i) })
+		})
+	case "values":
+		return arrayMethod(property, func(args []interface{}) Value {
+			return arrayIterator(arr, arr.Get)
+		})
+	case "entries":
+		return arrayMethod(property, func(args []interface{}) Value {
+			return arrayIterator(arr, func(i int) Value {
+				return array.NewArrayReference(Array{float64(i), arr.Get(i)})
+			})
+		})
+	case "forEach":
+		return arrayMethod(property, func(args []interface{}) Value {
+			fn := callbackArg(args)
+			length := len(*arr.Elements)
+			for i := 0; i < length; i++ {
+				fn.Call(argAt(args, 1), arr.Get(i), float64(i), arr)
+			}
+			return nil
+		})
 	case "map":
-		return createMapMethod(arr)
+		return arrayMethod(property, func(args []interface{}) Value {
+			fn := callbackArg(args)
+			length := len(*arr.Elements)
+			result := make(Array, 0, length)
+			for i := 0; i < length; i++ {
+				result = append(result, fn.Call(argAt(args, 1), arr.Get(i), float64(i), arr))
+			}
+			return array.NewArrayReference(result)
+		})
+	case "flatMap":
+		return arrayMethod(property, func(args []interface{}) Value {
+			fn := callbackArg(args)
+			length := len(*arr.Elements)
+			result := make(Array, 0, length)
+			for i := 0; i < length; i++ {
+				result = append(result, fn.Call(argAt(args, 1), arr.Get(i), float64(i), arr))
+			}
+			return array.NewArrayReference(flatten(result, 1))
+		})
 	case "filter":
-		return createFilterMethod(arr)
+		return arrayMethod(property, func(args []interface{}) Value {
+			fn := callbackArg(args)
+			length := len(*arr.Elements)
+			result := make(Array, 0)
+			for i := 0; i < length; i++ {
+				elem := arr.Get(i)
+				if isTruthy(fn.Call(argAt(args, 1), elem, float64(i), arr)) {
+					result = append(result, elem)
+				}
+			}
+			return array.NewArrayReference(result)
+		})
+	case "some":
+		return arrayMethod(property, func(args []interface{}) Value {
+			return findIndex(arr, args, false) >= 0
+		})
+	case "every":
+		return arrayMethod(property, func(args []interface{}) Value {
+			fn := callbackArg(args)
+			length := len(*arr.Elements)
+			for i := 0; i < length; i++ {
+				if !isTruthy(fn.Call(argAt(args, 1), arr.Get(i), float64(i), arr)) {
+					return false
+				}
+			}
+			return true
+		})
+	case "find", "findLast":
+		return arrayMethod(property, func(args []interface{}) Value {
+			if i := findIndex(arr, args, property == "findLast"); i >= 0 {
+				return arr.Get(i)
+			}
+			return nil
+		})
+	case "findIndex", "findLastIndex":
+		return arrayMethod(property, func(args []interface{}) Value {
+			return float64(findIndex(arr, args, property == "findLastIndex"))
+		})
+	case "reduce", "reduceRight":
+		return arrayMethod(property, func(args []interface{}) Value {
+			return reduce(arr, args, property == "reduceRight")
+		})
 	default:
 		return nil
 	}
 }
 
-func createPushMethod(arr *array.ArrayReference) Value {
+// arrayMethod wraps the body of an array method as a builtin
+func arrayMethod(name string, fn func(args []interface{}) Value) Value {
 	return &internal.Builtin{
+		Name: name,
 		Fn: func(args ...interface{}) interface{} {
-			values := make([]Value, len(args))
-			for i, arg := range args {
-				values[i] = arg
-			}
-			return arr.Push(values...)
+			return fn(args)
 		},
 	}
 }
 
-func createMapMethod(arr *array.ArrayReference) Value {
-	return &internal.Builtin{
-		Fn: func(args ...interface{}) interface{} {
-			if len(args) == 0 {
-				return nil
-			}
+// toValues converts builtin arguments to array elements
+func toValues(args []interface{}) Array {
+	values := make(Array, len(args))
+	for i, arg := range args {
+		values[i] = arg
+	}
+	return values
+}
 
-			fn, ok := args[0].(*Function)
-			if !ok {
-				return nil
-			}
+// callbackArg returns the callback passed to an array method, throwing a
+// TypeError if it can't be called
+func callbackArg(args []interface{}) internal.Callable {
+	fn, ok := argAt(args, 0).(internal.Callable)
+	if !ok {
+		internal.ThrowError("TypeError", internal.Inspect(argAt(args, 0))+" is not a function")
+	}
+	return fn
+}
 
-			result := make(Array, 0, len(*arr.Elements))
-			for i, elem := range *arr.Elements {
-				callbackEnv := environment.New(fn.Env)
+// findIndex returns the index of the first element (the last one, going
+// backward) the callback accepts, or -1
+func findIndex(arr *array.ArrayReference, args []interface{}, backward bool) int {
+	fn := callbackArg(args)
+	length := len(*arr.Elements)
+	for n := 0; n < length; n++ {
+		i := n
+		if backward {
+			i = length - 1 - n
+		}
+		if isTruthy(fn.Call(argAt(args, 1), arr.Get(i), float64(i), arr)) {
+			return i
+		}
+	}
+	return -1
+}
 
-				if len(fn.Parameters) > 0 {
-					callbackEnv.Set(fn.Parameters[0], elem)
-				}
-				if len(fn.Parameters) > 1 {
-					callbackEnv.Set(fn.Parameters[1], float64(i))
-				}
-				if len(fn.Parameters) > 2 {
-					callbackEnv.Set(fn.Parameters[2], arr)
-				}
+// reduce implements reduce and reduceRight
+// Without an initial value the first element (last, for reduceRight) is
+// used, and an empty array is a TypeError.
+//
+// Example: [1, 2, 3].reduce((sum, x) => sum + x, 10) → 16
+func reduce(arr *array.ArrayReference, args []interface{}, backward bool) Value {
+	fn := callbackArg(args)
+	length := len(*arr.Elements)
+	index := func(n int) int {
+		if backward {
+			return length - 1 - n
+		}
+		return n
+	}
 
-				callbackResult := Eval(fn.Body, callbackEnv)
+	n := 0
+	var accumulator Value
+	if len(args) > 1 {
+		accumulator = args[1]
+	} else {
+		if length == 0 {
+			internal.ThrowError("TypeError", "Reduce of empty array with no initial value")
+		}
+		accumulator = arr.Get(index(0))
+		n = 1
+	}
 
-				if returnVal, ok := callbackResult.(*ReturnValue); ok {
-					callbackResult = returnVal.Value
-				}
+	for ; n < length; n++ {
+		i := index(n)
+		accumulator = fn.Call(nil, accumulator, arr.Get(i), float64(i), arr)
+	}
+	return accumulator
+}
 
-				result = append(result, callbackResult)
-			}
+// splice removes deleteCount elements at start and inserts the remaining
+// arguments in their place, returning the removed elements
+//
+// Examples (on [1, 2, 3, 4]):
+//
+//	splice(1, 2)       → [2, 3], leaves [1, 4]
+//	splice(1, 0, "x")  → [], leaves [1, "x", 2, 3, 4]
+//	splice(-1)         → [4], leaves [1, 2, 3]
+func splice(arr *array.ArrayReference, args []interface{}) Value {
+	elements := *arr.Elements
+	start := relativeIndex(argAt(args, 0), len(elements), 0)
 
-			return array.NewArrayReference(result)
-		},
+	deleteCount := 0
+	switch {
+	case len(args) == 1:
+		deleteCount = len(elements) - start
+	case len(args) > 1:
+		deleteCount = clampIndex(internal.ToIntegerOrInfinity(args[1]), len(elements)-start)
 	}
+
+	var items Array
+	if len(args) > 2 {
+		items = toValues(args[2:])
+	}
+
+	switch {
+	case len(items) > deleteCount:
+		arr.CheckExtensible()
+	case len(items) < deleteCount:
+		arr.CheckDeletable()
+	}
+	if len(items) > 0 || deleteCount > 0 {
+		arr.CheckWritable()
+	}
+
+	removed := append(Array{}, elements[start:start+deleteCount]...)
+	result := append(Array{}, elements[:start]...)
+	result = append(result, items...)
+	result = append(result, elements[start+deleteCount:]...)
+	*arr.Elements = result
+
+	return array.NewArrayReference(removed)
 }
 
-func createFilterMethod(arr *array.ArrayReference) Value {
-	return &internal.Builtin{
-		Fn: func(args ...interface{}) interface{} {
-			if len(args) == 0 {
-				return nil
-			}
+func reverse(elements Array) {
+	for i, j := 0, len(elements)-1; i < j; i, j = i+1, j-1 {
+		elements[i], elements[j] = elements[j], elements[i]
+	}
+}
 
-			fn, ok := args[0].(*Function)
-			if !ok {
-				return nil
-			}
+// sortElements sorts in place, keeping equal elements in their original
+// order. Without a comparator elements are compared as strings, by UTF-16
+// code units, so [10, 9, 1].sort() gives [1, 10, 9]. Undefined elements
+// always go last.
+//
+// Example: [3, 1, 2].sort((a, b) => b - a) → [3, 2, 1]
+func sortElements(elements Array, comparator Value) {
+	fn, hasComparator := comparator.(internal.Callable)
+	if comparator != nil && !hasComparator {
+		internal.ThrowError("TypeError", "The comparison function must be either a function or undefined")
+	}
 
-			result := make(Array, 0)
-			for i, elem := range *arr.Elements {
-				callbackEnv := environment.New(fn.Env)
+	defined := elements[:0:0]
+	undefinedCount := 0
+	for _, elem := range elements {
+		if elem == nil {
+			undefinedCount++
+		} else {
+			defined = append(defined, elem)
+		}
+	}
 
-				if len(fn.Parameters) > 0 {
-					callbackEnv.Set(fn.Parameters[0], elem)
-				}
-				if len(fn.Parameters) > 1 {
-					callbackEnv.Set(fn.Parameters[1], float64(i))
-				}
-				if len(fn.Parameters) > 2 {
-					callbackEnv.Set(fn.Parameters[2], arr)
-				}
+	sort.SliceStable(defined, func(i, j int) bool {
+		if hasComparator {
+			return internal.ToNumber(fn.Call(nil, defined[i], defined[j])) < 0
+		}
+		return compareUnits(internal.ToString(defined[i]), internal.ToString(defined[j])) < 0
+	})
 
-				callbackResult := Eval(fn.Body, callbackEnv)
+	copy(elements, defined)
+	for i := len(defined); i < len(elements); i++ {
+		elements[i] = nil
+	}
+}
 
-				if returnVal, ok := callbackResult.(*ReturnValue); ok {
-					callbackResult = returnVal.Value
-				}
+// compareUnits compares strings by UTF-16 code units, the order JavaScript
+// uses for < on strings and for the default sort
+func compareUnits(a string, b string) int {
+	unitsA, unitsB := utf16.Encode([]rune(a)), utf16.Encode([]rune(b))
+	for i := 0; i < len(unitsA) && i < len(unitsB); i++ {
+		if unitsA[i] != unitsB[i] {
+			return int(unitsA[i]) - int(unitsB[i])
+		}
+	}
+	return len(unitsA) - len(unitsB)
+}
 
-				if isTruthy(callbackResult) {
-					result = append(result, elem)
-				}
-			}
+// flatten copies elements, replacing nested arrays by their elements down
+// to the given depth
+func flatten(elements Array, depth float64) Array {
+	result := make(Array, 0, len(elements))
+	for _, elem := range elements {
+		if nested, ok := elem.(*array.ArrayReference); ok && depth >= 1 {
+			result = append(result, flatten(*nested.Elements, depth-1)...)
+		} else {
+			result = append(result, elem)
+		}
+	}
+	return result
+}
 
-			return array.NewArrayReference(result)
-		},
+// arrayIterator returns an iterator producing value(i) for each index,
+// reading the length on every step so it sees elements added meanwhile
+func arrayIterator(arr *array.ArrayReference, value func(int) Value) Value {
+	index := 0
+	return internal.NewIterator(func() (Value, bool) {
+		if index >= len(*arr.Elements) {
+			return nil, false
+		}
+		index++
+		return value(index - 1), true
+	})
+}
+
+// strictEquals compares like ===: unlike ==, null and undefined differ
+func strictEquals(a, b Value) bool {
+	if internal.IsNullish(a) || internal.IsNullish(b) {
+		return a == b
+	}
+	return equals(a, b)
+}
+
+// sameValueZero compares like strictEquals, except that NaN equals NaN
+// This is how includes, Map and Set match values.
+func sameValueZero(a, b Value) bool {
+	if x, ok := a.(float64); ok && math.IsNaN(x) {
+		y, ok := b.(float64)
+		return ok && math.IsNaN(y)
 	}
+	return strictEquals(a, b)
+}
+
+// isArrayIndex reports whether a property name is an array index like "0"
+// or "42", returning the index
+func isArrayIndex(name string) (int, bool) {
+	index, err := strconv.Atoi(name)
+	return index, err == nil && index >= 0 && strconv.Itoa(index) == name
 }
@@ -0,0 +1,242 @@
+package evaluator
+
+import (
+	"go-script/internal"
+	"math"
+	"testing"
+)
+
+func TestArrayMutatorMethods(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		// pop, shift and unshift
+		{`var a = [1, 2, 3]; a.pop();`, 3.0},
+		{`var a = [1, 2, 3]; a.pop(); a.length;`, 2.0},
+		{`[].pop();`, nil},
+		{`var a = [1, 2, 3]; a.shift();`, 1.0},
+		{`var a = [1, 2, 3]; a.shift(); a[0];`, 2.0},
+		{`var a = [3]; a.unshift(1, 2);`, 3.0},
+		{`var a = [3]; a.unshift(1, 2); a.join();`, "1,2,3"},
+
+		// splice
+		{`var a = [1, 2, 3, 4]; a.splice(1, 2).join();`, "2,3"},
+		{`var a = [1, 2, 3, 4]; a.splice(1, 2); a.join();`, "1,4"},
+		{`var a = [1, 2, 3]; a.splice(1, 0, "x", "y"); a.join();`, "1,x,y,2,3"},
+		{`var a = [1, 2, 3]; a.splice(-1); a.join();`, "1,2"},
+		{`var a = [1, 2, 3]; a.splice(1).length;`, 2.0},
+
+		// fill, reverse and sort
+		{`[1, 2, 3].fill(0).join();`, "0,0,0"},
+		{`[1, 2, 3, 4].fill(9, 1, -1).join();`, "1,9,9,4"},
+		{`var a = [1, 2, 3]; a.reverse(); a.join();`, "3,2,1"},
+		{`[3, 1, 2].sort().join();`, "1,2,3"},
+		{`[10, 9, 1].sort().join();`, "1,10,9"},
+		{`[10, 9, 1].sort(function(a, b) { return a - b; }).join();`, "1,9,10"},
+		{`["b", undefined, "a"].sort()[2];`, nil},
+		{`var a = [{k: 1, v: "a"}, {k: 0, v: "b"}, {k: 1, v: "c"}]; a.sort(function(x, y) { return x.k - y.k; }).map(function(x) { return x.v; }).join("");`, "bac"},
+		{`try { [2, 1].sort(1); } catch (e) { e.name + ": " + e.message; }`, "TypeError: The comparison function must be either a function or undefined"},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestArrayCopyingMethods(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`var a = [1, 2, 3]; a.toReversed().join() + " " + a.join();`, "3,2,1 1,2,3"},
+		{`var a = [3, 1, 2]; a.toSorted().join() + " " + a.join();`, "1,2,3 3,1,2"},
+		{`var a = [1, 2, 3]; a.with(-1, 9).join() + " " + a.join();`, "1,2,9 1,2,3"},
+		{`try { [1].with(3, 0); } catch (e) { e.name + ": " + e.message; }`, "RangeError: Invalid index : 3"},
+
+		{`[1, 2, 3].at(-1);`, 3.0},
+		{`[1, 2, 3].at(5);`, nil},
+		{`[1, 2, 3, 4].slice(1, 3).join();`, "2,3"},
+		{`[1, 2, 3, 4].slice(-2).join();`, "3,4"},
+		{`[1].concat(2, [3, 4], [[5]]).length;`, 5.0},
+		{`[1, [2, [3, [4]]]].flat().length;`, 3.0},
+		{`[1, [2, [3, [4]]]].flat(3).length;`, 4.0},
+		{`[1, [2, [3, [4]]]].flat(0).length;`, 2.0},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestArrayJoinAndToString(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`[1, 2, 3].join();`, "1,2,3"},
+		{`[1, 2, 3].join(" - ");`, "1 - 2 - 3"},
+		{`[1, null, undefined, 2].join("-");`, "1---2"},
+		{`[[1, 2], [3]].join(";");`, "1,2;3"},
+		{`[1, 2].toString();`, "1,2"},
+		{`var a = [1]; a.push(a); a.join();`, "1,"},
+		{`[].join();`, ""},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestArraySearchMethods(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`[1, 2, 1].indexOf(1);`, 0.0},
+		{`[1, 2, 1].indexOf(1, 1);`, 2.0},
+		{`[1, 2, 1].lastIndexOf(1);`, 2.0},
+		{`[1, 2].indexOf("1");`, -1.0},
+		{`[1, 2].includes(3);`, false},
+		{`[1, 2, 3, 4].find(function(x) { return x > 2; });`, 3.0},
+		{`[1, 2, 3, 4].findLast(function(x) { return x < 3; });`, 2.0},
+		{`[1, 2, 3].findIndex(function(x) { return x == 5; });`, -1.0},
+		{`[1, 2, 3].findLastIndex(function(x) { return x < 3; });`, 1.0},
+		{`[1, 2].find(function(x) { return x > 5; });`, nil},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestArrayCallbackMethods(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`var s = 0; [1, 2, 3].forEach(function(x, i) { s = s + x * i; }); s;`, 8.0},
+		{`[1, 2, 3].forEach(function(x) { return x; });`, nil},
+		{`[1, 2, 3].map(function(x, i) { return x + i; }).join();`, "1,3,5"},
+		{`[1, 2].flatMap(function(x) { return [x, x * 10]; }).join();`, "1,10,2,20"},
+		{`[1, 2, 3, 4].filter(function(x, i) { return i > 1; }).join();`, "3,4"},
+		{`[1, 2, 3].some(function(x) { return x > 2; });`, true},
+		{`[].some(function(x) { return true; });`, false},
+		{`[1, 2, 3].every(function(x) { return x > 0; });`, true},
+		{`[].every(function(x) { return false; });`, true},
+		{`[1, 2, 3].reduce(function(acc, x) { return acc + x; });`, 6.0},
+		{`[1, 2, 3].reduce(function(acc, x) { return acc + x; }, 10);`, 16.0},
+		{`["a", "b", "c"].reduceRight(function(acc, x) { return acc + x; });`, "cba"},
+		{`try { [].reduce(function(a, b) { return a; }); } catch (e) { e.name + ": " + e.message; }`, "TypeError: Reduce of empty array with no initial value"},
+		{`try { [1].map(5); } catch (e) { e.name + ": " + e.message; }`, "TypeError: 5 is not a function"},
+		{`var a = [1, 2]; var n = 0; a.forEach(function(x) { a.push(x); n = n + 1; }); n;`, 2.0},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestArrayIteratorMethods(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`var out = ""; for (let k of ["a", "b"].keys()) { out = out + k; } out;`, "01"},
+		{`var out = ""; for (let v of ["a", "b"].values()) { out = out + v; } out;`, "ab"},
+		{`var out = ""; for (let e of ["a", "b"].entries()) { out = out + e[0] + e[1]; } out;`, "0a1b"},
+		{`["a", "b"].keys().next().value;`, 0.0},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestArrayStatics(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`Array.isArray([1]);`, true},
+		{`Array.isArray("a");`, false},
+		{`Array.isArray({length: 0});`, false},
+		{`Array.of(3).length;`, 1.0},
+		{`Array.of(1, 2, 3).join();`, "1,2,3"},
+		{`Array.from("abc").join();`, "a,b,c"},
+		{`Array.from([1, 2], function(x, i) { return x * 10 + i; }).join();`, "10,21"},
+		{`Array.from({length: 3}, function(v, i) { return i * 2; }).join();`, "0,2,4"},
+		{`Array.from({length: 2, 0: "x"})[0];`, "x"},
+		{`Array.from({length: 2, 0: "x"})[1];`, nil},
+		{`try { Array.from({length: 10000000000}); } catch (e) { e.name; }`, "RangeError"},
+		{`var a = [1]; Array.from(a) == a;`, false},
+		{`var gen = function*() { yield 1; yield 2; }; Array.from(gen()).length;`, 2.0},
+		{`new Array(3).length;`, 3.0},
+		{`new Array(3)[0];`, nil},
+		{`new Array(1, 2).join();`, "1,2"},
+		{`Array("a").length;`, 1.0},
+		{`[] instanceof Array;`, true},
+		{`({}) instanceof Array;`, false},
+		{`try { new Array(-1); } catch (e) { e.name + ": " + e.message; }`, "RangeError: Invalid array length"},
+		{`try { Array.from(null); } catch (e) { e.name + ": " + e.message; }`, "TypeError: null is not iterable"},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestSameValueZero(t *testing.T) {
+	nan := math.NaN()
+	tests := []struct {
+		a, b     Value
+		strict   bool
+		expected bool
+	}{
+		{nan, nan, false, true},
+		{1.0, 1.0, true, true},
+		{1.0, "1", false, false},
+		{nil, internal.Null, false, false},
+		{internal.Null, internal.Null, true, true},
+	}
+
+	for _, tt := range tests {
+		if result := strictEquals(tt.a, tt.b); result != tt.strict {
+			t.Errorf("strictEquals(%v, %v): expected %v, got %v", tt.a, tt.b, tt.strict, result)
+		}
+		if result := sameValueZero(tt.a, tt.b); result != tt.expected {
+			t.Errorf("sameValueZero(%v, %v): expected %v, got %v", tt.a, tt.b, tt.expected, result)
+		}
+	}
+}
+
+func TestArrayMethodsOnNull(t *testing.T) {
+	result := testEval(`[null].indexOf(null);`)
+	if result != 0.0 {
+		t.Errorf("Expected 0, got %v", result)
+	}
+	if result := testEval(`[1, null].at(1);`); result != internal.Null {
+		t.Errorf("Expected null, got %v", result)
+	}
+}
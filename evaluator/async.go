@@ -0,0 +1,86 @@
+package evaluator
+
+import (
+	"go-script/ast"
+	"go-script/environment"
+	"go-script/internal"
+)
+
+// callAsyncFunction runs an async function and returns the promise for its
+// result
+//
+// The body runs as a coroutine (see Generator) under the "await" binding.
+// It starts synchronously and runs until its first await, which hands the
+// awaited value back here. The rest of the body continues as a promise
+// reaction once that value settles, so the caller never blocks.
+//
+// Example:
+//
+//	var load = async function(url) { var res = await fetch(url); return res.status; };
+//	load(url)   → Promise { <pending> } right away
+//	            → the body resumes when the response arrives and fulfills with the status
+func callAsyncFunction(fn *Function, this Value, args []Value) *internal.Promise {
+	loop := fn.Env.EventLoop()
+	promise := loop.NewPromise()
+	body := &Generator{fn: fn, this: this, args: args, keyword: "await"}
+
+	var step func(mode resumeMode, value Value)
+	step = func(mode resumeMode, value Value) {
+		var out generatorYield
+		if exception := internal.Catch(func() { out = body.step(mode, value) }); exception != nil {
+			promise.Reject(exception.Value)
+			return
+		}
+
+		if out.done {
+			promise.Resolve(out.value)
+			return
+		}
+
+		loop.PromiseResolve(out.value).Subscribe(
+			func(result Value) { step(resumeNext, result) },
+			func(reason Value) { step(resumeThrow, reason) },
+		)
+	}
+
+	step(resumeNext, nil)
+	return promise
+}
+
+// evalAwaitExpression evaluates "await value"
+//
+// Inside an async function the body pauses until the value settles; a
+// rejection is thrown at the await. At the top level of a script there is no
+// coroutine to pause, so the event loop runs right here until the value
+// settles.
+//
+// Examples:
+//
+//	await Promise.resolve(1)  → 1
+//	await 5                   → 5 (non-promises are wrapped)
+//	await Promise.reject("x") → throws "x"
+func evalAwaitExpression(node *ast.AwaitExpression, env *environment.Environment) Value {
+	return await(Eval(node.Argument, env), env)
+}
+
+// await pauses until value settles and returns its result, the way an await
+// expression evaluated in env would; for await...of awaits through it too
+func await(value Value, env *environment.Environment) Value {
+	if binding, ok := env.Get("await"); ok {
+		if body, ok := binding.(*Generator); ok {
+			return body.yield(value)
+		}
+	}
+
+	loop := env.EventLoop()
+	promise := loop.PromiseResolve(value)
+	promise.Subscribe(func(Value) {}, func(Value) {}) // awaiting handles a rejection
+
+	if !loop.RunUntilSettled(promise) {
+		internal.ThrowError("Error", "top-level await never settled")
+	}
+	if promise.State == internal.PromiseRejected {
+		internal.Throw(promise.Value)
+	}
+	return promise.Value
+}
@@ -0,0 +1,298 @@
+package evaluator
+
+import (
+	"fmt"
+	"go-script/internal"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestArrowFunctions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`var double = x => x * 2; double(4);`, 8.0},
+		{`var add = (a, b) => a + b; add(2, 3);`, 5.0},
+		{`var answer = () => 42; answer();`, 42.0},
+		{`var block = (x) => { var y = x + 1; return y * 2; }; block(1);`, 4.0},
+		{`[1, 2, 3].map(x => x * 10)[2];`, 30.0},
+		{`var adder = a => b => a + b; adder(1)(2);`, 3.0},
+		{`var pick = (x) => x ? "yes" : "no"; pick(false);`, "no"},
+		// this comes from the enclosing function, not the caller
+		{`var counter = { count: 5, getter() { return () => this.count; } };
+		var get = counter.getter();
+		var other = { count: 0, get: get };
+		other.get();`, 5.0},
+		{`class Button {
+			constructor() { this.label = "ok"; this.onClick = () => this.label; }
+		}
+		var handler = new Button().onClick;
+		handler();`, "ok"},
+		{`class Base { name() { return "base"; } }
+		class Child extends Base { name() { var f = () => super.name(); return f() + "!"; } }
+		new Child().name();`, "base!"},
+		{`var f = (x) => (x, x + 1); f(1);`, 2.0},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestAsyncFunctions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`var log;
+		var f = async function() { return 1; };
+		f().then(v => { log = v; });`, 1.0},
+		{`var log;
+		var f = async function(a, b) { var x = await a; return x + b; };
+		f(Promise.resolve(1), 2).then(v => { log = v; });`, 3.0},
+		{`var log;
+		var f = async x => (await x) * 2;
+		f(21).then(v => { log = v; });`, 42.0},
+		{`var log;
+		var obj = { base: 1, async plus(n) { return this.base + await n; } };
+		obj.plus(2).then(v => { log = v; });`, 3.0},
+		{`var log;
+		class Store { constructor() { this.v = "stored"; } async get() { return this.v; } static async make() { return new Store(); } }
+		Store.make().then(s => s.get()).then(v => { log = v; });`, "stored"},
+		// A body without return fulfills with undefined
+		{`var log = "unset";
+		var f = async () => { 5; };
+		f().then(v => { log = v; });`, nil},
+		{`var log;
+		var f = async () => { throw "failed"; };
+		f().catch(e => { log = e; });`, "failed"},
+		{`var log;
+		var f = async () => { try { await Promise.reject("inner"); } catch (e) { return "caught " + e; } };
+		f().then(v => { log = v; });`, "caught inner"},
+		{`var log = "";
+		var f = async () => { try { await Promise.reject("x"); } finally { log = log + "finally"; } };
+		f().catch(e => { log = log + " " + e; });`, "finally x"},
+		{`var log;
+		var inner = async () => "inner";
+		var outer = async () => { var v = await inner(); return v + " outer"; };
+		outer().then(v => { log = v; });`, "inner outer"},
+		{`var log;
+		var f = async function() {};
+		log = f() instanceof Promise;`, true},
+	}
+
+	for _, tt := range tests {
+		result := testEvalLog(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestAwaitOrdering(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		// The body runs synchronously until the first await
+		{`var log = "";
+		var f = async () => { log = log + "1"; await null; log = log + "3"; };
+		f();
+		log = log + "2";`, "123"},
+		{`var log = "";
+		var a = async () => { log = log + "a1"; await null; log = log + "a2"; };
+		var b = async () => { log = log + "b1"; await null; log = log + "b2"; };
+		a(); b();`, "a1b1a2b2"},
+		{`var log = "";
+		var f = async () => { await null; log = log + "async "; };
+		f();
+		Promise.resolve().then(() => { log = log + "then "; });`, "async then "},
+	}
+
+	for _, tt := range tests {
+		result := testEvalLog(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestForAwait(t *testing.T) {
+	countdown := `var countdown = {
+		[Symbol.asyncIterator]() {
+			var n = 3;
+			return {
+				next() { n = n - 1; return Promise.resolve({ value: n, done: n < 0 }); },
+				return() { log = log + "closed "; return Promise.resolve({ done: true }); }
+			};
+		}
+	};
+	`
+
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{countdown + `var log = "";
+		var f = async () => { for await (let n of countdown) { log = log + n + " "; } };
+		f();`, "2 1 0 "},
+		// Without Symbol.asyncIterator the values of Symbol.iterator are awaited
+		{`var log = "";
+		var f = async () => { for await (let v of [Promise.resolve("a"), "b"]) { log = log + v; } };
+		f();`, "ab"},
+		{`var log = "";
+		var f = async () => { for await (let v of "xy") { log = log + v; } };
+		f();`, "xy"},
+		// Returning from the loop closes the iterator
+		{countdown + `var log = "";
+		var f = async () => { for await (let n of countdown) { return n; } };
+		f().then(v => { log = log + v; });`, "closed 2"},
+		// The loop pauses the function like await does
+		{countdown + `var log = "";
+		var f = async () => { for await (let n of countdown) { log = log + n; } };
+		f();
+		log = log + "sync ";`, "sync 210"},
+		{`var log;
+		var f = async () => { try { for await (let v of [Promise.reject("bad")]) {} } catch (e) { log = "caught " + e; } };
+		f();`, "caught bad"},
+		{`var log;
+		var f = async () => { for await (let v of 5) {} };
+		f().catch(e => { log = e.name + ": " + e.message; });`, "TypeError: 5 is not async iterable"},
+		// At the top level the event loop runs until each value settles
+		{countdown + `var log = "";
+		for await (let n of countdown) { log = log + n; }`, "210"},
+	}
+
+	for _, tt := range tests {
+		result := testEvalLog(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestTopLevelAwait(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`await Promise.resolve(5);`, 5.0},
+		{`await 7;`, 7.0},
+		{`var x = await (async () => 1)(); x + 1;`, 2.0},
+		{`try { await Promise.reject("no"); } catch (e) { "caught " + e; }`, "caught no"},
+		// Microtasks queued before the await run while it waits
+		{`var log = "";
+		Promise.resolve().then(() => { log = log + "micro "; });
+		await null;
+		log + "after";`, "micro after"},
+		{`var values = await Promise.all([1, Promise.resolve(2)]); values[0] + values[1];`, 3.0},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestTopLevelAwaitErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`await Promise.reject("uncaught");`, "uncaught"},
+		{`await new Promise(() => {});`, "Error: top-level await never settled"},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		exception, ok := result.(*internal.Exception)
+		if !ok {
+			t.Errorf("For input %q: expected an exception, got %v", tt.input, result)
+			continue
+		}
+		if thrown(exception) != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, exception)
+		}
+	}
+}
+
+func TestUnhandledAsyncRejection(t *testing.T) {
+	result := testEval(`var f = async () => { throw "lost"; }; f();`)
+
+	exception, ok := result.(*internal.Exception)
+	if !ok || !exception.InPromise || exception.Value != "lost" {
+		t.Errorf("Expected an unhandled rejection with lost, got %v", result)
+	}
+}
+
+func TestAwaitFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("hello " + r.URL.Path[1:]))
+	}))
+	defer server.Close()
+
+	input := fmt.Sprintf(`var res = await fetch("%s/world"); res.status + " " + res.body;`, server.URL)
+	if result := testEval(input); result != "200 hello world" {
+		t.Errorf("Expected 200 hello world, got %v", result)
+	}
+
+	// Callbacks on a fetch that's never awaited still run before the program ends
+	input = fmt.Sprintf(`var log; fetch("%s/later").then(res => { log = res.body; });`, server.URL)
+	if result := testEvalLog(input); result != "hello later" {
+		t.Errorf("Expected hello later, got %v", result)
+	}
+}
+
+func TestFetchBinding(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`typeof fetch + " " + typeof print;`, "function function"},
+		// fetch and print are ordinary globals that scripts can shadow
+		{`var fetch = function(url) { return "stub " + url; }; fetch("/users");`, "stub /users"},
+		{`var get = function(fetch) { return fetch("/a"); }; get(url => "param " + url);`, "param /a"},
+		{`var print = function(x) { return x * 2; }; print(21);`, 42.0},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestConcurrentFetches(t *testing.T) {
+	const delay = 200 * time.Millisecond
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(delay)
+		w.Write([]byte(r.URL.Path[1:]))
+	}))
+	defer server.Close()
+
+	input := fmt.Sprintf(`var url = "%s/";
+	var load = async (name) => { var res = await fetch(url + name); return res.body; };
+	var all = await Promise.all([load("a"), load("b"), load("c"), load("d"), load("e")]);
+	all[0] + all[1] + all[2] + all[3] + all[4];`, server.URL)
+
+	start := time.Now()
+	result := testEval(input)
+	elapsed := time.Since(start)
+
+	if result != "abcde" {
+		t.Errorf("Expected abcde, got %v", result)
+	}
+	if elapsed >= 5*delay {
+		t.Errorf("Expected the five requests to overlap, took %v", elapsed)
+	}
+}
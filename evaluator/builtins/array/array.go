@@ -2,12 +2,28 @@ package array
 
 import (
 	"go-script/internal"
+	"math"
+	"strconv"
+	"strings"
 )
 
 // ArrayReference wraps an array to make it mutable
 // This allows array methods like push to modify the array in place
+//
+// Named properties some arrays carry besides their elements, like the index
+// and groups of a RegExp match, live on the object of the SubclassSlot (see
+// OwnProperties); ordinary arrays have none.
 type ArrayReference struct {
+	internal.ObjectKind
 	Elements *internal.Array
+	internal.SubclassSlot
+
+	// Set by Object.preventExtensions, seal and freeze. A non-extensible
+	// array can't grow, a sealed one can't shrink either, and the elements
+	// of a frozen one can't change at all.
+	nonExtensible bool
+	sealed        bool
+	frozen        bool
 }
 
 func NewArrayReference(elements internal.Array) *ArrayReference {
@@ -21,13 +37,81 @@ func (ar *ArrayReference) Get(index int) internal.Value {
 	return (*ar.Elements)[index]
 }
 
-func (ar *ArrayReference) Set(index int, value internal.Value) {
-	if index >= 0 && index < len(*ar.Elements) {
-		(*ar.Elements)[index] = value
+// MaxGap is how far past its end a single write may grow an array.
+// Elements are stored densely, so without a limit a[4294967294] = 1 on an
+// empty array would try to allocate billions of slots.
+const MaxGap = 1 << 20
+
+// Set stores an element. Writing at or past the end grows the array, with
+// undefined filling any gap, so a[a.length] = x appends.
+// Writes a frozen or non-extensible array doesn't allow change nothing and
+// report false, and a write more than MaxGap past the end throws a
+// RangeError.
+func (ar *ArrayReference) Set(index int, value internal.Value) bool {
+	if index < 0 || ar.frozen || (ar.nonExtensible && index >= len(*ar.Elements)) {
+		return false
+	}
+	ar.grow(index + 1)
+	(*ar.Elements)[index] = value
+	return true
+}
+
+// SetLength changes the length the way assigning arr.length does: a
+// shorter length drops the elements past it, a longer one fills the gap
+// with undefined. A length that isn't a whole number below 2^32 throws a
+// RangeError.
+//
+// It reports false, changing nothing, when the array can't grow (it's
+// non-extensible) or shrink (it's sealed), or is frozen.
+//
+// Examples:
+//
+//	[1, 2, 3].length = 1 → [1]
+//	[1].length = 3       → [1, undefined, undefined]
+func (ar *ArrayReference) SetLength(value internal.Value) bool {
+	number := internal.ToNumber(value)
+	if number != math.Trunc(number) || number < 0 || number > math.MaxUint32 {
+		internal.ThrowError("RangeError", "Invalid array length")
+	}
+	length := int(number)
+
+	current := len(*ar.Elements)
+	switch {
+	case length == current:
+		return !ar.frozen
+	case length < current:
+		if ar.sealed {
+			return false
+		}
+		clear((*ar.Elements)[length:])
+		*ar.Elements = (*ar.Elements)[:length]
+	default:
+		if ar.nonExtensible {
+			return false
+		}
+		ar.grow(length)
+	}
+	return true
+}
+
+// grow extends the array to length elements, if it's shorter, throwing a
+// RangeError for growth of more than MaxGap
+func (ar *ArrayReference) grow(length int) {
+	current := len(*ar.Elements)
+	if length <= current {
+		return
+	}
+	if length-1-current > MaxGap {
+		internal.ThrowError("RangeError", "Invalid array index "+strconv.Itoa(length-1)+": too far past the end of an array of length "+strconv.Itoa(current))
 	}
+	*ar.Elements = append(*ar.Elements, make(internal.Array, length-current)...)
 }
 
+// Push appends values, throwing a TypeError if the array can't grow
 func (ar *ArrayReference) Push(values ...internal.Value) float64 {
+	if len(values) > 0 {
+		ar.CheckExtensible()
+	}
 	*ar.Elements = append(*ar.Elements, values...)
 	return float64(len(*ar.Elements))
 }
@@ -39,3 +123,298 @@ func (ar *ArrayReference) Length() float64 {
 func (ar *ArrayReference) GetElements() internal.Array {
 	return *ar.Elements
 }
+
+// PreventExtensions stops the array from growing (Object.preventExtensions)
+func (ar *ArrayReference) PreventExtensions() {
+	ar.nonExtensible = true
+	for _, obj := range ar.propertyObjects() {
+		obj.PreventExtensions()
+	}
+}
+
+// Seal stops the array from growing or shrinking (Object.seal)
+func (ar *ArrayReference) Seal() {
+	ar.nonExtensible = true
+	ar.sealed = true
+	for _, obj := range ar.propertyObjects() {
+		obj.Seal()
+	}
+}
+
+// Freeze makes the array and its elements read-only (Object.freeze)
+func (ar *ArrayReference) Freeze() {
+	ar.nonExtensible = true
+	ar.sealed = true
+	ar.frozen = true
+	for _, obj := range ar.propertyObjects() {
+		obj.Freeze()
+	}
+}
+
+func (ar *ArrayReference) IsExtensible() bool {
+	return !ar.nonExtensible
+}
+
+// IsSealed reports whether the array is sealed. Like an object, an empty
+// non-extensible array is sealed and frozen too.
+func (ar *ArrayReference) IsSealed() bool {
+	if !ar.sealed && !(ar.nonExtensible && len(*ar.Elements) == 0) {
+		return false
+	}
+	for _, obj := range ar.propertyObjects() {
+		if !obj.IsSealed() {
+			return false
+		}
+	}
+	return true
+}
+
+func (ar *ArrayReference) IsFrozen() bool {
+	if !ar.frozen && !(ar.nonExtensible && len(*ar.Elements) == 0) {
+		return false
+	}
+	for _, obj := range ar.propertyObjects() {
+		if !obj.IsFrozen() {
+			return false
+		}
+	}
+	return true
+}
+
+// propertyObjects returns the objects holding the array's other properties:
+// its named properties and, for instances of a subclass of Array, fields
+func (ar *ArrayReference) propertyObjects() []*internal.Object {
+	if ar.SubclassObject() == nil {
+		return nil
+	}
+	return []*internal.Object{ar.SubclassObject()}
+}
+
+// CheckExtensible throws the TypeError of adding an element to an array
+// that can't grow
+func (ar *ArrayReference) CheckExtensible() {
+	if ar.nonExtensible {
+		internal.ThrowError("TypeError", "Cannot add property "+strconv.Itoa(len(*ar.Elements))+", object is not extensible")
+	}
+}
+
+// CheckDeletable throws the TypeError of removing an element from a sealed
+// array
+func (ar *ArrayReference) CheckDeletable() {
+	if ar.sealed && len(*ar.Elements) > 0 {
+		internal.ThrowError("TypeError", "Cannot delete property '"+strconv.Itoa(len(*ar.Elements)-1)+"' of [object Array]")
+	}
+}
+
+// CheckWritable throws the TypeError of changing an element of a frozen
+// array in place, as sort, reverse and fill do
+func (ar *ArrayReference) CheckWritable() {
+	if ar.frozen && len(*ar.Elements) > 0 {
+		internal.ThrowError("TypeError", "Cannot assign to read only property '0' of object '[object Array]'")
+	}
+}
+
+// joining holds the arrays whose Join is running, so that an array that
+// contains itself contributes "" instead of recursing forever
+var joining = map[*ArrayReference]bool{}
+
+// Join converts every element to a string and joins them with separator.
+// undefined and null become empty strings, other elements go through
+// internal.ToString, so nested arrays are joined with commas.
+//
+// Examples:
+//
+//	[1, null, [2, 3]].join("-") → "1--2,3"
+//	a = [1]; a.push(a); a.join() → "1,"
+func (ar *ArrayReference) Join(separator string) string {
+	if joining[ar] {
+		return ""
+	}
+	joining[ar] = true
+	defer delete(joining, ar)
+
+	parts := make([]string, len(*ar.Elements))
+	for i, elem := range *ar.Elements {
+		if !internal.IsNullish(elem) {
+			parts[i] = internal.ToString(elem)
+		}
+	}
+	return strings.Join(parts, separator)
+}
+
+// ToPrimitive converts an array the way its toString method does, by
+// joining the elements with commas, whatever the hint
+//
+// Examples: "" + [1, 2] → "1,2", [5] * 2 → 10, [] + 1 → "1"
+func (ar *ArrayReference) ToPrimitive(hint string) internal.Value {
+	return ar.Join(",")
+}
+
+// Array is the global Array constructor
+//
+// Syntax: new Array(length), new Array(a, b, ...) or Array(...)
+//
+// With a single number it creates that many empty (undefined) elements;
+// otherwise the arguments become the elements.
+//
+// Examples:
+//
+//	new Array(3)       → [undefined, undefined, undefined]
+//	Array(1, 2)        → [1, 2]
+//	new Array(-1)      → throws RangeError: Invalid array length
+//
+// Lengths above MaxGap throw a RangeError too, since elements are stored
+// densely.
+var Array = &internal.Builtin{
+	Name: "Array",
+	Fn: func(args ...interface{}) interface{} {
+		return construct(args)
+	},
+	Construct: func(args ...interface{}) interface{} {
+		return construct(args)
+	},
+}
+
+func construct(args []interface{}) *ArrayReference {
+	if len(args) == 1 {
+		if n, ok := args[0].(float64); ok {
+			if n < 0 || n != math.Trunc(n) || n > math.MaxUint32 {
+				internal.ThrowError("RangeError", "Invalid array length")
+			}
+			checkDenseLength(n)
+			return NewArrayReference(make(internal.Array, int(n)))
+		}
+	}
+	return NewArrayReference(toElements(args))
+}
+
+// IsArray reports whether a value is an array
+//
+// Syntax: Array.isArray(value)
+//
+// Examples:
+//
+//	Array.isArray([1])   → true
+//	Array.isArray("ab")  → false
+var IsArray = &internal.Builtin{
+	Name: "isArray",
+	Fn: func(args ...interface{}) interface{} {
+		_, ok := firstArg(args).(*ArrayReference)
+		return ok
+	},
+}
+
+// Of creates an array of its arguments, even when there is a single number
+//
+// Syntax: Array.of(a, b, ...)
+//
+// Example: Array.of(3) → [3]
+var Of = &internal.Builtin{
+	Name: "of",
+	Fn: func(args ...interface{}) interface{} {
+		return NewArrayReference(toElements(args))
+	},
+}
+
+// From creates an array from an iterable (an array, a string, a generator,
+// anything with [Symbol.iterator]) or from an array-like object with a
+// length. An optional function maps each value and its index.
+//
+// Syntax: Array.from(items, mapFn)
+//
+// Examples:
+//
+//	Array.from("abc")                   → ["a", "b", "c"]
+//	Array.from({ length: 3 }, (_, i) => i * 2) → [0, 2, 4]
+//	Array.from([1, 2], x => x + 1)      → [2, 3]
+var From = &internal.Builtin{
+	Name: "from",
+	Fn: func(args ...interface{}) interface{} {
+		items := firstArg(args)
+		if internal.IsNullish(items) {
+			internal.ThrowError("TypeError", internal.Inspect(items)+" is not iterable")
+		}
+
+		var mapFn internal.Callable
+		if len(args) > 1 && args[1] != nil {
+			fn, ok := args[1].(internal.Callable)
+			if !ok {
+				internal.ThrowError("TypeError", internal.Inspect(args[1])+" is not a function")
+			}
+			mapFn = fn
+		}
+
+		values, ok := internal.IterableToList(items)
+		if !ok {
+			values = arrayLikeValues(items)
+		}
+
+		if mapFn != nil {
+			for i, value := range values {
+				values[i] = mapFn.Call(nil, value, float64(i))
+			}
+		}
+		return NewArrayReference(values)
+	},
+}
+
+// arrayLikeValues reads obj[0] to obj[length - 1] of an object with a length
+// Values without a length give an empty list.
+func arrayLikeValues(items internal.Value) internal.Array {
+	obj, ok := items.(*internal.Object)
+	if !ok {
+		return internal.Array{}
+	}
+	length, _ := obj.Get("length").(float64)
+	if math.IsNaN(length) || length < 0 {
+		length = 0
+	}
+
+	checkDenseLength(length)
+	values := make(internal.Array, int(length))
+	for i := range values {
+		values[i] = obj.Get(internal.ToPropertyKey(float64(i)))
+	}
+	return values
+}
+
+// checkDenseLength throws a RangeError before an array of length elements
+// is allocated, if that is more than MaxGap
+func checkDenseLength(length float64) {
+	if length > MaxGap {
+		internal.ThrowError("RangeError", "Invalid array length "+internal.ToString(length)+": too large to allocate")
+	}
+}
+
+// HasInstance makes "x instanceof Array" recognise arrays
+var HasInstance = &internal.Builtin{
+	Name: "[Symbol.hasInstance]",
+	Fn: func(args ...interface{}) interface{} {
+		_, ok := firstArg(args).(*ArrayReference)
+		return ok
+	},
+}
+
+func toElements(args []interface{}) internal.Array {
+	elements := make(internal.Array, len(args))
+	for i, arg := range args {
+		elements[i] = arg
+	}
+	return elements
+}
+
+func firstArg(args []interface{}) internal.Value {
+	if len(args) == 0 {
+		return nil
+	}
+	return args[0]
+}
+
+func init() {
+	statics := internal.NewObject()
+	statics.Set("isArray", IsArray)
+	statics.Set("of", Of)
+	statics.Set("from", From)
+	statics.Set(internal.SymbolHasInstance, HasInstance)
+	Array.Properties = statics
+}
@@ -2,6 +2,9 @@ package array
 
 import (
 	"go-script/internal"
+	"math"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -61,14 +64,56 @@ func TestArrayReferenceSet(t *testing.T) {
 		t.Errorf("Set(2, 'last') failed, got %v", (*arr.Elements)[2])
 	}
 
-	// Invalid sets (should not panic or modify array)
-	originalLen := len(*arr.Elements)
+	// Negative indexes are ignored
 	arr.Set(-1, "negative")
-	arr.Set(3, "out of bounds")
-	arr.Set(100, "way out")
+	if len(*arr.Elements) != 3 {
+		t.Errorf("Set(-1) modified the array length")
+	}
+
+	// Writing at the end appends, past the end fills the gap with undefined
+	arr.Set(3, "appended")
+	arr.Set(5, "way out")
+	expected := internal.Array{"first", 99.0, "last", "appended", nil, "way out"}
+	if !reflect.DeepEqual(*arr.Elements, expected) {
+		t.Errorf("Expected %v after growing, got %v", expected, *arr.Elements)
+	}
+}
+
+func TestArrayReferenceSetFarPastTheEnd(t *testing.T) {
+	arr := NewArrayReference(internal.Array{1.0})
+
+	arr.Set(1+MaxGap, "edge")
+	if len(*arr.Elements) != 2+MaxGap {
+		t.Errorf("A write MaxGap past the end should grow the array, length is %d", len(*arr.Elements))
+	}
+
+	exception := internal.Catch(func() { arr.Set(4294967294, 1.0) })
+	if exception == nil || !internal.IsError(exception.Value) || !strings.HasPrefix(internal.ErrorString(exception.Value), "RangeError: ") {
+		t.Fatalf("Expected a RangeError, got %v", exception)
+	}
+	if len(*arr.Elements) != 2+MaxGap {
+		t.Errorf("A rejected write should leave the array alone, length is %d", len(*arr.Elements))
+	}
+}
+
+func TestArrayReferenceIntegrity(t *testing.T) {
+	arr := NewArrayReference(internal.Array{1.0, 2.0})
+	arr.PreventExtensions()
+	arr.Set(2, 3.0)
+	arr.Set(0, 9.0)
+	if !reflect.DeepEqual(*arr.Elements, internal.Array{9.0, 2.0}) || arr.IsExtensible() || arr.IsSealed() {
+		t.Errorf("A non-extensible array should only change in place, got %v", *arr.Elements)
+	}
+
+	arr.Freeze()
+	arr.Set(0, 1.0)
+	if (*arr.Elements)[0] != 9.0 || !arr.IsFrozen() || !arr.IsSealed() {
+		t.Errorf("A frozen array should not change, got %v", *arr.Elements)
+	}
 
-	if len(*arr.Elements) != originalLen {
-		t.Errorf("Invalid Set operations modified array length")
+	exception := internal.Catch(func() { arr.Push(3.0) })
+	if exception == nil || internal.ErrorString(exception.Value) != "TypeError: Cannot add property 2, object is not extensible" {
+		t.Errorf("Push on a frozen array should throw, got %v", exception)
 	}
 }
 
@@ -211,3 +256,59 @@ func TestArrayReferenceNestedArrays(t *testing.T) {
 		t.Error("Get(0) didn't return an array")
 	}
 }
+
+func TestArrayConstructor(t *testing.T) {
+	tests := []struct {
+		args     []interface{}
+		expected int
+	}{
+		{[]interface{}{}, 0},
+		{[]interface{}{3.0}, 3},
+		{[]interface{}{"3"}, 1},
+		{[]interface{}{1.0, 2.0}, 2},
+	}
+
+	for _, tt := range tests {
+		arr := Array.Construct(tt.args...).(*ArrayReference)
+		if len(*arr.Elements) != tt.expected {
+			t.Errorf("For args %v: expected length %d, got %d", tt.args, tt.expected, len(*arr.Elements))
+		}
+	}
+
+	for _, length := range []float64{-1, 1.5, math.MaxUint32 + 1} {
+		exception := internal.Catch(func() { Array.Construct(length) })
+		if exception == nil || !internal.IsError(exception.Value) || internal.ErrorString(exception.Value) != "RangeError: Invalid array length" {
+			t.Errorf("For length %v: expected a RangeError, got %v", length, exception)
+		}
+	}
+
+	// Valid lengths that are too large to store densely fail before allocating
+	for _, length := range []float64{MaxGap + 1, math.MaxUint32} {
+		exception := internal.Catch(func() { Array.Construct(length) })
+		if exception == nil || !strings.HasPrefix(internal.ErrorString(exception.Value), "RangeError: ") {
+			t.Errorf("For length %v: expected a RangeError, got %v", length, exception)
+		}
+	}
+}
+
+func TestArrayFromArrayLike(t *testing.T) {
+	obj := internal.NewObject()
+	obj.Set("length", 3.0)
+	obj.Set("0", "a")
+	obj.Set("2", "c")
+
+	arr := From.Fn(obj).(*ArrayReference)
+	expected := internal.Array{"a", nil, "c"}
+	if len(*arr.Elements) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, *arr.Elements)
+	}
+	for i, value := range expected {
+		if (*arr.Elements)[i] != value {
+			t.Errorf("Element %d: expected %v, got %v", i, value, (*arr.Elements)[i])
+		}
+	}
+
+	if empty := From.Fn(internal.NewObject()).(*ArrayReference); len(*empty.Elements) != 0 {
+		t.Errorf("An object without a length should give an empty array, got %v", *empty.Elements)
+	}
+}
@@ -0,0 +1,30 @@
+package boolean
+
+import (
+	"go-script/internal"
+)
+
+// Boolean converts its argument to true or false
+//
+// Syntax: Boolean(value)
+//
+// false, 0, NaN, "", null and undefined give false; everything else,
+// including every object and the string "false", gives true. As with Number,
+// there are no wrapper objects, so new Boolean() is not supported.
+//
+// Examples:
+//
+//	Boolean(1)          → true
+//	Boolean("")         → false
+//	Boolean("false")    → true
+//	Boolean([])         → true
+//	[0, 1, ""].filter(Boolean) → [1]
+var Boolean = &internal.Builtin{
+	Name: "Boolean",
+	Fn: func(args ...interface{}) interface{} {
+		if len(args) == 0 {
+			return false
+		}
+		return internal.ToBoolean(args[0])
+	},
+}
@@ -0,0 +1,33 @@
+package boolean
+
+import (
+	"go-script/internal"
+	"math"
+	"testing"
+)
+
+func TestBoolean(t *testing.T) {
+	tests := []struct {
+		args     []interface{}
+		expected bool
+	}{
+		{[]interface{}{}, false},
+		{[]interface{}{nil}, false},
+		{[]interface{}{internal.Null}, false},
+		{[]interface{}{0.0}, false},
+		{[]interface{}{math.NaN()}, false},
+		{[]interface{}{""}, false},
+		{[]interface{}{false}, false},
+		{[]interface{}{1.0}, true},
+		{[]interface{}{-1.0}, true},
+		{[]interface{}{"false"}, true},
+		{[]interface{}{"0"}, true},
+		{[]interface{}{internal.NewObject()}, true},
+	}
+
+	for _, tt := range tests {
+		if result := Boolean.Fn(tt.args...); result != tt.expected {
+			t.Errorf("Boolean(%v): expected %v, got %v", tt.args, tt.expected, result)
+		}
+	}
+}
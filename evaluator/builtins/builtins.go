@@ -1,17 +1,25 @@
 package builtins
 
 import (
+	"go-script/evaluator/builtins/array"
+	"go-script/evaluator/builtins/boolean"
+	"go-script/evaluator/builtins/collection"
+	"go-script/evaluator/builtins/console"
+	"go-script/evaluator/builtins/date"
+	"go-script/evaluator/builtins/errors"
 	"go-script/evaluator/builtins/fetch"
 	"go-script/evaluator/builtins/json"
+	"go-script/evaluator/builtins/math"
+	"go-script/evaluator/builtins/number"
+	"go-script/evaluator/builtins/object"
 	"go-script/evaluator/builtins/print"
+	"go-script/evaluator/builtins/promise"
+	"go-script/evaluator/builtins/regexp"
+	"go-script/evaluator/builtins/symbol"
+	"go-script/evaluator/builtins/timers"
 	"go-script/internal"
 )
 
-var builtins = map[string]*internal.Builtin{
-	"print": {Name: print.Print.Name, Fn: print.Print.Fn},
-	"fetch": {Name: fetch.Fetch.Name, Fn: fetch.Fetch.Fn},
-}
-
 var jsonNamespace = make(map[string]*internal.Builtin)
 
 func init() {
@@ -20,11 +28,111 @@ func init() {
 	}
 }
 
-func Get(name string) (*internal.Builtin, bool) {
-	builtin, ok := builtins[name]
-	return builtin, ok
-}
-
 func GetJSON() map[string]*internal.Builtin {
 	return jsonNamespace
 }
+
+// GetMath returns the members of the Math namespace: constants like PI,
+// which the global Math object holds as read-only properties, and
+// functions like floor and max
+func GetMath() map[string]internal.Value {
+	return math.Math
+}
+
+// GetSymbol returns the global Symbol function, whose static members hold
+// Symbol.for, Symbol.keyFor and the well-known symbols
+func GetSymbol() *internal.Builtin {
+	return symbol.Symbol
+}
+
+// GetPromise returns the global Promise constructor, whose static members
+// hold Promise.resolve, Promise.all and the other combinators. Its promises
+// run their reactions on loop.
+func GetPromise(loop *internal.EventLoop) *internal.Builtin {
+	return promise.New(loop)
+}
+
+// GetRegExp returns the global RegExp constructor
+func GetRegExp() *internal.Builtin {
+	return regexp.Constructor
+}
+
+// GetArray returns the global Array constructor, whose static members hold
+// Array.isArray, Array.from and Array.of
+func GetArray() *internal.Builtin {
+	return array.Array
+}
+
+// GetNumber returns the global Number function, whose static members hold
+// Number.isInteger, Number.MAX_SAFE_INTEGER and the other constants
+func GetNumber() *internal.Builtin {
+	return number.Number
+}
+
+// GetBoolean returns the global Boolean function
+func GetBoolean() *internal.Builtin {
+	return boolean.Boolean
+}
+
+// GetGlobalFunctions returns the functions that are plain global values:
+// print, fetch, parseInt, parseFloat, isNaN and isFinite. fetch's requests
+// run on loop.
+func GetGlobalFunctions(loop *internal.EventLoop) map[string]*internal.Builtin {
+	return map[string]*internal.Builtin{
+		"print":      print.Print,
+		"fetch":      fetch.Fetch(loop),
+		"parseInt":   number.ParseInt,
+		"parseFloat": number.ParseFloat,
+		"isNaN":      number.IsNaN,
+		"isFinite":   number.IsFinite,
+	}
+}
+
+// GetTimers returns the event loop functions: setTimeout, setInterval,
+// clearTimeout, clearInterval and queueMicrotask, all scheduling on loop
+func GetTimers(loop *internal.EventLoop) map[string]*internal.Builtin {
+	return map[string]*internal.Builtin{
+		"setTimeout":     timers.SetTimeout(loop),
+		"setInterval":    timers.SetInterval(loop),
+		"clearTimeout":   timers.ClearTimeout(loop),
+		"clearInterval":  timers.ClearInterval(loop),
+		"queueMicrotask": timers.QueueMicrotask(loop),
+	}
+}
+
+// GetObject returns the global Object function, whose static members hold
+// Object.keys, Object.defineProperty and the rest
+func GetObject() *internal.Builtin {
+	return object.Object
+}
+
+// GetDate returns the global Date constructor, which reads the system clock
+// and uses the local time zone. evaluator.SetClock replaces it with one
+// that uses a given clock and zone.
+func GetDate() *internal.Builtin {
+	return date.Constructor
+}
+
+// GetCollections returns the keyed collection constructors: Map, Set,
+// WeakMap and WeakSet
+func GetCollections() map[string]*internal.Builtin {
+	return map[string]*internal.Builtin{
+		"Map":     collection.MapConstructor,
+		"Set":     collection.SetConstructor,
+		"WeakMap": collection.WeakMapConstructor,
+		"WeakSet": collection.WeakSetConstructor,
+	}
+}
+
+// GetErrors returns the native error constructors: Error, TypeError,
+// RangeError, ReferenceError, SyntaxError, EvalError, URIError and
+// AggregateError
+func GetErrors() map[string]*internal.Builtin {
+	return errors.Constructors
+}
+
+// GetConsole returns a new console object writing to stdout and stderr
+// evaluator.SetConsole replaces it with one writing elsewhere.
+func GetConsole() *internal.Object {
+	return console.NewObject()
+}
@@ -1,47 +1,29 @@
 package builtins
 
 import (
+	"go-script/internal"
 	"testing"
 )
 
-func TestGet(t *testing.T) {
-	tests := []struct {
-		name     string
-		expected bool
-	}{
-		{"print", true},
-		{"fetch", true},
-		{"nonexistent", false},
-		{"", false},
-	}
+func TestGetGlobalFunctions(t *testing.T) {
+	functions := GetGlobalFunctions(internal.NewEventLoop())
 
-	for _, tt := range tests {
-		builtin, ok := Get(tt.name)
-		if ok != tt.expected {
-			t.Errorf("Get(%q) returned ok=%v, expected %v", tt.name, ok, tt.expected)
-		}
-		if ok && builtin == nil {
-			t.Errorf("Get(%q) returned nil builtin", tt.name)
-		}
-		if ok && builtin.Name != tt.name {
-			t.Errorf("Get(%q) returned builtin with name %q", tt.name, builtin.Name)
+	for _, name := range []string{"print", "fetch", "parseInt", "parseFloat", "isNaN", "isFinite"} {
+		if builtin, ok := functions[name]; !ok || builtin.Name != name || builtin.Fn == nil {
+			t.Errorf("Expected global function %q, got %v", name, builtin)
 		}
 	}
+	if _, ok := functions["setTimeout"]; ok {
+		t.Error("setTimeout belongs to GetTimers")
+	}
 }
 
-func TestBuiltinRegistry(t *testing.T) {
-	expectedBuiltins := []string{"print", "fetch"}
+func TestGetTimers(t *testing.T) {
+	timers := GetTimers(internal.NewEventLoop())
 
-	for _, name := range expectedBuiltins {
-		builtin, ok := Get(name)
-		if !ok {
-			t.Errorf("Expected builtin %q to be registered", name)
-		}
-		if builtin == nil {
-			t.Errorf("Builtin %q is nil", name)
-		}
-		if builtin.Fn == nil {
-			t.Errorf("Builtin %q has nil function", name)
+	for _, name := range []string{"setTimeout", "setInterval", "clearTimeout", "clearInterval", "queueMicrotask"} {
+		if builtin, ok := timers[name]; !ok || builtin.Name != name || builtin.Fn == nil {
+			t.Errorf("Expected timer function %q, got %v", name, builtin)
 		}
 	}
 }
@@ -0,0 +1,421 @@
+package collection
+
+import (
+	"go-script/evaluator/builtins/array"
+	"go-script/internal"
+	"strconv"
+	"strings"
+)
+
+// Map is a JavaScript Map: values stored under keys of any type, in the
+// order the keys were first set
+//
+// Unlike an object's properties, keys aren't converted to strings: 1 and
+// "1" are different keys, and an object is a key by identity.
+//
+// Example:
+//
+//	var seen = new Map();
+//	seen.set(user, 1).set("1", "one").set(1, "uno");
+//	seen.get(user) → 1, seen.size → 3
+type Map struct {
+	internal.ObjectKind
+	*OrderedMap
+	internal.SubclassSlot
+}
+
+// Set is a JavaScript Set: unique values in insertion order
+//
+// Example:
+//
+//	new Set([1, 2, 2, NaN, NaN]).size → 3
+type Set struct {
+	internal.ObjectKind
+	*OrderedMap
+	internal.SubclassSlot
+}
+
+// WeakMap is a JavaScript WeakMap: values stored under object keys
+//
+// Entries can't be enumerated, which leaves the runtime free to drop them:
+// the map doesn't keep its keys alive, and an entry goes away once the
+// collector reclaims its key.
+type WeakMap struct {
+	internal.ObjectKind
+	entries *weakTable[internal.Value]
+	internal.SubclassSlot
+}
+
+// WeakSet is a JavaScript WeakSet: a set of objects that can't be enumerated
+// and that doesn't keep its members alive
+type WeakSet struct {
+	internal.ObjectKind
+	entries *weakTable[struct{}]
+	internal.SubclassSlot
+}
+
+// NewMap returns an empty Map
+func NewMap() *Map {
+	return &Map{OrderedMap: NewOrderedMap()}
+}
+
+// NewSet returns an empty Set
+func NewSet() *Set {
+	return &Set{OrderedMap: NewOrderedMap()}
+}
+
+// NewWeakMap returns an empty WeakMap
+func NewWeakMap() *WeakMap {
+	return &WeakMap{entries: newWeakTable[internal.Value]()}
+}
+
+// NewWeakSet returns an empty WeakSet
+func NewWeakSet() *WeakSet {
+	return &WeakSet{entries: newWeakTable[struct{}]()}
+}
+
+// MapConstructor is the global Map function
+//
+// Syntax: new Map(iterable)
+//
+// The iterable, if given, yields [key, value] entries, such as another
+// Map or the result of Object.entries.
+//
+// Examples:
+//
+//	new Map()                          → Map(0) {}
+//	new Map([["a", 1], ["b", 2]])      → Map(2) {a => 1, b => 2}
+//	new Map(Object.entries({ x: 1 }))  → Map(1) {x => 1}
+//	new Map([1, 2])                    → throws TypeError
+var MapConstructor = &internal.Builtin{
+	Name: "Map",
+	Fn: func(args ...interface{}) interface{} {
+		internal.ThrowError("TypeError", "Constructor Map requires 'new'")
+		return nil
+	},
+	Construct: func(args ...interface{}) interface{} {
+		m := NewMap()
+		addEntries(argAt(args, 0), func(entry internal.Value) {
+			key, value := entryParts(entry)
+			m.Set(key, value)
+		})
+		return m
+	},
+}
+
+// SetConstructor is the global Set function
+//
+// Syntax: new Set(iterable)
+//
+// Examples:
+//
+//	new Set([1, 1, 2])  → Set(2) {1, 2}
+//	new Set("hello")    → Set(4) {h, e, l, o}
+var SetConstructor = &internal.Builtin{
+	Name: "Set",
+	Fn: func(args ...interface{}) interface{} {
+		internal.ThrowError("TypeError", "Constructor Set requires 'new'")
+		return nil
+	},
+	Construct: func(args ...interface{}) interface{} {
+		s := NewSet()
+		addEntries(argAt(args, 0), func(value internal.Value) {
+			s.Add(value)
+		})
+		return s
+	},
+}
+
+// WeakMapConstructor is the global WeakMap function
+//
+// Syntax: new WeakMap(iterable)
+//
+// Example:
+//
+//	var meta = new WeakMap([[node, { visited: true }]]);
+//	meta.get(node).visited → true
+//	meta.set("id", 1)      → throws TypeError
+var WeakMapConstructor = &internal.Builtin{
+	Name: "WeakMap",
+	Fn: func(args ...interface{}) interface{} {
+		internal.ThrowError("TypeError", "Constructor WeakMap requires 'new'")
+		return nil
+	},
+	Construct: func(args ...interface{}) interface{} {
+		m := NewWeakMap()
+		addEntries(argAt(args, 0), func(entry internal.Value) {
+			key, value := entryParts(entry)
+			m.Set(key, value)
+		})
+		return m
+	},
+}
+
+// WeakSetConstructor is the global WeakSet function
+//
+// Syntax: new WeakSet(iterable)
+//
+// Example:
+//
+//	var visited = new WeakSet([a, b]);
+//	visited.has(a) → true
+var WeakSetConstructor = &internal.Builtin{
+	Name: "WeakSet",
+	Fn: func(args ...interface{}) interface{} {
+		internal.ThrowError("TypeError", "Constructor WeakSet requires 'new'")
+		return nil
+	},
+	Construct: func(args ...interface{}) interface{} {
+		s := NewWeakSet()
+		addEntries(argAt(args, 0), func(value internal.Value) {
+			s.Add(value)
+		})
+		return s
+	},
+}
+
+// GroupBy sorts the items of an iterable into arrays in a Map, by the key
+// the callback returns for each. Unlike Object.groupBy, the keys aren't
+// converted to strings.
+//
+// Syntax: Map.groupBy(items, callback(item, index))
+//
+// Example:
+//
+//	Map.groupBy(users, u => u.team)
+//	→ Map(2) {red => [...], blue => [...]}, keyed by the team objects
+var GroupBy = &internal.Builtin{
+	Name: "groupBy",
+	Fn: func(args ...interface{}) interface{} {
+		items := argAt(args, 0)
+		callback, ok := argAt(args, 1).(internal.Callable)
+		if !ok {
+			internal.ThrowError("TypeError", internal.Inspect(argAt(args, 1))+" is not a function")
+		}
+		values, ok := internal.IterableToList(items)
+		if !ok {
+			internal.ThrowError("TypeError", internal.Inspect(items)+" is not iterable")
+		}
+
+		groups := NewMap()
+		for i, value := range values {
+			key := callback.Call(nil, value, float64(i))
+			if group, ok := groups.Get(key); ok {
+				group.(*array.ArrayReference).Push(value)
+				continue
+			}
+			groups.Set(key, array.NewArrayReference(internal.Array{value}))
+		}
+		return groups
+	},
+}
+
+func init() {
+	statics := internal.NewObject()
+	statics.Set("groupBy", GroupBy)
+	statics.Set(internal.SymbolHasInstance, hasInstance(func(v internal.Value) bool { _, ok := v.(*Map); return ok }))
+	MapConstructor.Properties = statics
+
+	statics = internal.NewObject()
+	statics.Set(internal.SymbolHasInstance, hasInstance(func(v internal.Value) bool { _, ok := v.(*Set); return ok }))
+	SetConstructor.Properties = statics
+
+	statics = internal.NewObject()
+	statics.Set(internal.SymbolHasInstance, hasInstance(func(v internal.Value) bool { _, ok := v.(*WeakMap); return ok }))
+	WeakMapConstructor.Properties = statics
+
+	statics = internal.NewObject()
+	statics.Set(internal.SymbolHasInstance, hasInstance(func(v internal.Value) bool { _, ok := v.(*WeakSet); return ok }))
+	WeakSetConstructor.Properties = statics
+}
+
+// hasInstance makes "x instanceof C" recognise one of the collection types
+func hasInstance(is func(internal.Value) bool) *internal.Builtin {
+	return &internal.Builtin{
+		Name: "[Symbol.hasInstance]",
+		Fn: func(args ...interface{}) interface{} {
+			return is(argAt(args, 0))
+		},
+	}
+}
+
+// Add puts a value in the set, if it isn't there already
+func (s *Set) Add(value internal.Value) {
+	if !s.Has(value) {
+		s.OrderedMap.Set(value, value)
+	}
+}
+
+// Get returns the value stored under an object key, or undefined
+func (m *WeakMap) Get(key internal.Value) internal.Value {
+	value, _ := m.entries.get(key)
+	return value
+}
+
+// Has reports whether there is a value under an object key
+func (m *WeakMap) Has(key internal.Value) bool {
+	_, ok := m.entries.get(key)
+	return ok
+}
+
+// Set stores a value under an object key, throwing a TypeError for
+// primitive keys
+func (m *WeakMap) Set(key internal.Value, value internal.Value) {
+	if !canBeHeldWeakly(key) {
+		internal.ThrowError("TypeError", "Invalid value used as weak map key")
+	}
+	m.entries.set(key, value)
+}
+
+// Delete removes the value under an object key, reporting whether there
+// was one
+func (m *WeakMap) Delete(key internal.Value) bool {
+	return m.entries.delete(key)
+}
+
+// Add puts an object in the set, throwing a TypeError for primitives
+func (s *WeakSet) Add(value internal.Value) {
+	if !canBeHeldWeakly(value) {
+		internal.ThrowError("TypeError", "Invalid value used in weak set")
+	}
+	s.entries.set(value, struct{}{})
+}
+
+// Has reports whether an object is in the set
+func (s *WeakSet) Has(value internal.Value) bool {
+	_, ok := s.entries.get(value)
+	return ok
+}
+
+// Delete removes an object from the set, reporting whether it was there
+func (s *WeakSet) Delete(value internal.Value) bool {
+	return s.entries.delete(value)
+}
+
+// IterationValues returns the [key, value] entries of the map, which is
+// what iterating over a Map yields
+func (m *Map) IterationValues() []internal.Value {
+	values := []internal.Value{}
+	next := m.Iterate()
+	for entry, ok := next(); ok; entry, ok = next() {
+		values = append(values, array.NewArrayReference(internal.Array{entry.Key, entry.Value}))
+	}
+	return values
+}
+
+// IterationValues returns the members of the set in insertion order
+func (s *Set) IterationValues() []internal.Value {
+	values := []internal.Value{}
+	next := s.Iterate()
+	for entry, ok := next(); ok; entry, ok = next() {
+		values = append(values, entry.Key)
+	}
+	return values
+}
+
+// String writes a Map the way Node's console does
+//
+// Example: Map(2) {a => 1, b => [1, 2]}
+func (m *Map) String() string {
+	return m.InspectWith(internal.Inspect)
+}
+
+// InspectWith writes the Map like String, with its keys and values
+// written by inspect
+func (m *Map) InspectWith(inspect func(internal.Value) string) string {
+	parts := []string{}
+	next := m.Iterate()
+	for entry, ok := next(); ok; entry, ok = next() {
+		parts = append(parts, inspect(entry.Key)+" => "+inspect(entry.Value))
+	}
+	return "Map(" + strconv.Itoa(m.Size()) + ") {" + strings.Join(parts, ", ") + "}"
+}
+
+// String writes a Set the way Node's console does
+//
+// Example: Set(3) {1, two, [3]}
+func (s *Set) String() string {
+	return s.InspectWith(internal.Inspect)
+}
+
+// InspectWith writes the Set like String, with its members written by
+// inspect
+func (s *Set) InspectWith(inspect func(internal.Value) string) string {
+	parts := []string{}
+	next := s.Iterate()
+	for entry, ok := next(); ok; entry, ok = next() {
+		parts = append(parts, inspect(entry.Key))
+	}
+	return "Set(" + strconv.Itoa(s.Size()) + ") {" + strings.Join(parts, ", ") + "}"
+}
+
+// String writes a WeakMap without its entries, which can't be listed
+func (m *WeakMap) String() string {
+	return "WeakMap { <items unknown> }"
+}
+
+// String writes a WeakSet without its entries, which can't be listed
+func (s *WeakSet) String() string {
+	return "WeakSet { <items unknown> }"
+}
+
+// MarshalJSON writes collections as empty objects, as JSON.stringify does
+func (m *Map) MarshalJSON() ([]byte, error) { return []byte("{}"), nil }
+
+// MarshalJSON writes collections as empty objects, as JSON.stringify does
+func (s *Set) MarshalJSON() ([]byte, error) { return []byte("{}"), nil }
+
+// MarshalJSON writes collections as empty objects, as JSON.stringify does
+func (m *WeakMap) MarshalJSON() ([]byte, error) { return []byte("{}"), nil }
+
+// MarshalJSON writes collections as empty objects, as JSON.stringify does
+func (s *WeakSet) MarshalJSON() ([]byte, error) { return []byte("{}"), nil }
+
+// canBeHeldWeakly reports whether a value can be a WeakMap key or WeakSet
+// member: objects, arrays and functions, but not primitives or symbols
+func canBeHeldWeakly(val internal.Value) bool {
+	_, ok := internal.Referent(val)
+	return ok
+}
+
+// addEntries calls add for every value of the iterable a constructor was
+// given; undefined and null add nothing
+func addEntries(iterable internal.Value, add func(internal.Value)) {
+	if internal.IsNullish(iterable) {
+		return
+	}
+	values, ok := internal.IterableToList(iterable)
+	if !ok {
+		internal.ThrowError("TypeError", internal.Inspect(iterable)+" is not iterable")
+	}
+	for _, value := range values {
+		add(value)
+	}
+}
+
+// entryParts reads the key and value of a [key, value] entry
+func entryParts(entry internal.Value) (internal.Value, internal.Value) {
+	switch e := entry.(type) {
+	case internal.ArrayLike:
+		elements := e.GetElements()
+		var key, value internal.Value
+		if len(elements) > 0 {
+			key = elements[0]
+		}
+		if len(elements) > 1 {
+			value = elements[1]
+		}
+		return key, value
+	case *internal.Object:
+		return e.Get("0"), e.Get("1")
+	}
+	internal.ThrowError("TypeError", "Iterator value "+internal.Inspect(entry)+" is not an entry object")
+	return nil, nil
+}
+
+func argAt(args []interface{}, index int) internal.Value {
+	if index >= len(args) {
+		return nil
+	}
+	return args[index]
+}
@@ -0,0 +1,236 @@
+package collection
+
+import (
+	"go-script/evaluator/builtins/array"
+	"go-script/internal"
+	"math"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestHashKey(t *testing.T) {
+	obj := internal.NewObject()
+	arr := array.NewArrayReference(internal.Array{1.0})
+	sym := internal.NewSymbol("id")
+
+	tests := []struct {
+		a, b     internal.Value
+		expected bool
+	}{
+		{math.NaN(), math.NaN(), true},
+		{0.0, math.Copysign(0, -1), true},
+		{1.0, 1.0, true},
+		{1.0, "1", false},
+		{"a", "a", true},
+		{nil, nil, true},
+		{nil, internal.Null, false},
+		{true, 1.0, false},
+		{obj, obj, true},
+		{obj, internal.NewObject(), false},
+		{arr, arr, true},
+		{arr, array.NewArrayReference(internal.Array{1.0}), false},
+		{sym, sym, true},
+		{sym, internal.NewSymbol("id"), false},
+	}
+
+	for _, tt := range tests {
+		result := hashKey(tt.a) == hashKey(tt.b)
+		if result != tt.expected {
+			t.Errorf("For keys %v and %v: expected a match to be %v, got %v", tt.a, tt.b, tt.expected, result)
+		}
+	}
+}
+
+func TestOrderedMap(t *testing.T) {
+	m := NewOrderedMap()
+	m.Set("a", 1.0)
+	m.Set("b", 2.0)
+	m.Set("c", 3.0)
+	m.Set("a", 4.0) // keeps its place
+
+	if keys := keysOf(m); keys != "abc" {
+		t.Errorf("Expected keys in insertion order abc, got %s", keys)
+	}
+	if value, _ := m.Get("a"); value != 4.0 {
+		t.Errorf("Expected a to be updated to 4, got %v", value)
+	}
+	if !m.Delete("b") || m.Delete("b") || m.Size() != 2 {
+		t.Errorf("Expected b to be deleted once, leaving 2 entries, got %d", m.Size())
+	}
+
+	m.Set(math.Copysign(0, -1), "zero")
+	next := m.Iterate()
+	var last *Entry
+	for entry, ok := next(); ok; entry, ok = next() {
+		last = entry
+	}
+	if key := last.Key.(float64); math.Signbit(key) {
+		t.Errorf("Expected -0 to be stored as 0")
+	}
+}
+
+func TestOrderedMapIterationWhileChanging(t *testing.T) {
+	tests := []struct {
+		name     string
+		change   func(m *OrderedMap, key string)
+		expected string
+	}{
+		{"add while iterating", func(m *OrderedMap, key string) {
+			if key == "a" {
+				m.Set("d", nil)
+			}
+		}, "abcd"},
+		{"delete ahead", func(m *OrderedMap, key string) {
+			if key == "a" {
+				m.Delete("b")
+			}
+		}, "ac"},
+		{"delete current", func(m *OrderedMap, key string) {
+			if key == "b" {
+				m.Delete("b")
+			}
+		}, "abc"},
+		{"delete current and previous", func(m *OrderedMap, key string) {
+			if key == "b" {
+				m.Delete("b")
+				m.Delete("a")
+			}
+		}, "abc"},
+		{"delete the last and add", func(m *OrderedMap, key string) {
+			if key == "c" {
+				m.Delete("c")
+				m.Set("d", nil)
+			}
+		}, "abcd"},
+		{"delete and add back", func(m *OrderedMap, key string) {
+			if value, _ := m.Get("a"); key == "a" && value == nil {
+				m.Delete("a")
+				m.Set("a", true)
+			}
+		}, "abca"},
+		{"clear and add", func(m *OrderedMap, key string) {
+			if key == "b" {
+				m.Clear()
+				m.Set("x", nil)
+			}
+		}, "abx"},
+	}
+
+	for _, tt := range tests {
+		m := NewOrderedMap()
+		for _, key := range []string{"a", "b", "c"} {
+			m.Set(key, nil)
+		}
+
+		visited := ""
+		next := m.Iterate()
+		for entry, ok := next(); ok; entry, ok = next() {
+			key := entry.Key.(string)
+			visited += key
+			tt.change(m, key)
+		}
+		if visited != tt.expected {
+			t.Errorf("For %s: expected to visit %s, got %s", tt.name, tt.expected, visited)
+		}
+	}
+}
+
+func TestConstructors(t *testing.T) {
+	obj := internal.NewObject()
+	pairs := array.NewArrayReference(internal.Array{
+		array.NewArrayReference(internal.Array{"a", 1.0}),
+		array.NewArrayReference(internal.Array{obj, 2.0}),
+	})
+
+	m := MapConstructor.Construct(pairs).(*Map)
+	if value, _ := m.Get(obj); m.Size() != 2 || value != 2.0 {
+		t.Errorf("Expected new Map(pairs) to hold 2 entries with obj → 2, got %v", m)
+	}
+	if copied := MapConstructor.Construct(m).(*Map); copied.Size() != 2 || copied == m {
+		t.Errorf("Expected new Map(map) to copy the entries, got %v", copied)
+	}
+
+	s := SetConstructor.Construct(array.NewArrayReference(internal.Array{1.0, 1.0, math.NaN(), math.NaN(), "1"})).(*Set)
+	if s.String() != "Set(3) {1, NaN, 1}" {
+		t.Errorf("Expected duplicates to be dropped, got %v", s)
+	}
+	if s := SetConstructor.Construct("hello").(*Set); s.Size() != 4 {
+		t.Errorf("Expected new Set(\"hello\") to hold 4 letters, got %v", s)
+	}
+
+	errors := []struct {
+		construct func()
+		expected  string
+	}{
+		{func() { MapConstructor.Fn() }, "TypeError: Constructor Map requires 'new'"},
+		{func() { MapConstructor.Construct(array.NewArrayReference(internal.Array{1.0})) }, "TypeError: Iterator value 1 is not an entry object"},
+		{func() { SetConstructor.Construct(5.0) }, "TypeError: 5 is not iterable"},
+		{func() { NewWeakMap().Set("key", 1.0) }, "TypeError: Invalid value used as weak map key"},
+		{func() { NewWeakSet().Add(internal.NewSymbol("s")) }, "TypeError: Invalid value used in weak set"},
+	}
+	for _, tt := range errors {
+		exception := internal.Catch(tt.construct)
+		if exception == nil || !internal.IsError(exception.Value) || internal.ErrorString(exception.Value) != tt.expected {
+			t.Errorf("Expected %q, got %v", tt.expected, exception)
+		}
+	}
+}
+
+func TestWeakCollectionsDropCollectedKeys(t *testing.T) {
+	m := NewWeakMap()
+	s := NewWeakSet()
+	kept := internal.NewObject()
+	m.Set(kept, "kept")
+	s.Add(kept)
+
+	func() {
+		dropped := array.NewArrayReference(internal.Array{1.0})
+		m.Set(dropped, internal.NewObject())
+		s.Add(dropped)
+		if !m.Has(dropped) || !s.Has(dropped) {
+			t.Fatalf("Expected the new key to be in both collections")
+		}
+	}()
+
+	// Cleanups run on their own goroutine after a collection
+	deadline := time.Now().Add(5 * time.Second)
+	for (m.entries.len() > 1 || s.entries.len() > 1) && time.Now().Before(deadline) {
+		runtime.GC()
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if m.entries.len() != 1 || s.entries.len() != 1 {
+		t.Errorf("Expected the dropped key to be collected, got %d map and %d set entries", m.entries.len(), s.entries.len())
+	}
+	if m.Get(kept) != "kept" || !s.Has(kept) {
+		t.Errorf("Expected the key still in use to stay")
+	}
+	runtime.KeepAlive(kept)
+}
+
+func TestWeakMapDelete(t *testing.T) {
+	m := NewWeakMap()
+	key := internal.NewObject()
+	m.Set(key, 1.0)
+	m.Set(key, 2.0)
+
+	if m.Get(key) != 2.0 {
+		t.Errorf("Expected set to replace the value, got %v", m.Get(key))
+	}
+	if !m.Delete(key) || m.Delete(key) || m.Has(key) {
+		t.Errorf("Expected the key to be deleted once")
+	}
+	if m.Delete("key") || m.Has(1.0) || m.Get(internal.Null) != nil {
+		t.Errorf("Expected primitives to never be found")
+	}
+}
+
+func keysOf(m *OrderedMap) string {
+	keys := ""
+	next := m.Iterate()
+	for entry, ok := next(); ok; entry, ok = next() {
+		keys += entry.Key.(string)
+	}
+	return keys
+}
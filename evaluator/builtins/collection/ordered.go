@@ -0,0 +1,172 @@
+package collection
+
+import (
+	"go-script/internal"
+	"math"
+)
+
+// OrderedMap is a hash map that remembers insertion order, the storage of
+// Map and Set
+//
+// Keys match by SameValueZero: like ===, except that NaN matches NaN (and
+// 0 matches -0, as with ===). Objects, arrays and functions match only
+// themselves.
+//
+// Entries form a linked list in insertion order. A deleted entry keeps its
+// link to the entry before it, so an iterator standing on it can step back
+// to the live part of the list: iterating while entries are added and
+// deleted visits every entry that is present when the iterator reaches it,
+// as JavaScript requires.
+type OrderedMap struct {
+	index       map[interface{}]*Entry
+	first, last *Entry
+}
+
+// Entry is a key and its value in an OrderedMap
+type Entry struct {
+	Key, Value internal.Value
+	prev, next *Entry
+	deleted    bool
+}
+
+// NewOrderedMap returns an empty map
+func NewOrderedMap() *OrderedMap {
+	return &OrderedMap{index: make(map[interface{}]*Entry)}
+}
+
+// Size returns the number of entries
+func (m *OrderedMap) Size() int {
+	return len(m.index)
+}
+
+// Get returns the value stored under key
+func (m *OrderedMap) Get(key internal.Value) (internal.Value, bool) {
+	if entry, ok := m.index[hashKey(key)]; ok {
+		return entry.Value, true
+	}
+	return nil, false
+}
+
+// Has reports whether there is an entry for key
+func (m *OrderedMap) Has(key internal.Value) bool {
+	_, ok := m.index[hashKey(key)]
+	return ok
+}
+
+// Set stores a value under key, keeping the entry's place if the key is
+// already present. A key of -0 is stored as 0.
+func (m *OrderedMap) Set(key internal.Value, value internal.Value) {
+	hash := hashKey(key)
+	if entry, ok := m.index[hash]; ok {
+		entry.Value = value
+		return
+	}
+
+	if num, ok := key.(float64); ok && num == 0 {
+		key = 0.0
+	}
+	entry := &Entry{Key: key, Value: value, prev: m.last}
+	if m.last != nil {
+		m.last.next = entry
+	} else {
+		m.first = entry
+	}
+	m.last = entry
+	m.index[hash] = entry
+}
+
+// Delete removes the entry for key, reporting whether there was one
+func (m *OrderedMap) Delete(key internal.Value) bool {
+	hash := hashKey(key)
+	entry, ok := m.index[hash]
+	if !ok {
+		return false
+	}
+	delete(m.index, hash)
+	m.unlink(entry)
+	return true
+}
+
+// Clear removes every entry
+func (m *OrderedMap) Clear() {
+	for entry := m.first; entry != nil; entry = entry.next {
+		entry.deleted = true
+	}
+	m.index = make(map[interface{}]*Entry)
+	m.first, m.last = nil, nil
+}
+
+// unlink takes an entry out of the list, leaving its prev link for the
+// iterators that stand on it
+func (m *OrderedMap) unlink(entry *Entry) {
+	entry.deleted = true
+	if entry.prev != nil {
+		entry.prev.next = entry.next
+	} else {
+		m.first = entry.next
+	}
+	if entry.next != nil {
+		entry.next.prev = entry.prev
+	} else {
+		m.last = entry.prev
+	}
+}
+
+// Iterate returns a function that returns the entries one at a time, in
+// insertion order, and false once it has passed the last one
+//
+// Example:
+//
+//	next := m.Iterate()
+//	for entry, ok := next(); ok; entry, ok = next() {
+//	    fmt.Println(entry.Key, entry.Value)
+//	}
+func (m *OrderedMap) Iterate() func() (*Entry, bool) {
+	var current *Entry
+	finished := false
+
+	return func() (*Entry, bool) {
+		if finished {
+			return nil, false
+		}
+
+		// Step back from deleted entries to the last one still in the map
+		for current != nil && current.deleted {
+			current = current.prev
+		}
+		if current == nil {
+			current = m.first
+		} else {
+			current = current.next
+		}
+
+		if current == nil {
+			finished = true
+			return nil, false
+		}
+		return current, true
+	}
+}
+
+// nanKey stands for every NaN, which Go's == never matches
+type nanKey struct{}
+
+// hashKey returns the Go map key that stands for a JavaScript value, such
+// that two values give the same key exactly when they are SameValueZero
+func hashKey(val internal.Value) interface{} {
+	switch v := val.(type) {
+	case nil:
+		return nil
+	case float64:
+		if math.IsNaN(v) {
+			return nanKey{}
+		}
+		if v == 0 {
+			return 0.0
+		}
+		return v
+	}
+	// Other primitives compare by value with ==, and objects are pointers,
+	// which compare by identity
+	return val
+}
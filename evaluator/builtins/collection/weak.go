@@ -0,0 +1,85 @@
+package collection
+
+import (
+	"go-script/internal"
+	"runtime"
+	"sync"
+	"weak"
+)
+
+// weakTable maps objects to values without keeping the objects alive
+//
+// Entries are keyed on a weak pointer to the object, which stays equal to
+// itself after the object is gone. A cleanup attached to the object removes
+// its entry once the collector reclaims it, so the value can go too.
+//
+// A value that refers back to its own key keeps the key alive: the table
+// holds values strongly, and Go has no ephemerons to break that cycle.
+type weakTable[V any] struct {
+	mu      sync.Mutex // cleanups run on their own goroutine
+	entries map[weak.Pointer[internal.ObjectKind]]V
+}
+
+func newWeakTable[V any]() *weakTable[V] {
+	return &weakTable[V]{entries: make(map[weak.Pointer[internal.ObjectKind]]V)}
+}
+
+// get returns the value stored under an object and whether there is one
+func (t *weakTable[V]) get(key internal.Value) (V, bool) {
+	var value V
+	kind, ok := internal.Referent(key)
+	if !ok {
+		return value, false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	value, ok = t.entries[weak.Make(kind)]
+	return value, ok
+}
+
+// set stores a value under an object, which must not be a primitive
+func (t *weakTable[V]) set(key internal.Value, value V) {
+	kind, _ := internal.Referent(key)
+	ref := weak.Make(kind)
+
+	t.mu.Lock()
+	_, existed := t.entries[ref]
+	t.entries[ref] = value
+	t.mu.Unlock()
+
+	if !existed {
+		// The cleanup only holds the table weakly, so a dropped WeakMap
+		// doesn't live on until all of its keys are gone
+		table := weak.Make(t)
+		runtime.AddCleanup(kind, func(ref weak.Pointer[internal.ObjectKind]) {
+			if t := table.Value(); t != nil {
+				t.remove(ref)
+			}
+		}, ref)
+	}
+}
+
+// delete removes the entry of an object, reporting whether there was one
+func (t *weakTable[V]) delete(key internal.Value) bool {
+	kind, ok := internal.Referent(key)
+	if !ok {
+		return false
+	}
+	return t.remove(weak.Make(kind))
+}
+
+func (t *weakTable[V]) remove(ref weak.Pointer[internal.ObjectKind]) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	_, ok := t.entries[ref]
+	delete(t.entries, ref)
+	return ok
+}
+
+// len returns the number of entries, including those whose key was
+// reclaimed but whose cleanup hasn't run yet
+func (t *weakTable[V]) len() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.entries)
+}
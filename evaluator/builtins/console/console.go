@@ -0,0 +1,235 @@
+package console
+
+import (
+	"fmt"
+	"go-script/internal"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// Level is the severity of a console message
+type Level int
+
+const (
+	LevelDebug  Level = iota // console.debug
+	LevelInfo                // console.log, info, trace, dir, table, count, time and group
+	LevelWarn                // console.warn and the warnings of count and time
+	LevelError               // console.error and failed assertions
+	LevelSilent              // Above every message, to turn the console off
+)
+
+var levelNames = map[string]Level{
+	"debug":  LevelDebug,
+	"info":   LevelInfo,
+	"log":    LevelInfo,
+	"warn":   LevelWarn,
+	"error":  LevelError,
+	"silent": LevelSilent,
+}
+
+// ParseLevel reads a level name: debug, info (or log), warn, error or silent
+func ParseLevel(name string) (Level, error) {
+	level, ok := levelNames[strings.ToLower(name)]
+	if !ok {
+		return 0, fmt.Errorf("unknown log level %q (expected debug, info, warn, error or silent)", name)
+	}
+	return level, nil
+}
+
+// Console writes the output of the console object's methods.
+// Warnings and errors go to Stderr, everything else to Stdout; messages
+// below Level are dropped.
+//
+// Each Console keeps its own counters, timers and group indentation.
+type Console struct {
+	Stdout io.Writer
+	Stderr io.Writer
+	Level  Level            // The minimum level written, LevelDebug by default
+	Now    func() time.Time // The clock read by time, timeLog and timeEnd
+
+	indent string
+	counts map[string]int
+	timers map[string]time.Time
+}
+
+// New creates a console writing to the given streams, with every level
+// enabled
+func New(stdout, stderr io.Writer) *Console {
+	return &Console{
+		Stdout: stdout,
+		Stderr: stderr,
+		Level:  LevelDebug,
+		Now:    time.Now,
+		counts: map[string]int{},
+		timers: map[string]time.Time{},
+	}
+}
+
+// NewObject returns the global console object, writing to os.Stdout and
+// os.Stderr
+func NewObject() *internal.Object {
+	return New(os.Stdout, os.Stderr).Object()
+}
+
+// Object builds the console object whose methods write to c
+//
+// Syntax:
+//
+//	console.log(...data), console.info(...data), console.debug(...data)
+//	console.warn(...data), console.error(...data), console.trace(...data)
+//	console.assert(condition, ...data)
+//	console.count(label), console.countReset(label)
+//	console.time(label), console.timeLog(label, ...data), console.timeEnd(label)
+//	console.group(...label), console.groupCollapsed(...label), console.groupEnd()
+//	console.dir(value), console.table(data, columns)
+//
+// The first argument of the logging methods may be a format string with
+// %s, %d, %i, %f, %j, %o, %O, %c and %% (see Format). Labels default to
+// "default".
+//
+// Examples:
+//
+//	console.log("x =", 42)                 → x = 42
+//	console.log("%s is %i", "age", 42.5)   → age is 42
+//	console.error("failed")                → failed (on stderr)
+//	console.count(); console.count()       → default: 1, default: 2
+//	console.assert(1 == 2, "math")         → Assertion failed: math (on stderr)
+//	console.group("a"); console.log("b")   → a, then "  b" indented
+//	console.time("t"); console.timeEnd("t") → t: 0.012ms
+func (c *Console) Object() *internal.Object {
+	obj := internal.NewObject()
+	method := func(name string, fn func(args []interface{})) {
+		obj.Set(name, &internal.Builtin{
+			Name: name,
+			Fn: func(args ...interface{}) interface{} {
+				fn(args)
+				return nil
+			},
+		})
+	}
+
+	method("log", func(args []interface{}) { c.print(LevelInfo, c.Stdout, Format(args)) })
+	method("info", func(args []interface{}) { c.print(LevelInfo, c.Stdout, Format(args)) })
+	method("debug", func(args []interface{}) { c.print(LevelDebug, c.Stdout, Format(args)) })
+	method("warn", func(args []interface{}) { c.print(LevelWarn, c.Stderr, Format(args)) })
+	method("error", func(args []interface{}) { c.print(LevelError, c.Stderr, Format(args)) })
+	method("dir", func(args []interface{}) { c.print(LevelInfo, c.Stdout, Display(argAt(args, 0))) })
+	method("table", func(args []interface{}) { c.table(argAt(args, 0), argAt(args, 1)) })
+	method("trace", func(args []interface{}) {
+		message := "Trace"
+		if len(args) > 0 {
+			message += ": " + Format(args)
+		}
+		c.print(LevelInfo, c.Stderr, message)
+	})
+	method("assert", func(args []interface{}) {
+		if internal.ToBoolean(argAt(args, 0)) {
+			return
+		}
+		message := "Assertion failed"
+		if len(args) > 1 {
+			message += ": " + Format(args[1:])
+		}
+		c.print(LevelError, c.Stderr, message)
+	})
+
+	method("count", func(args []interface{}) {
+		label := labelArg(args)
+		c.counts[label]++
+		c.print(LevelInfo, c.Stdout, fmt.Sprintf("%s: %d", label, c.counts[label]))
+	})
+	method("countReset", func(args []interface{}) {
+		label := labelArg(args)
+		if _, ok := c.counts[label]; !ok {
+			c.print(LevelWarn, c.Stderr, fmt.Sprintf("Count for '%s' does not exist", label))
+			return
+		}
+		c.counts[label] = 0
+	})
+
+	method("time", func(args []interface{}) {
+		label := labelArg(args)
+		if _, ok := c.timers[label]; ok {
+			c.print(LevelWarn, c.Stderr, fmt.Sprintf("Warning: Label '%s' already exists for console.time()", label))
+			return
+		}
+		c.timers[label] = c.Now()
+	})
+	method("timeLog", func(args []interface{}) {
+		if message, ok := c.elapsed(labelArg(args), "console.timeLog()"); ok {
+			if len(args) > 1 {
+				message += " " + Format(args[1:])
+			}
+			c.print(LevelInfo, c.Stdout, message)
+		}
+	})
+	method("timeEnd", func(args []interface{}) {
+		label := labelArg(args)
+		if message, ok := c.elapsed(label, "console.timeEnd()"); ok {
+			delete(c.timers, label)
+			c.print(LevelInfo, c.Stdout, message)
+		}
+	})
+
+	group := func(args []interface{}) {
+		if len(args) > 0 {
+			c.print(LevelInfo, c.Stdout, Format(args))
+		}
+		c.indent += "  "
+	}
+	method("group", group)
+	method("groupCollapsed", group)
+	method("groupEnd", func(args []interface{}) {
+		if len(c.indent) >= 2 {
+			c.indent = c.indent[2:]
+		}
+	})
+
+	return obj
+}
+
+// print writes a message at the given level, indenting each of its lines
+// by the current group depth
+func (c *Console) print(level Level, w io.Writer, message string) {
+	if level < c.Level {
+		return
+	}
+	if c.indent != "" {
+		message = c.indent + strings.ReplaceAll(message, "\n", "\n"+c.indent)
+	}
+	fmt.Fprintln(w, message)
+}
+
+// elapsed formats the time since console.time(label), or warns that there
+// is no such timer
+//
+// Examples: "load: 12.345ms", "load: 2.500s"
+func (c *Console) elapsed(label string, method string) (string, bool) {
+	start, ok := c.timers[label]
+	if !ok {
+		c.print(LevelWarn, c.Stderr, fmt.Sprintf("Warning: No such label '%s' for %s", label, method))
+		return "", false
+	}
+	duration := c.Now().Sub(start)
+	if duration >= time.Second {
+		return fmt.Sprintf("%s: %.3fs", label, duration.Seconds()), true
+	}
+	return fmt.Sprintf("%s: %.3fms", label, float64(duration)/float64(time.Millisecond)), true
+}
+
+// labelArg reads the label of count and time, "default" if there is none
+func labelArg(args []interface{}) string {
+	if label := argAt(args, 0); label != nil {
+		return Display(label)
+	}
+	return "default"
+}
+
+func argAt(args []interface{}, index int) internal.Value {
+	if index >= len(args) {
+		return nil
+	}
+	return args[index]
+}
@@ -0,0 +1,269 @@
+package console
+
+import (
+	"bytes"
+	"go-script/evaluator/builtins/array"
+	"go-script/internal"
+	"math"
+	"testing"
+	"time"
+)
+
+// newTestConsole returns a console writing to buffers, whose clock moves
+// forward 1.5ms each time it is read
+func newTestConsole() (*Console, *bytes.Buffer, *bytes.Buffer) {
+	var stdout, stderr bytes.Buffer
+	c := New(&stdout, &stderr)
+	now := time.Unix(0, 0)
+	c.Now = func() time.Time {
+		now = now.Add(1500 * time.Microsecond)
+		return now
+	}
+	return c, &stdout, &stderr
+}
+
+// call invokes a method of the console object
+func call(obj *internal.Object, name string, args ...interface{}) {
+	obj.Get(name).(*internal.Builtin).Fn(args...)
+}
+
+func TestFormat(t *testing.T) {
+	obj := internal.NewObject()
+	obj.Set("a", array.NewArrayReference(internal.Array{1.0}))
+	circular := internal.NewObject()
+	circular.Set("self", circular)
+	undefinedMember := internal.NewObject()
+	undefinedMember.Set("a", nil)
+	shared := internal.NewObject()
+	empty := array.NewArrayReference(internal.Array{})
+	shared.Set("x", empty)
+	shared.Set("y", empty)
+
+	tests := []struct {
+		args     []interface{}
+		expected string
+	}{
+		{[]interface{}{}, ""},
+		{[]interface{}{"a", 1.0, true, nil, internal.Null}, "a 1 true undefined null"},
+		{[]interface{}{1.0, "%s"}, "1 %s"},
+		{[]interface{}{"%s=%d", "x", 42.5}, "x=42.5"},
+		{[]interface{}{"%i", 42.9}, "42"},
+		{[]interface{}{"%i", -42.9}, "-42"},
+		{[]interface{}{"%d", "12"}, "12"},
+		{[]interface{}{"%d", obj}, "NaN"},
+		{[]interface{}{"%f", "1.5"}, "1.5"},
+		{[]interface{}{"%j", obj}, `{"a":[1]}`},
+		{[]interface{}{"%j", circular}, "[Circular]"},
+		{[]interface{}{"%o", obj}, "{a: [1]}"},
+		{[]interface{}{"%cstyled", "color: red"}, "styled"},
+		{[]interface{}{"100%%", "done"}, "100% done"},
+		{[]interface{}{"%d + %d", 1.0}, "1 + %d"},
+		{[]interface{}{"%x %", 1.0}, "%x % 1"},
+		{[]interface{}{"%s", math.Inf(1), "extra"}, "Infinity extra"},
+		{[]interface{}{array.NewArrayReference(internal.Array{nil}), undefinedMember}, "[undefined] {a: undefined}"},
+		{[]interface{}{"%j", shared}, `{"x":[],"y":[]}`},
+	}
+
+	for _, tt := range tests {
+		if result := Format(tt.args); result != tt.expected {
+			t.Errorf("For args %v: expected %q, got %q", tt.args, tt.expected, result)
+		}
+	}
+}
+
+func TestFormatJSONPropagatesExceptions(t *testing.T) {
+	obj := internal.NewObject()
+	obj.Set("toJSON", &internal.Builtin{Name: "toJSON", Fn: func(args ...interface{}) interface{} {
+		internal.ThrowError("Error", "broken")
+		return nil
+	}})
+
+	exception := internal.Catch(func() { Format([]interface{}{"%j", obj}) })
+	if exception == nil || internal.ErrorString(exception.Value) != "Error: broken" {
+		t.Errorf("Expected the toJSON error to propagate, got %v", exception)
+	}
+}
+
+func TestLevelsAndStreams(t *testing.T) {
+	tests := []struct {
+		level          Level
+		stdout, stderr string
+	}{
+		{LevelDebug, "log\ninfo\ndebug\n", "warn\nerror\n"},
+		{LevelInfo, "log\ninfo\n", "warn\nerror\n"},
+		{LevelWarn, "", "warn\nerror\n"},
+		{LevelError, "", "error\n"},
+		{LevelSilent, "", ""},
+	}
+
+	for _, tt := range tests {
+		c, stdout, stderr := newTestConsole()
+		c.Level = tt.level
+		obj := c.Object()
+		for _, name := range []string{"log", "info", "debug", "warn", "error"} {
+			call(obj, name, name)
+		}
+		if stdout.String() != tt.stdout || stderr.String() != tt.stderr {
+			t.Errorf("For level %d: expected %q and %q, got %q and %q", tt.level, tt.stdout, tt.stderr, stdout, stderr)
+		}
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	for name, expected := range map[string]Level{"debug": LevelDebug, "LOG": LevelInfo, "Warn": LevelWarn, "silent": LevelSilent} {
+		if level, err := ParseLevel(name); err != nil || level != expected {
+			t.Errorf("For %q: expected %d, got %d (%v)", name, expected, level, err)
+		}
+	}
+	if _, err := ParseLevel("verbose"); err == nil {
+		t.Errorf("Expected an error for an unknown level")
+	}
+}
+
+func TestAssertTraceAndDir(t *testing.T) {
+	c, stdout, stderr := newTestConsole()
+	obj := c.Object()
+	call(obj, "assert", true, "not shown")
+	call(obj, "assert", 0.0)
+	call(obj, "assert", "", "expected %d items", 3.0)
+	call(obj, "trace")
+	call(obj, "trace", "here", 1.0)
+	call(obj, "dir", "%s")
+
+	expected := "Assertion failed\nAssertion failed: expected 3 items\nTrace\nTrace: here 1\n"
+	if stderr.String() != expected {
+		t.Errorf("Expected stderr %q, got %q", expected, stderr)
+	}
+	if stdout.String() != "%s\n" {
+		t.Errorf("Expected dir to write its argument without formatting, got %q", stdout)
+	}
+}
+
+func TestCount(t *testing.T) {
+	c, stdout, stderr := newTestConsole()
+	obj := c.Object()
+	call(obj, "count")
+	call(obj, "count")
+	call(obj, "count", "clicks")
+	call(obj, "countReset")
+	call(obj, "count")
+	call(obj, "countReset", "missing")
+
+	if expected := "default: 1\ndefault: 2\nclicks: 1\ndefault: 1\n"; stdout.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, stdout)
+	}
+	if expected := "Count for 'missing' does not exist\n"; stderr.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, stderr)
+	}
+}
+
+func TestTimers(t *testing.T) {
+	c, stdout, stderr := newTestConsole()
+	obj := c.Object()
+	call(obj, "time", "load")
+	call(obj, "timeLog", "load", "step", 1.0)
+	call(obj, "timeEnd", "load")
+	call(obj, "timeEnd", "load")
+	call(obj, "time")
+	call(obj, "time")
+
+	if expected := "load: 1.500ms step 1\nload: 3.000ms\n"; stdout.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, stdout)
+	}
+	expected := "Warning: No such label 'load' for console.timeEnd()\nWarning: Label 'default' already exists for console.time()\n"
+	if stderr.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, stderr)
+	}
+
+	c.Now = func() time.Time { return time.Unix(0, 0).Add(2500 * time.Millisecond) }
+	stdout.Reset()
+	call(obj, "timeEnd")
+	if stdout.String() != "default: 2.494s\n" {
+		t.Errorf("Expected durations over a second in seconds, got %q", stdout)
+	}
+}
+
+func TestGroups(t *testing.T) {
+	c, stdout, stderr := newTestConsole()
+	obj := c.Object()
+	call(obj, "log", "top")
+	call(obj, "group", "Outer")
+	call(obj, "log", "one")
+	call(obj, "groupCollapsed")
+	call(obj, "log", "two\nlines")
+	call(obj, "error", "nested")
+	call(obj, "groupEnd")
+	call(obj, "groupEnd")
+	call(obj, "groupEnd")
+	call(obj, "log", "back")
+
+	if expected := "top\nOuter\n  one\n    two\n    lines\nback\n"; stdout.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, stdout)
+	}
+	if expected := "    nested\n"; stderr.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, stderr)
+	}
+}
+
+func TestTable(t *testing.T) {
+	row := func(pairs ...interface{}) *internal.Object {
+		obj := internal.NewObject()
+		for i := 0; i < len(pairs); i += 2 {
+			obj.Set(pairs[i], pairs[i+1])
+		}
+		return obj
+	}
+	list := func(values ...internal.Value) *array.ArrayReference {
+		return array.NewArrayReference(values)
+	}
+
+	tests := []struct {
+		name     string
+		data     interface{}
+		columns  interface{}
+		expected string
+	}{
+		{"array of objects", list(row("name", "Ann", "age", 30.0), row("name", "Bob", "city", "Oslo")), nil,
+			"┌─────────┬───────┬─────┬────────┐\n" +
+				"│ (index) │ name  │ age │ city   │\n" +
+				"├─────────┼───────┼─────┼────────┤\n" +
+				"│ 0       │ 'Ann' │ 30  │        │\n" +
+				"│ 1       │ 'Bob' │     │ 'Oslo' │\n" +
+				"└─────────┴───────┴─────┴────────┘\n"},
+		{"primitives", list(1.0, "a"), nil,
+			"┌─────────┬────────┐\n" +
+				"│ (index) │ Values │\n" +
+				"├─────────┼────────┤\n" +
+				"│ 0       │ 1      │\n" +
+				"│ 1       │ 'a'    │\n" +
+				"└─────────┴────────┘\n"},
+		{"object of arrays", row("x", list(1.0, 2.0), "y", list(3.0)), nil,
+			"┌─────────┬───┬───┐\n" +
+				"│ (index) │ 0 │ 1 │\n" +
+				"├─────────┼───┼───┤\n" +
+				"│ x       │ 1 │ 2 │\n" +
+				"│ y       │ 3 │   │\n" +
+				"└─────────┴───┴───┘\n"},
+		{"chosen columns", list(row("a", 1.0, "b", 2.0, "c", 3.0)), list("c", "a"),
+			"┌─────────┬───┬───┐\n" +
+				"│ (index) │ c │ a │\n" +
+				"├─────────┼───┼───┤\n" +
+				"│ 0       │ 3 │ 1 │\n" +
+				"└─────────┴───┴───┘\n"},
+		{"non-ASCII", list(row("café", "é")), nil,
+			"┌─────────┬──────┐\n" +
+				"│ (index) │ café │\n" +
+				"├─────────┼──────┤\n" +
+				"│ 0       │ 'é'  │\n" +
+				"└─────────┴──────┘\n"},
+		{"not tabular", "text", nil, "text\n"},
+	}
+
+	for _, tt := range tests {
+		c, stdout, _ := newTestConsole()
+		call(c.Object(), "table", tt.data, tt.columns)
+		if stdout.String() != tt.expected {
+			t.Errorf("For %s: expected\n%s\ngot\n%s", tt.name, tt.expected, stdout)
+		}
+	}
+}
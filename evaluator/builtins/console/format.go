@@ -0,0 +1,142 @@
+package console
+
+import (
+	"go-script/evaluator/builtins/json"
+	"go-script/internal"
+	"math"
+	"strings"
+)
+
+// Format joins the arguments of a logging method with spaces. If the first
+// one is a string, its format specifiers take the following arguments:
+//
+//	%s      the value as a string
+//	%d      the value as a number
+//	%i      the value as an integer (truncated)
+//	%f      the value as a floating point number
+//	%j      the value as JSON, [Circular] if it refers to itself
+//	%o, %O  the value as an object
+//	%c      CSS styles, ignored
+//	%%      a percent sign
+//
+// Specifiers without an argument left are written as they are.
+//
+// Examples:
+//
+//	Format("a", 1, true)              → a 1 true
+//	Format("%s has %i items", "cart", 2.7) → cart has 2 items
+//	Format("%j", {a: [1]})            → {"a":[1]}
+//	Format("100%%", "done")           → 100% done
+//	Format("%d + %d", 1)              → 1 + %d
+func Format(args []interface{}) string {
+	if len(args) == 0 {
+		return ""
+	}
+
+	parts := []string{}
+	rest := args
+	if format, ok := args[0].(string); ok {
+		var formatted string
+		formatted, rest = substitute(format, args[1:])
+		parts = append(parts, formatted)
+	}
+	for _, arg := range rest {
+		parts = append(parts, Display(arg))
+	}
+	return strings.Join(parts, " ")
+}
+
+// substitute replaces the format specifiers of a format string, returning
+// the arguments it didn't use
+func substitute(format string, args []interface{}) (string, []interface{}) {
+	var b strings.Builder
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' || i+1 == len(format) {
+			b.WriteByte(format[i])
+			continue
+		}
+
+		verb := format[i+1]
+		if verb == '%' {
+			b.WriteByte('%')
+			i++
+			continue
+		}
+		if !strings.ContainsRune("sdifjoOc", rune(verb)) || len(args) == 0 {
+			b.WriteByte('%')
+			continue
+		}
+
+		arg := args[0]
+		args = args[1:]
+		i++
+		switch verb {
+		case 's', 'o', 'O':
+			b.WriteString(Display(arg))
+		case 'd', 'f':
+			b.WriteString(internal.FormatNumber(internal.ToNumber(arg)))
+		case 'i':
+			b.WriteString(internal.FormatNumber(math.Trunc(internal.ToNumber(arg))))
+		case 'j':
+			b.WriteString(toJSON(arg))
+		case 'c':
+			// Styles only make sense in a browser
+		}
+	}
+	return b.String(), args
+}
+
+// Display writes a value the way console.log shows it: strings as they are,
+// undefined as undefined, and everything else like print does. Undefined
+// values inside arrays, objects and collections are written the same way.
+//
+// Examples: "text" → text, nil → undefined, [1, "a"] → [1, a], [nil] → [undefined]
+func Display(value internal.Value) string {
+	return internal.Inspector{Undefined: "undefined"}.Inspect(value)
+}
+
+// toJSON writes a value for %j. Circular structures are written as
+// [Circular] like Node does; any other exception, e.g. one thrown by a
+// toJSON method, propagates to the caller.
+func toJSON(value internal.Value) string {
+	if isCircular(value, map[internal.Value]bool{}) {
+		return "[Circular]"
+	}
+	result := json.Stringify.Fn(value)
+	if result == nil {
+		return "undefined"
+	}
+	return result.(string)
+}
+
+// isCircular reports whether an object refers back to itself through the
+// properties and elements JSON would write. seen holds the objects on the
+// path from the root, so an object reached twice by separate paths is not
+// a cycle.
+func isCircular(value internal.Value, seen map[internal.Value]bool) bool {
+	var children []internal.Value
+	switch v := value.(type) {
+	case *internal.Object:
+		for _, key := range v.OwnKeys() {
+			if prop, _ := v.GetOwnProperty(key); !prop.NonEnumerable && !prop.IsAccessor() {
+				children = append(children, prop.Value)
+			}
+		}
+	case internal.ArrayLike:
+		children = v.GetElements()
+	default:
+		return false
+	}
+
+	if seen[value] {
+		return true
+	}
+	seen[value] = true
+	defer delete(seen, value)
+	for _, child := range children {
+		if isCircular(child, seen) {
+			return true
+		}
+	}
+	return false
+}
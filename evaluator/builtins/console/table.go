@@ -0,0 +1,161 @@
+package console
+
+import (
+	"go-script/internal"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// table writes an array or object of rows as a grid. The first column holds
+// the row's index or key; each property of the rows gets a column, in the
+// order they first appear, and rows that are primitives fill a Values
+// column. The columns argument, an array of property names, picks and
+// orders the property columns. Anything that isn't an array or an object
+// is logged as it is.
+//
+// Example: console.table([{ a: 1, b: "x" }, { a: 2 }])
+//
+//	┌─────────┬───┬─────┐
+//	│ (index) │ a │ b   │
+//	├─────────┼───┼─────┤
+//	│ 0       │ 1 │ 'x' │
+//	│ 1       │ 2 │     │
+//	└─────────┴───┴─────┘
+func (c *Console) table(data internal.Value, columns internal.Value) {
+	indexes, rows, ok := tableRows(data)
+	if !ok {
+		c.print(LevelInfo, c.Stdout, Display(data))
+		return
+	}
+
+	// The property columns, and each row's cells keyed by column
+	keys := []string{}
+	seen := map[string]bool{}
+	cells := make([]map[string]string, len(rows))
+	values := make([]string, len(rows))
+	hasValues := false
+	for i, row := range rows {
+		rowKeys, rowValues, isObject := properties(row)
+		if !isObject {
+			values[i] = formatCell(row)
+			hasValues = true
+			continue
+		}
+		cells[i] = map[string]string{}
+		for j, key := range rowKeys {
+			cells[i][key] = formatCell(rowValues[j])
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	if list, ok := columns.(internal.ArrayLike); ok {
+		keys = []string{}
+		for _, column := range list.GetElements() {
+			keys = append(keys, Display(column))
+		}
+	}
+
+	header := append([]string{"(index)"}, keys...)
+	if hasValues {
+		header = append(header, "Values")
+	}
+	grid := [][]string{header}
+	for i := range rows {
+		line := []string{indexes[i]}
+		for _, key := range keys {
+			line = append(line, cells[i][key])
+		}
+		if hasValues {
+			line = append(line, values[i])
+		}
+		grid = append(grid, line)
+	}
+	c.print(LevelInfo, c.Stdout, renderGrid(grid))
+}
+
+// tableRows lists the rows of an array (by index) or an object (by key)
+func tableRows(data internal.Value) ([]string, []internal.Value, bool) {
+	switch v := data.(type) {
+	case internal.ArrayLike:
+		elements := v.GetElements()
+		indexes := make([]string, len(elements))
+		for i := range elements {
+			indexes[i] = strconv.Itoa(i)
+		}
+		return indexes, elements, true
+	case *internal.Object:
+		keys := v.Keys()
+		rows := make([]internal.Value, len(keys))
+		for i, key := range keys {
+			rows[i] = v.Get(key)
+		}
+		return keys, rows, true
+	}
+	return nil, nil, false
+}
+
+// properties lists the columns an object or array row fills; other rows
+// report false
+func properties(row internal.Value) ([]string, []internal.Value, bool) {
+	switch v := row.(type) {
+	case internal.ArrayLike:
+		elements := v.GetElements()
+		keys := make([]string, len(elements))
+		for i := range elements {
+			keys[i] = strconv.Itoa(i)
+		}
+		return keys, elements, true
+	case *internal.Object:
+		keys := v.Keys()
+		values := make([]internal.Value, len(keys))
+		for i, key := range keys {
+			values[i] = v.Get(key)
+		}
+		return keys, values, true
+	}
+	return nil, nil, false
+}
+
+// formatCell quotes strings, as Node does, to tell "1" from 1
+func formatCell(value internal.Value) string {
+	if s, ok := value.(string); ok {
+		return "'" + s + "'"
+	}
+	return Display(value)
+}
+
+// renderGrid draws the lines of a table with box-drawing characters; the
+// first line is the header
+func renderGrid(grid [][]string) string {
+	widths := make([]int, len(grid[0]))
+	for _, line := range grid {
+		for i, cell := range line {
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+		}
+	}
+
+	border := func(left, middle, right string) string {
+		segments := make([]string, len(widths))
+		for i, width := range widths {
+			segments[i] = strings.Repeat("─", width+2)
+		}
+		return left + strings.Join(segments, middle) + right
+	}
+	row := func(line []string) string {
+		cells := make([]string, len(line))
+		for i, cell := range line {
+			cells[i] = " " + cell + strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell)) + " "
+		}
+		return "│" + strings.Join(cells, "│") + "│"
+	}
+
+	lines := []string{border("┌", "┬", "┐"), row(grid[0]), border("├", "┼", "┤")}
+	for _, line := range grid[1:] {
+		lines = append(lines, row(line))
+	}
+	lines = append(lines, border("└", "┴", "┘"))
+	return strings.Join(lines, "\n")
+}
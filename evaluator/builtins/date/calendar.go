@@ -0,0 +1,204 @@
+package date
+
+import (
+	"math"
+	"time"
+)
+
+// Dates are numbers of milliseconds since 1970-01-01T00:00:00Z, as in
+// JavaScript. The functions below are the spec's date arithmetic: they build
+// and split time values with plain calendar math, so out-of-range parts
+// carry over (month 12 is January of the next year, day 0 is the last day
+// of the previous month) and nothing depends on Go's time package except
+// the time zone offsets.
+
+const (
+	msPerSecond = 1000
+	msPerMinute = 60 * msPerSecond
+	msPerHour   = 60 * msPerMinute
+	msPerDay    = 24 * msPerHour
+
+	// maxTime is the largest distance from the epoch a Date can hold:
+	// 100,000,000 days either way
+	maxTime = 8.64e15
+)
+
+// fields are the calendar parts of a time value
+// Months count from 0, as in JavaScript; weekdays count from 0 for Sunday.
+type fields struct {
+	year, month, date                    int64
+	hours, minutes, seconds, millisecond int64
+	weekday                              int64
+}
+
+// split breaks a finite time value into its calendar parts
+func split(t float64) fields {
+	days := int64(math.Floor(t / msPerDay))
+	ms := int64(t) - days*msPerDay
+	year, month, date := civilFromDays(days)
+
+	return fields{
+		year:        year,
+		month:       month - 1,
+		date:        date,
+		hours:       ms / msPerHour,
+		minutes:     ms / msPerMinute % 60,
+		seconds:     ms / msPerSecond % 60,
+		millisecond: ms % msPerSecond,
+		weekday:     floorMod(days+4, 7), // 1970-01-01 was a Thursday
+	}
+}
+
+// values returns year, month, date, hours, minutes, seconds and milliseconds
+// as numbers, in the order the setters take them
+func (f fields) values() [7]float64 {
+	return [7]float64{
+		float64(f.year), float64(f.month), float64(f.date),
+		float64(f.hours), float64(f.minutes), float64(f.seconds), float64(f.millisecond),
+	}
+}
+
+// makeDay returns the day number of a date; out-of-range months and dates
+// carry over into the year and month
+func makeDay(year, month, date float64) float64 {
+	if !isFinite(year) || !isFinite(month) || !isFinite(date) {
+		return math.NaN()
+	}
+	year, month, date = math.Trunc(year), math.Trunc(month), math.Trunc(date)
+
+	y := year + math.Floor(month/12)
+	if math.Abs(y) > 400000 {
+		// Far outside the range of dates, and of int64 arithmetic below
+		return math.NaN()
+	}
+	m := floorMod(int64(month), 12)
+	return float64(daysFromCivil(int64(y), m+1, 1)) + date - 1
+}
+
+// makeTime returns the milliseconds into a day of a time of day
+func makeTime(hours, minutes, seconds, ms float64) float64 {
+	if !isFinite(hours) || !isFinite(minutes) || !isFinite(seconds) || !isFinite(ms) {
+		return math.NaN()
+	}
+	return math.Trunc(hours)*msPerHour + math.Trunc(minutes)*msPerMinute +
+		math.Trunc(seconds)*msPerSecond + math.Trunc(ms)
+}
+
+// makeDate combines a day number and a time of day into a time value
+func makeDate(day, time float64) float64 {
+	if !isFinite(day) || !isFinite(time) {
+		return math.NaN()
+	}
+	return day*msPerDay + time
+}
+
+// makeTimeValue builds a time value from the seven parts the setters and
+// the Date constructor take
+func makeTimeValue(parts [7]float64) float64 {
+	return makeDate(makeDay(parts[0], parts[1], parts[2]), makeTime(parts[3], parts[4], parts[5], parts[6]))
+}
+
+// timeClip limits a time value to the range of dates, NaN outside it
+func timeClip(t float64) float64 {
+	if !isFinite(t) || math.Abs(t) > maxTime {
+		return math.NaN()
+	}
+	return math.Trunc(t) + 0 // + 0 turns -0 into 0
+}
+
+// offset returns the time zone offset in milliseconds at a moment in time
+func offset(t float64, loc *time.Location) float64 {
+	_, seconds := time.UnixMilli(int64(t)).In(loc).Zone()
+	return float64(seconds) * msPerSecond
+}
+
+// localTime converts a time value to the wall clock time of a time zone,
+// still counted in milliseconds since the epoch
+func localTime(t float64, loc *time.Location) float64 {
+	if !isFinite(t) {
+		return t
+	}
+	return t + offset(t, loc)
+}
+
+// utc converts a wall clock time in a time zone back to a time value, the
+// way the specification's UTC(t) does:
+//   - a wall clock time that a daylight saving change repeats resolves to
+//     the earlier of its two moments
+//   - one that a change skips is read with the offset from before the
+//     change, which moves it forward by the size of the gap
+//
+// Example in America/New_York: 2024-03-10 02:30 → 03:30 EDT (07:30Z), and
+// 2024-11-03 01:30 → 01:30 EDT (05:30Z)
+//
+// Time zones don't change offset twice within a day, so the offsets a day
+// before and a day after are the only candidates.
+func utc(local float64, loc *time.Location) float64 {
+	if !isFinite(local) || math.Abs(local) > 2*maxTime {
+		return math.NaN()
+	}
+	before := offset(local-msPerDay, loc)
+	after := offset(local+msPerDay, loc)
+
+	result := math.NaN()
+	for _, candidate := range []float64{before, after} {
+		if t := local - candidate; offset(t, loc) == candidate && (math.IsNaN(result) || t < result) {
+			result = t
+		}
+	}
+	if math.IsNaN(result) {
+		return local - before
+	}
+	return result
+}
+
+// daysFromCivil returns the number of days from 1970-01-01 to a date in the
+// proleptic Gregorian calendar (months 1 to 12)
+// See Howard Hinnant, "chrono-Compatible Low-Level Date Algorithms".
+func daysFromCivil(year, month, day int64) int64 {
+	if month <= 2 {
+		year--
+	}
+	era := floorDiv(year, 400)
+	yearOfEra := year - era*400
+	dayOfYear := (153*((month+9)%12)+2)/5 + day - 1
+	dayOfEra := yearOfEra*365 + yearOfEra/4 - yearOfEra/100 + dayOfYear
+	return era*146097 + dayOfEra - 719468
+}
+
+// civilFromDays is the inverse of daysFromCivil
+func civilFromDays(days int64) (year, month, day int64) {
+	days += 719468
+	era := floorDiv(days, 146097)
+	dayOfEra := days - era*146097
+	yearOfEra := (dayOfEra - dayOfEra/1460 + dayOfEra/36524 - dayOfEra/146096) / 365
+	dayOfYear := dayOfEra - (365*yearOfEra + yearOfEra/4 - yearOfEra/100)
+	shiftedMonth := (5*dayOfYear + 2) / 153
+
+	day = dayOfYear - (153*shiftedMonth+2)/5 + 1
+	month = shiftedMonth + 3
+	if month > 12 {
+		month -= 12
+	}
+	year = yearOfEra + era*400
+	if month <= 2 {
+		year++
+	}
+	return year, month, day
+}
+
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
+
+func floorMod(a, b int64) int64 {
+	return a - floorDiv(a, b)*b
+}
+
+func isFinite(x float64) bool {
+	return !math.IsNaN(x) && !math.IsInf(x, 0)
+}
@@ -0,0 +1,213 @@
+package date
+
+import (
+	"go-script/internal"
+	"math"
+	"time"
+)
+
+// Date is a moment in time, stored as JavaScript stores it: milliseconds
+// since 1970-01-01T00:00:00Z, or NaN for an invalid date
+//
+// Each date remembers the time zone of the runtime that created it, which
+// the local getters and setters and toString use. The UTC methods and
+// toISOString don't depend on it.
+//
+// Example:
+//
+//	new Date(0).toISOString()  → "1970-01-01T00:00:00.000Z"
+//	new Date("nope").getTime() → NaN
+type Date struct {
+	internal.ObjectKind
+	Time     float64
+	Location *time.Location
+	internal.SubclassSlot
+}
+
+// Clock tells the Date constructor and Date.now what time it is
+// Tests and replays use a FixedClock so that scripts see the same time on
+// every run.
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc turns a function into a Clock
+type ClockFunc func() time.Time
+
+// Now calls the function
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// SystemClock reads the time from the operating system
+var SystemClock Clock = ClockFunc(time.Now)
+
+// FixedClock returns a clock that is stopped at t
+//
+// Example:
+//
+//	clock := date.FixedClock(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC))
+//	→ Date.now() is always 1709294400000
+func FixedClock(t time.Time) Clock {
+	return ClockFunc(func() time.Time { return t })
+}
+
+// Constructor is the global Date function of a runtime that reads the
+// system clock and uses the local time zone (the TZ environment variable)
+var Constructor = NewConstructor(SystemClock, time.Local)
+
+// NewConstructor returns a Date function whose new dates, Date() and
+// Date.now() read the time from clock, and whose dates use loc as their
+// local time zone
+//
+// Syntax:
+//
+//	new Date()
+//	new Date(milliseconds)
+//	new Date(dateString)
+//	new Date(date)
+//	new Date(year, monthIndex, day, hours, minutes, seconds, milliseconds)
+//	Date()
+//
+// The parts are local time, months count from 0 and out-of-range parts
+// carry over; years 0 to 99 mean 1900 to 1999. Date() without new returns
+// the current time as a string.
+//
+// Examples:
+//
+//	new Date(0)                       → 1970-01-01T00:00:00.000Z
+//	new Date("2024-03-01")            → 2024-03-01T00:00:00.000Z
+//	new Date(2024, 0, 31 + 1)         → February 1st 2024, local time
+//	new Date(8.64e15 + 1).getTime()   → NaN
+//
+// Example with a fixed clock and zone:
+//
+//	loc, _ := time.LoadLocation("Europe/Paris")
+//	date.NewConstructor(date.FixedClock(t), loc)
+func NewConstructor(clock Clock, loc *time.Location) *internal.Builtin {
+	now := func() float64 {
+		return float64(clock.Now().UnixMilli())
+	}
+
+	constructor := &internal.Builtin{
+		Name: "Date",
+		Fn: func(args ...interface{}) interface{} {
+			return (&Date{Time: now(), Location: loc}).String()
+		},
+		Construct: func(args ...interface{}) interface{} {
+			return construct(args, now, loc)
+		},
+	}
+
+	statics := internal.NewObject()
+	statics.Set("now", &internal.Builtin{
+		Name: "now",
+		Fn: func(args ...interface{}) interface{} {
+			return now()
+		},
+	})
+	statics.Set("parse", &internal.Builtin{
+		Name: "parse",
+		Fn: func(args ...interface{}) interface{} {
+			return Parse(internal.ToString(argAt(args, 0)), loc)
+		},
+	})
+	statics.Set("UTC", UTC)
+	statics.Set(internal.SymbolHasInstance, HasInstance)
+	constructor.Properties = statics
+	return constructor
+}
+
+// UTC returns the time value of a date given in UTC parts
+//
+// Syntax: Date.UTC(year, monthIndex, day, hours, minutes, seconds, milliseconds)
+//
+// Examples:
+//
+//	Date.UTC(1970, 0, 1)     → 0
+//	Date.UTC(2024, 1, 29, 12) → 1709208000000
+//	Date.UTC(99, 0)          → 915148800000 (1999)
+var UTC = &internal.Builtin{
+	Name: "UTC",
+	Fn: func(args ...interface{}) interface{} {
+		return timeClip(makeTimeValue(toParts(args)))
+	},
+}
+
+// HasInstance makes "x instanceof Date" recognise dates
+var HasInstance = &internal.Builtin{
+	Name: "[Symbol.hasInstance]",
+	Fn: func(args ...interface{}) interface{} {
+		_, ok := argAt(args, 0).(*Date)
+		return ok
+	},
+}
+
+func construct(args []interface{}, now func() float64, loc *time.Location) *Date {
+	switch len(args) {
+	case 0:
+		return &Date{Time: now(), Location: loc}
+	case 1:
+		switch value := args[0].(type) {
+		case *Date:
+			return &Date{Time: value.Time, Location: loc}
+		case string:
+			return &Date{Time: Parse(value, loc), Location: loc}
+		default:
+			return &Date{Time: timeClip(internal.ToNumber(value)), Location: loc}
+		}
+	default:
+		return &Date{Time: timeClip(utc(makeTimeValue(toParts(args)), loc)), Location: loc}
+	}
+}
+
+// toParts reads year, month, day, hours, minutes, seconds and milliseconds
+// from arguments the way the Date constructor and Date.UTC do: a missing
+// day is 1, other missing parts are 0, and years 0 to 99 are 1900 to 1999
+func toParts(args []interface{}) [7]float64 {
+	parts := [7]float64{math.NaN(), 0, 1, 0, 0, 0, 0}
+	for i := 0; i < len(args) && i < len(parts); i++ {
+		parts[i] = internal.ToNumber(args[i])
+	}
+
+	if year := math.Trunc(parts[0]); year >= 0 && year <= 99 {
+		parts[0] = 1900 + year
+	}
+	return parts
+}
+
+// String returns the date the way toString writes it, which is also how
+// Date() returns the current time
+//
+// Example: "Fri Mar 01 2024 13:00:00 GMT+0100 (CET)"
+func (d *Date) String() string {
+	return d.toString()
+}
+
+// ToPrimitive makes a date its time value for the "number" hint and its
+// string otherwise, so that subtracting dates gives milliseconds and adding
+// one to a string writes it
+//
+// Examples: new Date(5000) - new Date(1000) → 4000, "" + new Date(0) → "Thu Jan 01 1970 ..."
+func (d *Date) ToPrimitive(hint string) internal.Value {
+	if hint == "number" {
+		return d.Time
+	}
+	return d.String()
+}
+
+// MarshalJSON writes a date as its ISO string, or null when it's invalid,
+// as JSON.stringify does through toJSON
+func (d *Date) MarshalJSON() ([]byte, error) {
+	if !isFinite(d.Time) {
+		return []byte("null"), nil
+	}
+	return []byte(`"` + d.toISOString() + `"`), nil
+}
+
+func argAt(args []interface{}, index int) internal.Value {
+	if index >= len(args) {
+		return nil
+	}
+	return args[index]
+}
@@ -0,0 +1,263 @@
+package date
+
+import (
+	"go-script/internal"
+	"math"
+	"testing"
+	"time"
+)
+
+func loadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("Time zone %s is not available: %v", name, err)
+	}
+	return loc
+}
+
+func TestCalendar(t *testing.T) {
+	tests := []struct {
+		parts    [7]float64
+		expected float64
+	}{
+		{[7]float64{1970, 0, 1, 0, 0, 0, 0}, 0},
+		{[7]float64{2024, 2, 1, 12, 0, 0, 0}, 1709294400000},
+		{[7]float64{2024, 1, 29, 0, 0, 0, 0}, 1709164800000},
+		{[7]float64{2024, 12, 1, 0, 0, 0, 0}, 1735689600000}, // January 2025
+		{[7]float64{2025, 0, 0, 0, 0, 0, 0}, 1735603200000},  // December 31st 2024
+		{[7]float64{1969, 11, 31, 23, 59, 59, 999}, -1},
+		{[7]float64{1900, 0, 1, 0, 0, 0, 0}, -2208988800000},
+		{[7]float64{275760, 8, 13, 0, 0, 0, 0}, 8.64e15},
+		{[7]float64{-271821, 3, 20, 0, 0, 0, 0}, -8.64e15},
+	}
+
+	for _, tt := range tests {
+		result := makeTimeValue(tt.parts)
+		if result != tt.expected {
+			t.Errorf("For parts %v: expected %v, got %v", tt.parts, tt.expected, result)
+		}
+		if got := split(result).values(); timeClip(makeTimeValue(got)) != result {
+			t.Errorf("For parts %v: split gave %v, which doesn't build the same time", tt.parts, got)
+		}
+	}
+
+	if f := split(0); f.weekday != 4 {
+		t.Errorf("Expected 1970-01-01 to be a Thursday, got weekday %d", f.weekday)
+	}
+	if f := split(-1); f.year != 1969 || f.month != 11 || f.date != 31 || f.millisecond != 999 {
+		t.Errorf("Expected -1 to be 1969-12-31T23:59:59.999, got %+v", f)
+	}
+	if !math.IsNaN(timeClip(8.64e15 + 1)) {
+		t.Errorf("Expected times past 8.64e15 to be NaN")
+	}
+}
+
+func TestParse(t *testing.T) {
+	paris := loadLocation(t, "Europe/Paris")
+
+	tests := []struct {
+		input    string
+		expected float64
+	}{
+		{"2024-03-01", 1709251200000},
+		{"2024-03", 1709251200000},
+		{"2024", 1704067200000},
+		{"2024-03-01T12:00:00Z", 1709294400000},
+		{"2024-03-01T12:00Z", 1709294400000},
+		{"2024-03-01T12:00:00.123Z", 1709294400123},
+		{"2024-03-01T12:00:00.5+01:00", 1709290800500},
+		{"2024-03-01T12:00:00-05:30", 1709314200000},
+		{"2024-03-01T24:00:00Z", 1709337600000},
+		{"+275760-09-13T00:00:00.000Z", 8.64e15},
+		{"-000001-01-01T00:00:00Z", -62198755200000},
+
+		// Date-times without an offset are local time (UTC+1 in March)
+		{"2024-03-01T12:00:00", 1709290800000},
+		{"2024-03-01 12:00", 1709290800000},
+
+		// The formats of toString, toUTCString and toLocaleString
+		{"Fri Mar 01 2024 13:00:00 GMT+0100 (Central European Standard Time)", 1709294400000},
+		{"Fri, 01 Mar 2024 12:00:00 GMT", 1709294400000},
+		{"3/1/2024, 1:00:00 PM", 1709294400000},
+		{"3/1/2024", 1709247600000},
+		{"2024/03/01", 1709247600000},
+		{"March 1, 2024 12:00 PM GMT+0100", 1709290800000},
+		{"1 Mar 2024 12:00 UTC", 1709294400000},
+		{"Mar 1 2024 07:00:00 -0500", 1709294400000},
+		{"Mar 1 99 UTC", 920246400000},
+	}
+
+	for _, tt := range tests {
+		result := Parse(tt.input, paris)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+
+	invalid := []string{
+		"", "tomorrow", "2024-02-30", "2024-13-01", "2024-3-1x", "2024-03-01T25:00Z",
+		"2024-03-01T24:00:01Z", "2024-03-01T12:00:60Z", "-000000-01-01T00:00:00Z",
+		"+275760-09-13T00:00:00.001Z", "13:00 PM Mar 1 2024", "Mar Apr 2024",
+	}
+	for _, input := range invalid {
+		if result := Parse(input, paris); !math.IsNaN(result) {
+			t.Errorf("For input %q: expected NaN, got %v", input, result)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	newYork := loadLocation(t, "America/New_York")
+	d := &Date{Time: 1709294400000, Location: newYork} // 2024-03-01T12:00:00Z
+
+	tests := []struct {
+		method   string
+		args     []interface{}
+		expected internal.Value
+	}{
+		{"toISOString", nil, "2024-03-01T12:00:00.000Z"},
+		{"toJSON", nil, "2024-03-01T12:00:00.000Z"},
+		{"toString", nil, "Fri Mar 01 2024 07:00:00 GMT-0500 (EST)"},
+		{"toDateString", nil, "Fri Mar 01 2024"},
+		{"toTimeString", nil, "07:00:00 GMT-0500 (EST)"},
+		{"toUTCString", nil, "Fri, 01 Mar 2024 12:00:00 GMT"},
+		{"toLocaleString", nil, "3/1/2024, 7:00:00 AM"},
+		{"toLocaleDateString", nil, "3/1/2024"},
+		{"toLocaleTimeString", nil, "7:00:00 AM"},
+		{"toLocaleString", []interface{}{"en-US", options("timeZone", "UTC")}, "3/1/2024, 12:00:00 PM"},
+		{"toLocaleString", []interface{}{"en-US", options("timeZone", "Asia/Tokyo")}, "3/1/2024, 9:00:00 PM"},
+		{"toLocaleTimeString", []interface{}{"en-US", options("hour12", false)}, "07:00:00"},
+		{"getTimezoneOffset", nil, 300.0},
+		{"getHours", nil, 7.0},
+		{"getUTCHours", nil, 12.0},
+		{"getDay", nil, 5.0},
+	}
+
+	for _, tt := range tests {
+		result := Methods[tt.method](d, tt.args)
+		if result != tt.expected {
+			t.Errorf("For %s(%v): expected %v, got %v", tt.method, tt.args, tt.expected, result)
+		}
+	}
+
+	years := map[float64]string{
+		8.64e15:         "+275760-09-13T00:00:00.000Z",
+		-62198755200000: "-000001-01-01T00:00:00.000Z",
+		-1:              "1969-12-31T23:59:59.999Z",
+	}
+	for time, expected := range years {
+		if result := (&Date{Time: time, Location: newYork}).toISOString(); result != expected {
+			t.Errorf("For time %v: expected %q, got %q", time, expected, result)
+		}
+	}
+
+	invalid := &Date{Time: math.NaN(), Location: newYork}
+	if result := invalid.String(); result != "Invalid Date" {
+		t.Errorf("Expected an invalid date to print as Invalid Date, got %q", result)
+	}
+	if result := Methods["toJSON"](invalid, nil); result != internal.Null {
+		t.Errorf("Expected toJSON of an invalid date to be null, got %v", result)
+	}
+	exception := internal.Catch(func() { Methods["toISOString"](invalid, nil) })
+	if exception == nil || !internal.IsError(exception.Value) || internal.ErrorString(exception.Value) != "RangeError: Invalid time value" {
+		t.Errorf("Expected toISOString of an invalid date to throw a RangeError, got %v", exception)
+	}
+	exception = internal.Catch(func() { Methods["toLocaleString"](d, []interface{}{"en-US", options("timeZone", "Mars/Olympus")}) })
+	if exception == nil || !internal.IsError(exception.Value) || internal.ErrorString(exception.Value) != "RangeError: Invalid time zone specified: Mars/Olympus" {
+		t.Errorf("Expected an unknown time zone to throw a RangeError, got %v", exception)
+	}
+}
+
+func TestSetters(t *testing.T) {
+	newYork := loadLocation(t, "America/New_York")
+
+	tests := []struct {
+		method   string
+		args     []interface{}
+		expected float64
+	}{
+		{"setUTCDate", []interface{}{32.0}, 1711972800000},          // April 1st
+		{"setUTCMonth", []interface{}{0.0, 0.0}, 1704024000000},     // December 31st 2023
+		{"setUTCHours", []interface{}{25.0}, 1709341200000},         // 01:00 the next day
+		{"setUTCFullYear", []interface{}{2023.0}, 1677672000000},    // March 1st 2023
+		{"setUTCMinutes", []interface{}{30.0, 15.0}, 1709296215000}, // 12:30:15
+		{"setMilliseconds", []interface{}{1500.0}, 1709294401500},   // carries into the seconds
+		{"setHours", []interface{}{0.0}, 1709269200000},             // midnight in New York
+		{"setDate", []interface{}{10.0}, 1710068400000},             // March 10th, still 07:00 local but in EDT
+		{"setTime", []interface{}{0.0}, 0},                          // the epoch
+		{"setUTCSeconds", []interface{}{math.NaN()}, math.NaN()},    // NaN parts make the date invalid
+		{"setUTCFullYear", []interface{}{1e6}, math.NaN()},          // out of range
+	}
+
+	for _, tt := range tests {
+		d := &Date{Time: 1709294400000, Location: newYork} // 2024-03-01T12:00:00Z, 07:00 EST
+		result := Methods[tt.method](d, tt.args)
+		if result != tt.expected && !(math.IsNaN(tt.expected) && math.IsNaN(result.(float64))) {
+			t.Errorf("For %s(%v): expected %v, got %v", tt.method, tt.args, tt.expected, result)
+		}
+		if d.Time != result && !math.IsNaN(d.Time) {
+			t.Errorf("For %s(%v): the date holds %v, but the setter returned %v", tt.method, tt.args, d.Time, result)
+		}
+	}
+
+	// Setting the year of an invalid date starts from 1970-01-01
+	d := &Date{Time: math.NaN(), Location: time.UTC}
+	if result := Methods["setUTCFullYear"](d, []interface{}{2000.0}); result != 946684800000.0 {
+		t.Errorf("Expected setUTCFullYear on an invalid date to give 946684800000, got %v", result)
+	}
+	d = &Date{Time: math.NaN(), Location: time.UTC}
+	if result := Methods["setUTCHours"](d, []interface{}{1.0}); !math.IsNaN(result.(float64)) {
+		t.Errorf("Expected setUTCHours on an invalid date to stay NaN, got %v", result)
+	}
+}
+
+func TestConstructor(t *testing.T) {
+	fixed := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	tokyo := loadLocation(t, "Asia/Tokyo")
+	constructor := NewConstructor(FixedClock(fixed), tokyo)
+
+	tests := []struct {
+		args     []interface{}
+		expected float64
+	}{
+		{nil, 1709294400000},
+		{[]interface{}{0.0}, 0},
+		{[]interface{}{"2024-03-01"}, 1709251200000},
+		{[]interface{}{"2024-03-01T21:00"}, 1709294400000}, // local time in Tokyo
+		{[]interface{}{2024.0, 2.0}, 1709218800000},        // March 1st, midnight in Tokyo
+		{[]interface{}{2024.0, 2.0, 1.0, 21.0}, 1709294400000},
+		{[]interface{}{99.0, 0.0}, 915116400000}, // 1999
+		{[]interface{}{&Date{Time: 42}}, 42},
+		{[]interface{}{true}, 1},
+		{[]interface{}{8.64e15 + 1}, math.NaN()},
+		{[]interface{}{"nope"}, math.NaN()},
+	}
+
+	for _, tt := range tests {
+		d := constructor.Construct(tt.args...).(*Date)
+		if d.Time != tt.expected && !(math.IsNaN(tt.expected) && math.IsNaN(d.Time)) {
+			t.Errorf("For new Date(%v): expected %v, got %v", tt.args, tt.expected, d.Time)
+		}
+		if d.Location != tokyo {
+			t.Errorf("For new Date(%v): expected the Tokyo time zone, got %v", tt.args, d.Location)
+		}
+	}
+
+	now := constructor.Properties.Get("now").(*internal.Builtin)
+	if result := now.Fn(); result != 1709294400000.0 {
+		t.Errorf("Expected Date.now() to read the clock, got %v", result)
+	}
+	if result := constructor.Fn(); result != "Fri Mar 01 2024 21:00:00 GMT+0900 (JST)" {
+		t.Errorf("Expected Date() to return the time as a string, got %v", result)
+	}
+	if result := UTC.Fn(2024.0, 1.0, 29.0, 12.0); result != 1709208000000.0 {
+		t.Errorf("Expected Date.UTC(2024, 1, 29, 12) to be 1709208000000, got %v", result)
+	}
+}
+
+func options(key string, value internal.Value) *internal.Object {
+	obj := internal.NewObject()
+	obj.Set(key, value)
+	return obj
+}
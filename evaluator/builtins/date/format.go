@@ -0,0 +1,160 @@
+package date
+
+import (
+	"fmt"
+	"go-script/internal"
+	"time"
+)
+
+var (
+	weekdayNames = []string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"}
+	monthNames   = []string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"}
+)
+
+// toISOString writes a valid date in the simplified ISO 8601 format, always
+// in UTC; years outside 0 to 9999 get a sign and six digits
+//
+// Examples:
+//
+//	"2024-03-01T12:00:00.000Z"
+//	"+275760-09-13T00:00:00.000Z"
+//	"-000001-01-01T00:00:00.000Z"
+func (d *Date) toISOString() string {
+	f := split(d.Time)
+	return fmt.Sprintf("%s-%02d-%02dT%02d:%02d:%02d.%03dZ",
+		isoYear(f.year), f.month+1, f.date, f.hours, f.minutes, f.seconds, f.millisecond)
+}
+
+func isoYear(year int64) string {
+	switch {
+	case year < 0:
+		return fmt.Sprintf("-%06d", -year)
+	case year > 9999:
+		return fmt.Sprintf("+%06d", year)
+	default:
+		return fmt.Sprintf("%04d", year)
+	}
+}
+
+// toString writes the date in local time with its offset and zone name
+//
+// Example: "Fri Mar 01 2024 13:00:00 GMT+0100 (CET)"
+//
+// The zone name is Go's abbreviation for the zone at that moment.
+func (d *Date) toString() string {
+	if !isFinite(d.Time) {
+		return "Invalid Date"
+	}
+	return d.toDateString() + " " + d.toTimeString()
+}
+
+// toDateString writes the local date part of toString
+//
+// Example: "Fri Mar 01 2024"
+func (d *Date) toDateString() string {
+	if !isFinite(d.Time) {
+		return "Invalid Date"
+	}
+	f := split(localTime(d.Time, d.Location))
+	return fmt.Sprintf("%s %s %02d %s", weekdayNames[f.weekday], monthNames[f.month], f.date, yearString(f.year))
+}
+
+// toTimeString writes the local time part of toString
+//
+// Example: "13:00:00 GMT+0100 (CET)"
+func (d *Date) toTimeString() string {
+	if !isFinite(d.Time) {
+		return "Invalid Date"
+	}
+	f := split(localTime(d.Time, d.Location))
+	name, seconds := time.UnixMilli(int64(d.Time)).In(d.Location).Zone()
+
+	sign := '+'
+	if seconds < 0 {
+		sign, seconds = '-', -seconds
+	}
+	minutes := seconds / 60
+	return fmt.Sprintf("%02d:%02d:%02d GMT%c%02d%02d (%s)",
+		f.hours, f.minutes, f.seconds, sign, minutes/60, minutes%60, name)
+}
+
+// toUTCString writes the date in UTC in the format of HTTP headers
+//
+// Example: "Fri, 01 Mar 2024 12:00:00 GMT"
+func (d *Date) toUTCString() string {
+	if !isFinite(d.Time) {
+		return "Invalid Date"
+	}
+	f := split(d.Time)
+	return fmt.Sprintf("%s, %02d %s %s %02d:%02d:%02d GMT",
+		weekdayNames[f.weekday], f.date, monthNames[f.month], yearString(f.year), f.hours, f.minutes, f.seconds)
+}
+
+func yearString(year int64) string {
+	if year < 0 {
+		return fmt.Sprintf("-%04d", -year)
+	}
+	return fmt.Sprintf("%04d", year)
+}
+
+// toLocaleString writes the date and/or the time the way the en-US locale
+// does, in the date's time zone or the options' timeZone
+//
+// Syntax:
+//
+//	date.toLocaleString(locales, options)
+//	date.toLocaleDateString(locales, options)
+//	date.toLocaleTimeString(locales, options)
+//
+// Other locales are written as en-US too. The options understood are
+// timeZone, an IANA name looked up with time.LoadLocation, and hour12.
+//
+// Examples:
+//
+//	d.toLocaleString("en-US", { timeZone: "UTC" })                → "3/1/2024, 12:00:00 PM"
+//	d.toLocaleDateString("en-US", { timeZone: "Asia/Tokyo" })     → "3/1/2024"
+//	d.toLocaleTimeString("en-US", { timeZone: "UTC", hour12: false }) → "12:00:00"
+//	d.toLocaleString("en-US", { timeZone: "Mars/Olympus" })       → throws RangeError
+func (d *Date) toLocaleString(options internal.Value, showDate bool, showTime bool) string {
+	loc := d.Location
+	hour12 := true
+	if opts, ok := options.(*internal.Object); ok {
+		if zone := opts.Get("timeZone"); zone != nil {
+			name := internal.ToString(zone)
+			var err error
+			if loc, err = time.LoadLocation(name); err != nil || name == "" || name == "Local" {
+				internal.ThrowError("RangeError", "Invalid time zone specified: "+name)
+			}
+		}
+		if value := opts.Get("hour12"); value != nil {
+			hour12 = internal.ToBoolean(value)
+		}
+	}
+
+	if !isFinite(d.Time) {
+		return "Invalid Date"
+	}
+	f := split(localTime(d.Time, loc))
+
+	date := fmt.Sprintf("%d/%d/%d", f.month+1, f.date, f.year)
+	clock := fmt.Sprintf("%02d:%02d:%02d", f.hours, f.minutes, f.seconds)
+	if hour12 {
+		hours, period := f.hours%12, "AM"
+		if hours == 0 {
+			hours = 12
+		}
+		if f.hours >= 12 {
+			period = "PM"
+		}
+		clock = fmt.Sprintf("%d:%02d:%02d %s", hours, f.minutes, f.seconds, period)
+	}
+
+	switch {
+	case showDate && showTime:
+		return date + ", " + clock
+	case showDate:
+		return date
+	default:
+		return clock
+	}
+}
@@ -0,0 +1,164 @@
+package date
+
+import (
+	"go-script/internal"
+	"math"
+)
+
+// Method is a Date.prototype method, called with the date it was read from
+type Method func(d *Date, args []interface{}) internal.Value
+
+// Methods holds the Date.prototype methods by name
+//
+// Every getter and setter comes in a local form, which works in the date's
+// time zone, and a UTC form. The setters take the parts after the first as
+// optional arguments, keep the rest and carry over out-of-range values, so
+// date arithmetic is a matter of adding to a part.
+//
+// Examples:
+//
+//	var d = new Date(Date.UTC(2024, 0, 31));
+//	d.getUTCDay()                 → 3 (Wednesday)
+//	d.setUTCDate(d.getUTCDate() + 1) → February 1st
+//	d.setUTCHours(25)             → 01:00 the next day
+//	d.setUTCMonth(0, 0)           → December 31st 2023
+var Methods = map[string]Method{
+	"getTime": valueOf,
+	"valueOf": valueOf,
+	"setTime": func(d *Date, args []interface{}) internal.Value {
+		d.Time = timeClip(internal.ToNumber(argAt(args, 0)))
+		return d.Time
+	},
+
+	"getFullYear":     getter(true, func(f fields) int64 { return f.year }),
+	"getMonth":        getter(true, func(f fields) int64 { return f.month }),
+	"getDate":         getter(true, func(f fields) int64 { return f.date }),
+	"getDay":          getter(true, func(f fields) int64 { return f.weekday }),
+	"getHours":        getter(true, func(f fields) int64 { return f.hours }),
+	"getMinutes":      getter(true, func(f fields) int64 { return f.minutes }),
+	"getSeconds":      getter(true, func(f fields) int64 { return f.seconds }),
+	"getMilliseconds": getter(true, func(f fields) int64 { return f.millisecond }),
+
+	"getUTCFullYear":     getter(false, func(f fields) int64 { return f.year }),
+	"getUTCMonth":        getter(false, func(f fields) int64 { return f.month }),
+	"getUTCDate":         getter(false, func(f fields) int64 { return f.date }),
+	"getUTCDay":          getter(false, func(f fields) int64 { return f.weekday }),
+	"getUTCHours":        getter(false, func(f fields) int64 { return f.hours }),
+	"getUTCMinutes":      getter(false, func(f fields) int64 { return f.minutes }),
+	"getUTCSeconds":      getter(false, func(f fields) int64 { return f.seconds }),
+	"getUTCMilliseconds": getter(false, func(f fields) int64 { return f.millisecond }),
+
+	"getTimezoneOffset": func(d *Date, args []interface{}) internal.Value {
+		if !isFinite(d.Time) {
+			return math.NaN()
+		}
+		return (d.Time - localTime(d.Time, d.Location)) / msPerMinute
+	},
+
+	"setFullYear":     setter(true, 0, 3),
+	"setMonth":        setter(true, 1, 2),
+	"setDate":         setter(true, 2, 1),
+	"setHours":        setter(true, 3, 4),
+	"setMinutes":      setter(true, 4, 3),
+	"setSeconds":      setter(true, 5, 2),
+	"setMilliseconds": setter(true, 6, 1),
+
+	"setUTCFullYear":     setter(false, 0, 3),
+	"setUTCMonth":        setter(false, 1, 2),
+	"setUTCDate":         setter(false, 2, 1),
+	"setUTCHours":        setter(false, 3, 4),
+	"setUTCMinutes":      setter(false, 4, 3),
+	"setUTCSeconds":      setter(false, 5, 2),
+	"setUTCMilliseconds": setter(false, 6, 1),
+
+	"toISOString": func(d *Date, args []interface{}) internal.Value {
+		if !isFinite(d.Time) {
+			internal.ThrowError("RangeError", "Invalid time value")
+		}
+		return d.toISOString()
+	},
+	"toJSON": func(d *Date, args []interface{}) internal.Value {
+		if !isFinite(d.Time) {
+			return internal.Null
+		}
+		return d.toISOString()
+	},
+	"toString": func(d *Date, args []interface{}) internal.Value {
+		return d.toString()
+	},
+	"toDateString": func(d *Date, args []interface{}) internal.Value {
+		return d.toDateString()
+	},
+	"toTimeString": func(d *Date, args []interface{}) internal.Value {
+		return d.toTimeString()
+	},
+	"toUTCString": func(d *Date, args []interface{}) internal.Value {
+		return d.toUTCString()
+	},
+	"toGMTString": func(d *Date, args []interface{}) internal.Value {
+		return d.toUTCString()
+	},
+	"toLocaleString": func(d *Date, args []interface{}) internal.Value {
+		return d.toLocaleString(argAt(args, 1), true, true)
+	},
+	"toLocaleDateString": func(d *Date, args []interface{}) internal.Value {
+		return d.toLocaleString(argAt(args, 1), true, false)
+	},
+	"toLocaleTimeString": func(d *Date, args []interface{}) internal.Value {
+		return d.toLocaleString(argAt(args, 1), false, true)
+	},
+}
+
+func valueOf(d *Date, args []interface{}) internal.Value {
+	return d.Time
+}
+
+// getter returns a method that reads one part of the date, in local time
+// or UTC; invalid dates give NaN
+func getter(local bool, part func(f fields) int64) Method {
+	return func(d *Date, args []interface{}) internal.Value {
+		t := d.Time
+		if !isFinite(t) {
+			return math.NaN()
+		}
+		if local {
+			t = localTime(t, d.Location)
+		}
+		return float64(part(split(t)))
+	}
+}
+
+// setter returns a method that sets up to count parts of the date, starting
+// with part first (0 for the year, 6 for milliseconds), in local time or
+// UTC, and returns the new time value
+//
+// Setting a part of an invalid date leaves it invalid, except for the year,
+// which starts over from January 1st 1970.
+//
+// Example: setter(true, 3, 4) is setHours(hours, minutes, seconds, ms)
+func setter(local bool, first int, count int) Method {
+	return func(d *Date, args []interface{}) internal.Value {
+		t := d.Time
+		switch {
+		case !isFinite(t) && first == 0:
+			t = 0
+		case !isFinite(t):
+			return math.NaN()
+		case local:
+			t = localTime(t, d.Location)
+		}
+
+		parts := split(t).values()
+		parts[first] = internal.ToNumber(argAt(args, 0))
+		for i := 1; i < count && i < len(args); i++ {
+			parts[first+i] = internal.ToNumber(args[i])
+		}
+
+		updated := makeTimeValue(parts)
+		if local {
+			updated = utc(updated, d.Location)
+		}
+		d.Time = timeClip(updated)
+		return d.Time
+	}
+}
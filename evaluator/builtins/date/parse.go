@@ -0,0 +1,426 @@
+package date
+
+import (
+	"math"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Parse reads a date string and returns its time value, or NaN if it isn't
+// a date, the way Date.parse and new Date(string) do
+//
+// Syntax: Date.parse(dateString)
+//
+// The ISO 8601 format of toISOString is read strictly: a date alone is
+// UTC, a date and time without an offset is local time. Anything else goes
+// to a lenient parser that understands what toString, toUTCString and
+// toLocaleString write, month names, M/D/Y dates, AM/PM and GMT offsets;
+// without an offset those are local time too.
+//
+// Examples:
+//
+//	Date.parse("2024-03-01")                        → 1709251200000
+//	Date.parse("2024-03-01T12:00:00Z")              → 1709294400000
+//	Date.parse("2024-03-01T12:00:00.5+01:00")       → 1709290800500
+//	Date.parse("+275760-09-13T00:00:00Z")           → 8640000000000000
+//	Date.parse("Fri, 01 Mar 2024 12:00:00 GMT")     → 1709294400000
+//	Date.parse("March 1, 2024 12:00 PM GMT+0100")   → 1709290800000
+//	Date.parse("3/1/2024")                          → March 1st 2024, local time
+//	Date.parse("2024-02-30")                        → NaN
+//	Date.parse("tomorrow")                          → NaN
+func Parse(s string, loc *time.Location) float64 {
+	s = strings.TrimSpace(s)
+	if t, ok := parseISO(s, loc); ok {
+		return t
+	}
+	if t, ok := parseLegacy(s, loc); ok {
+		return t
+	}
+	return math.NaN()
+}
+
+// scanner walks through a date string byte by byte
+type scanner struct {
+	s string
+	i int
+}
+
+func (sc *scanner) done() bool {
+	return sc.i >= len(sc.s)
+}
+
+func (sc *scanner) peek() byte {
+	if sc.done() {
+		return 0
+	}
+	return sc.s[sc.i]
+}
+
+// accept skips the next byte if it is one of chars
+func (sc *scanner) accept(chars string) bool {
+	if !sc.done() && strings.IndexByte(chars, sc.s[sc.i]) >= 0 {
+		sc.i++
+		return true
+	}
+	return false
+}
+
+// digits reads exactly n digits
+func (sc *scanner) digits(n int) (int64, bool) {
+	if sc.i+n > len(sc.s) {
+		return 0, false
+	}
+	var value int64
+	for _, ch := range []byte(sc.s[sc.i : sc.i+n]) {
+		if ch < '0' || ch > '9' {
+			return 0, false
+		}
+		value = value*10 + int64(ch-'0')
+	}
+	sc.i += n
+	return value, true
+}
+
+// parseISO reads YYYY, YYYY-MM or YYYY-MM-DD, optionally followed by
+// THH:mm, THH:mm:ss or THH:mm:ss.sss and an offset, Z or ±HH:mm
+// Years may also be written ±YYYYYY.
+func parseISO(s string, loc *time.Location) (float64, bool) {
+	sc := &scanner{s: s}
+
+	var year int64
+	var ok bool
+	if sign := sc.peek(); sign == '+' || sign == '-' {
+		sc.i++
+		if year, ok = sc.digits(6); !ok || (sign == '-' && year == 0) {
+			return 0, false
+		}
+		if sign == '-' {
+			year = -year
+		}
+	} else if year, ok = sc.digits(4); !ok {
+		return 0, false
+	}
+
+	month, day := int64(1), int64(1)
+	if sc.accept("-") {
+		if month, ok = sc.digits(2); !ok {
+			return 0, false
+		}
+		if sc.accept("-") {
+			if day, ok = sc.digits(2); !ok {
+				return 0, false
+			}
+		}
+	}
+	if month < 1 || month > 12 || day < 1 || day > daysInMonth(year, month) {
+		return 0, false
+	}
+
+	var hours, minutes, seconds, ms int64
+	hasTime := sc.accept("Tt ")
+	if hasTime {
+		if hours, ok = sc.digits(2); !ok || !sc.accept(":") {
+			return 0, false
+		}
+		if minutes, ok = sc.digits(2); !ok {
+			return 0, false
+		}
+		if sc.accept(":") {
+			if seconds, ok = sc.digits(2); !ok {
+				return 0, false
+			}
+			if sc.accept(".") {
+				// Only milliseconds count, but any number of digits may follow
+				start := sc.i
+				for scale := int64(100); sc.peek() >= '0' && sc.peek() <= '9'; scale /= 10 {
+					ms += int64(sc.peek()-'0') * scale
+					sc.i++
+				}
+				if sc.i == start {
+					return 0, false
+				}
+			}
+		}
+		if minutes > 59 || seconds > 59 || hours > 24 ||
+			(hours == 24 && (minutes > 0 || seconds > 0 || ms > 0)) {
+			return 0, false
+		}
+	}
+
+	offset, hasOffset := int64(0), false
+	if hasTime {
+		if sc.accept("Zz") {
+			hasOffset = true
+		} else if sign := sc.peek(); sign == '+' || sign == '-' {
+			sc.i++
+			h, ok := sc.digits(2)
+			sc.accept(":")
+			m, ok2 := sc.digits(2)
+			if !ok || !ok2 || h > 23 || m > 59 {
+				return 0, false
+			}
+			offset, hasOffset = h*60+m, true
+			if sign == '-' {
+				offset = -offset
+			}
+		}
+	}
+	if !sc.done() {
+		return 0, false
+	}
+
+	t := makeTimeValue([7]float64{
+		float64(year), float64(month - 1), float64(day),
+		float64(hours), float64(minutes), float64(seconds), float64(ms),
+	})
+	switch {
+	case hasOffset:
+		t -= float64(offset) * msPerMinute
+	case hasTime:
+		// A date-time without an offset is local time
+		t = utc(t, loc)
+	}
+	return timeClip(t), true
+}
+
+// daysInMonth returns the number of days in a month from 1 to 12
+func daysInMonth(year, month int64) int64 {
+	next := daysFromCivil(year+month/12, month%12+1, 1)
+	return next - daysFromCivil(year, month, 1)
+}
+
+// token is a word, a number or a punctuation character of a date string
+type token struct {
+	kind  byte // 'a' for words, '0' for numbers, else the character itself
+	text  string
+	value int64
+}
+
+// tokenize splits a date string into words, numbers and punctuation,
+// dropping spaces, commas and parenthesised comments like "(CET)"
+func tokenize(s string) ([]token, bool) {
+	var tokens []token
+	runes := []rune(s)
+	for i := 0; i < len(runes); {
+		ch := runes[i]
+		switch {
+		case unicode.IsSpace(ch) || ch == ',':
+			i++
+		case ch == '(':
+			depth := 0
+			for ; i < len(runes); i++ {
+				if runes[i] == '(' {
+					depth++
+				} else if runes[i] == ')' {
+					if depth--; depth == 0 {
+						break
+					}
+				}
+			}
+			i++
+		case ch >= '0' && ch <= '9':
+			start := i
+			var value int64
+			for ; i < len(runes) && runes[i] >= '0' && runes[i] <= '9'; i++ {
+				if value > math.MaxInt32 {
+					return nil, false
+				}
+				value = value*10 + int64(runes[i]-'0')
+			}
+			tokens = append(tokens, token{kind: '0', text: string(runes[start:i]), value: value})
+		case unicode.IsLetter(ch):
+			start := i
+			for ; i < len(runes) && unicode.IsLetter(runes[i]); i++ {
+			}
+			tokens = append(tokens, token{kind: 'a', text: strings.ToLower(string(runes[start:i]))})
+		case strings.ContainsRune("+-:/.", ch):
+			tokens = append(tokens, token{kind: byte(ch)})
+			i++
+		default:
+			return nil, false
+		}
+	}
+	return tokens, true
+}
+
+// parseLegacy reads the date formats that aren't ISO 8601
+func parseLegacy(s string, loc *time.Location) (float64, bool) {
+	tokens, ok := tokenize(s)
+	if !ok {
+		return 0, false
+	}
+	at := func(i int) token {
+		if i < len(tokens) {
+			return tokens[i]
+		}
+		return token{}
+	}
+
+	year, month, day := int64(-1), int64(-1), int64(-1)
+	var hours, minutes, seconds, ms int64
+	var numbers []int64
+	period := ""
+	hasTime, hasOffset := false, false
+	offset := int64(0)
+
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+		switch {
+		case tok.kind == 'a':
+			switch word := tok.text; {
+			case word == "am" || word == "pm":
+				if !hasTime || period != "" {
+					return 0, false
+				}
+				period = word
+			case word == "gmt" || word == "utc" || word == "ut" || word == "z":
+				hasOffset = true
+			case len(word) >= 3 && monthIndex(word) >= 0:
+				if month >= 0 {
+					return 0, false
+				}
+				month = int64(monthIndex(word))
+			case len(word) >= 3 && isWeekday(word), word == "t":
+				// Weekdays are ignored, as is the T of date-times
+			default:
+				return 0, false
+			}
+
+		case tok.kind == '0' && at(i+1).kind == ':':
+			if hasTime {
+				return 0, false
+			}
+			hasTime = true
+			hours, minutes = tok.value, at(i+2).value
+			if at(i+2).kind != '0' {
+				return 0, false
+			}
+			i += 2
+			if at(i+1).kind == ':' && at(i+2).kind == '0' {
+				seconds = at(i + 2).value
+				i += 2
+				if at(i+1).kind == '.' && at(i+2).kind == '0' {
+					digits := at(i+2).text + "00"
+					ms = int64(digits[0]-'0')*100 + int64(digits[1]-'0')*10 + int64(digits[2]-'0')
+					i += 2
+				}
+			}
+
+		case tok.kind == '0' && at(i+1).kind == '/':
+			// M/D/Y, or Y/M/D when the first number can only be a year
+			if month >= 0 || at(i+2).kind != '0' {
+				return 0, false
+			}
+			first, second := tok.value, at(i+2).value
+			i += 2
+			third := int64(-1)
+			if at(i+1).kind == '/' && at(i+2).kind == '0' {
+				third = at(i + 2).value
+				i += 2
+			}
+			if first > 31 {
+				year, month, day = first, second-1, third
+			} else {
+				month, day, year = first-1, second, third
+			}
+
+		case tok.kind == '0':
+			numbers = append(numbers, tok.value)
+
+		case (tok.kind == '+' || tok.kind == '-') && at(i+1).kind == '0' && (hasOffset || hasTime):
+			// An offset like +0100, +01:00 or +1
+			number := at(i + 1)
+			i++
+			switch {
+			case at(i+1).kind == ':' && at(i+2).kind == '0':
+				offset = number.value*60 + at(i+2).value
+				i += 2
+			case len(number.text) <= 2:
+				offset = number.value * 60
+			default:
+				offset = number.value/100*60 + number.value%100
+			}
+			if tok.kind == '-' {
+				offset = -offset
+			}
+			hasOffset = true
+
+		case tok.kind == '-' || tok.kind == '.' || tok.kind == '/':
+			// Separators like the dashes of 1-Mar-2024
+
+		default:
+			return 0, false
+		}
+	}
+
+	// Loose numbers are the day and the year, in either order
+	for _, n := range numbers {
+		switch {
+		case day < 0 && n <= 31 && month >= 0 && year < 0 || day < 0 && year >= 0:
+			day = n
+		case year < 0:
+			year = n
+		default:
+			return 0, false
+		}
+	}
+	if month < 0 || year < 0 {
+		return 0, false
+	}
+	if day < 0 {
+		day = 1
+	}
+
+	switch {
+	case year < 50:
+		year += 2000
+	case year < 100:
+		year += 1900
+	}
+
+	if period != "" {
+		if hours > 12 {
+			return 0, false
+		}
+		hours %= 12
+		if period == "pm" {
+			hours += 12
+		}
+	}
+	if month > 11 || day < 1 || day > 31 || hours > 24 || minutes > 59 || seconds > 59 {
+		return 0, false
+	}
+
+	t := makeTimeValue([7]float64{
+		float64(year), float64(month), float64(day),
+		float64(hours), float64(minutes), float64(seconds), float64(ms),
+	})
+	if hasOffset {
+		t -= float64(offset) * msPerMinute
+	} else {
+		t = utc(t, loc)
+	}
+	return timeClip(t), true
+}
+
+// monthIndex returns the month a name or its abbreviation stands for,
+// from 0 for January, or -1
+func monthIndex(word string) int {
+	for i, name := range []string{"january", "february", "march", "april", "may", "june",
+		"july", "august", "september", "october", "november", "december"} {
+		if strings.HasPrefix(name, word) {
+			return i
+		}
+	}
+	return -1
+}
+
+func isWeekday(word string) bool {
+	for _, name := range []string{"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday"} {
+		if strings.HasPrefix(name, word) {
+			return true
+		}
+	}
+	return false
+}
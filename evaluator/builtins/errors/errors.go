@@ -0,0 +1,108 @@
+package errors
+
+import (
+	"go-script/evaluator/builtins/array"
+	"go-script/internal"
+)
+
+// Constructors holds the global error constructors by name: Error,
+// TypeError, RangeError, ReferenceError, SyntaxError, EvalError, URIError
+// and AggregateError (see internal.ErrorNames)
+//
+// Syntax: new Error(message, options), Error(message, options),
+// new AggregateError(errors, message, options)
+//
+// Calling them with or without new creates an error whose prototype is the
+// constructor's prototype, so e instanceof TypeError and e.name work the
+// same for errors scripts create and the ones builtins throw. A message
+// that isn't undefined is converted to a string; options.cause is kept as
+// the error's cause.
+//
+// Examples:
+//
+//	new TypeError("bad input").message            → "bad input"
+//	String(new RangeError("too big"))             → "RangeError: too big"
+//	new Error("x", { cause: err }).cause          → err
+//	new AggregateError([a, b], "all failed").errors → [a, b]
+//	new TypeError("x") instanceof Error           → true
+var Constructors = map[string]*internal.Builtin{}
+
+func init() {
+	for _, name := range internal.ErrorNames {
+		Constructors[name] = newConstructor(name)
+	}
+}
+
+func newConstructor(name string) *internal.Builtin {
+	create := func(args ...interface{}) interface{} {
+		return construct(name, args)
+	}
+	constructor := &internal.Builtin{Name: name, Fn: create, Construct: create}
+
+	proto := internal.ErrorPrototypeFor(name)
+	proto.Define("constructor", &internal.Property{Value: constructor, NonEnumerable: true})
+
+	statics := internal.NewObject()
+	statics.Set("prototype", proto)
+	statics.Set("name", name)
+	statics.Set(internal.SymbolHasInstance, hasInstance(proto))
+	constructor.Properties = statics
+	return constructor
+}
+
+// construct creates an error of the named type from the constructor's
+// arguments
+func construct(name string, args []interface{}) *internal.Object {
+	var errors internal.Value
+	if name == "AggregateError" {
+		errors = argAt(args, 0)
+		args = args[min(1, len(args)):]
+	}
+
+	err := internal.NewError(name, "")
+	if message := argAt(args, 0); message != nil {
+		err.Define("message", &internal.Property{Value: internal.ToString(message), NonEnumerable: true})
+	} else {
+		err.Delete("message")
+	}
+	if options, ok := argAt(args, 1).(*internal.Object); ok && options.Has("cause") {
+		err.Define("cause", &internal.Property{Value: options.Get("cause"), NonEnumerable: true})
+	}
+
+	if name == "AggregateError" {
+		list, ok := internal.IterableToList(errors)
+		if !ok {
+			internal.ThrowError("TypeError", internal.Inspect(errors)+" is not iterable")
+		}
+		err.Define("errors", &internal.Property{Value: array.NewArrayReference(list), NonEnumerable: true})
+	}
+	return err
+}
+
+// hasInstance makes "x instanceof TypeError" look for TypeError.prototype
+// on the prototype chain of x, which also finds it on instances of classes
+// that extend TypeError
+func hasInstance(proto *internal.Object) *internal.Builtin {
+	return &internal.Builtin{
+		Name: "[Symbol.hasInstance]",
+		Fn: func(args ...interface{}) interface{} {
+			obj, ok := argAt(args, 0).(*internal.Object)
+			if !ok {
+				return false
+			}
+			for current := obj.Prototype; current != nil; current = current.Prototype {
+				if current == proto {
+					return true
+				}
+			}
+			return false
+		},
+	}
+}
+
+func argAt(args []interface{}, index int) internal.Value {
+	if index >= len(args) {
+		return nil
+	}
+	return args[index]
+}
@@ -0,0 +1,72 @@
+package errors
+
+import (
+	"go-script/evaluator/builtins/array"
+	"go-script/internal"
+	"testing"
+)
+
+func TestConstructors(t *testing.T) {
+	for _, name := range internal.ErrorNames {
+		constructor, ok := Constructors[name]
+		if !ok {
+			t.Errorf("Expected a %s constructor", name)
+			continue
+		}
+
+		args := []interface{}{"boom"}
+		if name == "AggregateError" {
+			args = []interface{}{array.NewArrayReference(internal.Array{1.0}), "boom"}
+		}
+		err, ok := constructor.Construct(args...).(*internal.Object)
+		if !ok || !internal.IsError(err) {
+			t.Errorf("%s: expected an error object, got %v", name, err)
+			continue
+		}
+		if got := internal.ErrorString(err); got != name+": boom" {
+			t.Errorf("%s: expected %q, got %q", name, name+": boom", got)
+		}
+		if err.Prototype != internal.ErrorPrototypeFor(name) {
+			t.Errorf("%s: expected the instance to inherit from %s.prototype", name, name)
+		}
+		if hasInstance, _ := constructor.Properties.Get(internal.SymbolHasInstance).(internal.Callable); hasInstance == nil || hasInstance.Call(constructor, err) != true {
+			t.Errorf("%s: expected the error to be an instance", name)
+		}
+	}
+}
+
+func TestConstructorArguments(t *testing.T) {
+	options := internal.NewObject()
+	options.Set("cause", "disk full")
+
+	err := Constructors["Error"].Fn("write failed", options).(*internal.Object)
+	if err.Get("cause") != "disk full" {
+		t.Errorf("Expected the cause to be kept, got %v", err.Get("cause"))
+	}
+	if err.Get("message") != "write failed" {
+		t.Errorf("Expected Error() without new to create an error, got %v", err.Get("message"))
+	}
+
+	// Without a message the error inherits the empty one from its prototype
+	empty := Constructors["TypeError"].Construct().(*internal.Object)
+	if _, own := empty.GetOwnProperty("message"); own || internal.ErrorString(empty) != "TypeError" {
+		t.Errorf("Expected a TypeError without a message, got %q", internal.ErrorString(empty))
+	}
+
+	exception := internal.Catch(func() { Constructors["AggregateError"].Construct(5.0) })
+	if exception == nil || internal.ErrorString(exception.Value) != "TypeError: 5 is not iterable" {
+		t.Errorf("Expected AggregateError to require an iterable, got %v", exception)
+	}
+}
+
+func TestSubtypeIsNotParent(t *testing.T) {
+	err := Constructors["RangeError"].Construct("x")
+	hasInstance := Constructors["TypeError"].Properties.Get(internal.SymbolHasInstance).(internal.Callable)
+	if hasInstance.Call(Constructors["TypeError"], err) != false {
+		t.Error("Expected a RangeError not to be a TypeError")
+	}
+	hasInstance = Constructors["Error"].Properties.Get(internal.SymbolHasInstance).(internal.Callable)
+	if hasInstance.Call(Constructors["Error"], err) != true {
+		t.Error("Expected a RangeError to be an Error")
+	}
+}
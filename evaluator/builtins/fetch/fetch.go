@@ -2,11 +2,11 @@ package fetch
 
 import (
 	"bytes"
-	"fmt"
 	"go-script/internal"
 	"io"
 	"net/http"
-	"reflect"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -22,11 +22,11 @@ import (
 // Examples:
 //
 //	// Simple GET request
-//	let response = fetch("https://api.example.com/data")
+//	let response = await fetch("https://api.example.com/data")
 //	print(response.body)
 //
 //	// POST request with JSON body
-//	let response = fetch("https://api.example.com/users", {
+//	let response = await fetch("https://api.example.com/users", {
 //	    method: "POST",
 //	    headers: {
 //	        "Content-Type": "application/json",
@@ -34,115 +34,139 @@ import (
 //	    },
 //	    body: '{"name": "Alice", "age": 30}'
 //	})
-var Fetch = &internal.Builtin{
-	Name: "fetch",
-	Fn: func(args ...interface{}) interface{} {
-		if len(args) < 1 || len(args) > 2 {
-			return map[string]interface{}{
-				"error": "fetch requires 1 or 2 arguments (url, options?)",
-			}
-		}
-
-		url := internal.ToString(args[0])
-
-		// Default options
-		method := "GET"
-		headers := make(map[string]string)
-		var bodyStr string
-
-		// Parse options if provided
-		if len(args) == 2 {
-			// Use reflection to handle any map type
-			v := reflect.ValueOf(args[1])
-			if v.Kind() != reflect.Map {
-				return map[string]interface{}{
-					"error": fmt.Sprintf("second argument must be an options object, got %T", args[1]),
-				}
-			}
-
-			// Parse method
-			if methodVal := v.MapIndex(reflect.ValueOf("method")); methodVal.IsValid() {
-				method = internal.ToString(methodVal.Interface())
-			}
-
-			// Parse headers
-			if headersVal := v.MapIndex(reflect.ValueOf("headers")); headersVal.IsValid() {
-				headersInterface := headersVal.Interface()
-				hv := reflect.ValueOf(headersInterface)
-				if hv.Kind() == reflect.Map {
-					for _, key := range hv.MapKeys() {
-						keyStr := key.String()
-						val := hv.MapIndex(key)
-						if val.IsValid() {
-							headers[keyStr] = internal.ToString(val.Interface())
-						}
-					}
-				}
+//
+// fetch returns a promise right away and the request runs in the background,
+// so several requests can be in flight at once. The request keeps the loop
+// that created the promise running until it completes:
+//
+//	var responses = await Promise.all([fetch(urlA), fetch(urlB)])
+func Fetch(loop *internal.EventLoop) *internal.Builtin {
+	return &internal.Builtin{
+		Name: "fetch",
+		Fn: func(args ...interface{}) interface{} {
+			promise := loop.NewPromise()
+
+			// Arguments are read now, on the script's thread; only the
+			// round trip happens on another goroutine
+			req, failure := newRequest(args)
+			if failure != nil {
+				promise.Resolve(failure)
+				return promise
 			}
 
-			// Parse body
-			if bodyVal := v.MapIndex(reflect.ValueOf("body")); bodyVal.IsValid() {
-				bodyStr = internal.ToString(bodyVal.Interface())
-			}
+			op := loop.StartOperation()
+			go func() {
+				response := send(req)
+				op.Complete(func() { promise.Resolve(response) })
+			}()
+
+			return promise
+		},
+	}
+}
+
+// newRequest builds the HTTP request described by fetch's arguments
+// Invalid arguments produce an error response instead of a request.
+func newRequest(args []interface{}) (*http.Request, *internal.Object) {
+	if len(args) < 1 || len(args) > 2 {
+		return nil, errorResponse("fetch requires 1 or 2 arguments (url, options?)")
+	}
+
+	url := internal.ToString(args[0])
+
+	// Default options
+	method := "GET"
+	headers := make(map[string]string)
+	var bodyStr string
+
+	// Parse options if provided
+	if len(args) == 2 {
+		options, ok := args[1].(*internal.Object)
+		if !ok {
+			return nil, errorResponse("second argument must be an options object, got " + internal.Inspect(args[1]))
 		}
 
-		// Create HTTP client with timeout
-		client := &http.Client{
-			Timeout: 30 * time.Second,
+		if methodVal := options.Get("method"); methodVal != nil {
+			method = internal.ToString(methodVal)
 		}
 
-		// Create request
-		var bodyReader io.Reader
-		if bodyStr != "" {
-			bodyReader = bytes.NewBufferString(bodyStr)
-		}
-
-		req, err := http.NewRequest(method, url, bodyReader)
-		if err != nil {
-			return map[string]interface{}{
-				"error": err.Error(),
+		if headersObj, ok := options.Get("headers").(*internal.Object); ok {
+			for _, key := range headersObj.Keys() {
+				headers[key] = internal.ToString(headersObj.Get(key))
 			}
 		}
 
-		// Set headers
-		for key, value := range headers {
-			req.Header.Set(key, value)
+		if bodyVal := options.Get("body"); bodyVal != nil {
+			bodyStr = internal.ToString(bodyVal)
 		}
+	}
 
-		// Make request
-		resp, err := client.Do(req)
-		if err != nil {
-			return map[string]interface{}{
-				"error": err.Error(),
-			}
-		}
-		defer resp.Body.Close()
+	// Create request
+	var bodyReader io.Reader
+	if bodyStr != "" {
+		bodyReader = bytes.NewBufferString(bodyStr)
+	}
 
-		// Read response body
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return map[string]interface{}{
-				"error": err.Error(),
-			}
-		}
+	req, err := http.NewRequest(method, url, bodyReader)
+	if err != nil {
+		return nil, errorResponse(err.Error())
+	}
 
-		// Parse response headers
-		responseHeaders := make(map[string]interface{})
-		for key, values := range resp.Header {
-			if len(values) == 1 {
-				responseHeaders[key] = values[0]
-			} else {
-				responseHeaders[key] = values
-			}
-		}
+	// Set headers
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
 
-		// Return response object
-		return map[string]interface{}{
-			"status":     float64(resp.StatusCode),
-			"statusText": resp.Status,
-			"body":       string(body),
-			"headers":    responseHeaders,
-			"ok":         resp.StatusCode >= 200 && resp.StatusCode < 300,
-		}
-	},
-}
\ No newline at end of file
+	return req, nil
+}
+
+// send performs the request and converts the response into the object
+// the promise returned by fetch fulfills with
+//
+// Example: {status: 200, statusText: 200 OK, body: ..., headers: {...}, ok: true}
+func send(req *http.Request) *internal.Object {
+	// Create HTTP client with timeout
+	client := &http.Client{
+		Timeout: 30 * time.Second,
+	}
+
+	// Make request
+	resp, err := client.Do(req)
+	if err != nil {
+		return errorResponse(err.Error())
+	}
+	defer resp.Body.Close()
+
+	// Read response body
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errorResponse(err.Error())
+	}
+
+	// Headers sent more than once are joined with ", ", as Headers.get does
+	names := make([]string, 0, len(resp.Header))
+	for name := range resp.Header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	responseHeaders := internal.NewObject()
+	for _, name := range names {
+		responseHeaders.Set(name, strings.Join(resp.Header[name], ", "))
+	}
+
+	response := internal.NewObject()
+	response.Set("status", float64(resp.StatusCode))
+	response.Set("statusText", resp.Status)
+	response.Set("body", string(body))
+	response.Set("headers", responseHeaders)
+	response.Set("ok", resp.StatusCode >= 200 && resp.StatusCode < 300)
+	return response
+}
+
+// errorResponse is the object fetch fulfills with when the request can't
+// be made: {error: message}
+func errorResponse(message string) *internal.Object {
+	response := internal.NewObject()
+	response.Set("error", message)
+	return response
+}
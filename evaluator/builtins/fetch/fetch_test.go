@@ -2,12 +2,42 @@ package fetch
 
 import (
 	"bytes"
+	"go-script/internal"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
+	"time"
 )
 
+// loop runs the requests the tests make
+var loop = internal.NewEventLoop()
+
+// settle runs the event loop until the promise returned by fetch settles and
+// returns its value
+func settle(t *testing.T, result interface{}) interface{} {
+	t.Helper()
+
+	promise, ok := result.(*internal.Promise)
+	if !ok {
+		t.Fatalf("Expected fetch to return a *internal.Promise, got %T", result)
+	}
+	if !loop.RunUntilSettled(promise) {
+		t.Fatalf("Expected the fetch promise to settle")
+	}
+	return promise.Value
+}
+
+// newObject builds the options object a script would pass to fetch
+func newObject(properties map[string]interface{}) *internal.Object {
+	obj := internal.NewObject()
+	for key, value := range properties {
+		obj.Set(key, value)
+	}
+	return obj
+}
+
 func TestFetchSuccess(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -15,26 +45,26 @@ func TestFetchSuccess(t *testing.T) {
 	}))
 	defer server.Close()
 
-	result := Fetch.Fn(server.URL)
+	result := settle(t, Fetch(loop).Fn(server.URL))
 
-	response, ok := result.(map[string]interface{})
+	response, ok := result.(*internal.Object)
 	if !ok {
-		t.Fatalf("Expected map[string]interface{}, got %T", result)
+		t.Fatalf("Expected *internal.Object, got %T", result)
 	}
 
-	status, ok := response["status"].(float64)
+	status, ok := response.Get("status").(float64)
 	if !ok || status != 200 {
-		t.Errorf("Expected status 200, got %v", response["status"])
+		t.Errorf("Expected status 200, got %v", response.Get("status"))
 	}
 
-	body, ok := response["body"].(string)
+	body, ok := response.Get("body").(string)
 	if !ok || body != `{"message": "success"}` {
 		t.Errorf("Expected body %q, got %q", `{"message": "success"}`, body)
 	}
 
-	okField, ok := response["ok"].(bool)
+	okField, ok := response.Get("ok").(bool)
 	if !ok || !okField {
-		t.Errorf("Expected ok=true, got %v", response["ok"])
+		t.Errorf("Expected ok=true, got %v", response.Get("ok"))
 	}
 }
 
@@ -45,62 +75,62 @@ func TestFetchNotFound(t *testing.T) {
 	}))
 	defer server.Close()
 
-	result := Fetch.Fn(server.URL)
+	result := settle(t, Fetch(loop).Fn(server.URL))
 
-	response, ok := result.(map[string]interface{})
+	response, ok := result.(*internal.Object)
 	if !ok {
-		t.Fatalf("Expected map[string]interface{}, got %T", result)
+		t.Fatalf("Expected *internal.Object, got %T", result)
 	}
 
-	status, ok := response["status"].(float64)
+	status, ok := response.Get("status").(float64)
 	if !ok || status != 404 {
-		t.Errorf("Expected status 404, got %v", response["status"])
+		t.Errorf("Expected status 404, got %v", response.Get("status"))
 	}
 
-	okField, ok := response["ok"].(bool)
+	okField, ok := response.Get("ok").(bool)
 	if !ok || okField {
-		t.Errorf("Expected ok=false, got %v", response["ok"])
+		t.Errorf("Expected ok=false, got %v", response.Get("ok"))
 	}
 }
 
 func TestFetchInvalidURL(t *testing.T) {
-	result := Fetch.Fn("not-a-valid-url")
+	result := settle(t, Fetch(loop).Fn("not-a-valid-url"))
 
-	response, ok := result.(map[string]interface{})
+	response, ok := result.(*internal.Object)
 	if !ok {
-		t.Fatalf("Expected map[string]interface{}, got %T", result)
+		t.Fatalf("Expected *internal.Object, got %T", result)
 	}
 
-	if _, hasError := response["error"]; !hasError {
+	if !response.Has("error") {
 		t.Errorf("Expected error field in response, got %v", response)
 	}
 }
 
 func TestFetchNoArgs(t *testing.T) {
-	result := Fetch.Fn()
+	result := settle(t, Fetch(loop).Fn())
 
 	// Check result contains error
-	response, ok := result.(map[string]interface{})
+	response, ok := result.(*internal.Object)
 	if !ok {
-		t.Fatalf("Expected map[string]interface{}, got %T", result)
+		t.Fatalf("Expected *internal.Object, got %T", result)
 	}
 
 	// Check error message
-	errorMsg, ok := response["error"].(string)
+	errorMsg, ok := response.Get("error").(string)
 	if !ok || errorMsg != "fetch requires 1 or 2 arguments (url, options?)" {
 		t.Errorf("Expected specific error message, got %q", errorMsg)
 	}
 }
 
 func TestFetchTooManyArgs(t *testing.T) {
-	result := Fetch.Fn("url1", "url2", "url3")
+	result := settle(t, Fetch(loop).Fn("url1", "url2", "url3"))
 
-	response, ok := result.(map[string]interface{})
+	response, ok := result.(*internal.Object)
 	if !ok {
-		t.Fatalf("Expected map[string]interface{}, got %T", result)
+		t.Fatalf("Expected *internal.Object, got %T", result)
 	}
 
-	errorMsg, ok := response["error"].(string)
+	errorMsg, ok := response.Get("error").(string)
 	if !ok || errorMsg != "fetch requires 1 or 2 arguments (url, options?)" {
 		t.Errorf("Expected specific error message, got %q", errorMsg)
 	}
@@ -127,18 +157,18 @@ func TestFetchStatusCodes(t *testing.T) {
 			w.Write([]byte("test"))
 		}))
 
-		result := Fetch.Fn(server.URL)
-		response, ok := result.(map[string]interface{})
+		result := settle(t, Fetch(loop).Fn(server.URL))
+		response, ok := result.(*internal.Object)
 		if !ok {
-			t.Fatalf("Expected map[string]interface{}, got %T", result)
+			t.Fatalf("Expected *internal.Object, got %T", result)
 		}
 
-		status := response["status"].(float64)
+		status := response.Get("status").(float64)
 		if int(status) != tt.statusCode {
 			t.Errorf("Expected status %d, got %v", tt.statusCode, status)
 		}
 
-		okField := response["ok"].(bool)
+		okField := response.Get("ok").(bool)
 		if okField != tt.expectedOk {
 			t.Errorf("For status %d, expected ok=%v, got %v", tt.statusCode, tt.expectedOk, okField)
 		}
@@ -157,17 +187,17 @@ func TestFetchWithCustomMethod(t *testing.T) {
 			w.Write([]byte(r.Method))
 		}))
 
-		options := map[string]interface{}{
+		options := newObject(map[string]interface{}{
 			"method": method,
-		}
+		})
 
-		result := Fetch.Fn(server.URL, options)
-		response, ok := result.(map[string]interface{})
+		result := settle(t, Fetch(loop).Fn(server.URL, options))
+		response, ok := result.(*internal.Object)
 		if !ok {
-			t.Fatalf("Expected map[string]interface{}, got %T", result)
+			t.Fatalf("Expected *internal.Object, got %T", result)
 		}
 
-		body := response["body"].(string)
+		body := response.Get("body").(string)
 		if body != method {
 			t.Errorf("Expected method %s, got %s", method, body)
 		}
@@ -187,20 +217,20 @@ func TestFetchWithHeaders(t *testing.T) {
 	}))
 	defer server.Close()
 
-	options := map[string]interface{}{
-		"headers": map[string]interface{}{
+	options := newObject(map[string]interface{}{
+		"headers": newObject(map[string]interface{}{
 			"Authorization": "Bearer token123",
 			"Content-Type":  "application/json",
-		},
-	}
+		}),
+	})
 
-	result := Fetch.Fn(server.URL, options)
-	response, ok := result.(map[string]interface{})
+	result := settle(t, Fetch(loop).Fn(server.URL, options))
+	response, ok := result.(*internal.Object)
 	if !ok {
-		t.Fatalf("Expected map[string]interface{}, got %T", result)
+		t.Fatalf("Expected *internal.Object, got %T", result)
 	}
 
-	body := response["body"].(string)
+	body := response.Get("body").(string)
 	expected := "Bearer token123|application/json"
 	if body != expected {
 		t.Errorf("Expected body %q, got %q", expected, body)
@@ -217,18 +247,18 @@ func TestFetchWithBody(t *testing.T) {
 	defer server.Close()
 
 	requestBody := `{"name": "Alice", "age": 30}`
-	options := map[string]interface{}{
+	options := newObject(map[string]interface{}{
 		"method": "POST",
 		"body":   requestBody,
-	}
+	})
 
-	result := Fetch.Fn(server.URL, options)
-	response, ok := result.(map[string]interface{})
+	result := settle(t, Fetch(loop).Fn(server.URL, options))
+	response, ok := result.(*internal.Object)
 	if !ok {
-		t.Fatalf("Expected map[string]interface{}, got %T", result)
+		t.Fatalf("Expected *internal.Object, got %T", result)
 	}
 
-	body := response["body"].(string)
+	body := response.Get("body").(string)
 	if body != requestBody {
 		t.Errorf("Expected body %q, got %q", requestBody, body)
 	}
@@ -243,18 +273,18 @@ func TestFetchResponseHeaders(t *testing.T) {
 	}))
 	defer server.Close()
 
-	result := Fetch.Fn(server.URL)
-	response, ok := result.(map[string]interface{})
+	result := settle(t, Fetch(loop).Fn(server.URL))
+	response, ok := result.(*internal.Object)
 	if !ok {
-		t.Fatalf("Expected map[string]interface{}, got %T", result)
+		t.Fatalf("Expected *internal.Object, got %T", result)
 	}
 
-	headers, ok := response["headers"].(map[string]interface{})
+	headers, ok := response.Get("headers").(*internal.Object)
 	if !ok {
-		t.Fatalf("Expected headers to be map[string]interface{}, got %T", response["headers"])
+		t.Fatalf("Expected headers to be *internal.Object, got %T", response.Get("headers"))
 	}
 
-	customHeader, exists := headers["X-Custom-Header"]
+	customHeader, exists := headers.Get("X-Custom-Header"), headers.Has("X-Custom-Header")
 	if !exists {
 		t.Errorf("Expected X-Custom-Header to exist in response headers")
 	}
@@ -262,7 +292,7 @@ func TestFetchResponseHeaders(t *testing.T) {
 		t.Errorf("Expected X-Custom-Header=test-value, got %v", customHeader)
 	}
 
-	contentType, exists := headers["Content-Type"]
+	contentType, exists := headers.Get("Content-Type"), headers.Has("Content-Type")
 	if !exists {
 		t.Errorf("Expected Content-Type to exist in response headers")
 	}
@@ -271,6 +301,21 @@ func TestFetchResponseHeaders(t *testing.T) {
 	}
 }
 
+func TestFetchRepeatedHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("X-Tag", "a")
+		w.Header().Add("X-Tag", "b")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	response := settle(t, Fetch(loop).Fn(server.URL)).(*internal.Object)
+	headers := response.Get("headers").(*internal.Object)
+	if tag := headers.Get("X-Tag"); tag != "a, b" {
+		t.Errorf("Expected repeated headers joined as \"a, b\", got %v", tag)
+	}
+}
+
 func TestFetchComplexRequest(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != "POST" {
@@ -293,60 +338,122 @@ func TestFetchComplexRequest(t *testing.T) {
 	}))
 	defer server.Close()
 
-	options := map[string]interface{}{
+	options := newObject(map[string]interface{}{
 		"method": "POST",
-		"headers": map[string]interface{}{
+		"headers": newObject(map[string]interface{}{
 			"Content-Type":  "application/json",
 			"Authorization": "Bearer secret",
-		},
+		}),
 		"body": `{"action": "create", "data": "test"}`,
-	}
+	})
 
-	result := Fetch.Fn(server.URL, options)
-	response, ok := result.(map[string]interface{})
+	result := settle(t, Fetch(loop).Fn(server.URL, options))
+	response, ok := result.(*internal.Object)
 	if !ok {
-		t.Fatalf("Expected map[string]interface{}, got %T", result)
+		t.Fatalf("Expected *internal.Object, got %T", result)
 	}
 
-	status := response["status"].(float64)
+	status := response.Get("status").(float64)
 	if status != 201 {
 		t.Errorf("Expected status 201, got %v", status)
 	}
 
 	// Check ok
-	okField := response["ok"].(bool)
+	okField := response.Get("ok").(bool)
 	if !okField {
 		t.Errorf("Expected ok=true")
 	}
 
-	body := response["body"].(string)
+	body := response.Get("body").(string)
 	if !bytes.Contains([]byte(body), []byte("success")) {
 		t.Errorf("Expected body to contain 'success', got %q", body)
 	}
 
-	headers := response["headers"].(map[string]interface{})
-	if headers["X-Response-Id"] != "123" {
-		t.Errorf("Expected X-Response-Id=123, got %v", headers["X-Response-Id"])
+	headers := response.Get("headers").(*internal.Object)
+	if headers.Get("X-Response-Id") != "123" {
+		t.Errorf("Expected X-Response-Id=123, got %v", headers.Get("X-Response-Id"))
 	}
 }
 
 func TestFetchInvalidOptions(t *testing.T) {
 	// Call fetch with invalid options type
-	result := Fetch.Fn("http://example.com", "not-an-object")
+	result := settle(t, Fetch(loop).Fn("http://example.com", "not-an-object"))
 
 	// Check result contains error
-	response, ok := result.(map[string]interface{})
+	response, ok := result.(*internal.Object)
 	if !ok {
-		t.Fatalf("Expected map[string]interface{}, got %T", result)
+		t.Fatalf("Expected *internal.Object, got %T", result)
 	}
 
 	// Check error message
-	errorMsg, ok := response["error"].(string)
+	errorMsg, ok := response.Get("error").(string)
 	if !ok {
-		t.Errorf("Expected error field to be string, got %T", response["error"])
+		t.Errorf("Expected error field to be string, got %T", response.Get("error"))
 	}
 	// Error message now includes type information
 	if !ok || len(errorMsg) == 0 {
 		t.Errorf("Expected error message, got %q", errorMsg)
 	}
 }
+
+func TestFetchReturnsPromiseImmediately(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.Write([]byte("late"))
+	}))
+	defer server.Close()
+
+	promise, ok := Fetch(loop).Fn(server.URL).(*internal.Promise)
+	if !ok {
+		t.Fatalf("Expected a promise")
+	}
+	if promise.State != internal.PromisePending {
+		t.Errorf("Expected the promise to be pending while the request is in flight")
+	}
+
+	close(release)
+	response := settle(t, promise).(*internal.Object)
+	if response.Get("body") != "late" {
+		t.Errorf("Expected body late, got %v", response.Get("body"))
+	}
+}
+
+func TestFetchRequestsOverlap(t *testing.T) {
+	const requests = 5
+	const delay = 200 * time.Millisecond
+
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(delay)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	start := time.Now()
+	promises := []interface{}{}
+	for i := 0; i < requests; i++ {
+		promises = append(promises, Fetch(loop).Fn(server.URL))
+	}
+	for _, promise := range promises {
+		settle(t, promise)
+	}
+
+	if maxInFlight != requests {
+		t.Errorf("Expected %d requests in flight at once, got %d", requests, maxInFlight)
+	}
+	if elapsed := time.Since(start); elapsed >= requests*delay {
+		t.Errorf("Expected overlapping requests to take less than %v, took %v", requests*delay, elapsed)
+	}
+}
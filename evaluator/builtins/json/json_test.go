@@ -1,6 +1,8 @@
 package json
 
 import (
+	"go-script/evaluator/builtins/array"
+	"go-script/internal"
 	"testing"
 )
 
@@ -11,9 +13,14 @@ func TestJSONStringify(t *testing.T) {
 		expected string
 	}{
 		{
-			name:     "simple object",
-			input:    map[string]interface{}{"name": "Alice", "age": float64(30)},
-			expected: `{"age":30,"name":"Alice"}`,
+			name: "simple object",
+			input: func() *internal.Object {
+				obj := internal.NewObject()
+				obj.Set("name", "Alice")
+				obj.Set("age", float64(30))
+				return obj
+			}(),
+			expected: `{"name":"Alice","age":30}`,
 		},
 		{
 			name:     "string",
@@ -37,7 +44,7 @@ func TestJSONStringify(t *testing.T) {
 		},
 		{
 			name:     "null",
-			input:    nil,
+			input:    internal.Null,
 			expected: `null`,
 		},
 	}
@@ -45,67 +52,31 @@ func TestJSONStringify(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			result := Stringify.Fn(tt.input)
-			
-			// Check if result is an error
-			if errMap, ok := result.(map[string]interface{}); ok {
-				if _, hasError := errMap["error"]; hasError {
-					t.Fatalf("Stringify returned error: %v", errMap["error"])
-				}
-			}
-			
+
 			resultStr, ok := result.(string)
 			if !ok {
 				t.Fatalf("Expected string result, got %T", result)
 			}
-			
-			// For objects, check both possible orderings (map iteration is non-deterministic)
-			if tt.name == "simple object" {
-				alt := `{"name":"Alice","age":30}`
-				if resultStr != tt.expected && resultStr != alt {
-					t.Errorf("Expected %q or %q, got %q", tt.expected, alt, resultStr)
-				}
-			} else {
-				if resultStr != tt.expected {
-					t.Errorf("Expected %q, got %q", tt.expected, resultStr)
-				}
+
+			if resultStr != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, resultStr)
 			}
 		})
 	}
 }
 
 func TestJSONStringifyNoArgs(t *testing.T) {
-	result := Stringify.Fn()
-	
-	errMap, ok := result.(map[string]interface{})
-	if !ok {
-		t.Fatalf("Expected error map, got %T", result)
-	}
-	
-	errorMsg, hasError := errMap["error"]
-	if !hasError {
-		t.Errorf("Expected error field")
-	}
-	
-	if errorMsg != "JSON.stringify requires exactly 1 argument" {
-		t.Errorf("Expected specific error message, got %v", errorMsg)
+	// JSON.stringify() stringifies undefined, which has no JSON representation
+	if result := Stringify.Fn(); result != nil {
+		t.Errorf("Expected undefined, got %v", result)
 	}
 }
 
 func TestJSONStringifyTooManyArgs(t *testing.T) {
-	result := Stringify.Fn("arg1", "arg2")
-	
-	errMap, ok := result.(map[string]interface{})
-	if !ok {
-		t.Fatalf("Expected error map, got %T", result)
-	}
-	
-	errorMsg, hasError := errMap["error"]
-	if !hasError {
-		t.Errorf("Expected error field")
-	}
-	
-	if errorMsg != "JSON.stringify requires exactly 1 argument" {
-		t.Errorf("Expected specific error message, got %v", errorMsg)
+	// Arguments after the replacer and space are ignored
+	result := Stringify.Fn("arg1", nil, nil, "arg4")
+	if result != `"arg1"` {
+		t.Errorf("Expected %q, got %v", `"arg1"`, result)
 	}
 }
 
@@ -119,15 +90,15 @@ func TestJSONParse(t *testing.T) {
 			name:  "simple object",
 			input: `{"name":"Alice","age":30}`,
 			checkResult: func(t *testing.T, result interface{}) {
-				obj, ok := result.(map[string]interface{})
+				obj, ok := result.(*internal.Object)
 				if !ok {
-					t.Fatalf("Expected map[string]interface{}, got %T", result)
+					t.Fatalf("Expected *internal.Object, got %T", result)
 				}
-				if obj["name"] != "Alice" {
-					t.Errorf("Expected name=Alice, got %v", obj["name"])
+				if obj.Get("name") != "Alice" {
+					t.Errorf("Expected name=Alice, got %v", obj.Get("name"))
 				}
-				if obj["age"] != float64(30) {
-					t.Errorf("Expected age=30, got %v", obj["age"])
+				if obj.Get("age") != float64(30) {
+					t.Errorf("Expected age=30, got %v", obj.Get("age"))
 				}
 			},
 		},
@@ -187,8 +158,8 @@ func TestJSONParse(t *testing.T) {
 			name:  "null",
 			input: `null`,
 			checkResult: func(t *testing.T, result interface{}) {
-				if result != nil {
-					t.Errorf("Expected nil, got %v", result)
+				if result != internal.Null {
+					t.Errorf("Expected null, got %v", result)
 				}
 			},
 		},
@@ -196,12 +167,12 @@ func TestJSONParse(t *testing.T) {
 			name:  "array",
 			input: `[1,2,3]`,
 			checkResult: func(t *testing.T, result interface{}) {
-				arr, ok := result.([]interface{})
+				arr, ok := result.(*array.ArrayReference)
 				if !ok {
-					t.Fatalf("Expected []interface{}, got %T", result)
+					t.Fatalf("Expected *array.ArrayReference, got %T", result)
 				}
-				if len(arr) != 3 {
-					t.Errorf("Expected array length 3, got %d", len(arr))
+				if arr.Length() != 3 {
+					t.Errorf("Expected array length 3, got %v", arr.Length())
 				}
 			},
 		},
@@ -209,81 +180,46 @@ func TestJSONParse(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := Parse.Fn(tt.input)
-			
-			// Check if result is an error
-			if errMap, ok := result.(map[string]interface{}); ok {
-				if _, hasError := errMap["error"]; hasError {
-					t.Fatalf("Parse returned error: %v", errMap["error"])
-				}
+			var result interface{}
+			if exception := internal.Catch(func() { result = Parse.Fn(tt.input) }); exception != nil {
+				t.Fatalf("Parse threw: %v", exception.Value)
 			}
-			
+
 			tt.checkResult(t, result)
 		})
 	}
 }
 
 func TestJSONParseInvalidJSON(t *testing.T) {
-	result := Parse.Fn(`{invalid json}`)
-	
-	errMap, ok := result.(map[string]interface{})
-	if !ok {
-		t.Fatalf("Expected error map, got %T", result)
-	}
-	
-	_, hasError := errMap["error"]
-	if !hasError {
-		t.Errorf("Expected error field for invalid JSON")
+	exception := internal.Catch(func() { Parse.Fn(`{invalid json}`) })
+	if exception == nil || !internal.IsError(exception.Value) || internal.ErrorString(exception.Value) != "SyntaxError: Unexpected token i in JSON at position 1" {
+		t.Errorf("Expected a SyntaxError for invalid JSON, got %v", exception)
 	}
 }
 
 func TestJSONParseNoArgs(t *testing.T) {
-	result := Parse.Fn()
-	
-	errMap, ok := result.(map[string]interface{})
-	if !ok {
-		t.Fatalf("Expected error map, got %T", result)
-	}
-	
-	errorMsg, hasError := errMap["error"]
-	if !hasError {
-		t.Errorf("Expected error field")
-	}
-	
-	if errorMsg != "JSON.parse requires exactly 1 argument" {
-		t.Errorf("Expected specific error message, got %v", errorMsg)
+	// JSON.parse() parses the string "undefined"
+	exception := internal.Catch(func() { Parse.Fn() })
+	if exception == nil || !internal.IsError(exception.Value) || internal.ErrorString(exception.Value) != "SyntaxError: Unexpected token u in JSON at position 0" {
+		t.Errorf("Expected a SyntaxError, got %v", exception)
 	}
 }
 
 func TestJSONParseTooManyArgs(t *testing.T) {
-	result := Parse.Fn("arg1", "arg2")
-	
-	errMap, ok := result.(map[string]interface{})
-	if !ok {
-		t.Fatalf("Expected error map, got %T", result)
-	}
-	
-	errorMsg, hasError := errMap["error"]
-	if !hasError {
-		t.Errorf("Expected error field")
-	}
-	
-	if errorMsg != "JSON.parse requires exactly 1 argument" {
-		t.Errorf("Expected specific error message, got %v", errorMsg)
+	// A second argument that isn't a function isn't a reviver
+	result := Parse.Fn(`[1]`, "arg2")
+	if arr, ok := result.(*array.ArrayReference); !ok || arr.Get(0) != 1.0 {
+		t.Errorf("Expected [1], got %v", result)
 	}
 }
 
 func TestJSONParseNonString(t *testing.T) {
-	result := Parse.Fn(float64(42))
-	
-	errMap, ok := result.(map[string]interface{})
-	if !ok {
-		t.Fatalf("Expected error map, got %T", result)
+	// Other values are converted to strings first
+	if result := Parse.Fn(float64(42)); result != 42.0 {
+		t.Errorf("Expected 42, got %v", result)
 	}
-	
-	_, hasError := errMap["error"]
-	if !hasError {
-		t.Errorf("Expected error field for non-string argument")
+	if result := Parse.Fn(true); result != true {
+		t.Errorf("Expected true, got %v", result)
 	}
 }
 
@@ -295,7 +231,7 @@ func TestJSONNamespace(t *testing.T) {
 	if JSON["parse"] == nil {
 		t.Errorf("JSON.parse should be defined")
 	}
-	
+
 	// Check that they have the correct names
 	if JSON["stringify"].Name != "stringify" {
 		t.Errorf("Expected name 'stringify', got %q", JSON["stringify"].Name)
@@ -0,0 +1,385 @@
+package json
+
+import (
+	"fmt"
+	"go-script/evaluator/builtins/array"
+	"go-script/internal"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Parse converts a JSON string to a JavaScript value
+//
+// Syntax: JSON.parse(text, reviver)
+//
+// Objects become plain objects, with their keys in the order they appear in
+// the text, and arrays become arrays, so the result works like any literal
+// would. Invalid JSON throws a SyntaxError with the byte offset of the
+// problem.
+//
+// The reviver, a function(key, value), is called for every property from
+// the innermost out with the holding object as this; what it returns
+// replaces the value, and undefined removes the property.
+//
+// Numbers are read as float64, like every number in JavaScript: integers
+// beyond Number.MAX_SAFE_INTEGER (2^53 - 1) lose precision, so large ids are
+// best sent as strings.
+//
+// Examples:
+//
+//	JSON.parse('{"name":"Alice","tags":["a","b"]}').tags[1]   → b
+//	JSON.parse("[1,2,3]").map(x => x * 2)                     → [2, 4, 6]
+//	JSON.parse('{"a":1,"b":2}', (k, v) => k == "a" ? undefined : v) → {b: 2}
+//	JSON.parse("9007199254740993")                            → 9007199254740992
+//	JSON.parse('{"a":}')     → throws SyntaxError: Unexpected token } in JSON at position 5
+//	JSON.parse('[1,2')       → throws SyntaxError: Unexpected end of JSON input
+//
+// Arrays and objects nest at most 10000 deep; deeper text throws a
+// SyntaxError too.
+var Parse = &internal.Builtin{
+	Name: "parse",
+	Fn: func(args ...interface{}) interface{} {
+		value, err := Decode(internal.ToString(argAt(args, 0)))
+		if err != nil {
+			internal.ThrowError("SyntaxError", err.Error())
+		}
+
+		if reviver, ok := argAt(args, 1).(internal.Callable); ok {
+			holder := internal.NewObject()
+			holder.Set("", value)
+			return internalize(reviver, holder, "", value)
+		}
+		return value
+	},
+}
+
+// SyntaxError describes invalid JSON text
+type SyntaxError struct {
+	Message string
+	Offset  int // Byte offset of the unexpected input, len(text) at the end
+}
+
+func (e *SyntaxError) Error() string {
+	return e.Message
+}
+
+// Decode parses JSON text into runtime values: *internal.Object for objects,
+// *array.ArrayReference for arrays, internal.Null, float64, string and bool.
+// Errors are *SyntaxError.
+func Decode(text string) (internal.Value, error) {
+	d := &decoder{text: text}
+	d.skipWhitespace()
+	value, err := d.value()
+	if err != nil {
+		return nil, err
+	}
+	d.skipWhitespace()
+	if d.pos < len(d.text) {
+		return nil, d.unexpected()
+	}
+	return value, nil
+}
+
+// maxDepth is how deeply arrays and objects may nest. The decoder recurses
+// once per level, so without a limit text like "[[[[..." could exhaust the
+// stack.
+const maxDepth = 10000
+
+// decoder is a recursive descent parser over the JSON grammar
+type decoder struct {
+	text  string
+	pos   int
+	depth int // arrays and objects currently open
+}
+
+func (d *decoder) value() (internal.Value, error) {
+	if d.pos >= len(d.text) {
+		return nil, d.unexpected()
+	}
+
+	switch ch := d.text[d.pos]; {
+	case ch == '{' || ch == '[':
+		if d.depth == maxDepth {
+			return nil, d.errorAt(d.pos, "Maximum nesting depth exceeded")
+		}
+		d.depth++
+		defer func() { d.depth-- }()
+		if ch == '{' {
+			return d.object()
+		}
+		return d.array()
+	case ch == '"':
+		return d.string()
+	case ch == '-' || isDigit(ch):
+		return d.number()
+	case strings.HasPrefix(d.text[d.pos:], "true"):
+		d.pos += len("true")
+		return true, nil
+	case strings.HasPrefix(d.text[d.pos:], "false"):
+		d.pos += len("false")
+		return false, nil
+	case strings.HasPrefix(d.text[d.pos:], "null"):
+		d.pos += len("null")
+		return internal.Null, nil
+	}
+	return nil, d.unexpected()
+}
+
+// object parses {"key": value, ...}; a repeated key keeps the last value
+func (d *decoder) object() (internal.Value, error) {
+	obj := internal.NewObject()
+	d.pos++ // {
+	d.skipWhitespace()
+	if d.consume('}') {
+		return obj, nil
+	}
+
+	for {
+		if d.pos >= len(d.text) || d.text[d.pos] != '"' {
+			return nil, d.unexpected()
+		}
+		key, err := d.string()
+		if err != nil {
+			return nil, err
+		}
+		d.skipWhitespace()
+		if !d.consume(':') {
+			return nil, d.unexpected()
+		}
+		d.skipWhitespace()
+		value, err := d.value()
+		if err != nil {
+			return nil, err
+		}
+		// Define rather than Set, so "__proto__" is an ordinary property
+		obj.Define(key, &internal.Property{Value: value})
+
+		d.skipWhitespace()
+		if d.consume('}') {
+			return obj, nil
+		}
+		if !d.consume(',') {
+			return nil, d.unexpected()
+		}
+		d.skipWhitespace()
+	}
+}
+
+// array parses [value, ...]
+func (d *decoder) array() (internal.Value, error) {
+	elements := internal.Array{}
+	d.pos++ // [
+	d.skipWhitespace()
+	if d.consume(']') {
+		return array.NewArrayReference(elements), nil
+	}
+
+	for {
+		value, err := d.value()
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, value)
+
+		d.skipWhitespace()
+		if d.consume(']') {
+			return array.NewArrayReference(elements), nil
+		}
+		if !d.consume(',') {
+			return nil, d.unexpected()
+		}
+		d.skipWhitespace()
+	}
+}
+
+// string parses a quoted string, decoding its escapes. A \u escape for a
+// lone surrogate, which a Go string can't hold, becomes U+FFFD.
+func (d *decoder) string() (string, error) {
+	var b strings.Builder
+	d.pos++ // "
+
+	for {
+		if d.pos >= len(d.text) {
+			return "", d.unexpected()
+		}
+		ch := d.text[d.pos]
+		switch {
+		case ch == '"':
+			d.pos++
+			return b.String(), nil
+		case ch < 0x20:
+			return "", d.errorAt(d.pos, "Bad control character in string literal")
+		case ch != '\\':
+			b.WriteByte(ch)
+			d.pos++
+			continue
+		}
+
+		// An escape sequence
+		start := d.pos
+		d.pos++
+		if d.pos >= len(d.text) {
+			return "", d.unexpected()
+		}
+		switch d.text[d.pos] {
+		case '"':
+			b.WriteByte('"')
+		case '\\':
+			b.WriteByte('\\')
+		case '/':
+			b.WriteByte('/')
+		case 'b':
+			b.WriteByte('\b')
+		case 'f':
+			b.WriteByte('\f')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case 'u':
+			r, ok := d.hex4(d.pos + 1)
+			if !ok {
+				return "", d.errorAt(start, "Bad Unicode escape")
+			}
+			d.pos += 4
+			if utf16.IsSurrogate(r) {
+				if low, ok := d.lowSurrogate(); ok {
+					r = utf16.DecodeRune(r, low)
+				} else {
+					r = utf8.RuneError
+				}
+			}
+			b.WriteRune(r)
+		default:
+			return "", d.errorAt(start, "Bad escaped character")
+		}
+		d.pos++
+	}
+}
+
+// lowSurrogate reads a \uDC00-\uDFFF escape completing the surrogate pair
+// that ends at d.pos
+func (d *decoder) lowSurrogate() (rune, bool) {
+	if !strings.HasPrefix(d.text[d.pos+1:], `\u`) {
+		return 0, false
+	}
+	low, ok := d.hex4(d.pos + 3)
+	if !ok || low < 0xdc00 || low > 0xdfff {
+		return 0, false
+	}
+	d.pos += 6
+	return low, true
+}
+
+// hex4 reads the four hex digits of a \u escape
+func (d *decoder) hex4(at int) (rune, bool) {
+	if at+4 > len(d.text) {
+		return 0, false
+	}
+	n, err := strconv.ParseUint(d.text[at:at+4], 16, 32)
+	if err != nil {
+		return 0, false
+	}
+	return rune(n), true
+}
+
+// number parses -?(0|[1-9][0-9]*)(.[0-9]+)?([eE][+-]?[0-9]+)?
+// Numbers too large for a float64 become ±Infinity, as in JavaScript.
+func (d *decoder) number() (internal.Value, error) {
+	start := d.pos
+	d.consume('-')
+	if !d.consume('0') && !d.digits() { // No leading zeros
+		return nil, d.unexpected()
+	}
+	if d.consume('.') && !d.digits() {
+		return nil, d.unexpected()
+	}
+	if d.consume('e') || d.consume('E') {
+		if !d.consume('+') {
+			d.consume('-')
+		}
+		if !d.digits() {
+			return nil, d.unexpected()
+		}
+	}
+
+	n, _ := strconv.ParseFloat(d.text[start:d.pos], 64)
+	return n, nil
+}
+
+// digits skips one or more digits, reporting false if there are none
+func (d *decoder) digits() bool {
+	start := d.pos
+	for d.pos < len(d.text) && isDigit(d.text[d.pos]) {
+		d.pos++
+	}
+	return d.pos > start
+}
+
+func (d *decoder) consume(ch byte) bool {
+	if d.pos < len(d.text) && d.text[d.pos] == ch {
+		d.pos++
+		return true
+	}
+	return false
+}
+
+// skipWhitespace skips the four characters JSON allows between tokens
+func (d *decoder) skipWhitespace() {
+	for d.pos < len(d.text) {
+		switch d.text[d.pos] {
+		case ' ', '\t', '\n', '\r':
+			d.pos++
+		default:
+			return
+		}
+	}
+}
+
+// unexpected reports the character at the current position, or the end of
+// the input
+//
+// Examples:
+//
+//	Unexpected token } in JSON at position 5
+//	Unexpected end of JSON input
+func (d *decoder) unexpected() error {
+	if d.pos >= len(d.text) {
+		return &SyntaxError{Message: "Unexpected end of JSON input", Offset: len(d.text)}
+	}
+	r, _ := utf8.DecodeRuneInString(d.text[d.pos:])
+	return d.errorAt(d.pos, fmt.Sprintf("Unexpected token %c", r))
+}
+
+func (d *decoder) errorAt(offset int, problem string) error {
+	return &SyntaxError{Message: fmt.Sprintf("%s in JSON at position %d", problem, offset), Offset: offset}
+}
+
+func isDigit(ch byte) bool {
+	return ch >= '0' && ch <= '9'
+}
+
+// internalize runs the reviver over holder[key] after reviving its
+// properties, removing the ones it returns undefined for
+func internalize(reviver internal.Callable, holder internal.Value, key string, value internal.Value) internal.Value {
+	switch v := value.(type) {
+	case *array.ArrayReference:
+		length := len(*v.Elements)
+		for i := 0; i < length; i++ {
+			v.Set(i, internalize(reviver, v, strconv.Itoa(i), v.Get(i)))
+		}
+	case *internal.Object:
+		for _, k := range v.Keys() {
+			if revived := internalize(reviver, v, k, v.Get(k)); revived == nil {
+				v.Delete(k)
+			} else {
+				v.Set(k, revived)
+			}
+		}
+	}
+	return reviver.Call(holder, key, value)
+}
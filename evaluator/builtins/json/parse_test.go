@@ -0,0 +1,172 @@
+package json
+
+import (
+	"go-script/evaluator/builtins/array"
+	"go-script/internal"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestDecode(t *testing.T) {
+	// Each input is decoded and written back with Stringify
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`{"b":1,"a":2}`, `{"b":1,"a":2}`},
+		{`{"a":1,"b":2,"a":3}`, `{"a":3,"b":2}`},
+		{` [ 1 , "two" , true , false , null ] `, `[1,"two",true,false,null]`},
+		{`{"nested":{"list":[[],{}]}}`, `{"nested":{"list":[[],{}]}}`},
+		{"\t\r\n{}\n", `{}`},
+		{`-0.5e2`, `-50`},
+		{`1E+2`, `100`},
+		{`0`, `0`},
+		{`"a\"b\\c\/d\n"`, `"a\"b\\c/d\n"`},
+		{`"é中"`, `"é中"`},
+		{`"😀"`, `"😀"`},
+		{`"\ud83d"`, `"` + "�" + `"`},
+		{`"héllo"`, `"héllo"`},
+		{`{"__proto__":1}`, `{"__proto__":1}`},
+	}
+
+	for _, tt := range tests {
+		value, err := Decode(tt.input)
+		if err != nil {
+			t.Errorf("For input %q: unexpected error %v", tt.input, err)
+			continue
+		}
+		if result := Stringify.Fn(value); result != tt.expected {
+			t.Errorf("For input %q: expected %s, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestDecodeNumbers(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected float64
+	}{
+		{`9007199254740993`, 9007199254740992}, // Beyond 2^53, precision is lost
+		{`1e400`, math.Inf(1)},
+		{`-1e400`, math.Inf(-1)},
+		{`0.1`, 0.1},
+	}
+
+	for _, tt := range tests {
+		value, err := Decode(tt.input)
+		if err != nil || value != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v (%v)", tt.input, tt.expected, value, err)
+		}
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		offset   int
+	}{
+		{``, "Unexpected end of JSON input", 0},
+		{`[1,2`, "Unexpected end of JSON input", 4},
+		{`{"a":}`, "Unexpected token } in JSON at position 5", 5},
+		{`[1,]`, "Unexpected token ] in JSON at position 3", 3},
+		{`{a:1}`, "Unexpected token a in JSON at position 1", 1},
+		{`{'a':1}`, "Unexpected token ' in JSON at position 1", 1},
+		{`{"a" 1}`, "Unexpected token 1 in JSON at position 5", 5},
+		{`01`, "Unexpected token 1 in JSON at position 1", 1},
+		{`1.`, "Unexpected end of JSON input", 2},
+		{`.5`, "Unexpected token . in JSON at position 0", 0},
+		{`+1`, "Unexpected token + in JSON at position 0", 0},
+		{`NaN`, "Unexpected token N in JSON at position 0", 0},
+		{`tru`, "Unexpected token t in JSON at position 0", 0},
+		{`[1] x`, "Unexpected token x in JSON at position 4", 4},
+		{`"é" ü`, "Unexpected token ü in JSON at position 5", 5},
+		{`"a` + "\n" + `"`, "Bad control character in string literal in JSON at position 2", 2},
+		{`"\x"`, "Bad escaped character in JSON at position 1", 1},
+		{`"\u12"`, "Bad Unicode escape in JSON at position 1", 1},
+		{`"abc`, "Unexpected end of JSON input", 4},
+	}
+
+	for _, tt := range tests {
+		_, err := Decode(tt.input)
+		syntaxErr, ok := err.(*SyntaxError)
+		if !ok {
+			t.Errorf("For input %q: expected a SyntaxError, got %v", tt.input, err)
+			continue
+		}
+		if syntaxErr.Message != tt.expected || syntaxErr.Offset != tt.offset {
+			t.Errorf("For input %q: expected %q at %d, got %q at %d", tt.input, tt.expected, tt.offset, syntaxErr.Message, syntaxErr.Offset)
+		}
+	}
+}
+
+func TestDecodeDepth(t *testing.T) {
+	nested := strings.Repeat("[", maxDepth) + strings.Repeat("]", maxDepth)
+	if _, err := Decode(nested); err != nil {
+		t.Errorf("Expected %d levels of nesting to parse, got %v", maxDepth, err)
+	}
+
+	tests := []struct {
+		input  string
+		offset int
+	}{
+		{strings.Repeat("[", 5000000), maxDepth},
+		{strings.Repeat(`{"a":`, maxDepth+1), 5 * maxDepth},
+		{strings.Repeat("[", maxDepth) + "{}" + strings.Repeat("]", maxDepth), maxDepth},
+	}
+
+	for _, tt := range tests {
+		_, err := Decode(tt.input)
+		syntaxErr, ok := err.(*SyntaxError)
+		if !ok {
+			t.Errorf("Expected a SyntaxError, got %v", err)
+			continue
+		}
+		expected := "Maximum nesting depth exceeded in JSON at position " + strconv.Itoa(tt.offset)
+		if syntaxErr.Message != expected || syntaxErr.Offset != tt.offset {
+			t.Errorf("Expected %q, got %q", expected, syntaxErr.Message)
+		}
+	}
+
+	exception := internal.Catch(func() { Parse.Fn(strings.Repeat("[", 5000000)) })
+	if exception == nil || internal.ErrorString(exception.Value) != "SyntaxError: Maximum nesting depth exceeded in JSON at position 10000" {
+		t.Errorf("Expected JSON.parse to throw a SyntaxError, got %v", exception)
+	}
+}
+
+func TestParseReviver(t *testing.T) {
+	var visited []string
+	record := &internal.Builtin{Fn: func(args ...interface{}) interface{} {
+		visited = append(visited, args[0].(string))
+		return args[1]
+	}}
+	Parse.Fn(`{"a":[1,{"b":2}],"c":3}`, record)
+	if result := Stringify.Fn(array.NewArrayReference(toArray(visited))); result != `["0","b","1","a","c",""]` {
+		t.Errorf("Expected the reviver to visit properties innermost first, got %v", result)
+	}
+
+	// Doubling numbers and dropping strings
+	reviver := &internal.Builtin{Fn: func(args ...interface{}) interface{} {
+		switch v := args[1].(type) {
+		case float64:
+			return v * 2
+		case string:
+			return nil
+		}
+		return args[1]
+	}}
+	result := Parse.Fn(`{"n":1,"s":"x","list":[2,"y"]}`, reviver)
+	if str := Stringify.Fn(result); str != `{"n":2,"list":[4,null]}` {
+		t.Errorf("Expected the reviver to replace and remove values, got %v", str)
+	}
+}
+
+func toArray(values []string) internal.Array {
+	elements := internal.Array{}
+	for _, value := range values {
+		elements = append(elements, value)
+	}
+	return elements
+}
@@ -0,0 +1,292 @@
+package json
+
+import (
+	"go-script/evaluator/builtins/array"
+	"go-script/evaluator/builtins/date"
+	"go-script/internal"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Stringify converts a JavaScript value to a JSON string
+//
+// Syntax: JSON.stringify(value, replacer, space)
+//
+// The replacer is either a function(key, value), called with the holder
+// as this for every property, whose result is written instead, or an
+// array of the property names to keep. The space indents nested values:
+// a number of spaces (up to 10) or a string (its first 10 characters).
+//
+// Following the spec:
+//   - A toJSON() method, like Date's, replaces the value before anything else
+//   - undefined, functions and symbols are left out of objects, written as
+//     null in arrays, and make the result undefined at the top level
+//   - NaN and ±Infinity are written as null
+//   - Object properties come out in enumeration order; symbol keys and
+//     non-enumerable properties are skipped
+//   - Strings are written as they are, without escaping HTML or non-ASCII
+//   - Circular structures throw a TypeError
+//
+// Examples:
+//
+//	JSON.stringify({ name: "Alice", age: 30 })     → {"name":"Alice","age":30}
+//	JSON.stringify([1, undefined, NaN])            → [1,null,null]
+//	JSON.stringify({ a: 1, b: 2 }, ["b"])          → {"b":2}
+//	JSON.stringify({ a: 1 }, (k, v) => typeof v == "number" ? v * 2 : v) → {"a":2}
+//	JSON.stringify({ a: [1] }, null, 2)            → "{\n  \"a\": [\n    1\n  ]\n}"
+//	JSON.stringify({ at: new Date(0) })            → {"at":"1970-01-01T00:00:00.000Z"}
+//	JSON.stringify(undefined)                      → undefined
+//	var o = {}; o.self = o; JSON.stringify(o)      → throws TypeError
+var Stringify = &internal.Builtin{
+	Name: "stringify",
+	Fn: func(args ...interface{}) interface{} {
+		s := &stringifier{gap: toGap(argAt(args, 2))}
+
+		switch replacer := argAt(args, 1).(type) {
+		case internal.Callable:
+			s.replacer = replacer
+		case internal.ArrayLike:
+			s.propertyList = toPropertyList(replacer.GetElements())
+		}
+
+		holder := internal.NewObject()
+		holder.Set("", argAt(args, 0))
+		if result, ok := s.serializeProperty(holder, "", argAt(args, 0)); ok {
+			return result
+		}
+		return nil
+	},
+}
+
+// stringifier holds the state of one JSON.stringify call
+type stringifier struct {
+	replacer     internal.Callable
+	propertyList []string // nil unless the replacer is an array
+	gap          string   // One level of indentation, "" for compact output
+	indent       string   // The indentation of the members being written
+	stack        []internal.Value
+}
+
+// serializeProperty writes the value of holder[key], reporting false when
+// it has no JSON representation (undefined, a function or a symbol)
+func (s *stringifier) serializeProperty(holder internal.Value, key string, value internal.Value) (string, bool) {
+	value = toJSON(value, key)
+	if s.replacer != nil {
+		value = s.replacer.Call(holder, key, value)
+	}
+
+	switch v := value.(type) {
+	case nil, *internal.Symbol, internal.Callable:
+		return "", false
+	case internal.NullValue:
+		return "null", true
+	case bool:
+		return strconv.FormatBool(v), true
+	case string:
+		return Quote(v), true
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return "null", true
+		}
+		return internal.FormatNumber(v), true
+	case internal.ArrayLike:
+		return s.serializeArray(value, v.GetElements()), true
+	case *internal.Object:
+		return s.serializeObject(value, v.Keys(), v.Get), true
+	default:
+		// Regular expressions, maps, sets and promises have no enumerable
+		// properties of their own
+		return "{}", true
+	}
+}
+
+// serializeObject writes an object's properties as "key":value pairs,
+// leaving out the ones without a JSON representation
+func (s *stringifier) serializeObject(object internal.Value, keys []string, get func(internal.PropertyKey) internal.Value) string {
+	s.enter(object)
+	defer s.leave()
+
+	if s.propertyList != nil {
+		keys = s.propertyList
+	}
+	separator := ":"
+	if s.gap != "" {
+		separator = ": "
+	}
+
+	var members []string
+	for _, key := range keys {
+		if str, ok := s.serializeProperty(object, key, get(key)); ok {
+			members = append(members, Quote(key)+separator+str)
+		}
+	}
+	return s.join("{", members, "}")
+}
+
+// serializeArray writes an array's elements, with null for the ones that
+// have no JSON representation
+func (s *stringifier) serializeArray(object internal.Value, elements []internal.Value) string {
+	s.enter(object)
+	defer s.leave()
+
+	members := make([]string, len(elements))
+	for i, element := range elements {
+		str, ok := s.serializeProperty(object, strconv.Itoa(i), element)
+		if !ok {
+			str = "null"
+		}
+		members[i] = str
+	}
+	return s.join("[", members, "]")
+}
+
+// join writes the members between brackets, one per line when indenting
+//
+// Examples:
+//
+//	compact:  {"a":1,"b":2}
+//	indented: {
+//	            "a": 1,
+//	            "b": 2
+//	          }
+func (s *stringifier) join(open string, members []string, close string) string {
+	if len(members) == 0 {
+		return open + close
+	}
+	if s.gap == "" {
+		return open + strings.Join(members, ",") + close
+	}
+	outer := s.indent[:len(s.indent)-len(s.gap)]
+	return open + "\n" + s.indent + strings.Join(members, ",\n"+s.indent) + "\n" + outer + close
+}
+
+// enter starts serializing an object or array one level deeper, throwing
+// a TypeError if it's already being serialized further up
+func (s *stringifier) enter(object internal.Value) {
+	for _, ancestor := range s.stack {
+		if ancestor == object {
+			internal.ThrowError("TypeError", "Converting circular structure to JSON")
+		}
+	}
+	s.stack = append(s.stack, object)
+	s.indent += s.gap
+}
+
+func (s *stringifier) leave() {
+	s.stack = s.stack[:len(s.stack)-1]
+	s.indent = s.indent[:len(s.indent)-len(s.gap)]
+}
+
+// toJSON calls the value's toJSON(key) method, if it has one
+func toJSON(value internal.Value, key string) internal.Value {
+	switch v := value.(type) {
+	case *internal.Object:
+		if method, ok := v.Get("toJSON").(internal.Callable); ok {
+			return method.Call(v, key)
+		}
+	case *date.Date:
+		return date.Methods["toJSON"](v, []interface{}{key})
+	case *array.ArrayReference:
+		if props := v.SubclassObject(); props != nil {
+			if method, ok := props.Get("toJSON").(internal.Callable); ok {
+				return method.Call(v, key)
+			}
+		}
+	}
+	return value
+}
+
+// toGap turns the space argument into one level of indentation
+//
+// Examples: 2 → "  ", 20 → 10 spaces, "\t" → "\t", "abcdefghijkl" → "abcdefghij"
+func toGap(space internal.Value) string {
+	switch v := space.(type) {
+	case float64:
+		n := min(10, v)
+		if n < 1 {
+			return ""
+		}
+		return strings.Repeat(" ", int(n))
+	case string:
+		if utf8.RuneCountInString(v) > 10 {
+			return string([]rune(v)[:10])
+		}
+		return v
+	}
+	return ""
+}
+
+// toPropertyList reads a replacer array: the names of the properties to
+// keep, from its strings and numbers, without duplicates
+func toPropertyList(elements []internal.Value) []string {
+	list := []string{}
+	seen := map[string]bool{}
+	for _, element := range elements {
+		var name string
+		switch v := element.(type) {
+		case string:
+			name = v
+		case float64:
+			name = internal.FormatNumber(v)
+		default:
+			continue
+		}
+		if !seen[name] {
+			seen[name] = true
+			list = append(list, name)
+		}
+	}
+	return list
+}
+
+// Quote writes a string as a JSON string literal
+// Quotes, backslashes and control characters are escaped; everything else,
+// including non-ASCII characters and <, > and &, is written as is.
+//
+// Examples:
+//
+//	Quote(`say "hi"`)  → "say \"hi\""
+//	Quote("a\nb")      → "a\nb" with the newline escaped
+//	Quote("\x01")      → "\u0001"
+//	Quote("héllo <b>") → "héllo <b>"
+func Quote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, ch := range s {
+		switch ch {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if ch < 0x20 {
+				b.WriteString(`\u00`)
+				b.WriteByte("0123456789abcdef"[ch>>4])
+				b.WriteByte("0123456789abcdef"[ch&0xf])
+			} else {
+				b.WriteRune(ch)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+func argAt(args []interface{}, index int) internal.Value {
+	if index >= len(args) {
+		return nil
+	}
+	return args[index]
+}
@@ -0,0 +1,149 @@
+package json
+
+import (
+	"go-script/evaluator/builtins/array"
+	"go-script/evaluator/builtins/date"
+	"go-script/internal"
+	"math"
+	"testing"
+)
+
+func TestStringifyValues(t *testing.T) {
+	obj := internal.NewObject()
+	obj.Set("b", 1.0)
+	obj.Set("a", "x")
+	obj.Set("skip", nil)
+	obj.Set("fn", &internal.Builtin{Name: "fn"})
+
+	tests := []struct {
+		name     string
+		input    interface{}
+		expected interface{}
+	}{
+		{"object keeps insertion order", obj, `{"b":1,"a":"x"}`},
+		{"array reference", array.NewArrayReference(internal.Array{1.0, "a", true}), `[1,"a",true]`},
+		{"undefined in arrays", array.NewArrayReference(internal.Array{nil, &internal.Builtin{}, internal.NewSymbol("s")}), `[null,null,null]`},
+		{"NaN", math.NaN(), `null`},
+		{"Infinity", math.Inf(-1), `null`},
+		{"negative zero", math.Copysign(0, -1), `0`},
+		{"undefined", nil, nil},
+		{"function", &internal.Builtin{Name: "fn"}, nil},
+		{"symbol", internal.NewSymbol("s"), nil},
+		{"non-ASCII", "héllo <b>&", `"héllo <b>&"`},
+		{"escapes", "a\"b\\c\nd\te\x01", `"a\"b\\c\nd\te\u0001"`},
+		{"empty array", array.NewArrayReference(internal.Array{}), `[]`},
+		{"date", &date.Date{Time: 0}, `"1970-01-01T00:00:00.000Z"`},
+		{"invalid date", &date.Date{Time: math.NaN()}, `null`},
+	}
+
+	for _, tt := range tests {
+		result := Stringify.Fn(tt.input)
+		if result != tt.expected {
+			t.Errorf("For %s: expected %v, got %v", tt.name, tt.expected, result)
+		}
+	}
+}
+
+func TestStringifyReplacer(t *testing.T) {
+	obj := internal.NewObject()
+	obj.Set("a", 1.0)
+	obj.Set("b", "two")
+	obj.Set("c", array.NewArrayReference(internal.Array{3.0}))
+
+	double := &internal.Builtin{Fn: func(args ...interface{}) interface{} {
+		if n, ok := args[1].(float64); ok {
+			return n * 2
+		}
+		return args[1]
+	}}
+	dropStrings := &internal.Builtin{Fn: func(args ...interface{}) interface{} {
+		if _, ok := args[1].(string); ok {
+			return nil
+		}
+		return args[1]
+	}}
+
+	tests := []struct {
+		name     string
+		replacer interface{}
+		expected string
+	}{
+		{"function", double, `{"a":2,"b":"two","c":[6]}`},
+		{"function dropping properties", dropStrings, `{"a":1,"c":[3]}`},
+		{"property list", array.NewArrayReference(internal.Array{"c", "a", "missing", "a"}), `{"c":[3],"a":1}`},
+		{"numeric property list", array.NewArrayReference(internal.Array{1.0}), `{}`},
+		{"null", internal.Null, `{"a":1,"b":"two","c":[3]}`},
+	}
+
+	for _, tt := range tests {
+		result := Stringify.Fn(obj, tt.replacer)
+		if result != tt.expected {
+			t.Errorf("For %s: expected %s, got %v", tt.name, tt.expected, result)
+		}
+	}
+}
+
+func TestStringifySpace(t *testing.T) {
+	inner := internal.NewObject()
+	inner.Set("b", array.NewArrayReference(internal.Array{1.0, 2.0}))
+	inner.Set("c", internal.NewObject())
+	obj := internal.NewObject()
+	obj.Set("a", inner)
+
+	tests := []struct {
+		space    interface{}
+		expected string
+	}{
+		{2.0, "{\n  \"a\": {\n    \"b\": [\n      1,\n      2\n    ],\n    \"c\": {}\n  }\n}"},
+		{"\t", "{\n\t\"a\": {\n\t\t\"b\": [\n\t\t\t1,\n\t\t\t2\n\t\t],\n\t\t\"c\": {}\n\t}\n}"},
+		{0.0, `{"a":{"b":[1,2],"c":{}}}`},
+		{"", `{"a":{"b":[1,2],"c":{}}}`},
+	}
+
+	for _, tt := range tests {
+		result := Stringify.Fn(obj, nil, tt.space)
+		if result != tt.expected {
+			t.Errorf("For space %q: expected %q, got %q", tt.space, tt.expected, result)
+		}
+	}
+}
+
+func TestToGap(t *testing.T) {
+	tests := []struct {
+		space    interface{}
+		expected string
+	}{
+		{2.0, "  "},
+		{20.0, "          "},
+		{-1.0, ""},
+		{"abcdefghijkl", "abcdefghij"},
+		{true, ""},
+	}
+
+	for _, tt := range tests {
+		if result := toGap(tt.space); result != tt.expected {
+			t.Errorf("For space %v: expected %q, got %q", tt.space, tt.expected, result)
+		}
+	}
+}
+
+func TestStringifyCycles(t *testing.T) {
+	obj := internal.NewObject()
+	obj.Set("self", obj)
+	arr := array.NewArrayReference(internal.Array{})
+	*arr.Elements = append(*arr.Elements, arr)
+
+	for _, value := range []interface{}{obj, arr} {
+		exception := internal.Catch(func() { Stringify.Fn(value) })
+		if exception == nil || !internal.IsError(exception.Value) || internal.ErrorString(exception.Value) != "TypeError: Converting circular structure to JSON" {
+			t.Errorf("Expected a TypeError for a circular structure, got %v", exception)
+		}
+	}
+
+	// The same object twice, but not inside itself, isn't a cycle
+	shared := internal.NewObject()
+	twice := array.NewArrayReference(internal.Array{shared, shared})
+	if result := Stringify.Fn(twice); result != `[{},{}]` {
+		t.Errorf("Expected a repeated object to be written twice, got %v", result)
+	}
+}
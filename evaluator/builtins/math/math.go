@@ -0,0 +1,254 @@
+package math
+
+import (
+	"go-script/internal"
+	gomath "math"
+	"math/bits"
+	"math/rand/v2"
+)
+
+// Math is a namespace object holding the mathematical constants and
+// functions. Every function converts its arguments to numbers first, so
+// Math.abs("-3") is 3 and Math.floor(undefined) is NaN.
+//
+// Examples:
+//
+//	Math.round(2.5)       → 3
+//	Math.round(-2.5)      → -2
+//	Math.max(1, 5, 3)     → 5
+//	Math.max(1, NaN)      → NaN
+//	Math.max()            → -Infinity
+//	Math.hypot(3, 4)      → 5
+//	Math.PI               → 3.141592653589793
+var Math = map[string]internal.Value{
+	"E":       gomath.E,
+	"LN10":    gomath.Ln10,
+	"LN2":     gomath.Ln2,
+	"LOG10E":  gomath.Log10E,
+	"LOG2E":   gomath.Log2E,
+	"PI":      gomath.Pi,
+	"SQRT1_2": gomath.Sqrt2 / 2,
+	"SQRT2":   gomath.Sqrt2,
+
+	"abs":   unary("abs", gomath.Abs),
+	"ceil":  unary("ceil", gomath.Ceil),
+	"floor": unary("floor", gomath.Floor),
+	"round": unary("round", round),
+	"trunc": unary("trunc", gomath.Trunc),
+	"sign":  unary("sign", sign),
+	"sqrt":  unary("sqrt", gomath.Sqrt),
+	"cbrt":  unary("cbrt", gomath.Cbrt),
+	"exp":   unary("exp", gomath.Exp),
+	"expm1": unary("expm1", gomath.Expm1),
+	"log":   unary("log", gomath.Log),
+	"log1p": unary("log1p", gomath.Log1p),
+	"log2":  unary("log2", gomath.Log2),
+	"log10": unary("log10", gomath.Log10),
+	"sin":   unary("sin", gomath.Sin),
+	"cos":   unary("cos", gomath.Cos),
+	"tan":   unary("tan", gomath.Tan),
+	"asin":  unary("asin", gomath.Asin),
+	"acos":  unary("acos", gomath.Acos),
+	"atan":  unary("atan", gomath.Atan),
+	"sinh":  unary("sinh", gomath.Sinh),
+	"cosh":  unary("cosh", gomath.Cosh),
+	"tanh":  unary("tanh", gomath.Tanh),
+	"asinh": unary("asinh", gomath.Asinh),
+	"acosh": unary("acosh", gomath.Acosh),
+	"atanh": unary("atanh", gomath.Atanh),
+	"clz32": unary("clz32", clz32),
+
+	"fround": unary("fround", func(x float64) float64 {
+		return float64(float32(x))
+	}),
+
+	"atan2": binary("atan2", gomath.Atan2),
+	"pow":   binary("pow", pow),
+	"imul":  binary("imul", imul),
+
+	"max":    Max,
+	"min":    Min,
+	"hypot":  Hypot,
+	"random": Random,
+}
+
+// Max returns the largest of its arguments
+//
+// Syntax: Math.max(a, b, ...)
+//
+// NaN wins over every number, +0 is larger than -0, and with no arguments
+// the result is -Infinity.
+var Max = &internal.Builtin{
+	Name: "max",
+	Fn: func(args ...interface{}) interface{} {
+		result := gomath.Inf(-1)
+		for _, num := range toNumbers(args) {
+			result = gomath.Max(result, num)
+		}
+		return result
+	},
+}
+
+// Min returns the smallest of its arguments
+//
+// Syntax: Math.min(a, b, ...)
+//
+// NaN wins over every number, -0 is smaller than +0, and with no arguments
+// the result is Infinity.
+var Min = &internal.Builtin{
+	Name: "min",
+	Fn: func(args ...interface{}) interface{} {
+		result := gomath.Inf(1)
+		for _, num := range toNumbers(args) {
+			result = gomath.Min(result, num)
+		}
+		return result
+	},
+}
+
+// Hypot returns the square root of the sum of squares of its arguments,
+// without overflowing for large values
+//
+// Syntax: Math.hypot(a, b, ...)
+//
+// Examples:
+//
+//	Math.hypot(3, 4)            → 5
+//	Math.hypot(1e200, 1e200)    → 1.4142135623730952e+200
+//	Math.hypot(NaN, Infinity)   → Infinity
+var Hypot = &internal.Builtin{
+	Name: "hypot",
+	Fn: func(args ...interface{}) interface{} {
+		nums := toNumbers(args)
+
+		// An infinite argument wins even over NaN
+		largest, isNaN := 0.0, false
+		for _, num := range nums {
+			if gomath.IsInf(num, 0) {
+				return gomath.Inf(1)
+			}
+			if gomath.IsNaN(num) {
+				isNaN = true
+			}
+			largest = gomath.Max(largest, gomath.Abs(num))
+		}
+		if isNaN {
+			return gomath.NaN()
+		}
+		if largest == 0 {
+			return 0.0
+		}
+
+		sum := 0.0
+		for _, num := range nums {
+			scaled := num / largest
+			sum += scaled * scaled
+		}
+		return largest * gomath.Sqrt(sum)
+	},
+}
+
+// Rand is the source of Math.random()
+// Tests can replace it to make random numbers predictable.
+var Rand = rand.Float64
+
+// Random returns a pseudo-random number in [0, 1)
+//
+// Syntax: Math.random()
+var Random = &internal.Builtin{
+	Name: "random",
+	Fn: func(args ...interface{}) interface{} {
+		return Rand()
+	},
+}
+
+// unary wraps a function of one number as a builtin
+func unary(name string, fn func(float64) float64) *internal.Builtin {
+	return &internal.Builtin{
+		Name: name,
+		Fn: func(args ...interface{}) interface{} {
+			return fn(argAt(args, 0))
+		},
+	}
+}
+
+// binary wraps a function of two numbers as a builtin
+func binary(name string, fn func(float64, float64) float64) *internal.Builtin {
+	return &internal.Builtin{
+		Name: name,
+		Fn: func(args ...interface{}) interface{} {
+			return fn(argAt(args, 0), argAt(args, 1))
+		},
+	}
+}
+
+// argAt converts the argument at index i to a number; missing ones are NaN
+func argAt(args []interface{}, i int) float64 {
+	if i >= len(args) {
+		return gomath.NaN()
+	}
+	return internal.ToNumber(args[i])
+}
+
+func toNumbers(args []interface{}) []float64 {
+	nums := make([]float64, len(args))
+	for i, arg := range args {
+		nums[i] = internal.ToNumber(arg)
+	}
+	return nums
+}
+
+// round rounds half-way cases up, towards +Infinity, unlike Go's math.Round
+// which rounds them away from zero: round(-2.5) is -2, not -3.
+// Values in [-0.5, -0) round to -0.
+func round(x float64) float64 {
+	if gomath.IsNaN(x) || gomath.IsInf(x, 0) {
+		return x
+	}
+	result := gomath.Floor(x)
+	if x-result >= 0.5 {
+		result++
+	}
+	if result == 0 && gomath.Signbit(x) {
+		return gomath.Copysign(0, -1)
+	}
+	return result
+}
+
+// sign returns 1, -1, or x itself for ±0 and NaN
+func sign(x float64) float64 {
+	switch {
+	case x > 0:
+		return 1
+	case x < 0:
+		return -1
+	}
+	return x
+}
+
+// pow differs from Go's math.Pow where JavaScript gives NaN:
+// pow(1, NaN) and pow(±1, ±Infinity)
+func pow(x, y float64) float64 {
+	if gomath.IsNaN(y) || (gomath.Abs(x) == 1 && gomath.IsInf(y, 0)) {
+		return gomath.NaN()
+	}
+	return gomath.Pow(x, y)
+}
+
+// clz32 counts the leading zero bits of x as a 32-bit unsigned integer
+func clz32(x float64) float64 {
+	return float64(bits.LeadingZeros32(toUint32(x)))
+}
+
+// imul multiplies like C does with 32-bit integers, wrapping on overflow
+func imul(x, y float64) float64 {
+	return float64(int32(toUint32(x) * toUint32(y)))
+}
+
+// toUint32 truncates x and wraps it modulo 2^32; NaN and ±Infinity are 0
+func toUint32(x float64) uint32 {
+	if gomath.IsNaN(x) || gomath.IsInf(x, 0) {
+		return 0
+	}
+	return uint32(int64(gomath.Mod(gomath.Trunc(x), 1<<32)))
+}
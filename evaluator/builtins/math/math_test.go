@@ -0,0 +1,150 @@
+package math
+
+import (
+	"go-script/internal"
+	gomath "math"
+	"testing"
+)
+
+func call(name string, args ...interface{}) interface{} {
+	return Math[name].(*internal.Builtin).Fn(args...)
+}
+
+func TestMathFunctions(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []interface{}
+		expected float64
+	}{
+		{"abs", []interface{}{-3.5}, 3.5},
+		{"abs", []interface{}{"-2"}, 2},
+		{"abs", []interface{}{internal.Null}, 0},
+		{"floor", []interface{}{-1.5}, -2},
+		{"ceil", []interface{}{1.2}, 2},
+		{"round", []interface{}{2.5}, 3},
+		{"round", []interface{}{-2.5}, -2},
+		{"round", []interface{}{-2.6}, -3},
+		{"round", []interface{}{0.49999999999999994}, 0},
+		{"round", []interface{}{4503599627370497.0}, 4503599627370497},
+		{"trunc", []interface{}{-4.7}, -4},
+		{"sign", []interface{}{-3.0}, -1},
+		{"sign", []interface{}{7.0}, 1},
+		{"sqrt", []interface{}{16.0}, 4},
+		{"cbrt", []interface{}{-27.0}, -3},
+		{"pow", []interface{}{2.0, 10.0}, 1024},
+		{"pow", []interface{}{"3", 2.0}, 9},
+		{"exp", []interface{}{0.0}, 1},
+		{"log", []interface{}{1.0}, 0},
+		{"log2", []interface{}{8.0}, 3},
+		{"log10", []interface{}{1000.0}, 3},
+		{"atan2", []interface{}{0.0, -1.0}, gomath.Pi},
+		{"max", []interface{}{1.0, 5.0, 3.0}, 5},
+		{"max", []interface{}{}, gomath.Inf(-1)},
+		{"min", []interface{}{1.0, -5.0, 3.0}, -5},
+		{"min", []interface{}{}, gomath.Inf(1)},
+		{"hypot", []interface{}{3.0, 4.0}, 5},
+		{"hypot", []interface{}{}, 0},
+		{"hypot", []interface{}{gomath.NaN(), gomath.Inf(-1)}, gomath.Inf(1)},
+		{"hypot", []interface{}{1e200, 1e200}, 1e200 * gomath.Sqrt2},
+		{"clz32", []interface{}{1.0}, 31},
+		{"clz32", []interface{}{0.0}, 32},
+		{"clz32", []interface{}{-1.0}, 0},
+		{"imul", []interface{}{3.0, 4.0}, 12},
+		{"imul", []interface{}{4294967295.0, 5.0}, -5},
+		{"imul", []interface{}{2147483648.0, 2.0}, 0},
+		{"fround", []interface{}{5.5}, 5.5},
+		{"fround", []interface{}{5.05}, float64(float32(5.05))},
+	}
+
+	for _, tt := range tests {
+		if result := call(tt.name, tt.args...); result != tt.expected {
+			t.Errorf("Math.%s(%v): expected %v, got %v", tt.name, tt.args, tt.expected, result)
+		}
+	}
+}
+
+func TestMathNaN(t *testing.T) {
+	tests := []struct {
+		name string
+		args []interface{}
+	}{
+		{"abs", nil},
+		{"floor", []interface{}{"abc"}},
+		{"round", []interface{}{gomath.NaN()}},
+		{"sign", []interface{}{"x"}},
+		{"max", []interface{}{1.0, gomath.NaN(), 3.0}},
+		{"min", []interface{}{"a", 3.0}},
+		{"hypot", []interface{}{gomath.NaN(), 1.0}},
+		{"pow", []interface{}{1.0, gomath.NaN()}},
+		{"pow", []interface{}{1.0, gomath.Inf(1)}},
+		{"pow", []interface{}{-1.0, gomath.Inf(-1)}},
+		{"sqrt", []interface{}{-1.0}},
+		{"log", []interface{}{-1.0}},
+	}
+
+	for _, tt := range tests {
+		if result := call(tt.name, tt.args...).(float64); !gomath.IsNaN(result) {
+			t.Errorf("Math.%s(%v): expected NaN, got %v", tt.name, tt.args, result)
+		}
+	}
+}
+
+func TestMathSignedZero(t *testing.T) {
+	negativeZero := gomath.Copysign(0, -1)
+	tests := []struct {
+		name     string
+		args     []interface{}
+		negative bool
+	}{
+		{"round", []interface{}{-0.4}, true},
+		{"round", []interface{}{0.4}, false},
+		{"sign", []interface{}{negativeZero}, true},
+		{"max", []interface{}{negativeZero, 0.0}, false},
+		{"min", []interface{}{0.0, negativeZero}, true},
+	}
+
+	for _, tt := range tests {
+		result := call(tt.name, tt.args...).(float64)
+		if result != 0 || gomath.Signbit(result) != tt.negative {
+			t.Errorf("Math.%s(%v): expected zero with sign bit %v, got %v", tt.name, tt.args, tt.negative, result)
+		}
+	}
+}
+
+func TestMathRandom(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		result := Random.Fn().(float64)
+		if result < 0 || result >= 1 {
+			t.Fatalf("Math.random() returned %v, outside [0, 1)", result)
+		}
+	}
+
+	saved := Rand
+	defer func() { Rand = saved }()
+	Rand = func() float64 { return 0.25 }
+	if result := Random.Fn(); result != 0.25 {
+		t.Errorf("Math.random() should use Rand, got %v", result)
+	}
+}
+
+func TestMathConstants(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected float64
+	}{
+		{"PI", 3.141592653589793},
+		{"E", 2.718281828459045},
+		{"LN2", 0.6931471805599453},
+		{"LN10", 2.302585092994046},
+		{"LOG2E", 1.4426950408889634},
+		{"LOG10E", 0.4342944819032518},
+		{"SQRT2", 1.4142135623730951},
+		{"SQRT1_2", 0.7071067811865476},
+	}
+
+	for _, tt := range tests {
+		if result := Math[tt.name]; result != tt.expected {
+			t.Errorf("Math.%s: expected %v, got %v", tt.name, tt.expected, result)
+		}
+	}
+}
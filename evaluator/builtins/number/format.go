@@ -0,0 +1,300 @@
+package number
+
+import (
+	"go-script/internal"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ToFixed writes x with a fixed number of digits after the decimal point,
+// rounding half-way cases away from zero
+//
+// Syntax: x.toFixed(digits)
+//
+// Numbers from 1e21 up are written as String(x) would.
+//
+// Examples:
+//
+//	(3.14159).toFixed(2)  → "3.14"
+//	(2.5).toFixed(0)      → "3"
+//	(1.005).toFixed(2)    → "1.00" (1.005 is really 1.00499999999999989...)
+//	(0).toFixed(2)        → "0.00"
+//	(1e21).toFixed(2)     → "1e+21"
+//	(1).toFixed(101)      → throws RangeError
+func ToFixed(x float64, fractionDigits internal.Value) string {
+	f := internal.ToIntegerOrInfinity(fractionDigits)
+	if f < 0 || f > 100 {
+		internal.ThrowError("RangeError", "toFixed() digits argument must be between 0 and 100")
+	}
+	if math.IsNaN(x) || math.Abs(x) >= 1e21 {
+		return internal.FormatNumber(x)
+	}
+
+	sign := ""
+	if x < 0 {
+		sign = "-"
+	}
+	x = math.Abs(x)
+
+	// 1100 places hold the exact value of every float64
+	integer, fraction, _ := strings.Cut(strconv.FormatFloat(x, 'f', 1100, 64), ".")
+	digits, _ := roundDigits(integer+fraction, len(integer)+int(f))
+	point := len(digits) - int(f)
+	if f == 0 {
+		return sign + digits
+	}
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// ToExponential writes x in exponential notation with the given number of
+// digits after the decimal point, or as many as it takes to tell x apart
+// from its neighbours when that's undefined
+//
+// Syntax: x.toExponential(digits)
+//
+// Examples:
+//
+//	(123456).toExponential(2)  → "1.23e+5"
+//	(123456).toExponential()   → "1.23456e+5"
+//	(0.00015).toExponential(1) → "1.5e-4"
+//	(0).toExponential(2)       → "0.00e+0"
+func ToExponential(x float64, fractionDigits internal.Value) string {
+	f := internal.ToIntegerOrInfinity(fractionDigits)
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return internal.FormatNumber(x)
+	}
+	if f < 0 || f > 100 {
+		internal.ThrowError("RangeError", "toExponential() argument must be between 0 and 100")
+	}
+
+	sign := ""
+	if x < 0 {
+		sign = "-"
+		x = -x
+	}
+
+	var digits string
+	var exponent int
+	if fractionDigits == nil {
+		digits, exponent = decimalDigits(x, -1)
+	} else {
+		digits, exponent = decimalDigits(x, int(f)+1)
+	}
+
+	mantissa := digits[:1]
+	if len(digits) > 1 {
+		mantissa += "." + digits[1:]
+	}
+	return sign + mantissa + formatExponent(exponent)
+}
+
+// ToPrecision writes x with the given number of significant digits, in
+// exponential notation when the exponent is below -6 or at least precision
+//
+// Syntax: x.toPrecision(precision)
+//
+// Examples:
+//
+//	(123.456).toPrecision(4)  → "123.5"
+//	(0.000123).toPrecision(2) → "0.00012"
+//	(123456).toPrecision(2)   → "1.2e+5"
+//	(1.5).toPrecision()       → "1.5"
+func ToPrecision(x float64, precision internal.Value) string {
+	if precision == nil {
+		return internal.FormatNumber(x)
+	}
+	p := internal.ToIntegerOrInfinity(precision)
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return internal.FormatNumber(x)
+	}
+	if p < 1 || p > 100 {
+		internal.ThrowError("RangeError", "toPrecision() argument must be between 1 and 100")
+	}
+
+	sign := ""
+	if x < 0 {
+		sign = "-"
+		x = -x
+	}
+
+	digits, e := decimalDigits(x, int(p))
+	switch {
+	case e < -6 || e >= int(p):
+		mantissa := digits[:1]
+		if len(digits) > 1 {
+			mantissa += "." + digits[1:]
+		}
+		return sign + mantissa + formatExponent(e)
+	case e == int(p)-1:
+		return sign + digits
+	case e >= 0:
+		return sign + digits[:e+1] + "." + digits[e+1:]
+	default:
+		return sign + "0." + strings.Repeat("0", -(e+1)) + digits
+	}
+}
+
+// ToRadixString writes x in base 2 to 36, the way x.toString(radix) does
+//
+// Syntax: x.toString(radix)
+//
+// Fractions get as many digits as it takes to tell x apart from the
+// neighbouring numbers, which gives the same digits as V8.
+//
+// Examples:
+//
+//	(255).toString(16)   → "ff"
+//	(255).toString(2)    → "11111111"
+//	(-10).toString(36)   → "-a"
+//	(0.5).toString(2)    → "0.1"
+//	(3.75).toString(8)   → "3.6"
+//	(1).toString(1)      → throws RangeError
+func ToRadixString(x float64, radix internal.Value) string {
+	r := 10.0
+	if radix != nil {
+		r = internal.ToIntegerOrInfinity(radix)
+	}
+	if r < 2 || r > 36 {
+		internal.ThrowError("RangeError", "toString() radix must be between 2 and 36")
+	}
+	if r == 10 || math.IsNaN(x) || math.IsInf(x, 0) {
+		return internal.FormatNumber(x)
+	}
+	return radixString(x, int(r))
+}
+
+const digitChars = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+func radixString(x float64, radix int) string {
+	sign := ""
+	if x < 0 {
+		sign = "-"
+		x = -x
+	}
+
+	integer := math.Floor(x)
+	fraction := x - integer
+
+	// Half the distance to the next number: digits below it can't matter
+	delta := math.Max(0.5*(math.Nextafter(x, math.Inf(1))-x), math.SmallestNonzeroFloat64)
+
+	var fractionDigits []int
+	if fraction >= delta {
+		for {
+			fraction *= float64(radix)
+			delta *= float64(radix)
+			digit := int(fraction)
+			fractionDigits = append(fractionDigits, digit)
+			fraction -= float64(digit)
+
+			if fraction > 0.5 || (fraction == 0.5 && digit&1 == 1) {
+				if fraction+delta > 1 {
+					// Round up, carrying into the integer part if need be
+					for {
+						last := len(fractionDigits) - 1
+						if last < 0 {
+							integer++
+							break
+						}
+						if fractionDigits[last]+1 < radix {
+							fractionDigits[last]++
+							break
+						}
+						fractionDigits = fractionDigits[:last]
+					}
+					break
+				}
+			}
+			if fraction < delta {
+				break
+			}
+		}
+	}
+
+	// Digits past the precision of a float64 are written as zeros
+	var zeros int
+	for integer/float64(radix) >= 1<<53 {
+		integer /= float64(radix)
+		zeros++
+	}
+	var result []byte
+	for {
+		remainder := math.Mod(integer, float64(radix))
+		result = append(result, digitChars[int(remainder)])
+		integer = (integer - remainder) / float64(radix)
+		if integer <= 0 {
+			break
+		}
+	}
+	for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+		result[i], result[j] = result[j], result[i]
+	}
+	result = append(result, strings.Repeat("0", zeros)...)
+
+	if len(fractionDigits) > 0 {
+		result = append(result, '.')
+		for _, digit := range fractionDigits {
+			result = append(result, digitChars[digit])
+		}
+	}
+	return sign + string(result)
+}
+
+// decimalDigits returns n significant digits of x > 0, rounded half up, and
+// the decimal exponent of the first one. With n = -1 it returns the
+// shortest digits that identify x.
+//
+// Example: decimalDigits(123.456, 4) → "1235", 2
+func decimalDigits(x float64, n int) (string, int) {
+	if x == 0 {
+		if n < 0 {
+			n = 1
+		}
+		return strings.Repeat("0", n), 0
+	}
+	if n < 0 {
+		mantissa, exponent, _ := strings.Cut(strconv.FormatFloat(x, 'e', -1, 64), "e")
+		e, _ := strconv.Atoi(exponent)
+		return strings.Replace(mantissa, ".", "", 1), e
+	}
+
+	// 800 significant digits hold the exact value of every float64
+	mantissa, exponent, _ := strings.Cut(strconv.FormatFloat(x, 'e', 800, 64), "e")
+	e, _ := strconv.Atoi(exponent)
+	digits, carried := roundDigits(strings.Replace(mantissa, ".", "", 1), n)
+	if carried {
+		// 9.99 → 10.0: one digit too many, and the exponent moves up
+		return digits[:n], e + 1
+	}
+	return digits, e
+}
+
+// roundDigits keeps the first n digits of an exact decimal digit string,
+// rounding up when the rest is at least half a unit. It reports whether
+// rounding added a digit in front (999 → 1000).
+func roundDigits(digits string, n int) (string, bool) {
+	if n >= len(digits) {
+		return digits + strings.Repeat("0", n-len(digits)), false
+	}
+	kept := []byte(digits[:n])
+	if digits[n] < '5' {
+		return string(kept), false
+	}
+
+	for i := n - 1; i >= 0; i-- {
+		if kept[i] < '9' {
+			kept[i]++
+			return string(kept), false
+		}
+		kept[i] = '0'
+	}
+	return "1" + string(kept), true
+}
+
+func formatExponent(e int) string {
+	if e < 0 {
+		return "e-" + strconv.Itoa(-e)
+	}
+	return "e+" + strconv.Itoa(e)
+}
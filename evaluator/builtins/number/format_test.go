@@ -0,0 +1,141 @@
+package number
+
+import (
+	"go-script/internal"
+	"math"
+	"testing"
+)
+
+func TestToFixed(t *testing.T) {
+	tests := []struct {
+		x        float64
+		digits   internal.Value
+		expected string
+	}{
+		{3.14159, 2.0, "3.14"},
+		{3.14159, nil, "3"},
+		{2.5, 0.0, "3"},
+		{-2.5, 0.0, "-3"},
+		{0.5, 0.0, "1"},
+		{1.25, 1.0, "1.3"},
+		{1.005, 2.0, "1.00"},
+		{1.45, 1.0, "1.4"},
+		{0, 2.0, "0.00"},
+		{math.Copysign(0, -1), 2.0, "0.00"},
+		{-0.0001, 2.0, "-0.00"},
+		{9.995, 2.0, "9.99"},
+		{9.9999, 2.0, "10.00"},
+		{0.000001, 7.0, "0.0000010"},
+		{123.456, "1", "123.5"},
+		{1e21, 2.0, "1e+21"},
+		{1e20, 2.0, "100000000000000000000.00"},
+		{math.NaN(), 2.0, "NaN"},
+	}
+
+	for _, tt := range tests {
+		if result := ToFixed(tt.x, tt.digits); result != tt.expected {
+			t.Errorf("(%v).toFixed(%v): expected %q, got %q", tt.x, tt.digits, tt.expected, result)
+		}
+	}
+}
+
+func TestToExponential(t *testing.T) {
+	tests := []struct {
+		x        float64
+		digits   internal.Value
+		expected string
+	}{
+		{123456, 2.0, "1.23e+5"},
+		{123456, nil, "1.23456e+5"},
+		{123456, 0.0, "1e+5"},
+		{0.00015, 1.0, "1.5e-4"},
+		{-0.00015, nil, "-1.5e-4"},
+		{0, 2.0, "0.00e+0"},
+		{0, nil, "0e+0"},
+		{9.99, 1.0, "1.0e+1"},
+		{1.25, 1.0, "1.3e+0"},
+		{math.Inf(1), 200.0, "Infinity"},
+	}
+
+	for _, tt := range tests {
+		if result := ToExponential(tt.x, tt.digits); result != tt.expected {
+			t.Errorf("(%v).toExponential(%v): expected %q, got %q", tt.x, tt.digits, tt.expected, result)
+		}
+	}
+}
+
+func TestToPrecision(t *testing.T) {
+	tests := []struct {
+		x         float64
+		precision internal.Value
+		expected  string
+	}{
+		{123.456, 4.0, "123.5"},
+		{123.456, 3.0, "123"},
+		{123.456, nil, "123.456"},
+		{0.000123, 2.0, "0.00012"},
+		{0.0000001, 1.0, "1e-7"},
+		{123456, 2.0, "1.2e+5"},
+		{99.99, 3.0, "100"},
+		{99.99, 2.0, "1.0e+2"},
+		{0, 3.0, "0.00"},
+		{-1.5, 4.0, "-1.500"},
+		{1, 1.0, "1"},
+	}
+
+	for _, tt := range tests {
+		if result := ToPrecision(tt.x, tt.precision); result != tt.expected {
+			t.Errorf("(%v).toPrecision(%v): expected %q, got %q", tt.x, tt.precision, tt.expected, result)
+		}
+	}
+}
+
+func TestToRadixString(t *testing.T) {
+	tests := []struct {
+		x        float64
+		radix    internal.Value
+		expected string
+	}{
+		{255, 16.0, "ff"},
+		{255, 2.0, "11111111"},
+		{255, nil, "255"},
+		{255, 10.0, "255"},
+		{-10, 36.0, "-a"},
+		{0, 2.0, "0"},
+		{0.5, 2.0, "0.1"},
+		{3.75, 8.0, "3.6"},
+		{0.1, 2.0, "0.0001100110011001100110011001100110011001100110011001101"},
+		{-255.5, 16.0, "-ff.8"},
+		{math.Pow(2, 60), 16.0, "1000000000000000"},
+		{1e21, 36.0, "5v1j4f4ds7c000"},
+		{math.NaN(), 2.0, "NaN"},
+		{math.Inf(-1), 16.0, "-Infinity"},
+	}
+
+	for _, tt := range tests {
+		if result := ToRadixString(tt.x, tt.radix); result != tt.expected {
+			t.Errorf("(%v).toString(%v): expected %q, got %q", tt.x, tt.radix, tt.expected, result)
+		}
+	}
+}
+
+func TestFormatRangeErrors(t *testing.T) {
+	tests := []struct {
+		fn       func()
+		expected string
+	}{
+		{func() { ToFixed(1, 101.0) }, "RangeError: toFixed() digits argument must be between 0 and 100"},
+		{func() { ToFixed(1, -1.0) }, "RangeError: toFixed() digits argument must be between 0 and 100"},
+		{func() { ToExponential(1, 101.0) }, "RangeError: toExponential() argument must be between 0 and 100"},
+		{func() { ToPrecision(1, 0.0) }, "RangeError: toPrecision() argument must be between 1 and 100"},
+		{func() { ToRadixString(1, 37.0) }, "RangeError: toString() radix must be between 2 and 36"},
+		{func() { ToRadixString(1, 1.0) }, "RangeError: toString() radix must be between 2 and 36"},
+	}
+
+	for _, tt := range tests {
+		exception := internal.Catch(tt.fn)
+		if exception == nil || !internal.IsError(exception.Value) || internal.ErrorString(exception.Value) != tt.expected {
+			t.Errorf("Expected %q, got %v", tt.expected, exception)
+		}
+	}
+}
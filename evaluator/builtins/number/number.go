@@ -0,0 +1,309 @@
+package number
+
+import (
+	"go-script/internal"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Number converts its argument to a number; without one it returns 0
+//
+// Syntax: Number(value)
+//
+// Number is also a namespace for the numeric constants and Number.isInteger
+// and friends. Wrapper objects don't exist: methods like toFixed are looked
+// up on the number itself, so new Number() is not supported.
+//
+// Examples:
+//
+//	Number("42")        → 42
+//	Number(" 0x1F ")    → 31
+//	Number("12px")      → NaN
+//	Number(true)        → 1
+//	Number(null)        → 0
+//	Number()            → 0
+var Number = &internal.Builtin{
+	Name: "Number",
+	Fn: func(args ...interface{}) interface{} {
+		if len(args) == 0 {
+			return 0.0
+		}
+		return internal.ToNumber(args[0])
+	},
+}
+
+// MaxSafeInteger is the largest integer n where n and n + 1 are both exact
+const MaxSafeInteger = 1<<53 - 1
+
+// IsInteger reports whether a value is a number without a fractional part
+//
+// Syntax: Number.isInteger(value)
+//
+// Examples:
+//
+//	Number.isInteger(5)      → true
+//	Number.isInteger(5.5)    → false
+//	Number.isInteger("5")    → false
+var IsInteger = &internal.Builtin{
+	Name: "isInteger",
+	Fn: func(args ...interface{}) interface{} {
+		num, ok := firstArg(args).(float64)
+		return ok && isInteger(num)
+	},
+}
+
+// IsSafeInteger reports whether a value is an integer that numbers represent
+// exactly, between -(2^53 - 1) and 2^53 - 1
+//
+// Syntax: Number.isSafeInteger(value)
+//
+// Examples:
+//
+//	Number.isSafeInteger(2 ** 53 - 1) → true
+//	Number.isSafeInteger(2 ** 53)     → false
+var IsSafeInteger = &internal.Builtin{
+	Name: "isSafeInteger",
+	Fn: func(args ...interface{}) interface{} {
+		num, ok := firstArg(args).(float64)
+		return ok && isInteger(num) && math.Abs(num) <= MaxSafeInteger
+	},
+}
+
+// NumberIsNaN is Number.isNaN, which unlike the global isNaN doesn't convert
+// its argument: Number.isNaN("abc") is false
+var NumberIsNaN = &internal.Builtin{
+	Name: "isNaN",
+	Fn: func(args ...interface{}) interface{} {
+		num, ok := firstArg(args).(float64)
+		return ok && math.IsNaN(num)
+	},
+}
+
+// NumberIsFinite is Number.isFinite, which unlike the global isFinite
+// doesn't convert its argument: Number.isFinite("5") is false
+var NumberIsFinite = &internal.Builtin{
+	Name: "isFinite",
+	Fn: func(args ...interface{}) interface{} {
+		num, ok := firstArg(args).(float64)
+		return ok && !math.IsNaN(num) && !math.IsInf(num, 0)
+	},
+}
+
+// IsNaN converts its argument to a number and reports whether that is NaN
+//
+// Syntax: isNaN(value)
+//
+// Examples:
+//
+//	isNaN("abc") → true
+//	isNaN("12")  → false
+var IsNaN = &internal.Builtin{
+	Name: "isNaN",
+	Fn: func(args ...interface{}) interface{} {
+		return math.IsNaN(internal.ToNumber(firstArg(args)))
+	},
+}
+
+// IsFinite converts its argument to a number and reports whether that is
+// neither NaN nor infinite
+//
+// Syntax: isFinite(value)
+var IsFinite = &internal.Builtin{
+	Name: "isFinite",
+	Fn: func(args ...interface{}) interface{} {
+		num := internal.ToNumber(firstArg(args))
+		return !math.IsNaN(num) && !math.IsInf(num, 0)
+	},
+}
+
+// ParseInt reads an integer from the start of a string, ignoring leading
+// whitespace and anything after the digits
+//
+// Syntax: parseInt(string, radix)
+//
+// The radix is 2 to 36. Without one, "0x" starts a hexadecimal number and
+// anything else is decimal.
+//
+// Examples:
+//
+//	parseInt("42px")      → 42
+//	parseInt("  -7.9")    → -7
+//	parseInt("0x1F")      → 31
+//	parseInt("ff", 16)    → 255
+//	parseInt("101", 2)    → 5
+//	parseInt("abc")       → NaN
+//	parseInt("1", 37)     → NaN
+var ParseInt = &internal.Builtin{
+	Name: "parseInt",
+	Fn: func(args ...interface{}) interface{} {
+		s := trimLeftSpace(internal.ToString(firstArg(args)))
+
+		sign := 1.0
+		if s != "" && (s[0] == '+' || s[0] == '-') {
+			if s[0] == '-' {
+				sign = -1
+			}
+			s = s[1:]
+		}
+
+		radix := 0
+		if len(args) > 1 {
+			radix = int(toInt32(internal.ToNumber(args[1])))
+		}
+		stripPrefix := true
+		if radix != 0 {
+			if radix < 2 || radix > 36 {
+				return math.NaN()
+			}
+			stripPrefix = radix == 16
+		} else {
+			radix = 10
+		}
+		if stripPrefix && len(s) >= 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X') {
+			s = s[2:]
+			radix = 16
+		}
+
+		end := 0
+		for end < len(s) && digitValue(s[end]) < radix {
+			end++
+		}
+		if end == 0 {
+			return math.NaN()
+		}
+
+		if radix == 10 {
+			// ParseFloat rounds long decimal strings correctly
+			num, _ := strconv.ParseFloat(s[:end], 64)
+			return sign * num
+		}
+		num := 0.0
+		for i := 0; i < end; i++ {
+			num = num*float64(radix) + float64(digitValue(s[i]))
+		}
+		return sign * num
+	},
+}
+
+// ParseFloat reads a decimal number from the start of a string, ignoring
+// leading whitespace and anything after the number
+//
+// Syntax: parseFloat(string)
+//
+// Examples:
+//
+//	parseFloat("3.14abc")   → 3.14
+//	parseFloat("  .5")      → 0.5
+//	parseFloat("1e3x")      → 1000
+//	parseFloat("1e")        → 1
+//	parseFloat("-Infinity") → -Infinity
+//	parseFloat("0x10")      → 0
+//	parseFloat("abc")       → NaN
+var ParseFloat = &internal.Builtin{
+	Name: "parseFloat",
+	Fn: func(args ...interface{}) interface{} {
+		s := trimLeftSpace(internal.ToString(firstArg(args)))
+
+		i := 0
+		if i < len(s) && (s[i] == '+' || s[i] == '-') {
+			i++
+		}
+		if strings.HasPrefix(s[i:], "Infinity") {
+			if s[0] == '-' {
+				return math.Inf(-1)
+			}
+			return math.Inf(1)
+		}
+
+		digits := 0
+		i, digits = skipDigits(s, i, digits)
+		if i < len(s) && s[i] == '.' {
+			i, digits = skipDigits(s, i+1, digits)
+		}
+		if digits == 0 {
+			return math.NaN()
+		}
+
+		// The exponent only counts if it has digits
+		if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+			j := i + 1
+			if j < len(s) && (s[j] == '+' || s[j] == '-') {
+				j++
+			}
+			if end, n := skipDigits(s, j, 0); n > 0 {
+				i = end
+			}
+		}
+
+		num, _ := strconv.ParseFloat(s[:i], 64)
+		return num
+	},
+}
+
+func init() {
+	statics := internal.NewObject()
+	statics.Set("MAX_SAFE_INTEGER", float64(MaxSafeInteger))
+	statics.Set("MIN_SAFE_INTEGER", float64(-MaxSafeInteger))
+	statics.Set("MAX_VALUE", math.MaxFloat64)
+	statics.Set("MIN_VALUE", math.SmallestNonzeroFloat64)
+	statics.Set("EPSILON", math.Nextafter(1, 2)-1)
+	statics.Set("POSITIVE_INFINITY", math.Inf(1))
+	statics.Set("NEGATIVE_INFINITY", math.Inf(-1))
+	statics.Set("NaN", math.NaN())
+	statics.Set("isInteger", IsInteger)
+	statics.Set("isSafeInteger", IsSafeInteger)
+	statics.Set("isNaN", NumberIsNaN)
+	statics.Set("isFinite", NumberIsFinite)
+	statics.Set("parseInt", ParseInt)
+	statics.Set("parseFloat", ParseFloat)
+	Number.Properties = statics
+}
+
+func firstArg(args []interface{}) internal.Value {
+	if len(args) == 0 {
+		return nil
+	}
+	return args[0]
+}
+
+func isInteger(num float64) bool {
+	return !math.IsInf(num, 0) && num == math.Trunc(num)
+}
+
+func trimLeftSpace(s string) string {
+	return strings.TrimLeftFunc(s, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '\ufeff'
+	})
+}
+
+func skipDigits(s string, i int, digits int) (int, int) {
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+		digits++
+	}
+	return i, digits
+}
+
+// digitValue returns the value of a digit or letter in bases up to 36, and
+// 36 for anything else
+func digitValue(ch byte) int {
+	switch {
+	case ch >= '0' && ch <= '9':
+		return int(ch - '0')
+	case ch >= 'a' && ch <= 'z':
+		return int(ch-'a') + 10
+	case ch >= 'A' && ch <= 'Z':
+		return int(ch-'A') + 10
+	}
+	return 36
+}
+
+// toInt32 truncates x and wraps it into a signed 32-bit integer
+func toInt32(x float64) int32 {
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return 0
+	}
+	return int32(uint32(int64(math.Mod(math.Trunc(x), 1<<32))))
+}
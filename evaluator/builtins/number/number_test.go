@@ -0,0 +1,152 @@
+package number
+
+import (
+	"go-script/internal"
+	"math"
+	"testing"
+)
+
+func TestNumberConversion(t *testing.T) {
+	tests := []struct {
+		args     []interface{}
+		expected float64
+	}{
+		{[]interface{}{}, 0},
+		{[]interface{}{"42"}, 42},
+		{[]interface{}{" 0x1F "}, 31},
+		{[]interface{}{true}, 1},
+		{[]interface{}{internal.Null}, 0},
+		{[]interface{}{""}, 0},
+	}
+
+	for _, tt := range tests {
+		if result := Number.Fn(tt.args...); result != tt.expected {
+			t.Errorf("Number(%v): expected %v, got %v", tt.args, tt.expected, result)
+		}
+	}
+
+	for _, arg := range []interface{}{nil, "12px", internal.NewObject()} {
+		if result := Number.Fn(arg).(float64); !math.IsNaN(result) {
+			t.Errorf("Number(%v): expected NaN, got %v", arg, result)
+		}
+	}
+}
+
+func TestParseInt(t *testing.T) {
+	tests := []struct {
+		args     []interface{}
+		expected float64
+	}{
+		{[]interface{}{"42px"}, 42},
+		{[]interface{}{"  -7.9"}, -7},
+		{[]interface{}{"+15"}, 15},
+		{[]interface{}{"0x1F"}, 31},
+		{[]interface{}{"0X1f"}, 31},
+		{[]interface{}{"-0xF"}, -15},
+		{[]interface{}{"ff", 16.0}, 255},
+		{[]interface{}{"0xff", 16.0}, 255},
+		{[]interface{}{"0x10", 10.0}, 0},
+		{[]interface{}{"101", 2.0}, 5},
+		{[]interface{}{"z", 36.0}, 35},
+		{[]interface{}{"12", 0.0}, 12},
+		{[]interface{}{"12", "8"}, 10},
+		{[]interface{}{15.99}, 15},
+		{[]interface{}{"123456789012345678901234567890"}, 123456789012345678901234567890},
+	}
+
+	for _, tt := range tests {
+		if result := ParseInt.Fn(tt.args...); result != tt.expected {
+			t.Errorf("parseInt(%v): expected %v, got %v", tt.args, tt.expected, result)
+		}
+	}
+
+	for _, args := range [][]interface{}{{"abc"}, {""}, {"-"}, {"0x"}, {"1", 37.0}, {"1", 1.0}, {"2", 2.0}, {nil}} {
+		if result := ParseInt.Fn(args...).(float64); !math.IsNaN(result) {
+			t.Errorf("parseInt(%v): expected NaN, got %v", args, result)
+		}
+	}
+}
+
+func TestParseFloat(t *testing.T) {
+	tests := []struct {
+		input    interface{}
+		expected float64
+	}{
+		{"3.14abc", 3.14},
+		{"  .5", 0.5},
+		{"-.5e1", -5},
+		{"1e3x", 1000},
+		{"1e", 1},
+		{"1e+", 1},
+		{"5.", 5},
+		{"0x10", 0},
+		{"Infinityx", math.Inf(1)},
+		{"-Infinity", math.Inf(-1)},
+		{"1_000", 1},
+		{2.5, 2.5},
+	}
+
+	for _, tt := range tests {
+		if result := ParseFloat.Fn(tt.input); result != tt.expected {
+			t.Errorf("parseFloat(%q): expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+
+	for _, input := range []interface{}{"abc", "", ".", "-", "e5", "inf", nil} {
+		if result := ParseFloat.Fn(input).(float64); !math.IsNaN(result) {
+			t.Errorf("parseFloat(%q): expected NaN, got %v", input, result)
+		}
+	}
+}
+
+func TestNumberPredicates(t *testing.T) {
+	tests := []struct {
+		builtin  *internal.Builtin
+		input    interface{}
+		expected bool
+	}{
+		{IsInteger, 5.0, true},
+		{IsInteger, 5.5, false},
+		{IsInteger, "5", false},
+		{IsInteger, math.Inf(1), false},
+		{IsInteger, math.NaN(), false},
+		{IsSafeInteger, float64(MaxSafeInteger), true},
+		{IsSafeInteger, float64(MaxSafeInteger + 1), false},
+		{IsSafeInteger, -float64(MaxSafeInteger), true},
+		{NumberIsNaN, math.NaN(), true},
+		{NumberIsNaN, "abc", false},
+		{IsNaN, "abc", true},
+		{IsNaN, "12", false},
+		{IsNaN, nil, true},
+		{NumberIsFinite, 5.0, true},
+		{NumberIsFinite, "5", false},
+		{IsFinite, "5", true},
+		{IsFinite, math.Inf(-1), false},
+	}
+
+	for _, tt := range tests {
+		if result := tt.builtin.Fn(tt.input); result != tt.expected {
+			t.Errorf("%s(%v): expected %v, got %v", tt.builtin.Name, tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestNumberConstants(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected float64
+	}{
+		{"MAX_SAFE_INTEGER", 9007199254740991},
+		{"MIN_SAFE_INTEGER", -9007199254740991},
+		{"EPSILON", 2.220446049250313e-16},
+		{"MAX_VALUE", 1.7976931348623157e308},
+		{"MIN_VALUE", 5e-324},
+		{"POSITIVE_INFINITY", math.Inf(1)},
+	}
+
+	for _, tt := range tests {
+		if result := Number.Properties.Get(tt.name); result != tt.expected {
+			t.Errorf("Number.%s: expected %v, got %v", tt.name, tt.expected, result)
+		}
+	}
+}
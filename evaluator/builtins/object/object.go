@@ -0,0 +1,678 @@
+package object
+
+import (
+	"go-script/evaluator/builtins/array"
+	"go-script/internal"
+	"strconv"
+	"unicode/utf16"
+)
+
+// Object converts its argument to an object: objects come back as they
+// are, and null or undefined give a new empty object
+//
+// Syntax: Object(value), new Object(value)
+//
+// Object is also the namespace of Object.keys, Object.defineProperty and
+// the other static methods below. They work on plain objects, arrays
+// (whose keys are their indices) and strings (whose keys are the positions
+// of their UTF-16 code units).
+var Object = &internal.Builtin{
+	Name: "Object",
+	Fn: func(args ...interface{}) interface{} {
+		return construct(args)
+	},
+	Construct: func(args ...interface{}) interface{} {
+		return construct(args)
+	},
+}
+
+func construct(args []interface{}) interface{} {
+	value := argAt(args, 0)
+	if internal.IsNullish(value) {
+		return internal.NewObject()
+	}
+	return value
+}
+
+// Keys returns the names of an object's own enumerable string-keyed
+// properties, in the order for...of over them would visit them
+//
+// Syntax: Object.keys(obj)
+//
+// Examples:
+//
+//	Object.keys({ b: 1, a: 2, 1: 3 }) → ["1", "b", "a"]
+//	Object.keys(["x", "y"])           → ["0", "1"]
+//	Object.keys("hi")                 → ["0", "1"]
+var Keys = &internal.Builtin{
+	Name: "keys",
+	Fn: func(args ...interface{}) interface{} {
+		entries := ownEntries(toObject(argAt(args, 0)))
+		keys := make(internal.Array, len(entries))
+		for i, entry := range entries {
+			keys[i] = entry.key
+		}
+		return array.NewArrayReference(keys)
+	},
+}
+
+// Values returns the values of an object's own enumerable string-keyed
+// properties, calling getters
+//
+// Syntax: Object.values(obj)
+//
+// Example: Object.values({ a: 1, b: 2 }) → [1, 2]
+var Values = &internal.Builtin{
+	Name: "values",
+	Fn: func(args ...interface{}) interface{} {
+		entries := ownEntries(toObject(argAt(args, 0)))
+		values := make(internal.Array, len(entries))
+		for i, entry := range entries {
+			values[i] = entry.value()
+		}
+		return array.NewArrayReference(values)
+	},
+}
+
+// Entries returns [key, value] pairs for an object's own enumerable
+// string-keyed properties
+//
+// Syntax: Object.entries(obj)
+//
+// Example: Object.entries({ a: 1, b: 2 }) → [["a", 1], ["b", 2]]
+var Entries = &internal.Builtin{
+	Name: "entries",
+	Fn: func(args ...interface{}) interface{} {
+		entries := ownEntries(toObject(argAt(args, 0)))
+		pairs := make(internal.Array, len(entries))
+		for i, entry := range entries {
+			pairs[i] = array.NewArrayReference(internal.Array{entry.key, entry.value()})
+		}
+		return array.NewArrayReference(pairs)
+	},
+}
+
+// FromEntries builds an object from an iterable of [key, value] pairs, the
+// reverse of Object.entries
+//
+// Syntax: Object.fromEntries(iterable)
+//
+// Examples:
+//
+//	Object.fromEntries([["a", 1], ["b", 2]])        → { a: 1, b: 2 }
+//	Object.fromEntries(Object.entries(o).map(...))  → a transformed copy of o
+var FromEntries = &internal.Builtin{
+	Name: "fromEntries",
+	Fn: func(args ...interface{}) interface{} {
+		iterable := argAt(args, 0)
+		entries, ok := internal.IterableToList(iterable)
+		if !ok {
+			internal.ThrowError("TypeError", internal.Inspect(iterable)+" is not iterable")
+		}
+
+		result := internal.NewObject()
+		for _, entry := range entries {
+			var key, value internal.Value
+			switch e := entry.(type) {
+			case *array.ArrayReference:
+				key, value = e.Get(0), e.Get(1)
+			case *internal.Object:
+				key, value = e.Get("0"), e.Get("1")
+			default:
+				internal.ThrowError("TypeError", "Iterator value "+internal.Inspect(entry)+" is not an entry object")
+			}
+			result.Define(internal.ToPropertyKey(key), &internal.Property{Value: value})
+		}
+		return result
+	},
+}
+
+// Assign copies the own enumerable properties (symbols included) of each
+// source onto the target, in order, and returns the target
+//
+// Syntax: Object.assign(target, ...sources)
+//
+// Null and undefined sources are skipped. Setters on the target run, and
+// a property the target doesn't let change throws a TypeError, like a
+// strict-mode assignment.
+//
+// Examples:
+//
+//	Object.assign({ a: 1 }, { b: 2 }, { a: 3 }) → { a: 3, b: 2 }
+//	Object.assign({}, defaults, options)        → a merged copy
+var Assign = &internal.Builtin{
+	Name: "assign",
+	Fn: func(args ...interface{}) interface{} {
+		target := toObject(argAt(args, 0))
+		for _, source := range args[1:] {
+			if internal.IsNullish(source) {
+				continue
+			}
+			if obj, ok := ownProperties(source).(*internal.Object); ok {
+				// Symbols are copied too, unlike in Object.keys
+				for _, key := range obj.OwnKeys() {
+					if prop, _ := obj.GetOwnProperty(key); !prop.NonEnumerable {
+						put(target, key, prop.Read(obj))
+					}
+				}
+				continue
+			}
+			for _, entry := range ownEntries(source) {
+				put(target, entry.key, entry.value())
+			}
+		}
+		return target
+	},
+}
+
+// Freeze stops an object from changing: its properties become read-only
+// and no properties can be added or redefined. Returns the object.
+//
+// Syntax: Object.freeze(obj)
+//
+// Assignments to a frozen object are ignored, and array methods that would
+// change a frozen array, like push, throw a TypeError. Plain objects,
+// arrays, functions and classes can be frozen; freezing other objects,
+// like a Map, throws a TypeError instead of leaving them changeable.
+//
+// Example:
+//
+//	var config = Object.freeze({ port: 80 });
+//	config.port = 8080;   // ignored
+//	config.port           → 80
+var Freeze = &internal.Builtin{
+	Name: "freeze",
+	Fn: func(args ...interface{}) interface{} {
+		if obj := freezable(argAt(args, 0), "freeze"); obj != nil {
+			obj.Freeze()
+		}
+		return argAt(args, 0)
+	},
+}
+
+// IsFrozen reports whether an object is frozen
+// Primitives count as frozen, since they can't change.
+//
+// Syntax: Object.isFrozen(obj)
+var IsFrozen = &internal.Builtin{
+	Name: "isFrozen",
+	Fn: func(args ...interface{}) interface{} {
+		if obj, ok := argAt(args, 0).(internal.Freezable); ok {
+			return obj.IsFrozen()
+		}
+		return isPrimitive(argAt(args, 0))
+	},
+}
+
+// Seal stops properties from being added, removed or redefined, while
+// their values can still change. Returns the object.
+//
+// Syntax: Object.seal(obj)
+var Seal = &internal.Builtin{
+	Name: "seal",
+	Fn: func(args ...interface{}) interface{} {
+		if obj := freezable(argAt(args, 0), "seal"); obj != nil {
+			obj.Seal()
+		}
+		return argAt(args, 0)
+	},
+}
+
+// IsSealed reports whether an object is sealed (or frozen)
+//
+// Syntax: Object.isSealed(obj)
+var IsSealed = &internal.Builtin{
+	Name: "isSealed",
+	Fn: func(args ...interface{}) interface{} {
+		if obj, ok := argAt(args, 0).(internal.Freezable); ok {
+			return obj.IsSealed()
+		}
+		return isPrimitive(argAt(args, 0))
+	},
+}
+
+// PreventExtensions stops new properties from being added. Returns the object.
+//
+// Syntax: Object.preventExtensions(obj)
+var PreventExtensions = &internal.Builtin{
+	Name: "preventExtensions",
+	Fn: func(args ...interface{}) interface{} {
+		if obj := freezable(argAt(args, 0), "preventExtensions"); obj != nil {
+			obj.PreventExtensions()
+		}
+		return argAt(args, 0)
+	},
+}
+
+// IsExtensible reports whether properties can be added to an object
+//
+// Syntax: Object.isExtensible(obj)
+var IsExtensible = &internal.Builtin{
+	Name: "isExtensible",
+	Fn: func(args ...interface{}) interface{} {
+		if obj, ok := argAt(args, 0).(internal.Freezable); ok {
+			return obj.IsExtensible()
+		}
+		return !isPrimitive(argAt(args, 0))
+	},
+}
+
+// freezable returns the value whose integrity level Object.freeze, seal or
+// preventExtensions (named by method) changes, or nil for primitives, which
+// are returned unchanged. Objects whose state can't be locked yet throw a
+// TypeError.
+func freezable(value internal.Value, method string) internal.Freezable {
+	if obj, ok := value.(internal.Freezable); ok {
+		return obj
+	}
+	if !isPrimitive(value) {
+		internal.ThrowError("TypeError", "Object."+method+" is not supported for "+internal.Inspect(value))
+	}
+	return nil
+}
+
+// Is compares two values like === does, except that NaN is NaN and 0 is
+// not -0
+//
+// Syntax: Object.is(a, b)
+//
+// Examples:
+//
+//	Object.is(NaN, NaN) → true
+//	Object.is(0, -0)    → false
+//	Object.is({}, {})   → false
+var Is = &internal.Builtin{
+	Name: "is",
+	Fn: func(args ...interface{}) interface{} {
+		return internal.SameValue(argAt(args, 0), argAt(args, 1))
+	},
+}
+
+// HasOwn reports whether an object has an own property with the given key,
+// enumerable or not; inherited properties don't count
+//
+// Syntax: Object.hasOwn(obj, key)
+//
+// Examples:
+//
+//	Object.hasOwn({ a: undefined }, "a") → true
+//	Object.hasOwn([1, 2], 1)             → true
+//	Object.hasOwn([1, 2], "length")      → true
+var HasOwn = &internal.Builtin{
+	Name: "hasOwn",
+	Fn: func(args ...interface{}) interface{} {
+		_, ok := getOwnProperty(toObject(argAt(args, 0)), internal.ToPropertyKey(argAt(args, 1)))
+		return ok
+	},
+}
+
+// GroupBy sorts the items of an iterable into arrays by the key the
+// callback returns for each, in the order the keys first appear
+//
+// Syntax: Object.groupBy(items, callback(item, index))
+//
+// Example:
+//
+//	Object.groupBy([1, 2, 3, 4], n => n % 2 == 0 ? "even" : "odd")
+//	→ { odd: [1, 3], even: [2, 4] }
+var GroupBy = &internal.Builtin{
+	Name: "groupBy",
+	Fn: func(args ...interface{}) interface{} {
+		items := argAt(args, 0)
+		callback, ok := argAt(args, 1).(internal.Callable)
+		if !ok {
+			internal.ThrowError("TypeError", internal.Inspect(argAt(args, 1))+" is not a function")
+		}
+		values, ok := internal.IterableToList(items)
+		if !ok {
+			internal.ThrowError("TypeError", internal.Inspect(items)+" is not iterable")
+		}
+
+		groups := internal.NewObject()
+		for i, value := range values {
+			key := internal.ToPropertyKey(callback.Call(nil, value, float64(i)))
+			if group, ok := groups.Get(key).(*array.ArrayReference); ok {
+				group.Push(value)
+				continue
+			}
+			groups.Set(key, array.NewArrayReference(internal.Array{value}))
+		}
+		return groups
+	},
+}
+
+// GetOwnPropertyNames returns the names of all own string-keyed properties,
+// including non-enumerable ones such as an array's length
+//
+// Syntax: Object.getOwnPropertyNames(obj)
+//
+// Example: Object.getOwnPropertyNames(["a"]) → ["0", "length"]
+var GetOwnPropertyNames = &internal.Builtin{
+	Name: "getOwnPropertyNames",
+	Fn: func(args ...interface{}) interface{} {
+		names := internal.Array{}
+		switch v := ownProperties(toObject(argAt(args, 0))).(type) {
+		case *internal.Object:
+			for _, name := range v.OwnPropertyNames() {
+				names = append(names, name)
+			}
+		case *array.ArrayReference:
+			names = append(indexKeys(len(*v.Elements)), "length")
+			if props := v.SubclassObject(); props != nil {
+				for _, name := range props.OwnPropertyNames() {
+					names = append(names, name)
+				}
+			}
+		case string:
+			names = append(indexKeys(len(utf16.Encode([]rune(v)))), "length")
+		}
+		return array.NewArrayReference(names)
+	},
+}
+
+// DefineProperty creates or changes a property from a descriptor and
+// returns the object
+//
+// Syntax: Object.defineProperty(obj, key, descriptor)
+//
+// A descriptor holds either value and writable, or get and set, plus
+// enumerable and configurable. Attributes left out of a new property's
+// descriptor are false, so a defined property is read-only, hidden from
+// Object.keys, printing and JSON, and fixed unless the descriptor says
+// otherwise.
+//
+// Examples:
+//
+//	Object.defineProperty(obj, "id", { value: 7 })
+//	Object.defineProperty(obj, "full", { get() { return this.a + this.b; }, enumerable: true })
+//	Object.defineProperty(obj, "id", { value: 8 }) → throws TypeError: Cannot redefine property: id
+var DefineProperty = &internal.Builtin{
+	Name: "defineProperty",
+	Fn: func(args ...interface{}) interface{} {
+		target := argAt(args, 0)
+		if isPrimitive(target) {
+			internal.ThrowError("TypeError", "Object.defineProperty called on non-object")
+		}
+		key := internal.ToPropertyKey(argAt(args, 1))
+		define(target, key, internal.ToPropertyDescriptor(argAt(args, 2)))
+		return target
+	},
+}
+
+// DefineProperties defines several properties at once, from an object
+// mapping keys to descriptors. Every descriptor is checked before any
+// property is defined.
+//
+// Syntax: Object.defineProperties(obj, descriptors)
+//
+// Example:
+//
+//	Object.defineProperties(obj, { a: { value: 1, enumerable: true }, b: { get() { return 2; } } })
+var DefineProperties = &internal.Builtin{
+	Name: "defineProperties",
+	Fn: func(args ...interface{}) interface{} {
+		target := argAt(args, 0)
+		if isPrimitive(target) {
+			internal.ThrowError("TypeError", "Object.defineProperties called on non-object")
+		}
+		props, ok := argAt(args, 1).(*internal.Object)
+		if !ok {
+			internal.ThrowError("TypeError", "Property description must be an object: "+internal.Inspect(argAt(args, 1)))
+		}
+
+		keys := []internal.PropertyKey{}
+		descriptors := []*internal.PropertyDescriptor{}
+		for _, key := range props.OwnKeys() {
+			if prop, _ := props.GetOwnProperty(key); !prop.NonEnumerable {
+				keys = append(keys, key)
+				descriptors = append(descriptors, internal.ToPropertyDescriptor(prop.Read(props)))
+			}
+		}
+		for i, key := range keys {
+			define(target, key, descriptors[i])
+		}
+		return target
+	},
+}
+
+// GetOwnPropertyDescriptor describes an own property, or returns undefined
+// if there is none
+//
+// Syntax: Object.getOwnPropertyDescriptor(obj, key)
+//
+// Examples:
+//
+//	Object.getOwnPropertyDescriptor({ a: 1 }, "a")
+//	→ { value: 1, writable: true, enumerable: true, configurable: true }
+//	Object.getOwnPropertyDescriptor([], "length")
+//	→ { value: 0, writable: true, enumerable: false, configurable: false }
+var GetOwnPropertyDescriptor = &internal.Builtin{
+	Name: "getOwnPropertyDescriptor",
+	Fn: func(args ...interface{}) interface{} {
+		prop, ok := getOwnProperty(toObject(argAt(args, 0)), internal.ToPropertyKey(argAt(args, 1)))
+		if !ok {
+			return nil
+		}
+		return prop.Descriptor().ToObject()
+	},
+}
+
+// HasInstance makes "x instanceof Object" true for every value that isn't
+// a primitive
+var HasInstance = &internal.Builtin{
+	Name: "[Symbol.hasInstance]",
+	Fn: func(args ...interface{}) interface{} {
+		return !isPrimitive(argAt(args, 0))
+	},
+}
+
+func init() {
+	statics := internal.NewObject()
+	statics.Set("keys", Keys)
+	statics.Set("values", Values)
+	statics.Set("entries", Entries)
+	statics.Set("fromEntries", FromEntries)
+	statics.Set("assign", Assign)
+	statics.Set("freeze", Freeze)
+	statics.Set("isFrozen", IsFrozen)
+	statics.Set("seal", Seal)
+	statics.Set("isSealed", IsSealed)
+	statics.Set("preventExtensions", PreventExtensions)
+	statics.Set("isExtensible", IsExtensible)
+	statics.Set("is", Is)
+	statics.Set("hasOwn", HasOwn)
+	statics.Set("groupBy", GroupBy)
+	statics.Set("getOwnPropertyNames", GetOwnPropertyNames)
+	statics.Set("defineProperty", DefineProperty)
+	statics.Set("defineProperties", DefineProperties)
+	statics.Set("getOwnPropertyDescriptor", GetOwnPropertyDescriptor)
+	statics.Set(internal.SymbolHasInstance, HasInstance)
+	Object.Properties = statics
+}
+
+// entry is an own enumerable property; value reads it when needed, so
+// getters only run for the methods that want values
+type entry struct {
+	key   string
+	value func() internal.Value
+}
+
+// ownEntries lists the own enumerable string-keyed properties of any value
+// Values without properties of their own (numbers, functions, ...) have none.
+func ownEntries(val internal.Value) []entry {
+	entries := []entry{}
+	switch v := val.(type) {
+	case *internal.Object:
+		for _, key := range v.Keys() {
+			prop, _ := v.GetOwnProperty(key)
+			entries = append(entries, entry{key, func() internal.Value { return prop.Read(v) }})
+		}
+	case *array.ArrayReference:
+		for i := range *v.Elements {
+			entries = append(entries, entry{strconv.Itoa(i), func() internal.Value { return v.Get(i) }})
+		}
+		if props := v.SubclassObject(); props != nil {
+			entries = append(entries, ownEntries(props)...)
+		}
+	case internal.PropertyHolder:
+		props := v.OwnProperties()
+		for _, key := range props.Keys() {
+			prop, _ := props.GetOwnProperty(key)
+			entries = append(entries, entry{key, func() internal.Value { return prop.Read(v) }})
+		}
+	case string:
+		units := utf16.Encode([]rune(v))
+		for i, unit := range units {
+			entries = append(entries, entry{strconv.Itoa(i), func() internal.Value { return decode(unit) }})
+		}
+	}
+	return entries
+}
+
+// getOwnProperty finds an own property of any value, describing array
+// elements and string characters as properties too
+func getOwnProperty(val internal.Value, key internal.PropertyKey) (*internal.Property, bool) {
+	switch v := val.(type) {
+	case *internal.Object:
+		return v.GetOwnProperty(key)
+	case *array.ArrayReference:
+		if key == "length" {
+			return &internal.Property{Value: v.Length(), NonEnumerable: true, NonConfigurable: true}, true
+		}
+		if index, ok := toIndex(key); ok && index < len(*v.Elements) {
+			return &internal.Property{Value: v.Get(index)}, true
+		}
+		if props := v.SubclassObject(); props != nil {
+			return props.GetOwnProperty(key)
+		}
+	case internal.PropertyHolder:
+		return v.OwnProperties().GetOwnProperty(key)
+	case string:
+		units := utf16.Encode([]rune(v))
+		if key == "length" {
+			return &internal.Property{Value: float64(len(units)), NonWritable: true, NonEnumerable: true, NonConfigurable: true}, true
+		}
+		if index, ok := toIndex(key); ok && index < len(units) {
+			return &internal.Property{Value: decode(units[index]), NonWritable: true, NonConfigurable: true}, true
+		}
+	}
+	return nil, false
+}
+
+// define applies a descriptor to a property of an object, array or class
+// Array elements take the descriptor's value; their attributes can't change.
+func define(target internal.Value, key internal.PropertyKey, desc *internal.PropertyDescriptor) {
+	switch v := target.(type) {
+	case *internal.Object:
+		v.DefineOwnProperty(key, desc)
+	case *array.ArrayReference:
+		if index, ok := toIndex(key); ok && index < len(*v.Elements) {
+			if desc.IsAccessor() {
+				internal.ThrowError("TypeError", "Cannot define an accessor for array element "+strconv.Itoa(index))
+			}
+			if desc.HasValue {
+				v.Set(index, desc.Value)
+			}
+			return
+		}
+		if key == "length" {
+			internal.ThrowError("TypeError", "Cannot redefine property: length")
+		}
+		v.OwnProperties().DefineOwnProperty(key, desc)
+	case internal.PropertyHolder:
+		v.OwnProperties().DefineOwnProperty(key, desc)
+	default:
+		internal.ThrowError("TypeError", "Cannot define property "+internal.Inspect(key)+" on "+internal.Inspect(target))
+	}
+}
+
+// put assigns a property the way an assignment in strict mode would, for
+// Object.assign: a write that isn't allowed throws a TypeError
+func put(target internal.Value, key internal.PropertyKey, value internal.Value) {
+	var done bool
+	var props *internal.Object
+	switch v := target.(type) {
+	case *internal.Object:
+		done, props = v.Set(key, value), v
+	case *array.ArrayReference:
+		if index, ok := toIndex(key); ok {
+			if !v.Set(index, value) {
+				internal.ThrowError("TypeError", internal.AssignError(v, key, &internal.Property{Value: v.Get(index)}))
+			}
+			return
+		}
+		if key == "length" {
+			if !v.SetLength(value) {
+				internal.ThrowError("TypeError", internal.AssignError(v, key, &internal.Property{Value: v.Length()}))
+			}
+			return
+		}
+		done, props = v.OwnProperties().SetWithThis(key, value, v), v.OwnProperties()
+	case internal.PropertyHolder:
+		done, props = v.OwnProperties().SetWithThis(key, value, v), v.OwnProperties()
+	}
+	// A primitive target stands for a wrapper object that nothing else sees
+	if !done && props != nil {
+		prop, _ := props.Lookup(key)
+		internal.ThrowError("TypeError", internal.AssignError(target, key, prop))
+	}
+}
+
+// ownProperties returns the object holding a value's own properties: the
+// value itself, or for a class, its static members. Arrays list their
+// elements too, so they stay as they are.
+func ownProperties(val internal.Value) internal.Value {
+	if _, ok := val.(*array.ArrayReference); ok {
+		return val
+	}
+	if holder, ok := val.(internal.PropertyHolder); ok {
+		return holder.OwnProperties()
+	}
+	return val
+}
+
+// toObject rejects null and undefined, which have no properties to list
+func toObject(val internal.Value) internal.Value {
+	if internal.IsNullish(val) {
+		internal.ThrowError("TypeError", "Cannot convert undefined or null to object")
+	}
+	return val
+}
+
+func isPrimitive(val internal.Value) bool {
+	switch val.(type) {
+	case nil, internal.NullValue, bool, float64, string, *internal.Symbol:
+		return true
+	}
+	return false
+}
+
+func toIndex(key internal.PropertyKey) (int, bool) {
+	name, ok := key.(string)
+	if !ok || !internal.IsArrayIndex(name) {
+		return 0, false
+	}
+	index, err := strconv.Atoi(name)
+	return index, err == nil
+}
+
+func indexKeys(length int) internal.Array {
+	keys := make(internal.Array, length)
+	for i := range keys {
+		keys[i] = strconv.Itoa(i)
+	}
+	return keys
+}
+
+// decode turns one UTF-16 code unit into a string; half of a surrogate
+// pair becomes U+FFFD, as string indexing does
+func decode(unit uint16) string {
+	return string(utf16.Decode([]uint16{unit}))
+}
+
+func argAt(args []interface{}, i int) internal.Value {
+	if i >= len(args) {
+		return nil
+	}
+	return args[i]
+}
@@ -0,0 +1,119 @@
+package object
+
+import (
+	"go-script/evaluator/builtins/array"
+	"go-script/internal"
+	"testing"
+)
+
+func keysOf(val interface{}) []interface{} {
+	return toSlice(Keys.Fn(val))
+}
+
+func toSlice(val interface{}) []interface{} {
+	result := []interface{}{}
+	for _, elem := range val.(*array.ArrayReference).GetElements() {
+		result = append(result, elem)
+	}
+	return result
+}
+
+func TestKeysOfValues(t *testing.T) {
+	obj := internal.NewObject()
+	obj.Set("b", 1.0)
+	obj.Set("1", 2.0)
+
+	arr := array.NewArrayReference(internal.Array{"x", "y"})
+
+	tests := []struct {
+		input    interface{}
+		expected []interface{}
+	}{
+		{obj, []interface{}{"1", "b"}},
+		{arr, []interface{}{"0", "1"}},
+		{"hi", []interface{}{"0", "1"}},
+		{"😀", []interface{}{"0", "1"}},
+		{5.0, []interface{}{}},
+	}
+
+	for _, tt := range tests {
+		result := keysOf(tt.input)
+		if len(result) != len(tt.expected) {
+			t.Errorf("Object.keys(%v): expected %v, got %v", tt.input, tt.expected, result)
+			continue
+		}
+		for i := range result {
+			if result[i] != tt.expected[i] {
+				t.Errorf("Object.keys(%v): expected %v, got %v", tt.input, tt.expected, result)
+			}
+		}
+	}
+}
+
+func TestNullishArguments(t *testing.T) {
+	builtins := []*internal.Builtin{Keys, Values, Entries, Assign, HasOwn, GetOwnPropertyNames, GetOwnPropertyDescriptor}
+	for _, builtin := range builtins {
+		for _, arg := range []interface{}{nil, internal.Null} {
+			exception := internal.Catch(func() { builtin.Fn(arg, "a") })
+			if exception == nil || !internal.IsError(exception.Value) || internal.ErrorString(exception.Value) != "TypeError: Cannot convert undefined or null to object" {
+				t.Errorf("Object.%s(%v): expected a TypeError, got %v", builtin.Name, arg, exception)
+			}
+		}
+	}
+}
+
+func TestGetOwnPropertyOfArraysAndStrings(t *testing.T) {
+	arr := array.NewArrayReference(internal.Array{"x"})
+	tests := []struct {
+		value    interface{}
+		key      string
+		expected interface{}
+		exists   bool
+	}{
+		{arr, "0", "x", true},
+		{arr, "1", nil, false},
+		{arr, "length", 1.0, true},
+		{"ab", "1", "b", true},
+		{"ab", "length", 2.0, true},
+		{"ab", "2", nil, false},
+		{5.0, "0", nil, false},
+	}
+
+	for _, tt := range tests {
+		prop, ok := getOwnProperty(tt.value, tt.key)
+		if ok != tt.exists || (ok && prop.Value != tt.expected) {
+			t.Errorf("getOwnProperty(%v, %q): expected %v (%v), got %v (%v)", tt.value, tt.key, tt.expected, tt.exists, prop, ok)
+		}
+	}
+}
+
+func TestDefineOnArrays(t *testing.T) {
+	arr := array.NewArrayReference(internal.Array{1.0})
+	define(arr, "0", &internal.PropertyDescriptor{Value: 5.0, HasValue: true})
+	define(arr, "label", &internal.PropertyDescriptor{Value: "nums", HasValue: true, Enumerable: true, HasEnumerable: true})
+
+	if arr.Get(0) != 5.0 {
+		t.Errorf("Expected element 0 to be 5, got %v", arr.Get(0))
+	}
+	if arr.OwnProperties().Get("label") != "nums" {
+		t.Errorf("Expected a label property, got %v", arr.OwnProperties().Get("label"))
+	}
+
+	exception := internal.Catch(func() { define(arr, "length", &internal.PropertyDescriptor{}) })
+	if exception == nil {
+		t.Error("Redefining length should throw")
+	}
+}
+
+func TestObjectConstructor(t *testing.T) {
+	obj := internal.NewObject()
+	if Object.Fn(obj) != obj {
+		t.Error("Object(obj) should return obj")
+	}
+	if _, ok := Object.Construct().(*internal.Object); !ok {
+		t.Error("new Object() should create an object")
+	}
+	if _, ok := Object.Fn(internal.Null).(*internal.Object); !ok {
+		t.Error("Object(null) should create an object")
+	}
+}
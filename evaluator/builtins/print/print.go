@@ -20,7 +20,7 @@ var Print = &internal.Builtin{
 			if i > 0 {
 				fmt.Print(" ")
 			}
-			fmt.Print(internal.ToString(arg))
+			fmt.Print(internal.Inspect(arg))
 		}
 		fmt.Println()
 		return nil
@@ -0,0 +1,277 @@
+package promise
+
+import (
+	"go-script/evaluator/builtins/array"
+	"go-script/internal"
+)
+
+// New returns the global Promise constructor for a realm. Its promises, and
+// the reactions registered on them, belong to the realm's event loop.
+//
+// Syntax: new Promise(executor)
+//
+// The executor runs immediately with resolve and reject functions. Throwing
+// inside it rejects the promise. Callbacks registered with then/catch/finally
+// run later, as microtasks.
+//
+// Examples:
+//
+//	var p = new Promise(function(resolve, reject) { resolve(42); });
+//	p.then(function(v) { print(v); })  → prints 42 once the script finishes
+//
+//	new Promise(function() { throw "boom"; })  → Promise { <rejected> boom }
+func New(loop *internal.EventLoop) *internal.Builtin {
+	statics := internal.NewObject()
+	statics.Set("resolve", Resolve(loop))
+	statics.Set("reject", Reject(loop))
+	statics.Set("all", All(loop))
+	statics.Set("allSettled", AllSettled(loop))
+	statics.Set("race", Race(loop))
+	statics.Set("any", Any(loop))
+	statics.Set(internal.SymbolHasInstance, HasInstance)
+
+	return &internal.Builtin{
+		Name:       "Promise",
+		Properties: statics,
+		Fn: func(args ...interface{}) interface{} {
+			internal.ThrowError("TypeError", "Promise constructor cannot be invoked without 'new'")
+			return nil
+		},
+		Construct: func(args ...interface{}) interface{} {
+			executor, ok := firstArg(args).(internal.Callable)
+			if !ok {
+				internal.ThrowError("TypeError", "Promise resolver "+internal.Inspect(firstArg(args))+" is not a function")
+			}
+
+			p := loop.NewPromise()
+			resolve, reject := p.ResolvingFunctions()
+			if exception := internal.Catch(func() { executor.Call(nil, resolve, reject) }); exception != nil {
+				reject.Call(nil, exception.Value)
+			}
+			return p
+		},
+	}
+}
+
+// Resolve returns a promise fulfilled with the value, or the value itself if
+// it is already a promise
+//
+// Syntax: Promise.resolve(value)
+//
+// Example:
+//
+//	Promise.resolve(1).then(function(v) { print(v); })  → prints 1
+func Resolve(loop *internal.EventLoop) *internal.Builtin {
+	return &internal.Builtin{
+		Name: "resolve",
+		Fn: func(args ...interface{}) interface{} {
+			return loop.PromiseResolve(firstArg(args))
+		},
+	}
+}
+
+// Reject returns a promise rejected with the reason
+//
+// Syntax: Promise.reject(reason)
+//
+// Example:
+//
+//	Promise.reject("nope").catch(function(e) { print(e); })  → prints nope
+func Reject(loop *internal.EventLoop) *internal.Builtin {
+	return &internal.Builtin{
+		Name: "reject",
+		Fn: func(args ...interface{}) interface{} {
+			return loop.PromiseReject(firstArg(args))
+		},
+	}
+}
+
+// All waits for every promise and fulfills with their values in input order.
+// It rejects as soon as any of them rejects.
+//
+// Syntax: Promise.all(iterable)
+//
+// Example:
+//
+//	Promise.all([1, Promise.resolve(2)])  → Promise { [1, 2] }
+func All(loop *internal.EventLoop) *internal.Builtin {
+	return &internal.Builtin{
+		Name: "all",
+		Fn: func(args ...interface{}) interface{} {
+			return combine(loop, firstArg(args), func(result *internal.Promise, items []internal.Value) {
+				values := make(internal.Array, len(items))
+				remaining := len(items)
+
+				for i, item := range items {
+					index := i
+					onFulfilled := handler(func(value internal.Value) {
+						values[index] = value
+						remaining--
+						if remaining == 0 {
+							result.Resolve(array.NewArrayReference(values))
+						}
+					})
+					onRejected := handler(result.Reject)
+					loop.PromiseResolve(item).Then(onFulfilled, onRejected)
+				}
+
+				if remaining == 0 {
+					result.Resolve(array.NewArrayReference(values))
+				}
+			})
+		},
+	}
+}
+
+// AllSettled waits for every promise and fulfills with an outcome object for
+// each: { status: "fulfilled", value } or { status: "rejected", reason }
+//
+// Syntax: Promise.allSettled(iterable)
+//
+// Example:
+//
+//	Promise.allSettled([1, Promise.reject("x")])
+//	→ Promise { [{status: fulfilled, value: 1}, {status: rejected, reason: x}] }
+func AllSettled(loop *internal.EventLoop) *internal.Builtin {
+	return &internal.Builtin{
+		Name: "allSettled",
+		Fn: func(args ...interface{}) interface{} {
+			return combine(loop, firstArg(args), func(result *internal.Promise, items []internal.Value) {
+				outcomes := make(internal.Array, len(items))
+				remaining := len(items)
+
+				settle := func(index int, status string, key string, value internal.Value) {
+					outcome := internal.NewObject()
+					outcome.Set("status", status)
+					outcome.Set(key, value)
+					outcomes[index] = outcome
+					remaining--
+					if remaining == 0 {
+						result.Resolve(array.NewArrayReference(outcomes))
+					}
+				}
+
+				for i, item := range items {
+					index := i
+					onFulfilled := handler(func(value internal.Value) { settle(index, "fulfilled", "value", value) })
+					onRejected := handler(func(reason internal.Value) { settle(index, "rejected", "reason", reason) })
+					loop.PromiseResolve(item).Then(onFulfilled, onRejected)
+				}
+
+				if remaining == 0 {
+					result.Resolve(array.NewArrayReference(outcomes))
+				}
+			})
+		},
+	}
+}
+
+// Race settles the same way as the first promise to settle
+//
+// Syntax: Promise.race(iterable)
+//
+// Example:
+//
+//	Promise.race([slow, fast])  → settles like fast
+func Race(loop *internal.EventLoop) *internal.Builtin {
+	return &internal.Builtin{
+		Name: "race",
+		Fn: func(args ...interface{}) interface{} {
+			return combine(loop, firstArg(args), func(result *internal.Promise, items []internal.Value) {
+				resolve, reject := result.ResolvingFunctions()
+				for _, item := range items {
+					loop.PromiseResolve(item).Then(resolve, reject)
+				}
+			})
+		},
+	}
+}
+
+// Any fulfills with the first promise to fulfill. If all of them reject, it
+// rejects with an AggregateError whose errors list every reason.
+//
+// Syntax: Promise.any(iterable)
+//
+// Example:
+//
+//	Promise.any([Promise.reject("a"), Promise.resolve("b")])  → Promise { b }
+func Any(loop *internal.EventLoop) *internal.Builtin {
+	return &internal.Builtin{
+		Name: "any",
+		Fn: func(args ...interface{}) interface{} {
+			return combine(loop, firstArg(args), func(result *internal.Promise, items []internal.Value) {
+				reasons := make(internal.Array, len(items))
+				remaining := len(items)
+
+				for i, item := range items {
+					index := i
+					onFulfilled := handler(result.Resolve)
+					onRejected := handler(func(reason internal.Value) {
+						reasons[index] = reason
+						remaining--
+						if remaining == 0 {
+							result.Reject(aggregateError(reasons))
+						}
+					})
+					loop.PromiseResolve(item).Then(onFulfilled, onRejected)
+				}
+
+				if remaining == 0 {
+					result.Reject(aggregateError(reasons))
+				}
+			})
+		},
+	}
+}
+
+// HasInstance makes "value instanceof Promise" recognize promises
+var HasInstance = &internal.Builtin{
+	Name: "[Symbol.hasInstance]",
+	Fn: func(args ...interface{}) interface{} {
+		_, ok := firstArg(args).(*internal.Promise)
+		return ok
+	},
+}
+
+// combine runs one of the Promise combinators. The iterable is read
+// up front; if it can't be iterated the returned promise is rejected.
+func combine(loop *internal.EventLoop, iterable internal.Value, subscribe func(result *internal.Promise, items []internal.Value)) *internal.Promise {
+	result := loop.NewPromise()
+
+	exception := internal.Catch(func() {
+		items, ok := internal.IterableToList(iterable)
+		if !ok {
+			internal.ThrowError("TypeError", internal.Inspect(iterable)+" is not iterable")
+		}
+		subscribe(result, items)
+	})
+	if exception != nil {
+		result.Reject(exception.Value)
+	}
+
+	return result
+}
+
+// handler wraps a Go callback as a promise reaction handler
+func handler(fn func(internal.Value)) *internal.Builtin {
+	return &internal.Builtin{
+		Fn: func(args ...interface{}) interface{} {
+			fn(firstArg(args))
+			return nil
+		},
+	}
+}
+
+// aggregateError is the rejection reason of Promise.any when nothing fulfills
+func aggregateError(reasons internal.Array) *internal.Object {
+	err := internal.NewError("AggregateError", "All promises were rejected")
+	err.Define("errors", &internal.Property{Value: array.NewArrayReference(reasons), NonEnumerable: true})
+	return err
+}
+
+func firstArg(args []interface{}) internal.Value {
+	if len(args) == 0 {
+		return nil
+	}
+	return args[0]
+}
@@ -0,0 +1,126 @@
+package promise
+
+import (
+	"go-script/evaluator/builtins/array"
+	"go-script/internal"
+	"testing"
+)
+
+// loop runs the promises the tests create
+var loop = internal.NewEventLoop()
+
+func settled(t *testing.T, value interface{}) *internal.Promise {
+	t.Helper()
+	p, ok := value.(*internal.Promise)
+	if !ok {
+		t.Fatalf("Expected a *internal.Promise, got %T", value)
+	}
+	loop.RunMicrotasks()
+	loop.TakeUnhandledRejections()
+	return p
+}
+
+func TestPromiseConstructor(t *testing.T) {
+	executor := &internal.Builtin{
+		Fn: func(args ...interface{}) interface{} {
+			args[0].(internal.Callable).Call(nil, "done")
+			return nil
+		},
+	}
+
+	p := settled(t, New(loop).Construct(executor))
+	if p.State != internal.PromiseFulfilled || p.Value != "done" {
+		t.Errorf("Expected a promise fulfilled with done, got %v", p)
+	}
+
+	throwing := &internal.Builtin{
+		Fn: func(args ...interface{}) interface{} {
+			internal.Throw("boom")
+			return nil
+		},
+	}
+
+	p = settled(t, New(loop).Construct(throwing))
+	if p.State != internal.PromiseRejected || p.Value != "boom" {
+		t.Errorf("Expected a promise rejected with boom, got %v", p)
+	}
+}
+
+func TestPromiseConstructorErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		call func()
+	}{
+		{"call without new", func() { New(loop).Fn(&internal.Builtin{}) }},
+		{"missing executor", func() { New(loop).Construct() }},
+	}
+
+	for _, tt := range tests {
+		exception := internal.Catch(tt.call)
+		if exception == nil {
+			t.Errorf("%s: expected a TypeError", tt.name)
+		}
+	}
+}
+
+func TestResolveAndReject(t *testing.T) {
+	p := settled(t, Resolve(loop).Fn(1.0))
+	if p.State != internal.PromiseFulfilled || p.Value != 1.0 {
+		t.Errorf("Expected Promise { 1 }, got %v", p)
+	}
+
+	if same := Resolve(loop).Fn(p); same != p {
+		t.Error("Promise.resolve should return promises unchanged")
+	}
+
+	p = settled(t, Reject(loop).Fn("no"))
+	if p.State != internal.PromiseRejected || p.Value != "no" {
+		t.Errorf("Expected Promise { <rejected> no }, got %v", p)
+	}
+}
+
+func TestCombinators(t *testing.T) {
+	values := func(items ...internal.Value) *array.ArrayReference {
+		return array.NewArrayReference(items)
+	}
+
+	tests := []struct {
+		name     string
+		builtin  *internal.Builtin
+		input    internal.Value
+		state    internal.PromiseState
+		expected string
+	}{
+		{"all", All(loop), values(1.0, loop.PromiseResolve(2.0)), internal.PromiseFulfilled, "[1, 2]"},
+		{"all rejects", All(loop), values(1.0, loop.PromiseReject("x")), internal.PromiseRejected, "x"},
+		{"all empty", All(loop), values(), internal.PromiseFulfilled, "[]"},
+		{"all not iterable", All(loop), 1.0, internal.PromiseRejected, "TypeError: 1 is not iterable"},
+		{"allSettled", AllSettled(loop), values(1.0, loop.PromiseReject("x")), internal.PromiseFulfilled, "[{status: fulfilled, value: 1}, {status: rejected, reason: x}]"},
+		{"race", Race(loop), values(loop.NewPromise(), "first"), internal.PromiseFulfilled, "first"},
+		{"any", Any(loop), values(loop.PromiseReject("a"), "b"), internal.PromiseFulfilled, "b"},
+		{"any empty", Any(loop), values(), internal.PromiseRejected, "AggregateError: All promises were rejected"},
+		{"string iterable", All(loop), "ab", internal.PromiseFulfilled, "[a, b]"},
+	}
+
+	for _, tt := range tests {
+		p := settled(t, tt.builtin.Fn(tt.input))
+		if p.State != tt.state {
+			t.Errorf("%s: expected state %d, got %d", tt.name, tt.state, p.State)
+		}
+		if got := internal.Inspect(p.Value); got != tt.expected {
+			t.Errorf("%s: expected %s, got %s", tt.name, tt.expected, got)
+		}
+	}
+}
+
+func TestHasInstance(t *testing.T) {
+	if HasInstance.Fn(loop.NewPromise()) != true {
+		t.Error("Expected promises to be instances of Promise")
+	}
+	if HasInstance.Fn(internal.NewObject()) != false {
+		t.Error("Expected plain objects not to be instances of Promise")
+	}
+	if New(loop).Properties.Get(internal.SymbolHasInstance) != HasInstance {
+		t.Error("Expected Promise[Symbol.hasInstance] to be installed")
+	}
+}
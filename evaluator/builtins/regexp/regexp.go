@@ -0,0 +1,690 @@
+package regexp
+
+import (
+	"go-script/evaluator/builtins/array"
+	"go-script/internal"
+	"go-script/regex"
+	"math"
+	"strings"
+	"unicode/utf16"
+)
+
+// RegExp is a regular expression object, created by a /pattern/flags
+// literal or by the RegExp constructor
+//
+// The compiled pattern keeps no state; the only thing that changes between
+// matches is LastIndex, which global and sticky expressions read and update
+// so that repeated exec() calls walk through a string.
+//
+// Example:
+//
+//	var re = /o/g;
+//	re.exec("foo").index → 1, re.lastIndex → 2
+//	re.exec("foo").index → 2, re.lastIndex → 3
+//	re.exec("foo")       → null, re.lastIndex → 0
+type RegExp struct {
+	internal.ObjectKind
+	Source    string // The pattern as source text, with "/" escaped
+	Flags     regex.Flags
+	LastIndex internal.Value
+	pattern   string // The pattern as given, for new RegExp(re)
+	re        *regex.Regexp
+	internal.SubclassSlot
+}
+
+// Constructor is the global RegExp function
+//
+// Syntax: new RegExp(pattern, flags) or RegExp(pattern, flags)
+//
+// The pattern may be a string or another RegExp, whose source is reused
+// (and whose flags are too, unless new ones are given). Called without new
+// on a RegExp and no flags, it returns that same RegExp.
+//
+// Examples:
+//
+//	new RegExp("\\d+", "g")  → /\d+/g
+//	new RegExp(/ab/i, "g")   → /ab/g
+//	RegExp(re) == re         → true
+var Constructor = &internal.Builtin{
+	Name: "RegExp",
+	Fn: func(args ...interface{}) interface{} {
+		if r, ok := argAt(args, 0).(*RegExp); ok && argAt(args, 1) == nil {
+			return r
+		}
+		return construct(args)
+	},
+	Construct: func(args ...interface{}) interface{} {
+		return construct(args)
+	},
+}
+
+// HasInstance makes "x instanceof RegExp" recognise RegExp objects
+var HasInstance = &internal.Builtin{
+	Name: "[Symbol.hasInstance]",
+	Fn: func(args ...interface{}) interface{} {
+		_, ok := argAt(args, 0).(*RegExp)
+		return ok
+	},
+}
+
+func construct(args []interface{}) *RegExp {
+	pattern, flags := argAt(args, 0), argAt(args, 1)
+
+	if r, ok := pattern.(*RegExp); ok {
+		if flags == nil {
+			return New(r.pattern, r.Flags.String())
+		}
+		return New(r.pattern, internal.ToString(flags))
+	}
+
+	source := ""
+	if pattern != nil {
+		source = internal.ToString(pattern)
+	}
+	if flags == nil {
+		return New(source, "")
+	}
+	return New(source, internal.ToString(flags))
+}
+
+func argAt(args []interface{}, index int) internal.Value {
+	if index >= len(args) {
+		return nil
+	}
+	return args[index]
+}
+
+func init() {
+	statics := internal.NewObject()
+	statics.Set(internal.SymbolHasInstance, HasInstance)
+	Constructor.Properties = statics
+}
+
+// New compiles a pattern into a RegExp, throwing a SyntaxError if the
+// pattern or the flags are invalid
+//
+// Examples:
+//
+//	New("a+", "g")  → /a+/g
+//	New("a/b", "")  → /a\/b/
+//	New("(", "")    → throws SyntaxError: Invalid regular expression: /(/: Unterminated group
+//	New("a", "gg")  → throws SyntaxError: Invalid flags supplied to RegExp constructor 'gg'
+func New(pattern string, flags string) *RegExp {
+	parsed, ok := regex.ParseFlags(flags)
+	if !ok {
+		internal.ThrowError("SyntaxError", "Invalid flags supplied to RegExp constructor '"+flags+"'")
+	}
+
+	source := escapeSource(pattern)
+	re, err := regex.Compile(pattern, parsed)
+	if err != nil {
+		internal.ThrowError("SyntaxError", "Invalid regular expression: /"+source+"/"+flags+": "+err.Error())
+	}
+
+	return &RegExp{Source: source, Flags: parsed, LastIndex: 0.0, pattern: pattern, re: re}
+}
+
+// escapeSource writes a pattern the way it would appear in a literal, so
+// that "/" + source + "/" reads back as the same expression
+//
+// Examples:
+//
+//	""     → "(?:)"
+//	"a/b"  → "a\/b"
+//	"[/]"  → "[/]"
+//	"a\nb" → "a\nb" with the newline written as an escape
+func escapeSource(pattern string) string {
+	if pattern == "" {
+		return "(?:)"
+	}
+
+	var b strings.Builder
+	inClass := false
+	escaped := false
+	for _, ch := range pattern {
+		switch {
+		case escaped:
+			escaped = false
+		case ch == '\\':
+			escaped = true
+		case ch == '[':
+			inClass = true
+		case ch == ']':
+			inClass = false
+		case ch == '/' && !inClass:
+			b.WriteString(`\/`)
+			continue
+		}
+
+		switch ch {
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case 0x2028:
+			b.WriteString(`\u2028`)
+		case 0x2029:
+			b.WriteString(`\u2029`)
+		default:
+			b.WriteRune(ch)
+		}
+	}
+	return b.String()
+}
+
+// String returns the expression as a literal, which is also how it prints
+//
+// Example: new RegExp("a+", "ig") → "/a+/gi"
+func (r *RegExp) String() string {
+	return "/" + r.Source + "/" + r.Flags.String()
+}
+
+// ToPrimitive converts a RegExp to its literal, whatever the hint
+func (r *RegExp) ToPrimitive(hint string) internal.Value {
+	return r.String()
+}
+
+// MarshalJSON writes a RegExp as an empty object, as JSON.stringify does
+func (r *RegExp) MarshalJSON() ([]byte, error) {
+	return []byte("{}"), nil
+}
+
+// Exec searches a string and returns the match, or null
+//
+// The match is an array of the matched text followed by every group's text
+// (undefined for groups that didn't take part). It also has the properties
+// index, input and groups (an object of the named groups, or undefined), and
+// with the d flag indices, the [start, end] pair of each group.
+//
+// Global and sticky expressions start at lastIndex and move it past the
+// match, or reset it to 0 when there is none. A sticky expression only
+// matches exactly at lastIndex.
+//
+// Example:
+//
+//	/(?<h>\d+):(\d+)/.exec("at 10:30")
+//	→ ["10:30", "10", "30"] with index 3, groups {h: "10"}
+func (r *RegExp) Exec(str string) internal.Value {
+	units := utf16.Encode([]rune(str))
+
+	start := 0
+	if r.updatesLastIndex() {
+		start = toIndex(r.LastIndex)
+	}
+	if start > len(units) {
+		r.LastIndex = 0.0
+		return internal.Null
+	}
+
+	captures := r.search(units, start)
+	if captures == nil {
+		if r.updatesLastIndex() {
+			r.LastIndex = 0.0
+		}
+		return internal.Null
+	}
+
+	if r.updatesLastIndex() {
+		r.LastIndex = float64(captures[1])
+	}
+	return r.matchArray(units, str, captures)
+}
+
+// Test reports whether exec() would find a match, updating lastIndex the same way
+//
+// Example: /\d/.test("a1") → true
+func (r *RegExp) Test(str string) bool {
+	return r.Exec(str) != internal.Null
+}
+
+// Match implements String.prototype.match
+// Without the g flag this is exec(); with it, the text of every match, or
+// null if there are none.
+//
+// Examples:
+//
+//	"a1b22".match(/\d+/g) → ["1", "22"]
+//	"abc".match(/\d/g)    → null
+func (r *RegExp) Match(str string) internal.Value {
+	if !r.Flags.Global {
+		return r.Exec(str)
+	}
+
+	units := utf16.Encode([]rune(str))
+	var matches internal.Array
+	for _, captures := range r.all(units, 0) {
+		matches = append(matches, decode(units[captures[0]:captures[1]]))
+	}
+	r.LastIndex = 0.0
+
+	if matches == nil {
+		return internal.Null
+	}
+	return array.NewArrayReference(matches)
+}
+
+// Search implements String.prototype.search: the index of the first match,
+// or -1. It always starts from the beginning and leaves lastIndex alone.
+//
+// Example: "abc1".search(/\d/) → 3
+func (r *RegExp) Search(str string) float64 {
+	units := utf16.Encode([]rune(str))
+	captures := r.search(units, 0)
+	if captures == nil {
+		return -1
+	}
+	return float64(captures[0])
+}
+
+// MatchAll implements String.prototype.matchAll: an iterator over every
+// match, each in the form exec() returns
+//
+// The iterator starts at the expression's lastIndex but, unlike exec(),
+// never changes it.
+//
+// Example:
+//
+//	for (let m of "a1b2".matchAll(/[a-z](\d)/g)) { print(m[1], m.index); }
+//	→ prints 1 0, then 2 2
+func (r *RegExp) MatchAll(str string) internal.Value {
+	units := utf16.Encode([]rune(str))
+	pos := toIndex(r.LastIndex)
+	done := false
+
+	return internal.NewIterator(func() (internal.Value, bool) {
+		if done || pos > len(units) {
+			return nil, false
+		}
+		captures := r.search(units, pos)
+		if captures == nil || !r.Flags.Global {
+			done = true
+		}
+		if captures == nil {
+			return nil, false
+		}
+		pos = r.next(units, captures)
+		return r.matchArray(units, str, captures), true
+	})
+}
+
+// Replace implements String.prototype.replace and replaceAll for a RegExp
+// pattern: the first match (every match with the g flag) is replaced
+//
+// The replacement is either a function, called with the matched text, each
+// group, the match position, the whole string and (if the pattern has named
+// groups) the groups object, or a string that may refer to the match:
+//
+//	$$       a "$"
+//	$&       the matched text
+//	$` $'    the text before / after the match
+//	$1..$99  a group
+//	$<name>  a named group
+//
+// Examples:
+//
+//	"2024-05".replace(/(\d+)-(\d+)/, "$2/$1")        → "05/2024"
+//	"a-b-c".replace(/-/g, "+")                      → "a+b+c"
+//	"abc".replace(/b/, function(m) { return m + m; }) → "abbc"
+func (r *RegExp) Replace(str string, replacement internal.Value) string {
+	units := utf16.Encode([]rune(str))
+
+	var matches [][]int
+	if r.Flags.Global {
+		matches = r.all(units, 0)
+		r.LastIndex = 0.0
+	} else {
+		start := 0
+		if r.Flags.Sticky {
+			start = toIndex(r.LastIndex)
+		}
+		var captures []int
+		if start <= len(units) {
+			captures = r.search(units, start)
+		}
+		if r.Flags.Sticky {
+			r.LastIndex = 0.0
+			if captures != nil {
+				r.LastIndex = float64(captures[1])
+			}
+		}
+		if captures != nil {
+			matches = [][]int{captures}
+		}
+	}
+
+	callback, isFunction := replacement.(internal.Callable)
+	template := ""
+	if !isFunction {
+		template = internal.ToString(replacement)
+	}
+
+	var result []uint16
+	last := 0
+	for _, captures := range matches {
+		groups := make([]internal.Value, len(captures)/2)
+		for i := range groups {
+			if captures[2*i] >= 0 {
+				groups[i] = decode(units[captures[2*i]:captures[2*i+1]])
+			}
+		}
+		named := r.namedGroups(groups)
+
+		var text string
+		if isFunction {
+			args := append([]internal.Value{}, groups...)
+			args = append(args, float64(captures[0]), str)
+			if named != nil {
+				args = append(args, named)
+			}
+			text = internal.ToString(callback.Call(nil, args...))
+		} else {
+			text = expand(template, units, captures[0], captures[1], groups, named)
+		}
+
+		result = append(result, units[last:captures[0]]...)
+		result = append(result, utf16.Encode([]rune(text))...)
+		last = captures[1]
+	}
+	result = append(result, units[last:]...)
+
+	return decode(result)
+}
+
+// ReplaceString implements String.prototype.replace and replaceAll for a
+// string pattern, which matches itself literally. The replacement works
+// as in Replace, with no groups.
+//
+// Examples:
+//
+//	ReplaceString("a.b.c", ".", "-", false) → "a-b.c"
+//	ReplaceString("a.b.c", ".", "-", true)  → "a-b-c"
+//	ReplaceString("abc", "b", "[$&]", false) → "a[b]c"
+func ReplaceString(str string, search string, replacement internal.Value, all bool) string {
+	units := utf16.Encode([]rune(str))
+	pattern := utf16.Encode([]rune(search))
+
+	var positions []int
+	for pos := indexOf(units, pattern, 0); pos >= 0; pos = indexOf(units, pattern, pos+max(len(pattern), 1)) {
+		positions = append(positions, pos)
+		if !all {
+			break
+		}
+	}
+
+	callback, isFunction := replacement.(internal.Callable)
+	template := ""
+	if !isFunction {
+		template = internal.ToString(replacement)
+	}
+
+	var result []uint16
+	last := 0
+	for _, pos := range positions {
+		var text string
+		if isFunction {
+			text = internal.ToString(callback.Call(nil, search, float64(pos), str))
+		} else {
+			text = expand(template, units, pos, pos+len(pattern), []internal.Value{search}, nil)
+		}
+
+		result = append(result, units[last:pos]...)
+		result = append(result, utf16.Encode([]rune(text))...)
+		last = pos + len(pattern)
+	}
+	result = append(result, units[last:]...)
+
+	return decode(result)
+}
+
+// Split implements String.prototype.split for a RegExp separator
+// The text of any groups is spliced into the result between the pieces,
+// and limit caps the number of items returned.
+//
+// Examples:
+//
+//	"a1b22c".split(/\d+/)   → ["a", "b", "c"]
+//	"a1b2c".split(/(\d)/)   → ["a", "1", "b", "2", "c"]
+//	"abc".split(/(?:)/, 2)  → ["a", "b"]
+func (r *RegExp) Split(str string, limit internal.Value) internal.Value {
+	units := utf16.Encode([]rune(str))
+	result := array.NewArrayReference(internal.Array{})
+
+	lim := uint32(math.MaxUint32)
+	if limit != nil {
+		lim = toUint32(limit)
+	}
+	if lim == 0 {
+		return result
+	}
+
+	if len(units) == 0 {
+		if r.matchAt(units, 0) == nil {
+			result.Push(str)
+		}
+		return result
+	}
+
+	p := 0
+	for q := 0; q < len(units); {
+		captures := r.matchAt(units, q)
+		if captures == nil || captures[1] == p {
+			q = r.re.Advance(units, q)
+			continue
+		}
+
+		result.Push(decode(units[p:q]))
+		if uint32(result.Length()) == lim {
+			return result
+		}
+		p = min(captures[1], len(units))
+		for i := 2; i < len(captures); i += 2 {
+			var group internal.Value
+			if captures[i] >= 0 {
+				group = decode(units[captures[i]:captures[i+1]])
+			}
+			result.Push(group)
+			if uint32(result.Length()) == lim {
+				return result
+			}
+		}
+		q = p
+	}
+
+	result.Push(decode(units[p:]))
+	return result
+}
+
+// updatesLastIndex reports whether exec() starts at and updates lastIndex
+func (r *RegExp) updatesLastIndex() bool {
+	return r.Flags.Global || r.Flags.Sticky
+}
+
+// search finds the next match at or after pos; a sticky expression only
+// tries pos itself
+func (r *RegExp) search(units []uint16, pos int) []int {
+	if r.Flags.Sticky {
+		return r.matchAt(units, pos)
+	}
+	captures, err := r.re.Find(units, pos)
+	if err != nil {
+		internal.ThrowError("RangeError", err.Error())
+	}
+	return captures
+}
+
+// matchAt matches exactly at pos, throwing a RangeError when the input is
+// too long for the pattern
+func (r *RegExp) matchAt(units []uint16, pos int) []int {
+	captures, err := r.re.MatchAt(units, pos)
+	if err != nil {
+		internal.ThrowError("RangeError", err.Error())
+	}
+	return captures
+}
+
+// all returns every match from pos on, as a global exec() loop would find them
+func (r *RegExp) all(units []uint16, pos int) [][]int {
+	var matches [][]int
+	for pos <= len(units) {
+		captures := r.search(units, pos)
+		if captures == nil {
+			break
+		}
+		matches = append(matches, captures)
+		pos = r.next(units, captures)
+	}
+	return matches
+}
+
+// next returns where the search after a match continues: the end of the
+// match, or one character further for an empty match so the loop moves on
+func (r *RegExp) next(units []uint16, captures []int) int {
+	if captures[1] == captures[0] {
+		return r.re.Advance(units, captures[1])
+	}
+	return captures[1]
+}
+
+// matchArray builds the array exec() returns for a match
+func (r *RegExp) matchArray(units []uint16, input string, captures []int) *array.ArrayReference {
+	groups := make(internal.Array, len(captures)/2)
+	for i := range groups {
+		if captures[2*i] >= 0 {
+			groups[i] = decode(units[captures[2*i]:captures[2*i+1]])
+		}
+	}
+
+	match := array.NewArrayReference(groups)
+	props := match.OwnProperties()
+	props.Set("index", float64(captures[0]))
+	props.Set("input", input)
+	props.Set("groups", r.namedGroups(groups))
+
+	if r.Flags.HasIndices {
+		pairs := make(internal.Array, len(groups))
+		for i := range pairs {
+			if captures[2*i] >= 0 {
+				pairs[i] = array.NewArrayReference(internal.Array{float64(captures[2*i]), float64(captures[2*i+1])})
+			}
+		}
+		indices := array.NewArrayReference(pairs)
+		indices.OwnProperties().Set("groups", r.namedGroups(pairs))
+		props.Set("indices", indices)
+	}
+
+	return match
+}
+
+// namedGroups returns an object holding the value of each named group, or
+// undefined if the pattern has no named groups
+func (r *RegExp) namedGroups(values []internal.Value) internal.Value {
+	if !r.re.HasNamedGroups() {
+		return nil
+	}
+	groups := internal.NewObject()
+	for i, name := range r.re.GroupNames() {
+		if name != "" {
+			groups.Set(name, values[i])
+		}
+	}
+	return groups
+}
+
+// expand fills in a replacement template for the match str[start:end]
+// groups holds the matched text followed by the text of each group.
+func expand(template string, str []uint16, start int, end int, groups []internal.Value, named internal.Value) string {
+	var b strings.Builder
+	for i := 0; i < len(template); i++ {
+		ch := template[i]
+		if ch != '$' || i+1 == len(template) {
+			b.WriteByte(ch)
+			continue
+		}
+
+		switch next := template[i+1]; {
+		case next == '$':
+			b.WriteByte('$')
+			i++
+		case next == '&':
+			b.WriteString(decode(str[start:end]))
+			i++
+		case next == '`':
+			b.WriteString(decode(str[:start]))
+			i++
+		case next == '\'':
+			b.WriteString(decode(str[end:]))
+			i++
+		case next >= '0' && next <= '9':
+			// Prefer a two-digit group number if that group exists
+			index, width := int(next-'0'), 1
+			if i+2 < len(template) && template[i+2] >= '0' && template[i+2] <= '9' {
+				if two := index*10 + int(template[i+2]-'0'); two >= 1 && two < len(groups) {
+					index, width = two, 2
+				}
+			}
+			if index < 1 || index >= len(groups) {
+				b.WriteByte('$')
+				continue
+			}
+			if text, ok := groups[index].(string); ok {
+				b.WriteString(text)
+			}
+			i += width
+		case next == '<':
+			closing := strings.IndexByte(template[i+2:], '>')
+			if named == nil || closing < 0 {
+				b.WriteByte('$')
+				continue
+			}
+			if text, ok := named.(*internal.Object).Get(template[i+2 : i+2+closing]).(string); ok {
+				b.WriteString(text)
+			}
+			i += closing + 2
+		default:
+			b.WriteByte('$')
+		}
+	}
+	return b.String()
+}
+
+// indexOf finds pattern in units at or after from, or returns -1
+func indexOf(units []uint16, pattern []uint16, from int) int {
+	for i := from; i+len(pattern) <= len(units); i++ {
+		found := true
+		for j := range pattern {
+			if units[i+j] != pattern[j] {
+				found = false
+				break
+			}
+		}
+		if found {
+			return i
+		}
+	}
+	return -1
+}
+
+func decode(units []uint16) string {
+	return string(utf16.Decode(units))
+}
+
+// toIndex converts a lastIndex value to a position, clamping it to 0 or more
+func toIndex(val internal.Value) int {
+	n, ok := val.(float64)
+	if !ok || math.IsNaN(n) || n <= 0 {
+		return 0
+	}
+	if n > math.MaxInt32 {
+		return math.MaxInt32
+	}
+	return int(n)
+}
+
+// toUint32 converts a split limit the way ToUint32 does, wrapping modulo 2^32
+func toUint32(val internal.Value) uint32 {
+	n, ok := val.(float64)
+	if !ok || math.IsNaN(n) || math.IsInf(n, 0) {
+		return 0
+	}
+	return uint32(int64(math.Trunc(n)))
+}
@@ -0,0 +1,94 @@
+package regexp
+
+import (
+	"go-script/evaluator/builtins/array"
+	"go-script/internal"
+	"testing"
+	"unicode/utf16"
+)
+
+func TestEscapeSource(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"", "(?:)"},
+		{"a/b", `a\/b`},
+		{`a\/b`, `a\/b`},
+		{"[/]", "[/]"},
+		{"a\nb", `a\nb`},
+	}
+
+	for _, tt := range tests {
+		if result := escapeSource(tt.input); result != tt.expected {
+			t.Errorf("For input %q: expected %q, got %q", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestConstructor(t *testing.T) {
+	re, ok := Constructor.Construct("a+", "gi").(*RegExp)
+	if !ok {
+		t.Fatalf("new RegExp() should return a *RegExp")
+	}
+	if re.String() != "/a+/gi" {
+		t.Errorf("Expected /a+/gi, got %s", re.String())
+	}
+
+	if same := Constructor.Fn(re); same != re {
+		t.Error("RegExp(re) should return re itself")
+	}
+	if copied := Constructor.Construct(re).(*RegExp); copied == re || copied.String() != "/a+/gi" {
+		t.Errorf("new RegExp(re) should copy re, got %v", copied)
+	}
+
+	exception := internal.Catch(func() { Constructor.Construct("(") })
+	if exception == nil || !internal.IsError(exception.Value) || internal.ErrorString(exception.Value) != "SyntaxError: Invalid regular expression: /(/: Unterminated group" {
+		t.Errorf("Expected a SyntaxError, got %v", exception)
+	}
+}
+
+func TestExpand(t *testing.T) {
+	tests := []struct {
+		template string
+		expected string
+	}{
+		{"$$", "$"},
+		{"[$&]", "[bc]"},
+		{"$`|$'", "a|d"},
+		{"$1-$2", "b-c"},
+		{"$3", "$3"},
+		{"$01", "b"},
+		{"$10", "b0"},
+		{"$", "$"},
+		{"$<x>", "$<x>"},
+	}
+
+	str := utf16.Encode([]rune("abcd"))
+	groups := []internal.Value{"bc", "b", "c"}
+	for _, tt := range tests {
+		if result := expand(tt.template, str, 1, 3, groups, nil); result != tt.expected {
+			t.Errorf("For template %q: expected %q, got %q", tt.template, tt.expected, result)
+		}
+	}
+}
+
+func TestSplitLimit(t *testing.T) {
+	re := New(",", "")
+	tests := []struct {
+		limit    internal.Value
+		expected int
+	}{
+		{nil, 3},
+		{0.0, 0},
+		{2.0, 2},
+		{-1.0, 3},
+	}
+
+	for _, tt := range tests {
+		result := re.Split("a,b,c", tt.limit).(*array.ArrayReference)
+		if int(result.Length()) != tt.expected {
+			t.Errorf("For limit %v: expected %d items, got %v", tt.limit, tt.expected, result.GetElements())
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package symbol
+
+import (
+	"go-script/internal"
+)
+
+// Symbol creates a new unique symbol
+//
+// Syntax: Symbol(description?)
+//
+// Examples:
+//
+//	let id = Symbol("id")
+//	print(id)                     → Symbol(id)
+//	print(Symbol("a") == Symbol("a")) → false
+var Symbol = &internal.Builtin{
+	Name: "Symbol",
+	Fn: func(args ...interface{}) interface{} {
+		description := ""
+		if len(args) > 0 && args[0] != nil {
+			description = internal.ToString(args[0])
+		}
+		return internal.NewSymbol(description)
+	},
+}
+
+// For returns the symbol registered under a key, creating it if needed
+//
+// Syntax: Symbol.for(key)
+//
+// Example:
+//
+//	Symbol.for("app") == Symbol.for("app") → true
+var For = &internal.Builtin{
+	Name: "for",
+	Fn: func(args ...interface{}) interface{} {
+		key := "undefined"
+		if len(args) > 0 && args[0] != nil {
+			key = internal.ToString(args[0])
+		}
+		return internal.SymbolFor(key)
+	},
+}
+
+// KeyFor returns the key of a symbol created by Symbol.for, or undefined
+//
+// Syntax: Symbol.keyFor(symbol)
+//
+// Example:
+//
+//	Symbol.keyFor(Symbol.for("app")) → "app"
+//	Symbol.keyFor(Symbol("app"))     → undefined
+var KeyFor = &internal.Builtin{
+	Name: "keyFor",
+	Fn: func(args ...interface{}) interface{} {
+		if len(args) == 0 {
+			return nil
+		}
+		sym, ok := args[0].(*internal.Symbol)
+		if !ok {
+			return nil
+		}
+		if key, ok := internal.SymbolKeyFor(sym); ok {
+			return key
+		}
+		return nil
+	},
+}
+
+func init() {
+	statics := internal.NewObject()
+	statics.Set("for", For)
+	statics.Set("keyFor", KeyFor)
+	statics.Set("iterator", internal.SymbolIterator)
+	statics.Set("asyncIterator", internal.SymbolAsyncIterator)
+	statics.Set("toPrimitive", internal.SymbolToPrimitive)
+	statics.Set("toStringTag", internal.SymbolToStringTag)
+	statics.Set("hasInstance", internal.SymbolHasInstance)
+	Symbol.Properties = statics
+}
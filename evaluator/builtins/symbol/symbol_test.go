@@ -0,0 +1,59 @@
+package symbol
+
+import (
+	"go-script/internal"
+	"testing"
+)
+
+func TestSymbolFunction(t *testing.T) {
+	a, ok := Symbol.Fn("id").(*internal.Symbol)
+	if !ok {
+		t.Fatalf("Symbol() should return a *internal.Symbol")
+	}
+	if a.Description != "id" {
+		t.Errorf("Expected description id, got %q", a.Description)
+	}
+
+	b := Symbol.Fn("id")
+	if a == b {
+		t.Error("Every Symbol() call should create a new symbol")
+	}
+
+	if empty := Symbol.Fn().(*internal.Symbol); empty.String() != "Symbol()" {
+		t.Errorf("Expected Symbol(), got %s", empty.String())
+	}
+}
+
+func TestSymbolForAndKeyFor(t *testing.T) {
+	a := For.Fn("shared")
+	b := For.Fn("shared")
+	if a != b {
+		t.Error("Symbol.for should return the same symbol for the same key")
+	}
+
+	if key := KeyFor.Fn(a); key != "shared" {
+		t.Errorf("Expected key shared, got %v", key)
+	}
+	if key := KeyFor.Fn(Symbol.Fn("shared")); key != nil {
+		t.Errorf("Expected undefined for an unregistered symbol, got %v", key)
+	}
+}
+
+func TestWellKnownSymbols(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected *internal.Symbol
+	}{
+		{"iterator", internal.SymbolIterator},
+		{"asyncIterator", internal.SymbolAsyncIterator},
+		{"toPrimitive", internal.SymbolToPrimitive},
+		{"toStringTag", internal.SymbolToStringTag},
+		{"hasInstance", internal.SymbolHasInstance},
+	}
+
+	for _, tt := range tests {
+		if got := Symbol.Properties.Get(tt.name); got != tt.expected {
+			t.Errorf("Symbol.%s = %v, expected %v", tt.name, got, tt.expected)
+		}
+	}
+}
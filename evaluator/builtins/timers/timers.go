@@ -0,0 +1,160 @@
+package timers
+
+import (
+	"go-script/internal"
+	"time"
+)
+
+// SetTimeout is a built-in function that runs a callback once, after a delay
+//
+// Syntax: setTimeout(callback, delay, arg1, arg2, ...)
+//
+// The delay is in milliseconds; a missing or non-number delay means 0.
+// Extra arguments are passed to the callback. Returns a timer id for
+// clearTimeout. The callback always runs after the current script and its
+// pending microtasks, even with a delay of 0.
+//
+// Examples:
+//
+//	setTimeout(function() { print("later"); }, 100)  → prints later after 100ms
+//	setTimeout(print, 0, "a", "b")                   → prints a b
+func SetTimeout(loop *internal.EventLoop) *internal.Builtin {
+	return &internal.Builtin{
+		Name: "setTimeout",
+		Fn: func(args ...interface{}) interface{} {
+			return schedule(loop, "setTimeout", args, false)
+		},
+	}
+}
+
+// SetInterval is a built-in function that runs a callback repeatedly
+//
+// Syntax: setInterval(callback, delay, arg1, arg2, ...)
+//
+// The callback runs every delay milliseconds until clearInterval is called
+// with the returned id. The program keeps running while an interval is active.
+//
+// Example:
+//
+//	var n = 0;
+//	var id = setInterval(function() {
+//	    n = n + 1;
+//	    if (n == 3) { clearInterval(id); }
+//	}, 10);                                   → runs 3 times, then the program exits
+func SetInterval(loop *internal.EventLoop) *internal.Builtin {
+	return &internal.Builtin{
+		Name: "setInterval",
+		Fn: func(args ...interface{}) interface{} {
+			return schedule(loop, "setInterval", args, true)
+		},
+	}
+}
+
+// ClearTimeout is a built-in function that cancels a pending setTimeout
+//
+// Syntax: clearTimeout(id)
+//
+// Unknown ids, and timers that already ran, are ignored.
+//
+// Example:
+//
+//	var id = setTimeout(function() { print("never"); }, 10);
+//	clearTimeout(id);                          → prints nothing
+func ClearTimeout(loop *internal.EventLoop) *internal.Builtin {
+	return &internal.Builtin{
+		Name: "clearTimeout",
+		Fn: func(args ...interface{}) interface{} {
+			cancel(loop, args)
+			return nil
+		},
+	}
+}
+
+// ClearInterval is a built-in function that stops a setInterval
+//
+// Syntax: clearInterval(id)
+//
+// Timeouts and intervals share ids, so either clear function accepts either.
+func ClearInterval(loop *internal.EventLoop) *internal.Builtin {
+	return &internal.Builtin{
+		Name: "clearInterval",
+		Fn: func(args ...interface{}) interface{} {
+			cancel(loop, args)
+			return nil
+		},
+	}
+}
+
+// QueueMicrotask is a built-in function that queues a callback as a microtask
+//
+// Syntax: queueMicrotask(callback)
+//
+// Microtasks run as soon as the current script (or macrotask) finishes,
+// interleaved with promise reactions in the order they were queued, and
+// always before any timer.
+//
+// Example:
+//
+//	setTimeout(function() { print("timeout"); }, 0);
+//	queueMicrotask(function() { print("microtask"); });
+//	print("sync");                           → prints sync, microtask, timeout
+func QueueMicrotask(loop *internal.EventLoop) *internal.Builtin {
+	return &internal.Builtin{
+		Name: "queueMicrotask",
+		Fn: func(args ...interface{}) interface{} {
+			callback := callableArg("queueMicrotask", args)
+			loop.QueueMicrotask(func() { callback.Call(nil) })
+			return nil
+		},
+	}
+}
+
+// schedule adds a timer to the loop that calls the first argument with the arguments
+// after the delay, returning the timer id as a number
+func schedule(loop *internal.EventLoop, name string, args []interface{}, repeat bool) interface{} {
+	callback := callableArg(name, args)
+
+	var delay time.Duration
+	if len(args) > 1 {
+		if ms := internal.ToNumber(args[1]); ms > 0 {
+			delay = time.Duration(ms * float64(time.Millisecond))
+		}
+	}
+
+	var callArgs []internal.Value
+	if len(args) > 2 {
+		for _, arg := range args[2:] {
+			callArgs = append(callArgs, arg)
+		}
+	}
+
+	id := loop.AddTimer(delay, repeat, func() {
+		callback.Call(nil, callArgs...)
+	})
+	return float64(id)
+}
+
+// cancel clears the timer whose id is the first argument
+func cancel(loop *internal.EventLoop, args []interface{}) {
+	if len(args) == 0 {
+		return
+	}
+	if id, ok := args[0].(float64); ok {
+		loop.ClearTimer(int(id))
+	}
+}
+
+// callableArg returns the first argument, throwing a TypeError if it can't
+// be called
+func callableArg(name string, args []interface{}) internal.Callable {
+	var first interface{}
+	if len(args) > 0 {
+		first = args[0]
+	}
+
+	callback, ok := first.(internal.Callable)
+	if !ok {
+		internal.ThrowError("TypeError", name+" callback must be a function, got "+internal.Inspect(first))
+	}
+	return callback
+}
@@ -0,0 +1,141 @@
+package timers
+
+import (
+	"go-script/internal"
+	"testing"
+)
+
+// recorder returns a builtin that appends its first argument to log
+func recorder(log *[]interface{}) *internal.Builtin {
+	return &internal.Builtin{
+		Name: "record",
+		Fn: func(args ...interface{}) interface{} {
+			var v interface{}
+			if len(args) > 0 {
+				v = args[0]
+			}
+			*log = append(*log, v)
+			return nil
+		},
+	}
+}
+
+func TestSetTimeout(t *testing.T) {
+	loop := internal.NewEventLoop()
+	var log []interface{}
+	record := recorder(&log)
+
+	SetTimeout(loop).Fn(record, 5.0, "second")
+	SetTimeout(loop).Fn(record, 0.0, "first")
+	SetTimeout(loop).Fn(record) // no delay, no argument
+
+	if len(log) != 0 {
+		t.Fatalf("Expected setTimeout not to call back synchronously, got %v", log)
+	}
+
+	loop.Run()
+
+	expected := []interface{}{"first", nil, "second"}
+	if len(log) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, log)
+	}
+	for i := range expected {
+		if log[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, log)
+			break
+		}
+	}
+}
+
+func TestTimerIDs(t *testing.T) {
+	loop := internal.NewEventLoop()
+	var log []interface{}
+	record := recorder(&log)
+
+	a, ok := SetTimeout(loop).Fn(record, 0.0, "a").(float64)
+	if !ok {
+		t.Fatalf("Expected setTimeout to return a number")
+	}
+	b := SetInterval(loop).Fn(record, 0.0, "b").(float64)
+	if a == b {
+		t.Errorf("Expected distinct timer ids, got %v twice", a)
+	}
+
+	ClearTimeout(loop).Fn(a)
+	ClearInterval(loop).Fn(b)
+	ClearTimeout(loop).Fn()       // no id
+	ClearTimeout(loop).Fn("nope") // not a number
+	ClearInterval(loop).Fn(999.0) // unknown id
+
+	loop.Run()
+	if len(log) != 0 {
+		t.Errorf("Expected cleared timers not to run, got %v", log)
+	}
+}
+
+func TestSetInterval(t *testing.T) {
+	loop := internal.NewEventLoop()
+	count := 0
+	var id interface{}
+	tick := &internal.Builtin{
+		Name: "tick",
+		Fn: func(args ...interface{}) interface{} {
+			count++
+			if count == 4 {
+				ClearInterval(loop).Fn(id)
+			}
+			return nil
+		},
+	}
+
+	id = SetInterval(loop).Fn(tick, 1.0)
+	loop.Run()
+
+	if count != 4 {
+		t.Errorf("Expected the interval to run 4 times, got %d", count)
+	}
+}
+
+func TestQueueMicrotask(t *testing.T) {
+	loop := internal.NewEventLoop()
+	var log []interface{}
+	record := recorder(&log)
+
+	SetTimeout(loop).Fn(record, 0.0, "timeout")
+	QueueMicrotask(loop).Fn(&internal.Builtin{
+		Name: "job",
+		Fn: func(args ...interface{}) interface{} {
+			log = append(log, "microtask")
+			return nil
+		},
+	})
+
+	loop.Run()
+
+	if len(log) != 2 || log[0] != "microtask" || log[1] != "timeout" {
+		t.Errorf("Expected microtasks to run before timers, got %v", log)
+	}
+}
+
+func TestNonCallableCallback(t *testing.T) {
+	loop := internal.NewEventLoop()
+	tests := []struct {
+		builtin  *internal.Builtin
+		expected string
+	}{
+		{SetTimeout(loop), "TypeError: setTimeout callback must be a function, got 42"},
+		{SetInterval(loop), "TypeError: setInterval callback must be a function, got 42"},
+		{QueueMicrotask(loop), "TypeError: queueMicrotask callback must be a function, got 42"},
+	}
+
+	for _, tt := range tests {
+		exception := internal.Catch(func() { tt.builtin.Fn(42.0, 0.0) })
+		if exception == nil {
+			t.Errorf("%s: expected a TypeError", tt.builtin.Name)
+			continue
+		}
+		if !internal.IsError(exception.Value) || internal.ErrorString(exception.Value) != tt.expected {
+			t.Errorf("%s: expected %q, got %v", tt.builtin.Name, tt.expected, exception)
+		}
+	}
+}
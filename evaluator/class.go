@@ -0,0 +1,781 @@
+package evaluator
+
+import (
+	"strings"
+
+	"go-script/ast"
+	"go-script/environment"
+	"go-script/evaluator/builtins"
+	"go-script/internal"
+	"go-script/token"
+)
+
+// Class represents a runtime class value
+// Instances are plain Objects whose prototype is Class.Prototype.
+// Static members live on Statics, whose prototype is the parent's Statics,
+// so static methods are inherited the same way instance methods are.
+//
+// A class can also extend a constructible builtin like Map or Error (Base).
+// Its instances are then created by the builtin, linked to the class
+// prototype (see internal.Subclassable).
+//
+// Example: class Dog extends Animal { bark() { ... } static create() { ... } }
+//
+//	Creates: Class{
+//	  Name: "Dog",
+//	  Parent: <Animal>,
+//	  Prototype: { bark } → Animal.Prototype,
+//	  Statics: { create } → Animal.Statics,
+//	}
+type Class struct {
+	internal.ObjectKind
+	Name        string
+	Parent      *Class
+	Base        *internal.Builtin // Builtin the class extends, like Map; nil otherwise
+	Constructor *Function         // nil when the class body has no constructor
+	Prototype   *Object           // Methods and accessors shared by instances
+	Statics     *Object           // Static methods, accessors and fields
+	Env         *environment.Environment
+
+	instanceMembers []*instanceMember // Fields and private methods set up on every new instance
+	extendsNull     bool              // class A extends null: derived, but there is no parent constructor
+}
+
+func (c *Class) String() string {
+	switch {
+	case c.Parent != nil:
+		return "[class " + c.Name + " extends " + c.Parent.Name + "]"
+	case c.Base != nil:
+		return "[class " + c.Name + " extends " + c.Base.Name + "]"
+	}
+	return "[class " + c.Name + "]"
+}
+
+// OwnProperties returns the static members, which are the class's own
+// properties as far as Object.keys and the other reflection functions go
+func (c *Class) OwnProperties() *Object {
+	return c.Statics
+}
+
+// PreventExtensions, Seal and Freeze lock the static members of the class,
+// which are its own properties
+func (c *Class) PreventExtensions() {
+	c.Statics.PreventExtensions()
+}
+
+func (c *Class) Seal() {
+	c.Statics.Seal()
+}
+
+func (c *Class) Freeze() {
+	c.Statics.Freeze()
+}
+
+func (c *Class) IsExtensible() bool {
+	return c.Statics.IsExtensible()
+}
+
+func (c *Class) IsSealed() bool {
+	return c.Statics.IsSealed()
+}
+
+func (c *Class) IsFrozen() bool {
+	return c.Statics.IsFrozen()
+}
+
+// derived reports whether the class has an extends clause, so that its
+// constructor must call super(...) before using this
+func (c *Class) derived() bool {
+	return c.Parent != nil || c.Base != nil || c.extendsNull
+}
+
+// instanceMember describes something every instance receives at construction:
+// either a field with an optional initializer, or a private method/accessor
+type instanceMember struct {
+	key         internal.PropertyKey
+	private     *internal.PrivateName
+	initializer ast.Expression
+	property    *internal.Property // Private methods and accessors
+}
+
+// staticInitializer is a static field or static block waiting to run once
+// all methods are installed. key holds the field name, already computed.
+type staticInitializer struct {
+	key    internal.PropertyKey
+	member *ast.ClassMember
+}
+
+// superBinding is stored under the name "super" in method environments
+// The keyword can't be used as a variable, so it never clashes with user code.
+type superBinding struct {
+	home      *Object // Object holding the running method; super.x starts at its prototype
+	class     *Class  // Class being constructed; super(...) runs its parent's constructor
+	newTarget *Class  // Class named by the new expression, whose prototype the instance gets
+}
+
+// thisUninitialized is the type of uninitializedThis, the value of `this`
+// in a derived constructor until super(...) returns
+type thisUninitialized struct{}
+
+var uninitializedThis = thisUninitialized{}
+
+// evalClassDeclaration evaluates "class Name { ... }" and binds Name
+func evalClassDeclaration(node *ast.ClassDeclaration, env *environment.Environment) Value {
+	class := evalClassLiteral(node.Class, env)
+	env.Set(node.Class.Name, class)
+	return nil
+}
+
+// evalClassLiteral builds a Class from its definition
+//
+// Steps:
+//  1. Resolve the parent class and link prototypes
+//  2. Create a class scope holding the class name and its #private names
+//  3. Install methods and accessors on the prototype or on the statics
+//  4. Record instance fields so they can be initialized by new
+//  5. Run static field initializers and static blocks in source order
+func evalClassLiteral(node *ast.ClassLiteral, env *environment.Environment) Value {
+	// A class body is always strict mode code
+	classEnv := environment.New(env)
+	classEnv.SetStrict(true)
+
+	class := &Class{
+		Name:      node.Name,
+		Prototype: internal.NewObject(),
+		Statics:   internal.NewObject(),
+		Env:       classEnv,
+	}
+	if node.SuperClass != nil {
+		linkSuperClass(class, Eval(node.SuperClass, env), node.ExtendsPos, env)
+	}
+	// Like methods, these are left out of Object.keys, for...in and printing
+	class.Prototype.Define("constructor", &internal.Property{Value: class, NonEnumerable: true})
+	class.Statics.Define("prototype", &internal.Property{Value: class.Prototype, NonWritable: true, NonEnumerable: true, NonConfigurable: true})
+	class.Statics.Define("name", &internal.Property{Value: class.Name, NonWritable: true, NonEnumerable: true})
+
+	if node.Name != "" {
+		classEnv.Set(node.Name, class)
+	}
+
+	// Declare every #private name up front so methods can refer to members
+	// declared later in the body
+	declared := declaredPrivateNames(node)
+	checkPrivateReferences(node, declared, env)
+	for name := range declared {
+		classEnv.Set(name, &internal.PrivateName{Name: name})
+	}
+
+	if node.Constructor != nil {
+		class.Constructor = &Function{
+			Parameters: node.Constructor.Parameters,
+			Body:       node.Constructor.Body,
+			Env:        classEnv,
+			HomeObject: class.Prototype,
+			Class:      class,
+			Strict:     true,
+		}
+	}
+
+	privateMethods := make(map[*internal.PrivateName]*internal.Property)
+	staticInitializers := []*staticInitializer{}
+
+	for _, member := range node.Members {
+		home := class.Prototype
+		if member.Static {
+			home = class.Statics
+		}
+
+		// Computed keys are evaluated once, in source order, while the class is defined
+		var key internal.PropertyKey = member.Key
+		if member.Computed != nil {
+			key = internal.ToPropertyKey(Eval(member.Computed, classEnv))
+		}
+
+		switch member.Kind {
+		case ast.ClassMethod, ast.ClassGetter, ast.ClassSetter:
+			literal := member.Value.(*ast.FunctionLiteral)
+			fn := &Function{
+				Parameters: literal.Parameters,
+				Body:       literal.Body,
+				Env:        classEnv,
+				HomeObject: home,
+				Generator:  literal.Generator,
+				Async:      literal.Async,
+				Strict:     true,
+			}
+
+			if member.Computed != nil || !isPrivateName(member.Key) {
+				defineClassMethod(home, key, member.Kind, fn)
+				continue
+			}
+
+			name := lookupPrivateName(member.Key, classEnv)
+			prop, exists := privateMethods[name]
+			if !exists {
+				prop = &internal.Property{}
+				privateMethods[name] = prop
+				if member.Static {
+					class.Statics.DefinePrivate(name, prop)
+				} else {
+					class.instanceMembers = append(class.instanceMembers, &instanceMember{private: name, property: prop})
+				}
+			}
+			setMethodSlot(prop, member.Kind, fn)
+		case ast.ClassField:
+			if member.Static {
+				staticInitializers = append(staticInitializers, &staticInitializer{key: key, member: member})
+				continue
+			}
+			field := &instanceMember{key: key, initializer: member.Value}
+			if member.Computed == nil && isPrivateName(member.Key) {
+				field.private = lookupPrivateName(member.Key, classEnv)
+			}
+			class.instanceMembers = append(class.instanceMembers, field)
+		case ast.ClassStaticBlock:
+			staticInitializers = append(staticInitializers, &staticInitializer{member: member})
+		}
+	}
+
+	// Static initializers run with this = the class itself
+	staticEnv := environment.New(classEnv)
+	staticEnv.Set("this", class)
+	staticEnv.Set("super", &superBinding{home: class.Statics})
+
+	for _, initializer := range staticInitializers {
+		member := initializer.member
+		if member.Kind == ast.ClassStaticBlock {
+			Eval(member.Body, staticEnv)
+			continue
+		}
+
+		var val Value
+		if member.Value != nil {
+			val = Eval(member.Value, staticEnv)
+		}
+
+		if member.Computed == nil && isPrivateName(member.Key) {
+			class.Statics.DefinePrivate(lookupPrivateName(member.Key, classEnv), &internal.Property{Value: val})
+		} else {
+			class.Statics.Define(initializer.key, &internal.Property{Value: val})
+		}
+	}
+
+	return class
+}
+
+// declaredPrivateNames returns the #names a class body declares, throwing
+// a SyntaxError if one is declared twice. Only a getter and a setter with
+// the same name, both static or both not, may share it.
+//
+// Examples:
+//
+//	class A { #x; #y() {} }                  → #x, #y
+//	class A { get #x() {} set #x(v) {} }     → #x
+//	class A { #x; #x; }                      → SyntaxError: Identifier '#x' has already been declared
+func declaredPrivateNames(node *ast.ClassLiteral) map[string]bool {
+	type accessors struct {
+		getter, setter bool
+		static         bool
+	}
+	seen := map[string]*accessors{}
+
+	for _, member := range node.Members {
+		if member.Computed != nil || !isPrivateName(member.Key) {
+			continue
+		}
+
+		previous, exists := seen[member.Key]
+		if !exists {
+			seen[member.Key] = &accessors{
+				getter: member.Kind == ast.ClassGetter,
+				setter: member.Kind == ast.ClassSetter,
+				static: member.Static,
+			}
+			continue
+		}
+
+		pairs := previous.static == member.Static &&
+			(member.Kind == ast.ClassGetter && previous.setter && !previous.getter ||
+				member.Kind == ast.ClassSetter && previous.getter && !previous.setter)
+		if !pairs {
+			internal.ThrowError("SyntaxError", "Identifier '"+member.Key+"' has already been declared")
+		}
+		previous.getter, previous.setter = true, true
+	}
+
+	names := make(map[string]bool, len(seen))
+	for name := range seen {
+		names[name] = true
+	}
+	return names
+}
+
+// checkPrivateReferences throws a SyntaxError for the first #name the class
+// body uses without a declaration in scope: in this class, in a class nested
+// in it around the use, or in a class enclosing this one (found in env).
+// The extends clause is outside the class, so it isn't checked here.
+//
+// Example:
+//
+//	class A { m() { return this.#y; } }
+//	→ SyntaxError: Private field '#y' must be declared in an enclosing class
+func checkPrivateReferences(node *ast.ClassLiteral, declared map[string]bool, env *environment.Environment) {
+	var check func(n ast.Node) bool
+	check = func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.PropertyAccess:
+			if isPrivateName(n.Property) && !declared[n.Property] && lookupPrivateName(n.Property, env) == nil {
+				throwError("SyntaxError", "Private field '"+n.Property+"' must be declared in an enclosing class", n.Pos, env)
+			}
+		case *ast.ClassLiteral:
+			ast.Inspect(n.SuperClass, check)
+			nested := declaredPrivateNames(n)
+			for name := range declared {
+				nested[name] = true
+			}
+			checkPrivateReferences(n, nested, env)
+			return false
+		}
+		return true
+	}
+
+	if node.Constructor != nil {
+		ast.Inspect(node.Constructor, check)
+	}
+	for _, member := range node.Members {
+		ast.Inspect(member, check)
+	}
+}
+
+// linkSuperClass links a class to the value of its extends clause: another
+// class, a constructible builtin or null
+//
+// Examples:
+//
+//	class Dog extends Animal {}  → Dog.prototype → Animal.prototype
+//	class Cache extends Map {}   → instances are Maps; Cache.get is Map's static side
+//	class Bare extends null {}   → Bare.prototype has no prototype
+//	class Bad extends 5 {}       → TypeError: Class extends value 5 is not a constructor or null
+func linkSuperClass(class *Class, parent Value, pos token.Position, env *environment.Environment) {
+	switch p := parent.(type) {
+	case *Class:
+		class.Parent = p
+		class.Prototype.Prototype = p.Prototype
+		class.Statics.Prototype = p.Statics
+		return
+	case *internal.Builtin:
+		if p.Construct != nil {
+			class.Base = p
+			class.Prototype.Prototype, _ = getProperty(p, "prototype").(*Object)
+			class.Statics.Prototype = p.Properties
+			// The builtin's own [Symbol.hasInstance] only knows its native
+			// values, so the class checks the prototype chain instead
+			class.Statics.Define(internal.SymbolHasInstance, &internal.Property{Value: classHasInstance, NonEnumerable: true})
+			return
+		}
+	case internal.NullValue:
+		class.extendsNull = true
+		return
+	}
+
+	description := "undefined"
+	if parent != nil {
+		description = internal.Inspect(parent)
+	}
+	throwError("TypeError", "Class extends value "+description+" is not a constructor or null", pos, env)
+}
+
+// classHasInstance is the [Symbol.hasInstance] of classes that extend a
+// builtin: value instanceof Cache looks for Cache.prototype
+var classHasInstance = &internal.Builtin{
+	Name: "[Symbol.hasInstance]",
+	Method: func(this interface{}, args ...interface{}) interface{} {
+		class, ok := this.(*Class)
+		return ok && hasPrototype(firstArg(args), class.Prototype)
+	},
+}
+
+// defineClassMethod installs a method or accessor on a prototype or statics object
+func defineClassMethod(home *Object, key internal.PropertyKey, kind ast.ClassMemberKind, fn *Function) {
+	switch kind {
+	case ast.ClassGetter:
+		defineAccessor(home, key, fn, nil)
+	case ast.ClassSetter:
+		defineAccessor(home, key, nil, fn)
+	default:
+		home.Define(key, &internal.Property{Value: fn})
+	}
+
+	// Unlike object literal members, class members aren't enumerable
+	if prop, ok := home.GetOwnProperty(key); ok {
+		prop.NonEnumerable = true
+	}
+}
+
+func setMethodSlot(prop *internal.Property, kind ast.ClassMemberKind, fn *Function) {
+	switch kind {
+	case ast.ClassGetter:
+		prop.Getter = fn
+	case ast.ClassSetter:
+		prop.Setter = fn
+	default:
+		prop.Value = fn
+	}
+}
+
+// evalNewExpression evaluates "new Class(args)"
+//
+// Example:
+//
+//	new Point(1, 2)
+//	→ creates an Object with prototype Point.prototype,
+//	  initializes its fields and runs the constructor with this = the object
+func evalNewExpression(node *ast.NewExpression, env *environment.Environment) Value {
+	callee := Eval(node.Callee, env)
+	args := evalArguments(node.Arguments, env)
+
+	var instance Value
+	switch constructor := callee.(type) {
+	case *Class:
+		instance = constructClass(constructor, args)
+	case *internal.Builtin:
+		if constructor.Construct == nil {
+			throwError("TypeError", describe(node.Callee)+" is not a constructor", node.Pos, env)
+		}
+		instance = constructor.New(args...)
+	default:
+		// Only classes and constructible builtins like Promise can be constructed
+		throwError("TypeError", describe(node.Callee)+" is not a constructor", node.Pos, env)
+	}
+
+	// new Error(...) records where it was created, like errors the evaluator
+	// raises, and so do instances of classes that extend Error
+	if err, ok := instance.(*Object); ok && internal.IsError(err) {
+		internal.SetErrorLocation(err, locate(node.Pos, env))
+	}
+	return instance
+}
+
+// constructClass creates and initializes a new instance of a class
+func constructClass(class *Class, args []Value) Value {
+	return construct(class, class, args)
+}
+
+// construct runs the constructor of class to create an instance of
+// newTarget, the class named in the new expression. For a derived class,
+// super(...) calls construct again with the parent class, so the instance
+// is created by the root of the hierarchy but gets newTarget's prototype.
+//
+// Base classes initialize their fields before the constructor body runs.
+// Derived classes initialize them when the body calls super(...), and
+// `this` can't be used before that. A class without a constructor behaves
+// like constructor(...args) { super(...args); }.
+// A constructor may explicitly return a different object to use instead.
+func construct(class *Class, newTarget *Class, args []Value) Value {
+	if class.Constructor == nil {
+		instance := newInstance(class, newTarget, args)
+		initializeInstance(class, instance)
+		return instance
+	}
+
+	var this Value = uninitializedThis
+	if !class.derived() {
+		this = newInstance(class, newTarget, args)
+		initializeInstance(class, this)
+	}
+
+	fnEnv := newFunctionEnvironment(class.Constructor, this, args)
+	fnEnv.Set("super", &superBinding{home: class.Prototype, class: class, newTarget: newTarget})
+
+	// Only an explicit return statement can replace the instance
+	if returnValue, ok := Eval(class.Constructor.Body, fnEnv).(*ReturnValue); ok {
+		if !internal.IsPrimitive(returnValue.Value) {
+			return returnValue.Value
+		}
+		if class.derived() && returnValue.Value != nil {
+			internal.ThrowError("TypeError", "Derived constructors may only return object or undefined")
+		}
+	}
+
+	this, _ = fnEnv.Get("this")
+	if this == uninitializedThis {
+		internal.ThrowError("ReferenceError", "Must call super constructor in derived class before accessing 'this' or returning from derived constructor")
+	}
+	return this
+}
+
+// newInstance creates the object a class constructor starts from: a plain
+// object for base classes, the parent's instance for derived ones, and a
+// builtin's own value (linked to newTarget's prototype) when the class
+// extends one
+//
+// Example: class Cache extends Map {}; new Cache() → a Map whose lookups
+// try Cache.prototype first
+func newInstance(class *Class, newTarget *Class, args []Value) Value {
+	switch {
+	case class.Parent != nil:
+		return construct(class.Parent, newTarget, args)
+	case class.Base != nil:
+		return newBuiltinInstance(class.Base, newTarget, args)
+	case class.extendsNull:
+		name := class.Name
+		if name == "" {
+			name = "anonymous class"
+		}
+		internal.ThrowError("TypeError", "Super constructor null of "+name+" is not a constructor")
+	}
+
+	instance := internal.NewObject()
+	instance.Prototype = newTarget.Prototype
+	return instance
+}
+
+// newBuiltinInstance constructs a builtin for a class that extends it and
+// links the result to the class prototype
+func newBuiltinInstance(base *internal.Builtin, newTarget *Class, args []Value) Value {
+	// Object(value) returns value itself, which must not be relinked
+	if base == builtins.GetObject() {
+		args = nil
+	}
+
+	switch instance := base.New(args...).(type) {
+	case *Object:
+		instance.Prototype = newTarget.Prototype
+		return instance
+	case internal.Subclassable:
+		own := internal.NewObject()
+		own.Prototype = newTarget.Prototype
+		instance.SetSubclassObject(own)
+		return instance
+	}
+
+	internal.ThrowError("TypeError", "Class constructor "+newTarget.Name+" cannot extend "+base.Name)
+	return nil
+}
+
+// initializeInstance installs private methods and evaluates field initializers
+// for one class in the hierarchy
+//
+// Example: class Counter { count = 0; #step = 1; }
+//
+//	new Counter() → instance gets count: 0 and the private #step: 1
+func initializeInstance(class *Class, instance Value) {
+	holder := privateHolder(instance)
+
+	for _, member := range class.instanceMembers {
+		if member.property != nil {
+			holder.DefinePrivate(member.private, member.property)
+		}
+	}
+
+	fieldEnv := environment.New(class.Env)
+	fieldEnv.Set("this", instance)
+	fieldEnv.Set("super", &superBinding{home: class.Prototype})
+
+	for _, member := range class.instanceMembers {
+		if member.property != nil {
+			continue
+		}
+
+		var val Value
+		if member.initializer != nil {
+			val = Eval(member.initializer, fieldEnv)
+		}
+
+		if member.private != nil {
+			holder.DefinePrivate(member.private, &internal.Property{Value: val})
+		} else {
+			holder.Define(member.key, &internal.Property{Value: val})
+		}
+	}
+}
+
+// evalSuperCall evaluates super(args) inside a derived class constructor
+// It creates the instance through the parent constructor, binds it as
+// `this`, then initializes the fields declared by the derived class.
+//
+// Examples:
+//
+//	super(name)          → this = the parent's instance
+//	super(); super();    → ReferenceError: Super constructor may only be called once
+//	super() in a base class constructor → SyntaxError: 'super' keyword unexpected here
+func evalSuperCall(node *ast.CallExpression, env *environment.Environment) Value {
+	binding := lookupSuperBinding(env)
+	if binding == nil || binding.class == nil || !binding.class.derived() {
+		throwError("SyntaxError", "'super' keyword unexpected here", node.Pos, env)
+	}
+
+	if this, _ := env.Get("this"); this != uninitializedThis {
+		throwError("ReferenceError", "Super constructor may only be called once", node.Pos, env)
+	}
+
+	args := evalArguments(node.Arguments, env)
+	instance := newInstance(binding.class, binding.newTarget, args)
+	env.Update("this", instance)
+	initializeInstance(binding.class, instance)
+
+	return nil
+}
+
+// evalSuperProperty evaluates super.name
+// The lookup starts above the object holding the current method, but getters
+// and methods still see the current `this`. Past the prototypes of classes
+// that extend a builtin, it finds the builtin's own members.
+//
+// Examples:
+//
+//	in Dog.prototype.speak, super.speak finds Animal.prototype.speak
+//	in Cache.prototype.get (Cache extends Map), super.get finds Map's get
+func evalSuperProperty(name string, pos token.Position, env *environment.Environment) Value {
+	binding := lookupSuperBinding(env)
+	if binding == nil || binding.home == nil {
+		throwError("SyntaxError", "'super' keyword unexpected here", pos, env)
+	}
+
+	this := evalThisExpression(pos, env)
+	if binding.home.Prototype != nil {
+		if prop, ok := binding.home.Prototype.Lookup(name); ok {
+			return prop.Read(this)
+		}
+	}
+	if _, ok := this.(internal.Subclassable); ok {
+		return builtinProperty(this, name)
+	}
+	return nil
+}
+
+// evalSuperAssignment evaluates super.name = val
+// A setter found above the object holding the current method runs with the
+// current `this`; otherwise the value becomes an own property of `this`,
+// as if the method had assigned this.name, except that `this`'s own class
+// can't intercept it with a setter.
+//
+// Example:
+//
+//	class A { set size(v) { this.n = v; } }
+//	class B extends A { set size(v) { super.size = v * 2; } }
+//	new B().size = 2  → A's setter runs, n is 4
+func evalSuperAssignment(key internal.PropertyKey, val Value, pos token.Position, env *environment.Environment) {
+	binding := lookupSuperBinding(env)
+	if binding == nil || binding.home == nil {
+		throwError("SyntaxError", "'super' keyword unexpected here", pos, env)
+	}
+
+	this := evalThisExpression(pos, env)
+	// Like any assignment, one that isn't allowed throws in strict mode
+	fail := func(prop *internal.Property) {
+		if env.Strict() {
+			throwError("TypeError", internal.AssignError(this, key, prop), pos, env)
+		}
+	}
+
+	if binding.home.Prototype != nil {
+		if prop, ok := binding.home.Prototype.Lookup(key); ok {
+			if prop.IsAccessor() {
+				if !prop.Write(this, val) {
+					fail(prop)
+				}
+				return
+			}
+			if prop.NonWritable {
+				fail(prop)
+				return
+			}
+		}
+	}
+
+	holder := privateHolder(this)
+	if holder == nil {
+		assignProperty(this, key, val, pos, env)
+		return
+	}
+	if prop, ok := holder.GetOwnProperty(key); ok {
+		if prop.IsAccessor() || prop.NonWritable {
+			fail(prop)
+			return
+		}
+		prop.Value = val
+		return
+	}
+	if !holder.IsExtensible() {
+		fail(nil)
+		return
+	}
+	holder.Define(key, &internal.Property{Value: val})
+}
+
+func lookupSuperBinding(env *environment.Environment) *superBinding {
+	val, ok := env.Get("super")
+	if !ok {
+		return nil
+	}
+	binding, _ := val.(*superBinding)
+	return binding
+}
+
+// isPrivateName reports whether a property name refers to a #private member
+func isPrivateName(name string) bool {
+	return strings.HasPrefix(name, "#")
+}
+
+// lookupPrivateName resolves #name to the PrivateName declared by the
+// enclosing class body
+func lookupPrivateName(name string, env *environment.Environment) *internal.PrivateName {
+	val, ok := env.Get(name)
+	if !ok {
+		return nil
+	}
+	privateName, _ := val.(*internal.PrivateName)
+	return privateName
+}
+
+// privateHolder returns the object that stores private members and fields
+// for a value: instances hold their own, classes keep static ones on their
+// Statics object, and instances of builtin subclasses their SubclassObject
+func privateHolder(object Value) *Object {
+	switch obj := object.(type) {
+	case *Object:
+		return obj
+	case *Class:
+		return obj.Statics
+	case internal.Subclassable:
+		return obj.SubclassObject()
+	}
+	return nil
+}
+
+// privateMember finds the #name member of an object, throwing a TypeError
+// for objects whose class didn't declare it (the brand check)
+//
+// Example: class A { #x = 1; static read(o) { return o.#x; } }
+//
+//	A.read({}) → TypeError: Cannot read private member #x from an object whose class did not declare it
+func privateMember(object Value, name string, access string, pos token.Position, env *environment.Environment) *internal.Property {
+	privateName := lookupPrivateName(name, env)
+	if privateName == nil {
+		throwError("SyntaxError", "Private field '"+name+"' must be declared in an enclosing class", pos, env)
+	}
+
+	if holder := privateHolder(object); holder != nil {
+		if prop, ok := holder.GetPrivate(privateName); ok {
+			return prop
+		}
+	}
+
+	preposition := "from"
+	if access == "write" {
+		preposition = "to"
+	}
+	throwError("TypeError", "Cannot "+access+" private member "+name+" "+preposition+" an object whose class did not declare it", pos, env)
+	return nil
+}
+
+// getPrivateMember evaluates object.#name
+func getPrivateMember(object Value, name string, pos token.Position, env *environment.Environment) Value {
+	return privateMember(object, name, "read", pos, env).Read(object)
+}
+
+// setPrivateMember evaluates object.#name = val
+func setPrivateMember(object Value, name string, val Value, pos token.Position, env *environment.Environment) {
+	privateMember(object, name, "write", pos, env).Write(object, val)
+}
@@ -0,0 +1,479 @@
+package evaluator
+
+import (
+	"go-script/internal"
+	"testing"
+)
+
+func TestClassConstructorAndMethods(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`class Point {
+			constructor(x, y) { this.x = x; this.y = y; }
+			sum() { return this.x + this.y; }
+		}
+		new Point(3, 4).sum();`, 7.0},
+		{`class Counter {
+			count = 0;
+			increment() { this.count = this.count + 1; return this; }
+		}
+		var c = new Counter();
+		c.increment().increment();
+		c.count;`, 2.0},
+		{`class Empty {}
+		var e = new Empty();
+		e.x = 5;
+		e.x;`, 5.0},
+		{`class Box {
+			constructor(v) { this.value = v; }
+		}
+		var make = function(v) { return new Box(v); };
+		make("boxed").value;`, "boxed"},
+		{`var Anonymous = class { greet() { return "hi"; } };
+		new Anonymous().greet();`, "hi"},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestClassInheritance(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`class Animal {
+			constructor(name) { this.name = name; }
+			speak() { return this.name + " makes a sound"; }
+		}
+		class Dog extends Animal {
+			speak() { return super.speak() + " (woof)"; }
+		}
+		new Dog("Rex").speak();`, "Rex makes a sound (woof)"},
+		{`class A { constructor() { this.a = 1; } }
+		class B extends A { constructor() { super(); this.b = this.a + 1; } }
+		new B().b;`, 2.0},
+		{`class A { base = "a"; }
+		class B extends A { derived = this.base + "b"; }
+		new B().derived;`, "ab"},
+		{`class A { who() { return "A"; } }
+		class B extends A {}
+		class C extends B { who() { return super.who() + "C"; } }
+		new C().who();`, "AC"},
+		{`class Shape {
+			constructor(name) { this.name = name; }
+			describe() { return this.name + " with area " + this.area(); }
+		}
+		class Square extends Shape {
+			constructor(side) { super("square"); this.side = side; }
+			area() { return this.side * this.side; }
+		}
+		new Square(3).describe();`, "square with area 9"},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestClassStaticMembers(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`class MathUtil { static double(x) { return x * 2; } }
+		MathUtil.double(21);`, 42.0},
+		{`class Config { static version = 3; }
+		Config.version;`, 3.0},
+		{`class Registry {
+			static items = 0;
+			static { this.items = this.items + 10; }
+		}
+		Registry.items;`, 10.0},
+		{`class Base { static create() { return new this(); } kind() { return "base"; } }
+		class Derived extends Base { kind() { return "derived"; } }
+		Derived.create().kind();`, "derived"},
+		{`class Base { static label() { return "base"; } }
+		class Derived extends Base { static label() { return super.label() + "+derived"; } }
+		Derived.label();`, "base+derived"},
+		// Static members are the class's own properties to Object's reflection
+		{`class A { static s = 1; static m() {} }
+		Object.getOwnPropertyNames(A).join(",");`, "prototype,name,m,s"},
+		{`class A { static s = 1; } Object.getOwnPropertyDescriptor(A, "s").value;`, 1.0},
+		{`class A { static m() {} } Object.getOwnPropertyDescriptor(A, "m").enumerable;`, false},
+		{`class A { static s = 1; static m() {} } Object.keys(A).join(",");`, "s"},
+		{`class A { static s = 1; } class B extends A {} Object.keys(B).length;`, 0.0},
+		{`class A { static s = 1; } Object.hasOwn(A, "s");`, true},
+		{`class A {} Object.defineProperty(A, "d", { value: 5 }); A.d;`, 5.0},
+		{`class A { static s = 1; } Object.assign({}, A).s;`, 1.0},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestClassAccessors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`class Temp {
+			constructor() { this.celsius = 100; }
+			get fahrenheit() { return this.celsius * 9 / 5 + 32; }
+			set fahrenheit(f) { this.celsius = (f - 32) * 5 / 9; }
+		}
+		var t = new Temp();
+		t.fahrenheit;`, 212.0},
+		{`class Temp {
+			constructor() { this.celsius = 0; }
+			get fahrenheit() { return this.celsius * 9 / 5 + 32; }
+			set fahrenheit(f) { this.celsius = (f - 32) * 5 / 9; }
+		}
+		var t = new Temp();
+		t.fahrenheit = 50;
+		t.celsius;`, 10.0},
+		{`class Base { get name() { return "base"; } }
+		class Derived extends Base { get name() { return "derived of " + super.name; } }
+		new Derived().name;`, "derived of base"},
+		{`class Settings {
+			static #level = 1;
+			static get level() { return this.#level; }
+			static set level(v) { this.#level = v; }
+		}
+		Settings.level = 5;
+		Settings.level;`, 5.0},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestClassPrivateMembers(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`class Account {
+			#balance = 0;
+			deposit(x) { this.#balance = this.#balance + x; return this.#balance; }
+		}
+		var a = new Account();
+		a.deposit(10);
+		a.deposit(5);`, 15.0},
+		{`class Account { #balance = 100; }
+		new Account().balance;`, nil},
+		{`class Secret {
+			#value = 7;
+			#double() { return this.#value * 2; }
+			reveal() { return this.#double(); }
+		}
+		new Secret().reveal();`, 14.0},
+		{`class A { #x = "a"; getA() { return this.#x; } }
+		class B extends A { #x = "b"; getB() { return this.#x; } }
+		var b = new B();
+		b.getA() + b.getB();`, "ab"},
+		{`class Id {
+			static #next = 1;
+			static take() { var id = Id.#next; Id.#next = id + 1; return id; }
+		}
+		Id.take();
+		Id.take();`, 2.0},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestClassPrivateBrandCheck(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`class A { #x = 1; static read(o) { return o.#x; } }
+		try { A.read({}); } catch (e) { e.name + ": " + e.message; }`, "TypeError: Cannot read private member #x from an object whose class did not declare it"},
+		{`class A { #x = 1; static write(o) { o.#x = 5; } }
+		try { A.write({}); } catch (e) { e.name + ": " + e.message; }`, "TypeError: Cannot write private member #x to an object whose class did not declare it"},
+		{`class A { #x = 1; static read(o) { return o.#x; } }
+		class B { #x = 2; }
+		try { A.read(new B()); } catch (e) { e.name; }`, "TypeError"},
+		{`class A { #m() { return 1; } static call(o) { return o.#m(); } }
+		try { A.call(5); } catch (e) { e.name; }`, "TypeError"},
+		{`class A { #x = 1; static read(o) { return o.#x; } }
+		A.read(new A());`, 1.0},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestPrivateNameEarlyErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		// Checked when the class is defined, even if no method ever runs
+		{`try { class A { #x = 1; #x = 2; } } catch (e) { e.name + ": " + e.message; }`, "SyntaxError: Identifier '#x' has already been declared"},
+		{`try { class A { #x; #x() {} } } catch (e) { e.name + ": " + e.message; }`, "SyntaxError: Identifier '#x' has already been declared"},
+		{`try { class A { get #x() {} get #x() {} } } catch (e) { e.name; }`, "SyntaxError"},
+		{`try { class A { static get #x() {} set #x(v) {} } } catch (e) { e.name; }`, "SyntaxError"},
+		{`try { class A { m() { return this.#y; } } } catch (e) { e.name + ": " + e.message; }`, "SyntaxError: Private field '#y' must be declared in an enclosing class"},
+		{`try { class A { #x; m() { return function() { return this.#z; }; } } } catch (e) { e.name; }`, "SyntaxError"},
+		{`try { class A { #x; m() { return class { n() { return this.#y; } }; } } } catch (e) { e.name; }`, "SyntaxError"},
+		// A getter and setter pair, and names of enclosing classes, are fine
+		{`class A { #v = 1; get #x() { return this.#v; } set #x(v) { this.#v = v; } m() { this.#x = 5; return this.#x; } }
+		new A().m();`, 5.0},
+		{`class A {
+			#x = "outer";
+			m() { var self = this; class B { read() { return self.#x; } } return new B().read(); }
+		}
+		new A().m();`, "outer"},
+		{`class A { m(o) { return o.#later; } #later = 3; } new A().m(new A());`, 3.0},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestDerivedConstructors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`class A {}
+		class B extends A { constructor() { this.y = 2; super(); } }
+		try { new B(); } catch (e) { e.name + ": " + e.message; }`, "ReferenceError: Must call super constructor in derived class before accessing 'this' or returning from derived constructor"},
+		{`class A {}
+		class B extends A { constructor() {} }
+		try { new B(); } catch (e) { e.name; }`, "ReferenceError"},
+		{`class A {}
+		class B extends A { constructor() { super(); super(); } }
+		try { new B(); } catch (e) { e.name + ": " + e.message; }`, "ReferenceError: Super constructor may only be called once"},
+		{`class A { constructor() { super(); } }
+		try { new A(); } catch (e) { e.name + ": " + e.message; }`, "SyntaxError: 'super' keyword unexpected here"},
+		{`class A {}
+		class B extends A { constructor() { return 1; } }
+		try { new B(); } catch (e) { e.name; }`, "TypeError"},
+		{`class A {}
+		class B extends A { constructor() { return { replaced: true }; } }
+		new B().replaced;`, true},
+		{`class A { constructor(x) { this.x = x; } }
+		class B extends A { constructor() { var init = () => super(5); init(); this.y = this.x + 1; } }
+		new B().y;`, 6.0},
+		{`class A { constructor() { this.base = true; } }
+		class B extends A { field = 1; }
+		var b = new B();
+		b.base && b.field == 1 && b instanceof B && b instanceof A;`, true},
+		{`class N extends null {}
+		try { new N(); } catch (e) { e.name + ": " + e.message; }`, "TypeError: Super constructor null of N is not a constructor"},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+
+	// The error points at the this (or super) used too early
+	locations := []struct {
+		input    string
+		expected string
+	}{
+		{"class A {}\nclass B extends A {\n  constructor() { this.y = 2; super(); }\n}\nnew B();", "3:19"},
+		{"class A { m() {} }\nclass B extends A {\n  constructor() { super.m(); super(); }\n}\nnew B();", "3:19"},
+	}
+	for _, tt := range locations {
+		exception, ok := testEval(tt.input).(*internal.Exception)
+		if !ok || exception.Location != tt.expected {
+			t.Errorf("For input %q: expected a ReferenceError at %s, got %v", tt.input, tt.expected, exception)
+		}
+	}
+}
+
+func TestExtendingBuiltins(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`class Cache extends Map {
+			hits = 0;
+			get(k) { this.hits = this.hits + 1; return super.get(k); }
+		}
+		var c = new Cache([["a", 1]]);
+		c.get("a") + c.get("a") + c.hits + c.size;`, 5.0},
+		{`class Cache extends Map {}
+		var c = new Cache();
+		[c instanceof Cache, c instanceof Map, new Map() instanceof Cache].join();`, "true,true,false"},
+		{`class Stack extends Array { peek() { return this[this.length - 1]; } }
+		var s = new Stack();
+		s.push(1, 2);
+		s.peek() + s.length;`, 4.0},
+		{`class Tags extends Set { #count = 0; tag(v) { this.#count = this.#count + 1; return this.add(v); } n() { return this.#count; } }
+		var t = new Tags();
+		t.tag("a").tag("a");
+		t.n() + t.size;`, 3.0},
+		{`class HttpError extends Error {
+			constructor(status, message) { super(message); this.status = status; }
+		}
+		var e = new HttpError(404, "Not Found");
+		[e.status, e.message, e instanceof HttpError, e instanceof Error, e instanceof TypeError].join();`, "404,Not Found,true,true,false"},
+		{`class HttpError extends Error {}
+		new HttpError("x").stack;`, "Error: x\n    at 2:3"},
+		{`var log;
+		class Deferred extends Promise {}
+		new Deferred(function(resolve) { resolve(7); }).then(function(v) { log = v; });
+		Deferred.resolve(1) instanceof Promise;`, true},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestInvalidSuperClass(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`try { class S extends 5 {} } catch (e) { e.name + ": " + e.message; }`, "TypeError: Class extends value 5 is not a constructor or null"},
+		{`try { class S extends undefined {} } catch (e) { e.message; }`, "Class extends value undefined is not a constructor or null"},
+		{`try { class S extends parseInt {} } catch (e) { e.name; }`, "TypeError"},
+		{`var Base = function() {}; try { class S extends Base {} } catch (e) { e.name; }`, "TypeError"},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+
+	exception, ok := testEval("var n = 5;\nclass S extends n {}").(*internal.Exception)
+	if !ok || exception.Location != "2:17" {
+		t.Errorf("Expected the error at the extends clause (2:17), got %v", exception)
+	}
+}
+
+func TestSuperAssignment(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		// Without an inherited setter the value lands on this
+		{`class A {}
+		class B extends A { constructor() { super(); super.x = 1; } }
+		new B().x;`, 1.0},
+		{`class A {}
+		class B extends A { constructor() { super(); super.x = 1; } }
+		A.prototype.x;`, nil},
+		// An inherited setter runs with this, skipping the class's own setter
+		{`class A { set size(v) { this.n = v; } }
+		class B extends A { set size(v) { super.size = v * 2; } }
+		var b = new B(); b.size = 2; b.n;`, 4.0},
+		{`class A {}
+		class B extends A { put(k, v) { super[k] = v; return this; } }
+		new B().put("y", 7).y;`, 7.0},
+		{`class A {}
+		class B extends A { static init() { super.count = 3; } }
+		B.init(); B.count;`, 3.0},
+		{`class A { m() { return super.x = 5; } } new A().m();`, 5.0},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestClassConstructorProperty(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`class A { m() {} } A.prototype.constructor == A;`, true},
+		{`class A { m() {} } Object.keys(A.prototype).length;`, 0.0},
+		{`class A {} Object.getOwnPropertyDescriptor(A.prototype, "constructor").enumerable;`, false},
+		{`class A {} Object.getOwnPropertyDescriptor(A.prototype, "constructor").writable;`, true},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestClassInstanceShape(t *testing.T) {
+	input := `
+		class User {
+			role = "guest";
+			#token = "secret";
+			constructor(name) { this.name = name; }
+			greet() { return "hi"; }
+		}
+		new User("ann");
+	`
+	result := testEval(input)
+
+	obj, ok := result.(*Object)
+	if !ok {
+		t.Fatalf("Expected *Object, got %T", result)
+	}
+
+	// Only fields and constructor assignments are own properties;
+	// methods live on the prototype and private fields are hidden
+	if obj.Len() != 2 {
+		t.Errorf("Expected 2 own properties, got %d (%v)", obj.Len(), obj.Keys())
+	}
+
+	if _, ok := obj.GetOwnProperty("greet"); ok {
+		t.Error("Method 'greet' should live on the prototype, not the instance")
+	}
+
+	if obj.Prototype == nil || !obj.Prototype.Has("greet") {
+		t.Error("Prototype should provide 'greet'")
+	}
+}
+
+func TestCallingClassWithoutNew(t *testing.T) {
+	result := testEval(`class A {} A();`)
+	exception, ok := result.(*internal.Exception)
+	if !ok || !internal.IsError(exception.Value) || internal.ErrorString(exception.Value) != "TypeError: Class constructor A cannot be invoked without 'new'" {
+		t.Errorf("Expected a TypeError when calling a class without new, got %v", result)
+	}
+}
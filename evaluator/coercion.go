@@ -0,0 +1,90 @@
+package evaluator
+
+import (
+	"go-script/internal"
+)
+
+// ToPrimitive converts a function to the string it prints as, since
+// there is no source text to return
+//
+// Example: "" + function() {} → "[Function]"
+func (f *Function) ToPrimitive(hint string) Value {
+	return f.String()
+}
+
+// ToPrimitive converts a class to the string it prints as
+//
+// Example: "" + class A {} → "[class A]"
+func (c *Class) ToPrimitive(hint string) Value {
+	return c.String()
+}
+
+// instanceOf evaluates "value instanceof target"
+// A [Symbol.hasInstance] method on the target decides if present; otherwise
+// the value's prototype chain is searched for the class prototype.
+//
+// Examples:
+//
+//	new Dog() instanceof Animal → true when Dog extends Animal
+//	class Even { static [Symbol.hasInstance](n) { return n % 2 == 0; } }
+//	2 instanceof Even           → true
+func instanceOf(value Value, target Value) bool {
+	if hook, ok := getProperty(target, internal.SymbolHasInstance).(internal.Callable); ok {
+		return isTruthy(hook.Call(target, value))
+	}
+
+	class, ok := target.(*Class)
+	return ok && hasPrototype(value, class.Prototype)
+}
+
+// hasPrototype reports whether proto is on the prototype chain of a value:
+// an object's, or for instances of builtin subclasses, their subclass object's
+func hasPrototype(value Value, proto *Object) bool {
+	var obj *Object
+	switch v := value.(type) {
+	case *Object:
+		obj = v
+	case internal.Subclassable:
+		obj = v.SubclassObject()
+	}
+	if obj == nil {
+		return false
+	}
+
+	for current := obj.Prototype; current != nil; current = current.Prototype {
+		if current == proto {
+			return true
+		}
+	}
+	return false
+}
+
+// typeOf implements the typeof operator
+//
+// Examples:
+//
+//	typeof 1          → "number"
+//	typeof Symbol()   → "symbol"
+//	typeof null       → "object"
+//	typeof undefined  → "undefined"
+func typeOf(val Value) string {
+	switch val.(type) {
+	case internal.Callable, *Class:
+		return "function"
+	}
+
+	switch internal.TypeOf(val) {
+	case internal.TypeUndefined:
+		return "undefined"
+	case internal.TypeBoolean:
+		return "boolean"
+	case internal.TypeNumber:
+		return "number"
+	case internal.TypeString:
+		return "string"
+	case internal.TypeSymbol:
+		return "symbol"
+	default:
+		return "object"
+	}
+}
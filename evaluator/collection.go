@@ -0,0 +1,215 @@
+package evaluator
+
+import (
+	"go-script/evaluator/builtins/array"
+	"go-script/evaluator/builtins/collection"
+	"go-script/internal"
+)
+
+// GetMapProperty returns the methods and properties every Map has
+// Methods are bound to the map, like array methods.
+//
+// Examples:
+//
+//	var m = new Map([["a", 1]]);
+//	m.set("b", 2).get("b")  → 2 (set returns the map, so calls chain)
+//	m.has("a")              → true
+//	m.size                  → 2
+//	[...m.keys()]           → ["a", "b"]
+//	for (const e of m) {}   → e is ["a", 1], then ["b", 2]
+func GetMapProperty(m *collection.Map, key internal.PropertyKey) Value {
+	switch key {
+	case "size":
+		return float64(m.Size())
+	case "get":
+		return arrayMethod("get", func(args []interface{}) Value {
+			value, _ := m.Get(argAt(args, 0))
+			return value
+		})
+	case "set":
+		return arrayMethod("set", func(args []interface{}) Value {
+			m.Set(argAt(args, 0), argAt(args, 1))
+			return m
+		})
+	case "has":
+		return arrayMethod("has", func(args []interface{}) Value {
+			return m.Has(argAt(args, 0))
+		})
+	case "delete":
+		return arrayMethod("delete", func(args []interface{}) Value {
+			return m.Delete(argAt(args, 0))
+		})
+	case "clear":
+		return arrayMethod("clear", func(args []interface{}) Value {
+			m.Clear()
+			return nil
+		})
+	case "forEach":
+		return arrayMethod("forEach", func(args []interface{}) Value {
+			fn := callbackArg(args)
+			next := m.Iterate()
+			for entry, ok := next(); ok; entry, ok = next() {
+				fn.Call(argAt(args, 1), entry.Value, entry.Key, m)
+			}
+			return nil
+		})
+	case "keys":
+		return arrayMethod("keys", func(args []interface{}) Value {
+			return collectionIterator(m.OrderedMap, entryKey)
+		})
+	case "values":
+		return arrayMethod("values", func(args []interface{}) Value {
+			return collectionIterator(m.OrderedMap, entryValue)
+		})
+	case "entries", internal.SymbolIterator:
+		return arrayMethod("entries", func(args []interface{}) Value {
+			return collectionIterator(m.OrderedMap, entryPair)
+		})
+	case internal.SymbolToStringTag:
+		return "Map"
+	default:
+		return nil
+	}
+}
+
+// GetSetProperty returns the methods and properties every Set has
+//
+// Examples:
+//
+//	var s = new Set([1, 2]);
+//	s.add(2).add(3).size  → 3 (2 was already there)
+//	s.has(3)              → true
+//	[...s]                → [1, 2, 3]
+//	s.entries()           → iterates over [1, 1], [2, 2], [3, 3]
+func GetSetProperty(s *collection.Set, key internal.PropertyKey) Value {
+	switch key {
+	case "size":
+		return float64(s.Size())
+	case "add":
+		return arrayMethod("add", func(args []interface{}) Value {
+			s.Add(argAt(args, 0))
+			return s
+		})
+	case "has":
+		return arrayMethod("has", func(args []interface{}) Value {
+			return s.Has(argAt(args, 0))
+		})
+	case "delete":
+		return arrayMethod("delete", func(args []interface{}) Value {
+			return s.Delete(argAt(args, 0))
+		})
+	case "clear":
+		return arrayMethod("clear", func(args []interface{}) Value {
+			s.Clear()
+			return nil
+		})
+	case "forEach":
+		return arrayMethod("forEach", func(args []interface{}) Value {
+			fn := callbackArg(args)
+			next := s.Iterate()
+			for entry, ok := next(); ok; entry, ok = next() {
+				fn.Call(argAt(args, 1), entry.Key, entry.Key, s)
+			}
+			return nil
+		})
+	case "values", "keys", internal.SymbolIterator:
+		return arrayMethod("values", func(args []interface{}) Value {
+			return collectionIterator(s.OrderedMap, entryKey)
+		})
+	case "entries":
+		return arrayMethod("entries", func(args []interface{}) Value {
+			return collectionIterator(s.OrderedMap, entryPair)
+		})
+	case internal.SymbolToStringTag:
+		return "Set"
+	default:
+		return nil
+	}
+}
+
+// GetWeakMapProperty returns the methods every WeakMap has: get, set, has
+// and delete. Keys must be objects.
+//
+// Example:
+//
+//	var cache = new WeakMap();
+//	cache.set(request, response).get(request) → response
+func GetWeakMapProperty(m *collection.WeakMap, key internal.PropertyKey) Value {
+	switch key {
+	case "get":
+		return arrayMethod("get", func(args []interface{}) Value {
+			return m.Get(argAt(args, 0))
+		})
+	case "set":
+		return arrayMethod("set", func(args []interface{}) Value {
+			m.Set(argAt(args, 0), argAt(args, 1))
+			return m
+		})
+	case "has":
+		return arrayMethod("has", func(args []interface{}) Value {
+			return m.Has(argAt(args, 0))
+		})
+	case "delete":
+		return arrayMethod("delete", func(args []interface{}) Value {
+			return m.Delete(argAt(args, 0))
+		})
+	case internal.SymbolToStringTag:
+		return "WeakMap"
+	default:
+		return nil
+	}
+}
+
+// GetWeakSetProperty returns the methods every WeakSet has: add, has and
+// delete. Members must be objects.
+//
+// Example:
+//
+//	var seen = new WeakSet();
+//	seen.add(node).has(node) → true
+func GetWeakSetProperty(s *collection.WeakSet, key internal.PropertyKey) Value {
+	switch key {
+	case "add":
+		return arrayMethod("add", func(args []interface{}) Value {
+			s.Add(argAt(args, 0))
+			return s
+		})
+	case "has":
+		return arrayMethod("has", func(args []interface{}) Value {
+			return s.Has(argAt(args, 0))
+		})
+	case "delete":
+		return arrayMethod("delete", func(args []interface{}) Value {
+			return s.Delete(argAt(args, 0))
+		})
+	case internal.SymbolToStringTag:
+		return "WeakSet"
+	default:
+		return nil
+	}
+}
+
+// collectionIterator returns an iterator over the entries of a Map or Set
+// Entries added while iterating are visited, deleted ones are skipped.
+func collectionIterator(m *collection.OrderedMap, value func(*collection.Entry) Value) Value {
+	next := m.Iterate()
+	return internal.NewIterator(func() (Value, bool) {
+		entry, ok := next()
+		if !ok {
+			return nil, false
+		}
+		return value(entry), true
+	})
+}
+
+func entryKey(entry *collection.Entry) Value {
+	return entry.Key
+}
+
+func entryValue(entry *collection.Entry) Value {
+	return entry.Value
+}
+
+func entryPair(entry *collection.Entry) Value {
+	return array.NewArrayReference(Array{entry.Key, entry.Value})
+}
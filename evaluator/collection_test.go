@@ -0,0 +1,179 @@
+package evaluator
+
+import (
+	"testing"
+)
+
+func TestMap(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`var m = new Map(); m.set("a", 1); m.get("a");`, 1.0},
+		{`var m = new Map(); m.set("a", 1).set("b", 2).size;`, 2.0},
+		{`new Map([["a", 1], ["b", 2]]).get("b");`, 2.0},
+		{`new Map(Object.entries({ x: 1, y: 2 })).size;`, 2.0},
+		{`new Map().get("missing");`, nil},
+		{`var m = new Map([[1, "number"], ["1", "string"]]); m.get(1) + m.get("1");`, "numberstring"},
+		{`var m = new Map(); m.set(NaN, "nan"); m.get(NaN);`, "nan"},
+		{`var m = new Map(); m.set(-0, "zero"); m.get(0);`, "zero"},
+		{`var m = new Map(); m.set(null, 1); m.has(undefined);`, false},
+		{`var m = new Map(); m.set("a", 1); m.set("a", 2); m.size;`, 1.0},
+		{`var m = new Map([["a", 1]]); m.delete("a");`, true},
+		{`var m = new Map([["a", 1]]); m.delete("a"); m.delete("a");`, false},
+		{`var m = new Map([["a", 1], ["b", 2]]); m.clear(); m.size;`, 0.0},
+		{`typeof new Map();`, "object"},
+		{`new Map() instanceof Map;`, true},
+		{`new Set() instanceof Map;`, false},
+		{`try { Map(); } catch (e) { e.name + ": " + e.message; }`, "TypeError: Constructor Map requires 'new'"},
+		{`try { new Map([1]); } catch (e) { e.name + ": " + e.message; }`, "TypeError: Iterator value 1 is not an entry object"},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestMapObjectKeys(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`var a = {}; var m = new Map([[a, "a"]]); m.get(a);`, "a"},
+		{`var m = new Map([[{}, "a"]]); m.get({});`, nil},
+		{`var arr = [1, 2]; var m = new Map(); m.set(arr, "arr"); m.get(arr);`, "arr"},
+		{`var m = new Map(); m.set([1, 2], "arr"); m.has([1, 2]);`, false},
+		{`var f = function() {}; var m = new Map(); m.set(f, "fn"); m.get(f);`, "fn"},
+		{`var f = x => x; var m = new Map(); m.set(f, "arrow"); m.get(f);`, "arrow"},
+		{`class A {} var m = new Map(); m.set(A, "class"); m.get(A);`, "class"},
+		{`var s = Symbol("s"); var m = new Map(); m.set(s, 1); m.get(s);`, 1.0},
+		{`var m = new Map(); m.set(Math.max, "builtin"); m.get(Math.max);`, "builtin"},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestMapIteration(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`var m = new Map([["a", 1], ["b", 2]]); var s = ""; for (let e of m) { s = s + e[0] + e[1]; } s;`, "a1b2"},
+		{`var m = new Map([["a", 1], ["b", 2]]); var s = ""; for (let k of m.keys()) { s = s + k; } s;`, "ab"},
+		{`var m = new Map([["a", 1], ["b", 2]]); var s = 0; for (let v of m.values()) { s = s + v; } s;`, 3.0},
+		{`var m = new Map([["a", 1], ["b", 2]]); m.entries().next().value[1];`, 1.0},
+		{`Array.from(new Map([["a", 1], ["b", 2]])).length;`, 2.0},
+		{`Array.from(new Map([["a", 1], ["b", 2]]).keys()).join();`, "a,b"},
+		{`var m = new Map([["z", 1], ["a", 2]]); m.set("m", 3); Array.from(m.keys()).join("");`, "zam"},
+		{`var s = ""; new Map([["a", 1], ["b", 2]]).forEach((v, k) => { s = s + k + v; }); s;`, "a1b2"},
+		{`var m = new Map([["a", 1]]); var same = false; m.forEach((v, k, map) => { same = map == m; }); same;`, true},
+		{`var m = new Map([["a", 1]]); var n = 0; m.forEach(function () { n = n + this.step; }, { step: 5 }); n;`, 5.0},
+		{`var m = new Map([["a", 1], ["b", 2], ["c", 3]]); var s = ""; m.forEach((v, k) => { s = s + k; if (k == "a") { m.delete("b"); m.set("d", 4); } }); s;`, "acd"},
+		{`Map.groupBy([1, 2, 3, 4], n => n > 2).get(true).join();`, "3,4"},
+		{`var red = {}; var blue = {}; var g = Map.groupBy([{ t: red }, { t: blue }, { t: red }], x => x.t); g.get(red).length;`, 2.0},
+		{`new Map([["a", 1]])[Symbol.toStringTag];`, "Map"},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestSet(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`new Set([1, 2, 2, 3]).size;`, 3.0},
+		{`new Set([NaN, NaN, 0, -0]).size;`, 2.0},
+		{`new Set([1, "1"]).size;`, 2.0},
+		{`new Set("hello").size;`, 4.0},
+		{`new Set().add(1).add(1).add(2).size;`, 2.0},
+		{`new Set([1, 2]).has(2);`, true},
+		{`new Set([1, 2]).has("2");`, false},
+		{`var s = new Set([1, 2]); s.delete(1); s.has(1);`, false},
+		{`var s = new Set([1, 2]); s.clear(); s.size;`, 0.0},
+		{`Array.from(new Set([3, 1, 3, 2, 1])).join();`, "3,1,2"},
+		{`var s = ""; for (let v of new Set(["a", "b", "a"])) { s = s + v; } s;`, "ab"},
+		{`new Set(["a"]).entries().next().value.join();`, "a,a"},
+		{`Array.from(new Set(["a", "b"]).keys()).join();`, "a,b"},
+		{`var s = ""; new Set(["a", "b"]).forEach((v, k) => { s = s + v + k; }); s;`, "aabb"},
+		{`var set = new Set([1, 2, 3]); var s = ""; set.forEach(v => { s = s + v; if (v == 1) { set.delete(2); set.add(4); } }); s;`, "134"},
+		{`new Set(new Set([1, 2])).size;`, 2.0},
+		{`new Set([1]) instanceof Set;`, true},
+		{`try { new Set(5); } catch (e) { e.name + ": " + e.message; }`, "TypeError: 5 is not iterable"},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestSetDedupesRecords(t *testing.T) {
+	input := `
+		var a = { id: 1 };
+		var b = { id: 2 };
+		var records = [a, b, a, a, b];
+		var unique = Array.from(new Set(records));
+		unique.length + ":" + unique[0].id + unique[1].id;
+	`
+	if result := testEval(input); result != "2:12" {
+		t.Errorf("Expected records to be deduplicated by identity, got %v", result)
+	}
+
+	input = `
+		var byId = new Map();
+		[{ id: 1, v: "a" }, { id: 2, v: "b" }, { id: 1, v: "c" }].forEach(r => byId.set(r.id, r));
+		byId.size + ":" + byId.get(1).v;
+	`
+	if result := testEval(input); result != "2:c" {
+		t.Errorf("Expected records to be deduplicated by id, got %v", result)
+	}
+}
+
+func TestWeakCollections(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`var k = {}; var w = new WeakMap(); w.set(k, 1); w.get(k);`, 1.0},
+		{`var k = {}; var w = new WeakMap([[k, "v"]]); w.has(k);`, true},
+		{`var w = new WeakMap(); w.has({});`, false},
+		{`var k = {}; var w = new WeakMap(); w.set(k, 1).set(k, 2); w.get(k);`, 2.0},
+		{`var k = []; var w = new WeakMap(); w.set(k, 1); w.delete(k) + "," + w.has(k);`, "true,false"},
+		{`var w = new WeakMap(); w.get("primitive");`, nil},
+		{`var w = new WeakMap(); w.delete(1);`, false},
+		{`try { new WeakMap().set("key", 1); } catch (e) { e.name + ": " + e.message; }`, "TypeError: Invalid value used as weak map key"},
+		{`try { new WeakMap().set(Symbol("s"), 1); } catch (e) { e.name + ": " + e.message; }`, "TypeError: Invalid value used as weak map key"},
+		{`var k = {}; var w = new WeakSet(); w.add(k).has(k);`, true},
+		{`var f = function () {}; new WeakSet([f]).has(f);`, true},
+		{`var k = {}; var w = new WeakSet([k]); w.delete(k); w.has(k);`, false},
+		{`new WeakSet().has(1);`, false},
+		{`try { new WeakSet().add(1); } catch (e) { e.name + ": " + e.message; }`, "TypeError: Invalid value used in weak set"},
+		{`try { WeakSet(); } catch (e) { e.name + ": " + e.message; }`, "TypeError: Constructor WeakSet requires 'new'"},
+		{`new WeakMap() instanceof WeakMap;`, true},
+		{`new WeakSet() instanceof WeakMap;`, false},
+		{`new WeakMap().keys;`, nil},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package evaluator
+
+import (
+	"fmt"
+	"go-script/ast"
+	"go-script/evaluator/builtins/json"
+	"go-script/internal"
+	"path/filepath"
+)
+
+// CommonJS modules share the module registry with ES modules: a file is
+// loaded once whether it is required or imported, and require() can load
+// ES modules and JSON files too.
+//
+// A CommonJS module runs in its own scope with these bindings:
+//
+//	module      { exports, id, filename, loaded }
+//	exports     the object module.exports starts as
+//	require     loads a module relative to this file
+//	__filename  the module's key, e.g. its absolute path
+//	__dirname   the directory of __filename
+//
+// Example:
+//
+//	// util.js
+//	exports.double = (x) => x * 2;
+//
+//	// main.js
+//	var util = require("./util");
+//	print(util.double(21));  → 42
+
+// isCommonJS decides how a JavaScript module runs, the way Node does when
+// there is no package.json "type": .cjs files are CommonJS, .mjs files are ES
+// modules, and any other file is an ES module only if it has import or
+// export declarations.
+func isCommonJS(key string, program *ast.Program) bool {
+	switch filepath.Ext(key) {
+	case ".cjs":
+		return true
+	case ".mjs":
+		return false
+	}
+
+	for _, statement := range program.Statements {
+		switch statement.(type) {
+		case *ast.ImportDeclaration, *ast.ExportDeclaration, *ast.ExportDefaultDeclaration:
+			return false
+		}
+	}
+	return true
+}
+
+// defineCommonJSScope creates module, exports, __filename and __dirname
+// At the top level of a CommonJS module, this is module.exports too.
+func (m *Module) defineCommonJSScope() {
+	exports := internal.NewObject()
+
+	m.moduleObject = internal.NewObject()
+	m.moduleObject.Set("exports", exports)
+	m.moduleObject.Set("id", m.Key)
+	m.moduleObject.Set("filename", m.Key)
+	m.moduleObject.Set("loaded", false)
+
+	m.env.Set("module", m.moduleObject)
+	m.env.Set("exports", exports)
+	m.env.Set("this", exports)
+	m.env.Set("__filename", m.Key)
+	m.env.Set("__dirname", filepath.Dir(m.Key))
+}
+
+// runCommonJS evaluates a CommonJS module's statements. A top-level return
+// ends the module early, as in Node. Afterwards module.exports becomes the
+// module's default export.
+func (m *Module) runCommonJS() {
+	checkScript(m.program)
+
+	for _, statement := range m.program.Statements {
+		if _, ok := Eval(statement, m.env).(*ReturnValue); ok {
+			break
+		}
+	}
+
+	m.moduleObject.Set("loaded", true)
+	m.env.Set(defaultBinding, m.moduleObject.Get("exports"))
+}
+
+// requireValue is what require() returns for a module: module.exports for
+// CommonJS (possibly incomplete while the module is still running, in a
+// require cycle), the parsed value of a JSON file, or an ES module's
+// namespace object
+func (m *Module) requireValue() Value {
+	switch m.kind {
+	case commonJS:
+		return m.moduleObject.Get("exports")
+	case jsonModule:
+		value, _ := m.env.Get(defaultBinding)
+		return value
+	}
+	return m.Namespace()
+}
+
+// newRequire creates the require function for code in the module referrer
+// ("" for scripts, which resolve relative to the loader's root)
+//
+// Syntax: require(specifier)
+//
+//	require.resolve(specifier) → the key the specifier resolves to
+//
+// Examples:
+//
+//	var config = require("./config.json");
+//	var { helper } = require("../lib/helpers");  → lib/helpers.js or lib/helpers/index.js
+func newRequire(registry *moduleRegistry, referrer string) *internal.Builtin {
+	specifierArg := func(args []interface{}) string {
+		specifier, ok := firstArg(args).(string)
+		if !ok {
+			internal.ThrowError("TypeError", fmt.Sprintf("The \"id\" argument must be of type string, got %s", internal.Inspect(firstArg(args))))
+		}
+		return specifier
+	}
+
+	statics := internal.NewObject()
+	statics.Set("resolve", &internal.Builtin{
+		Name: "resolve",
+		Fn: func(args ...interface{}) interface{} {
+			key, err := registry.loader.Resolve(specifierArg(args), referrer)
+			if err != nil {
+				internal.ThrowError("Error", err.Error())
+			}
+			return key
+		},
+	})
+
+	return &internal.Builtin{
+		Name: "require",
+		Fn: func(args ...interface{}) interface{} {
+			return registry.importModule(specifierArg(args), referrer).requireValue()
+		},
+		Properties: statics,
+	}
+}
+
+// parseJSONModule parses the contents of a required or imported .json file
+func parseJSONModule(key string, source string) Value {
+	value, err := json.Decode(source)
+	if err != nil {
+		internal.ThrowError("SyntaxError", fmt.Sprintf("%s: %v", key, err))
+	}
+	return value
+}
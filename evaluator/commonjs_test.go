@@ -0,0 +1,250 @@
+package evaluator
+
+import (
+	"go-script/environment"
+	"go-script/internal"
+	"go-script/module"
+	"go-script/parser"
+	"testing"
+)
+
+// testRequireResult runs main.js as a CommonJS module and returns
+// module.exports.result
+func testRequireResult(t *testing.T, files module.MapLoader) Value {
+	t.Helper()
+	result := testEvalModule(files)
+	namespace, ok := result.(*internal.Object)
+	if !ok {
+		t.Fatalf("Expected a module namespace, got %v", result)
+	}
+	exports, ok := namespace.Get("default").(*internal.Object)
+	if !ok {
+		t.Fatalf("Expected module.exports to be an object, got %v", namespace.Get("default"))
+	}
+	return exports.Get("result")
+}
+
+func TestRequire(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    module.MapLoader
+		expected Value
+	}{
+		{"exports properties", module.MapLoader{
+			"main.js": `var math = require("./math.js"); exports.result = math.add(2, 3);`,
+			"math.js": `exports.add = (a, b) => a + b;`,
+		}, 5.0},
+		{"module.exports replaced", module.MapLoader{
+			"main.js":  `var greet = require("./greet"); exports.result = greet("Ada");`,
+			"greet.js": `module.exports = function(name) { return "Hello, " + name; };`,
+		}, "Hello, Ada"},
+		{"relative to the requiring file", module.MapLoader{
+			"main.js":     `exports.result = require("./lib/a").value;`,
+			"lib/a.js":    `exports.value = "a" + require("./b").value;`,
+			"lib/b.js":    `exports.value = "b" + require("../shared/c.js").value;`,
+			"shared/c.js": `exports.value = "c";`,
+		}, "abc"},
+		{"directory index", module.MapLoader{
+			"main.js":          `exports.result = require("./helpers").name;`,
+			"helpers/index.js": `exports.name = "index";`,
+		}, "index"},
+		{"filename and dirname", module.MapLoader{
+			"main.js":      `exports.result = require("./lib/where.js");`,
+			"lib/where.js": `module.exports = __filename + " " + __dirname;`,
+		}, "lib/where.js lib"},
+		{"this is module.exports", module.MapLoader{
+			"main.js": `this.result = "via this";`,
+		}, "via this"},
+		{"top-level return", module.MapLoader{
+			"main.js":  `exports.result = require("./early.js").value;`,
+			"early.js": `exports.value = "before"; return 0; exports.value = "after";`,
+		}, "before"},
+		{"cjs extension", module.MapLoader{
+			"main.js": `exports.result = require("./old.cjs").kind;`,
+			"old.cjs": `exports.kind = "commonjs";`,
+		}, "commonjs"},
+	}
+
+	for _, tt := range tests {
+		result := testRequireResult(t, tt.files)
+		if result != tt.expected {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, result)
+		}
+	}
+}
+
+func TestRequireCache(t *testing.T) {
+	files := module.MapLoader{
+		"main.js": `var a = require("./counter.js");
+			var b = require("./counter");
+			require("./other.js");
+			exports.result = (a == b) + " " + a.runs;`,
+		"other.js":   `require("./counter.js");`,
+		"counter.js": `var state = require("./state.js"); state.runs = state.runs + 1; module.exports = state;`,
+		"state.js":   `module.exports = { runs: 0 };`,
+	}
+
+	// Every require of the same file returns the same, once-evaluated exports
+	if result := testRequireResult(t, files); result != "true 1" {
+		t.Errorf("Expected \"true 1\", got %v", result)
+	}
+}
+
+func TestRequireCycles(t *testing.T) {
+	// The example from the Node documentation: b.js requires a.js while a.js
+	// is still running, and gets its incomplete exports
+	files := module.MapLoader{
+		"main.js": `var a = require("./a.js");
+			var b = require("./b.js");
+			exports.result = a.done + " " + b.done + " " + b.sawA;`,
+		"a.js": `exports.done = false;
+			var b = require("./b.js");
+			exports.done = true;`,
+		"b.js": `exports.done = false;
+			var a = require("./a.js");
+			exports.sawA = a.done;
+			exports.done = true;`,
+	}
+
+	if result := testRequireResult(t, files); result != "true true false" {
+		t.Errorf("Expected \"true true false\", got %v", result)
+	}
+}
+
+func TestRequireLoaded(t *testing.T) {
+	files := module.MapLoader{
+		"main.js": `var during = module.loaded;
+			var lib = require("./lib.js");
+			exports.result = during + " " + lib.loaded + " " + require("./lib.js").module.loaded;`,
+		"lib.js": `exports.loaded = module.loaded; exports.module = module;`,
+	}
+
+	if result := testRequireResult(t, files); result != "false false true" {
+		t.Errorf("Expected \"false false true\", got %v", result)
+	}
+}
+
+func TestRequireJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    module.MapLoader
+		expected Value
+	}{
+		{"object", module.MapLoader{
+			"main.js":     `var config = require("./config.json"); exports.result = config.name + " " + config.port;`,
+			"config.json": `{"name": "app", "port": 8080}`,
+		}, "app 8080"},
+		{"without extension", module.MapLoader{
+			"main.js":   `exports.result = require("./data").count;`,
+			"data.json": `{"count": 3}`,
+		}, 3.0},
+		{"primitive", module.MapLoader{
+			"main.js":      `exports.result = require("./version.json");`,
+			"version.json": `"1.2.3"`,
+		}, "1.2.3"},
+		{"cached", module.MapLoader{
+			"main.js":     `var a = require("./config.json"); a.changed = true; exports.result = require("./config.json").changed;`,
+			"config.json": `{}`,
+		}, true},
+		{"imported", module.MapLoader{
+			"main.js":     `exports.result = require("./esm.js").name;`,
+			"esm.js":      `import config from "./config.json"; export var name = config.name;`,
+			"config.json": `{"name": "from json"}`,
+		}, "from json"},
+	}
+
+	for _, tt := range tests {
+		result := testRequireResult(t, tt.files)
+		if result != tt.expected {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, result)
+		}
+	}
+}
+
+func TestCommonJSInterop(t *testing.T) {
+	// require() of an ES module returns its namespace
+	required := module.MapLoader{
+		"main.js": `var esm = require("./esm.js"); exports.result = esm.named + " " + esm.default;`,
+		"esm.js":  `export var named = "named"; export default "default";`,
+	}
+	if result := testRequireResult(t, required); result != "named default" {
+		t.Errorf("Expected require of an ES module to return its namespace, got %v", result)
+	}
+
+	// import of a CommonJS module gets module.exports as the default export
+	imported := module.MapLoader{
+		"main.js": `import util from "./util.js"; import * as ns from "./util.js";
+			var dynamic = await import("./util.js");
+			export var result = util.twice(2) + " " + (ns.default == util) + " " + (dynamic.default == util);`,
+		"util.js": `module.exports = { twice: (x) => x * 2 };`,
+	}
+	if result := testModuleResult(t, imported); result != "4 true true" {
+		t.Errorf("Expected importing CommonJS to give module.exports, got %v", result)
+	}
+}
+
+func TestRequireResolve(t *testing.T) {
+	files := module.MapLoader{
+		"main.js":    `exports.result = require("./lib/a.js").where;`,
+		"lib/a.js":   `exports.where = require.resolve("./b") + " " + require.resolve("../main.js");`,
+		"lib/b.json": `{}`,
+	}
+
+	if result := testRequireResult(t, files); result != "lib/b.json main.js" {
+		t.Errorf("Expected resolved keys, got %v", result)
+	}
+}
+
+func TestRequireFromScript(t *testing.T) {
+	env := environment.NewGlobalEnvironment()
+	SetModuleLoader(env, module.MapLoader{"lib/util.js": `exports.value = 7;`})
+
+	program := parser.New(`var log = require("./lib/util").value;`).ParseProgram()
+	Eval(program, env)
+
+	if log, _ := env.Get("log"); log != 7.0 {
+		t.Errorf("Expected scripts to require modules, got %v", log)
+	}
+}
+
+func TestCommonJSErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    module.MapLoader
+		expected Value
+	}{
+		{"missing module", module.MapLoader{
+			"main.js": `require("./missing");`,
+		}, "Error: cannot find module './missing' imported from main.js"},
+		{"invalid JSON", module.MapLoader{
+			"main.js":     `require("./config.json");`,
+			"config.json": `{"name": }`,
+		}, "SyntaxError: config.json: Unexpected token } in JSON at position 9"},
+		{"non-string specifier", module.MapLoader{
+			"main.js": `require(42);`,
+		}, `TypeError: The "id" argument must be of type string, got 42`},
+		{"import in a .cjs file", module.MapLoader{
+			"main.js": `require("./bad.cjs");`,
+			"bad.cjs": `import x from "./x.js";`,
+		}, "SyntaxError: Cannot use import statement outside a module"},
+		{"named import from CommonJS", module.MapLoader{
+			"main.js": `import { twice } from "./util.js";`,
+			"util.js": `exports.twice = (x) => x * 2;`,
+		}, "SyntaxError: Named export 'twice' not found. The requested module './util.js' only provides a default export"},
+		{"exception is catchable", module.MapLoader{
+			"main.js": `try { require("./boom.js"); } catch (e) { throw "caught " + e; }`,
+			"boom.js": `throw "boom";`,
+		}, "caught boom"},
+	}
+
+	for _, tt := range tests {
+		exception, ok := testEvalModule(tt.files).(*internal.Exception)
+		if !ok {
+			t.Errorf("%s: expected an exception", tt.name)
+			continue
+		}
+		if thrown(exception) != tt.expected {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, exception)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package evaluator
+
+import (
+	"go-script/environment"
+	"go-script/evaluator/builtins/console"
+)
+
+// SetConsole makes console in env write to c, to capture its output or
+// change the minimum level
+//
+// Example:
+//
+//	env := environment.NewGlobalEnvironment()
+//	var out bytes.Buffer
+//	c := console.New(&out, &out)
+//	c.Level = console.LevelWarn
+//	evaluator.SetConsole(env, c)
+//	→ console.log is dropped and console.warn writes to out
+func SetConsole(env *environment.Environment, c *console.Console) {
+	env.Set("console", c.Object())
+}
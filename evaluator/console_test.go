@@ -0,0 +1,56 @@
+package evaluator
+
+import (
+	"bytes"
+	"go-script/environment"
+	"go-script/evaluator/builtins/console"
+	"go-script/parser"
+	"testing"
+)
+
+// testConsole runs input with console writing to buffers, dropping messages
+// below level
+func testConsole(input string, level console.Level) (string, string) {
+	var stdout, stderr bytes.Buffer
+	c := console.New(&stdout, &stderr)
+	c.Level = level
+
+	env := environment.NewGlobalEnvironment()
+	SetConsole(env, c)
+	Eval(parser.New(input).ParseProgram(), env)
+	return stdout.String(), stderr.String()
+}
+
+func TestConsole(t *testing.T) {
+	tests := []struct {
+		input          string
+		stdout, stderr string
+	}{
+		{`console.log("x =", 42, [1, 2], { a: 1 });`, "x = 42 [1, 2] {a: 1}\n", ""},
+		{`console.log();`, "\n", ""},
+		{`var v; console.log(v, null);`, "undefined null\n", ""},
+		{`console.info("%s scored %i points", "Ann", 9.5);`, "Ann scored 9 points\n", ""},
+		{`console.warn("careful"); console.error("failed", 1);`, "", "careful\nfailed 1\n"},
+		{`console.assert(1 == 1, "fine"); console.assert(1 == 2, "math");`, "", "Assertion failed: math\n"},
+		{`[1, 2, 3].forEach(() => console.count("loop"));`, "loop: 1\nloop: 2\nloop: 3\n", ""},
+		{`console.group("a"); console.log("b"); console.groupEnd(); console.log("c");`, "a\n  b\nc\n", ""},
+		{`console.table([{ id: 1 }, { id: 2 }]);`, "┌─────────┬────┐\n│ (index) │ id │\n├─────────┼────┤\n│ 0       │ 1  │\n│ 1       │ 2  │\n└─────────┴────┘\n", ""},
+		{`console.timeEnd("nope");`, "", "Warning: No such label 'nope' for console.timeEnd()\n"},
+		{`typeof console.log;`, "", ""},
+	}
+
+	for _, tt := range tests {
+		stdout, stderr := testConsole(tt.input, console.LevelDebug)
+		if stdout != tt.stdout || stderr != tt.stderr {
+			t.Errorf("For input %q: expected %q and %q, got %q and %q", tt.input, tt.stdout, tt.stderr, stdout, stderr)
+		}
+	}
+}
+
+func TestConsoleLevel(t *testing.T) {
+	input := `console.debug("d"); console.log("l"); console.warn("w"); console.error("e");`
+	stdout, stderr := testConsole(input, console.LevelWarn)
+	if stdout != "" || stderr != "w\ne\n" {
+		t.Errorf("Expected only warnings and errors, got %q and %q", stdout, stderr)
+	}
+}
@@ -0,0 +1,46 @@
+package evaluator
+
+import (
+	"go-script/environment"
+	"go-script/evaluator/builtins/date"
+	"go-script/internal"
+	"time"
+)
+
+// GetDateProperty returns the methods every Date has
+// Methods are bound to the date, like array methods.
+//
+// Examples:
+//
+//	new Date(0).getUTCFullYear()         → 1970
+//	new Date(0).toISOString()            → "1970-01-01T00:00:00.000Z"
+//	var d = new Date(0); d.setUTCDate(32) → 2678400000 (February 1st)
+func GetDateProperty(d *date.Date, key internal.PropertyKey) Value {
+	name, ok := key.(string)
+	if !ok {
+		return nil
+	}
+	method, ok := date.Methods[name]
+	if !ok {
+		return nil
+	}
+	return &internal.Builtin{
+		Name: name,
+		Fn: func(args ...interface{}) interface{} {
+			return method(d, args)
+		},
+	}
+}
+
+// SetClock makes Date in env read the current time from clock and use loc
+// as the local time zone, so that runs are reproducible
+//
+// Example:
+//
+//	env := environment.NewGlobalEnvironment()
+//	loc, _ := time.LoadLocation("America/New_York")
+//	evaluator.SetClock(env, date.FixedClock(time.Unix(0, 0)), loc)
+//	→ Date.now() is 0 and new Date().getHours() is 19
+func SetClock(env *environment.Environment, clock date.Clock, loc *time.Location) {
+	env.Set("Date", date.NewConstructor(clock, loc))
+}
@@ -0,0 +1,138 @@
+package evaluator
+
+import (
+	"go-script/environment"
+	"go-script/evaluator/builtins/date"
+	"go-script/internal"
+	"go-script/parser"
+	"testing"
+	"time"
+)
+
+// testEvalAt runs a script whose clock is stopped at 2024-03-01T12:00:00Z
+// and whose local time zone is loc
+func testEvalAt(input string, loc *time.Location) Value {
+	env := environment.NewGlobalEnvironment()
+	SetClock(env, date.FixedClock(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)), loc)
+	return Eval(parser.New(input).ParseProgram(), env)
+}
+
+func TestDate(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`Date.now();`, 1709294400000.0},
+		{`new Date().getTime();`, 1709294400000.0},
+		{`new Date().toISOString();`, "2024-03-01T12:00:00.000Z"},
+		{`Date();`, "Fri Mar 01 2024 12:00:00 GMT+0000 (UTC)"},
+		{`new Date(0).toISOString();`, "1970-01-01T00:00:00.000Z"},
+		{`new Date("2024-03-01").valueOf();`, 1709251200000.0},
+		{`Date.parse("2024-03-01T12:00:00Z");`, 1709294400000.0},
+		{`Date.UTC(2024, 1, 29);`, 1709164800000.0},
+		{`new Date(2024, 0, 31).getDate();`, 31.0},
+		{`new Date(2024, 0, 32).getMonth();`, 1.0},
+		{`new Date(new Date(5)).getTime();`, 5.0},
+		{`new Date() instanceof Date;`, true},
+		{`({}) instanceof Date;`, false},
+		{`typeof new Date();`, "object"},
+		{`typeof Date.now();`, "number"},
+		{`var d = new Date(); d == d;`, true},
+		{`new Date(0) == new Date(0);`, false},
+	}
+
+	for _, tt := range tests {
+		result := testEvalAt(tt.input, time.UTC)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestDateArithmetic(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`new Date(5000) - new Date(1000);`, 4000.0},
+		{`new Date(1000) < new Date(5000);`, true},
+		{`new Date(42) - 0;`, 42.0},
+		{`new Date(0) * 1;`, 0.0},
+		{`"" + new Date(0);`, "Thu Jan 01 1970 00:00:00 GMT+0000 (UTC)"},
+		{`"" + new Date("nope");`, "Invalid Date"},
+		{`var d = new Date(); d.setUTCDate(d.getUTCDate() + 30); d.toISOString();`, "2024-03-31T12:00:00.000Z"},
+		{`var d = new Date(); d.setUTCMonth(d.getUTCMonth() + 11); d.toISOString();`, "2025-02-01T12:00:00.000Z"},
+		{`var d = new Date(); d.setUTCHours(36, 30); d.toISOString();`, "2024-03-02T12:30:00.000Z"},
+		{`var d = new Date(); d.setTime(d.getTime() + 1000); d.getUTCSeconds();`, 1.0},
+		{`var d = new Date(); d.setUTCFullYear(2023); d.getUTCDay();`, 3.0},
+		{`var start = new Date(2024, 0, 1); var end = new Date(2024, 11, 31); (end - start) / 86400000;`, 365.0},
+	}
+
+	for _, tt := range tests {
+		result := testEvalAt(tt.input, time.UTC)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestDateTimeZones(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("Time zone America/New_York is not available: %v", err)
+	}
+
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`new Date().getHours();`, 7.0},
+		{`new Date().getUTCHours();`, 12.0},
+		{`new Date().getTimezoneOffset();`, 300.0},
+		{`new Date(2024, 6, 1).getTimezoneOffset();`, 240.0},
+		{`new Date().toString();`, "Fri Mar 01 2024 07:00:00 GMT-0500 (EST)"},
+		{`new Date(2024, 2, 1, 7).toISOString();`, "2024-03-01T12:00:00.000Z"},
+		{`new Date("2024-03-01T07:00").getTime();`, 1709294400000.0},
+		{`new Date().toLocaleDateString();`, "3/1/2024"},
+		{`new Date().toLocaleTimeString();`, "7:00:00 AM"},
+		{`new Date().toLocaleString("en-US", { timeZone: "Asia/Tokyo" });`, "3/1/2024, 9:00:00 PM"},
+		{`new Date().toLocaleDateString("en-US", { timeZone: "Pacific/Kiritimati" });`, "3/2/2024"},
+		{`var d = new Date(); d.setDate(d.getDate() + 10); d.getHours();`, 7.0},
+		{`var d = new Date(); d.setDate(d.getDate() + 10); d.getUTCHours();`, 11.0},
+		{`new Date(2024, 2, 10, 2, 30).toISOString();`, "2024-03-10T07:30:00.000Z"},
+		{`new Date(2024, 2, 10, 2, 30).getHours();`, 3.0},
+		{`new Date("2024-03-10T02:30").getTime();`, 1710055800000.0},
+		{`var d = new Date(2024, 2, 10); d.setHours(2); d.getHours();`, 3.0},
+		{`new Date(2024, 10, 3, 1, 30).toISOString();`, "2024-11-03T05:30:00.000Z"},
+		{`new Date(2024, 10, 3, 1, 30).getTimezoneOffset();`, 240.0},
+		{`try { new Date().toLocaleString("en-US", { timeZone: "Nowhere" }); } catch (e) { e.name + ": " + e.message; }`,
+			"RangeError: Invalid time zone specified: Nowhere"},
+		{`try { new Date(NaN).toISOString(); } catch (e) { e.name + ": " + e.message; }`, "RangeError: Invalid time value"},
+	}
+
+	for _, tt := range tests {
+		result := testEvalAt(tt.input, newYork)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestDateJSON(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`new Date(0).toJSON();`, "1970-01-01T00:00:00.000Z"},
+		{`new Date(NaN).toJSON();`, internal.Null},
+		{`JSON.stringify({ at: new Date(0) });`, `{"at":"1970-01-01T00:00:00.000Z"}`},
+		{`JSON.stringify({ at: new Date(NaN) });`, `{"at":null}`},
+	}
+
+	for _, tt := range tests {
+		result := testEvalAt(tt.input, time.UTC)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package evaluator
+
+import (
+	"go-script/ast"
+	"go-script/environment"
+	"go-script/internal"
+	"go-script/token"
+)
+
+// throwError raises an error the evaluator detected itself, such as a
+// ReferenceError or a TypeError, as an error object scripts can catch.
+// The exception and the error's stack record where it happened, prefixed
+// with the module's file when the code runs in one.
+//
+// Example: throwError("ReferenceError", "x is not defined", pos, env)
+// → Uncaught ReferenceError: x is not defined
+// →     at main.js:3:5
+func throwError(name string, message string, pos token.Position, env *environment.Environment) {
+	err := internal.NewError(name, message)
+	location := locate(pos, env)
+	internal.SetErrorLocation(err, location)
+	panic(&internal.Exception{Value: err, Location: location})
+}
+
+// locate writes a source position as line:column, or file:line:column
+// inside a module; "" if the position is unknown
+func locate(pos token.Position, env *environment.Environment) string {
+	if !pos.IsValid() {
+		return ""
+	}
+	if _, key := importerOf(env); key != "" {
+		return key + ":" + pos.String()
+	}
+	return pos.String()
+}
+
+// throwNotDefined raises the ReferenceError for reading an undeclared variable
+//
+// Example: print(totl) → ReferenceError: totl is not defined
+func throwNotDefined(node *ast.Identifier, env *environment.Environment) {
+	throwError("ReferenceError", node.Name+" is not defined", node.Pos, env)
+}
+
+// throwNotCallable raises the TypeError for calling a value that isn't a
+// function; classes get their own message since they need new
+//
+// Examples:
+//
+//	user.save()   (save is undefined) → TypeError: user.save is not a function
+//	Point(1, 2)   (Point is a class)  → TypeError: Class constructor Point cannot be invoked without 'new'
+func throwNotCallable(callee ast.Expression, function Value, pos token.Position, env *environment.Environment) {
+	if class, ok := function.(*Class); ok {
+		throwError("TypeError", "Class constructor "+class.Name+" cannot be invoked without 'new'", pos, env)
+	}
+	throwError("TypeError", describe(callee)+" is not a function", pos, env)
+}
+
+// throwNullishAccess raises the TypeError for reading or writing a
+// property of null or undefined
+//
+// Examples:
+//
+//	user.name        (user is undefined) → TypeError: Cannot read properties of undefined (reading 'name')
+//	config.x = 1     (config is null)    → TypeError: Cannot set properties of null (setting 'x')
+func throwNullishAccess(setting bool, object Value, key internal.PropertyKey, pos token.Position, env *environment.Environment) {
+	action, gerund := "read", "reading"
+	if setting {
+		action, gerund = "set", "setting"
+	}
+	kind := "undefined"
+	if object == internal.Null {
+		kind = "null"
+	}
+	name := internal.Inspect(key)
+	if sym, ok := key.(*internal.Symbol); ok {
+		name = sym.String()
+	}
+	throwError("TypeError", "Cannot "+action+" properties of "+kind+" ("+gerund+" '"+name+"')", pos, env)
+}
+
+// describe writes the expression a value came from the way error messages
+// name it. Expressions that have no short name are "(intermediate value)".
+//
+// Examples:
+//
+//	user.address.save  → "user.address.save"
+//	handlers["click"]  → "handlers[\"click\"]"
+//	list[i + 1]        → "list[...]"
+//	getUser().save     → "getUser(...).save"
+func describe(node ast.Expression) string {
+	switch n := node.(type) {
+	case *ast.Identifier:
+		return n.Name
+	case *ast.ThisExpression:
+		return "this"
+	case *ast.SuperExpression:
+		return "super"
+	case *ast.OptionalChain:
+		return describe(n.Expression)
+	case *ast.PropertyAccess:
+		if n.Optional {
+			return describe(n.Object) + "?." + n.Property
+		}
+		return describe(n.Object) + "." + n.Property
+	case *ast.IndexExpression:
+		index := "..."
+		switch i := n.Index.(type) {
+		case *ast.Identifier:
+			index = i.Name
+		case *ast.NumberLiteral:
+			index = internal.FormatNumber(i.Value)
+		case *ast.StringLiteral:
+			index = `"` + i.Value + `"`
+		}
+		return describe(n.Left) + "[" + index + "]"
+	case *ast.CallExpression:
+		return describe(n.Function) + "(...)"
+	}
+	return "(intermediate value)"
+}
@@ -1,12 +1,14 @@
 package evaluator
 
 import (
-	"fmt"
 	"go-script/ast"
 	"go-script/environment"
-	"go-script/evaluator/builtins"
 	"go-script/evaluator/builtins/array"
+	"go-script/evaluator/builtins/collection"
+	"go-script/evaluator/builtins/date"
+	"go-script/evaluator/builtins/regexp"
 	"go-script/internal"
+	"go-script/token"
 )
 
 // Function represents a runtime function value
@@ -20,9 +22,62 @@ import (
 //	  Env: <current environment>
 //	}
 type Function struct {
+	internal.ObjectKind
 	Parameters []string
 	Body       *ast.BlockStatement
 	Env        *environment.Environment
+	HomeObject *Object // Object a class method is installed on; super.x starts at its prototype
+	Class      *Class  // Class whose constructor this is; the target of super(...)
+	Generator  bool    // function*: calling it returns a generator object
+	Async      bool    // async function: calling it returns a promise
+	Arrow      bool    // arrow function: this and super come from where it was defined
+	Strict     bool    // strict mode function: defined in strict code, or its body says "use strict"
+
+	properties *Object // Properties assigned to the function, like fn.cache = {}; nil until there are some
+}
+
+func (f *Function) String() string {
+	return "[Function]"
+}
+
+// OwnProperties returns the object holding the properties assigned to the
+// function, creating it for functions that have none yet
+func (f *Function) OwnProperties() *Object {
+	if f.properties == nil {
+		f.properties = internal.NewObject()
+	}
+	return f.properties
+}
+
+// PreventExtensions, Seal and Freeze lock the function's own properties
+func (f *Function) PreventExtensions() {
+	f.OwnProperties().PreventExtensions()
+}
+
+func (f *Function) Seal() {
+	f.OwnProperties().Seal()
+}
+
+func (f *Function) Freeze() {
+	f.OwnProperties().Freeze()
+}
+
+func (f *Function) IsExtensible() bool {
+	return f.properties == nil || f.properties.IsExtensible()
+}
+
+func (f *Function) IsSealed() bool {
+	return f.properties != nil && f.properties.IsSealed()
+}
+
+func (f *Function) IsFrozen() bool {
+	return f.properties != nil && f.properties.IsFrozen()
+}
+
+// Call invokes the function with the given receiver, so builtins and
+// accessors can run user defined functions through internal.Callable
+func (f *Function) Call(this Value, args ...Value) Value {
+	return callFunction(f, this, args)
 }
 
 type Value = internal.Value
@@ -54,12 +109,28 @@ func Eval(node ast.Node, env *environment.Environment) Value {
 		return evalIfStatement(node, env)
 	case *ast.WhileStatement:
 		return evalWhileStatement(node, env)
+	case *ast.ForOfStatement:
+		return evalForOfStatement(node, env)
+	case *ast.ThrowStatement:
+		return evalThrowStatement(node, env)
+	case *ast.TryStatement:
+		return evalTryStatement(node, env)
+	case *ast.YieldExpression:
+		return evalYieldExpression(node, env)
+	case *ast.ImportExpression:
+		return evalImportExpression(node, env)
+	case *ast.AwaitExpression:
+		return evalAwaitExpression(node, env)
 	case *ast.NumberLiteral:
 		return node.Value
 	case *ast.StringLiteral:
 		return node.Value
+	case *ast.RegExpLiteral:
+		return regexp.New(node.Pattern, node.Flags)
 	case *ast.BooleanLiteral:
 		return node.Value
+	case *ast.NullLiteral:
+		return internal.Null
 	case *ast.Identifier:
 		return evalIdentifier(node, env)
 	case *ast.PrefixExpression:
@@ -68,11 +139,19 @@ func Eval(node ast.Node, env *environment.Environment) Value {
 		return evalInfixExpression(node, env)
 	case *ast.AssignExpression:
 		return evalAssignExpression(node, env)
+	case *ast.ConditionalExpression:
+		return evalConditionalExpression(node, env)
+	case *ast.SequenceExpression:
+		return evalSequenceExpression(node, env)
 	case *ast.FunctionLiteral:
 		return &Function{
 			Parameters: node.Parameters,
 			Body:       node.Body,
 			Env:        env,
+			Generator:  node.Generator,
+			Async:      node.Async,
+			Arrow:      node.Arrow,
+			Strict:     env.Strict() || hasUseStrict(node.Body.Statements),
 		}
 	case *ast.CallExpression:
 		return evalCallExpression(node, env)
@@ -84,6 +163,18 @@ func Eval(node ast.Node, env *environment.Environment) Value {
 		return evalPropertyAccess(node, env)
 	case *ast.IndexExpression:
 		return evalIndexExpression(node, env)
+	case *ast.OptionalChain:
+		return evalOptionalChain(node, env)
+	case *ast.MemberAssignExpression:
+		return evalMemberAssignExpression(node, env)
+	case *ast.ThisExpression:
+		return evalThisExpression(node.Pos, env)
+	case *ast.NewExpression:
+		return evalNewExpression(node, env)
+	case *ast.ClassLiteral:
+		return evalClassLiteral(node, env)
+	case *ast.ClassDeclaration:
+		return evalClassDeclaration(node, env)
 	}
 
 	return nil
@@ -96,15 +187,65 @@ func Eval(node ast.Node, env *environment.Environment) Value {
 //  1. Evaluate "var x = 5" (stores x in environment)
 //  2. Evaluate "x + 3" (returns 8)
 //  3. Return 8 as final result
+//
+// Once the statements have run, the event loop runs until no microtasks,
+// timers or pending operations (like fetch requests) remain, so promise
+// callbacks and setTimeout/setInterval callbacks registered by the program
+// run before it completes.
+//
+// An exception nobody catches stops the program; the *internal.Exception is
+// returned as the result so the caller can report it. So is the first
+// promise rejection that still has no handler after the queue drains.
+//
+// A program evaluated this way is a script: import and export declarations
+// are a SyntaxError, only import() works. EvalModule runs modules.
 func evalProgram(program *ast.Program, env *environment.Environment) Value {
-	var result Value
+	return runToCompletion(env, func() (result Value) {
+		checkScript(program)
+
+		// "use strict" applies to this program only: the next one run in
+		// the same environment, like a REPL line, starts out sloppy again
+		if hasUseStrict(program.Statements) && !env.Strict() {
+			env.SetStrict(true)
+			defer env.SetStrict(false)
+		}
 
-	for _, statement := range program.Statements {
-		result = Eval(statement, env)
+		for _, statement := range program.Statements {
+			result = Eval(statement, env)
 
-		if returnValue, ok := result.(*ReturnValue); ok {
-			return returnValue.Value
+			if returnValue, ok := result.(*ReturnValue); ok {
+				return returnValue.Value
+			}
+		}
+		return result
+	})
+}
+
+// runToCompletion runs the synchronous part of a program (a script, or a
+// module graph), then env's event loop until no work is left
+// Returns the program's result, or the *internal.Exception that ended it.
+// Only env's loop is reset when the program throws, so other programs keep
+// their timers and pending operations.
+func runToCompletion(env *environment.Environment, program func() Value) (result Value) {
+	loop := env.EventLoop()
+
+	defer func() {
+		if r := recover(); r != nil {
+			exception, ok := r.(*internal.Exception)
+			if !ok {
+				panic(r)
+			}
+			loop.Reset()
+			result = exception
 		}
+	}()
+
+	result = program()
+
+	loop.Run()
+
+	if unhandled := loop.TakeUnhandledRejections(); len(unhandled) > 0 {
+		return &internal.Exception{Value: unhandled[0].Value, InPromise: true}
 	}
 
 	return result
@@ -215,14 +356,27 @@ func evalWhileStatement(node *ast.WhileStatement, env *environment.Environment)
 }
 
 // evalIdentifier looks up a variable's value in the environment
+// Builtins like print and fetch are variables of the global scope, so
+// scripts can shadow them. A name that is declared nowhere is a
+// ReferenceError.
+//
+// Examples:
 //
-// Example: "x" → looks up x in environment, returns its value
+//	"x"     → looks up x in environment, returns its value
+//	"print" → the print builtin, so it can be passed around
+//	"totl"  → throws ReferenceError: totl is not defined
 func evalIdentifier(node *ast.Identifier, env *environment.Environment) Value {
-	val, ok := env.Get(node.Name)
-	if !ok {
-		return nil // Variable not found
+	if val, ok := env.Get(node.Name); ok {
+		return val
 	}
-	return val
+	throwNotDefined(node, env)
+	return nil
+}
+
+// isDeclared reports whether reading a name would find a variable
+func isDeclared(name string, env *environment.Environment) bool {
+	_, ok := env.Get(name)
+	return ok
 }
 
 // evalPrefixExpression evaluates prefix operators (-, !)
@@ -233,17 +387,20 @@ func evalIdentifier(node *ast.Identifier, env *environment.Environment) Value {
 //	"!true" → false
 //	"-x" → negation of x's value
 func evalPrefixExpression(node *ast.PrefixExpression, env *environment.Environment) Value {
+	// typeof is the one way to read an undeclared name without an error
+	if ident, ok := node.Right.(*ast.Identifier); ok && node.Operator == "typeof" && !isDeclared(ident.Name, env) {
+		return "undefined"
+	}
+
 	right := Eval(node.Right, env)
 
 	switch node.Operator {
 	case "!":
 		return !isTruthy(right)
+	case "typeof":
+		return typeOf(right)
 	case "-":
-		// Negation
-		if num, ok := right.(float64); ok {
-			return -num
-		}
-		return 0.0
+		return -internal.ToNumber(right)
 	}
 
 	return nil
@@ -258,45 +415,84 @@ func evalPrefixExpression(node *ast.PrefixExpression, env *environment.Environme
 //	"4 * 3" → 12.0
 //	"x == 5" → true or false
 //	"hello" + " world" → "hello world"
+//	"dog instanceof Animal" → true or false
 func evalInfixExpression(node *ast.InfixExpression, env *environment.Environment) Value {
 	left := Eval(node.Left, env)
 	right := Eval(node.Right, env)
 
+	// Arithmetic and comparison convert objects to primitives first,
+	// which runs Symbol.toPrimitive, valueOf or toString if defined
 	switch node.Operator {
+	case "instanceof":
+		return instanceOf(left, right)
+	case "==":
+		return equals(left, right)
+	case "!=":
+		return !equals(left, right)
 	case "+":
-		if leftStr, ok := left.(string); ok {
-			return leftStr + internal.ToString(right)
-		}
-		if rightStr, ok := right.(string); ok {
-			return internal.ToString(left) + rightStr
-		}
+		left, right = internal.ToPrimitive(left, "default"), internal.ToPrimitive(right, "default")
+	default:
+		left, right = internal.ToPrimitive(left, "number"), internal.ToPrimitive(right, "number")
+	}
 
-		return toFloat(left) + toFloat(right)
+	switch node.Operator {
+	case "+":
+		_, leftIsString := left.(string)
+		_, rightIsString := right.(string)
+		if leftIsString || rightIsString {
+			return internal.ToString(left) + internal.ToString(right)
+		}
+		return internal.ToNumber(left) + internal.ToNumber(right)
 	case "-":
-		return toFloat(left) - toFloat(right)
+		return internal.ToNumber(left) - internal.ToNumber(right)
 	case "*":
-		return toFloat(left) * toFloat(right)
+		return internal.ToNumber(left) * internal.ToNumber(right)
 	case "/":
-		rightNum := toFloat(right)
+		rightNum := internal.ToNumber(right)
 		if rightNum == 0 {
 			return 0.0 // division by zero
 		}
-		return toFloat(left) / rightNum
-	case "==":
-		return equals(left, right)
-	case "!=":
-		return !equals(left, right)
+		return internal.ToNumber(left) / rightNum
+	case "<", ">", "<=", ">=":
+		return compare(node.Operator, left, right)
+	}
+
+	return nil
+}
+
+// compare evaluates a relational operator on two primitives: two strings
+// are compared by UTF-16 code units, anything else as numbers, and a NaN
+// on either side makes every comparison false
+//
+// Examples: "a" < "b" → true, "10" < "9" → true, "10" < 9 → false
+func compare(operator string, left, right Value) bool {
+	leftStr, leftIsString := left.(string)
+	rightStr, rightIsString := right.(string)
+	if leftIsString && rightIsString {
+		order := compareUnits(leftStr, rightStr)
+		switch operator {
+		case "<":
+			return order < 0
+		case ">":
+			return order > 0
+		case "<=":
+			return order <= 0
+		default:
+			return order >= 0
+		}
+	}
+
+	a, b := internal.ToNumber(left), internal.ToNumber(right)
+	switch operator {
 	case "<":
-		return toFloat(left) < toFloat(right)
+		return a < b
 	case ">":
-		return toFloat(left) > toFloat(right)
+		return a > b
 	case "<=":
-		return toFloat(left) <= toFloat(right)
-	case ">=":
-		return toFloat(left) >= toFloat(right)
+		return a <= b
+	default:
+		return a >= b
 	}
-
-	return nil
 }
 
 // evalAssignExpression evaluates an assignment
@@ -309,49 +505,152 @@ func evalAssignExpression(node *ast.AssignExpression, env *environment.Environme
 	return val
 }
 
+// evalConditionalExpression evaluates "cond ? a : b"
+// Only the chosen branch is evaluated.
+//
+// Example: "x > 0 ? "positive" : "not positive"" with x = 5 → "positive"
+func evalConditionalExpression(node *ast.ConditionalExpression, env *environment.Environment) Value {
+	if isTruthy(Eval(node.Condition, env)) {
+		return Eval(node.Consequence, env)
+	}
+	return Eval(node.Alternative, env)
+}
+
+// evalSequenceExpression evaluates the comma operator
+//
+// Example: "(x = 1, x + 1)" → sets x to 1, returns 2
+func evalSequenceExpression(node *ast.SequenceExpression, env *environment.Environment) Value {
+	var result Value
+	for _, exp := range node.Expressions {
+		result = Eval(exp, env)
+	}
+	return result
+}
+
 // evalCallExpression evaluates a function call
+// Method calls (obj.method()) pass the object as `this`
 //
 // Examples:
 //
 //	"add(5, 3)" → calls add function with arguments [5, 3]
 //	"print("hello")" → calls builtin print function
 //	"JSON.stringify(obj)" → calls JSON.stringify builtin
+//	"counter.increment()" → calls increment with this = counter
 func evalCallExpression(node *ast.CallExpression, env *environment.Environment) Value {
-	// Check for builtin functions
-	if ident, ok := node.Function.(*ast.Identifier); ok {
-		if builtin, ok := builtins.Get(ident.Name); ok {
-			args := []interface{}{}
-			for _, arg := range node.Arguments {
-				args = append(args, Eval(arg, env))
-			}
-			return builtin.Fn(args...)
-		}
+	// super(...) inside a derived class constructor
+	if _, ok := node.Function.(*ast.SuperExpression); ok {
+		return evalSuperCall(node, env)
 	}
 
-	function := Eval(node.Function, env)
+	function, this := evalCallee(node.Function, env)
+	if function == shortCircuit || (node.Optional && internal.IsNullish(function)) {
+		return shortCircuit
+	}
+	if _, ok := function.(internal.Callable); !ok {
+		throwNotCallable(node.Function, function, node.Pos, env)
+	}
+
+	return applyFunction(function, this, evalArguments(node.Arguments, env))
+}
 
-	// Check if it's a builtin from property access (like JSON.stringify)
-	if builtin, ok := function.(*internal.Builtin); ok {
-		args := []interface{}{}
-		for _, arg := range node.Arguments {
-			args = append(args, Eval(arg, env))
+// evalCallee evaluates the function part of a call and returns it together
+// with the receiver that becomes `this` inside the call
+//
+// Examples:
+//
+//	"greet()"        → (greet, nil)
+//	"user.greet()"   → (user.greet, user)
+//	"super.greet()"  → (Parent.prototype.greet, this)
+func evalCallee(node ast.Expression, env *environment.Environment) (Value, Value) {
+	switch callee := node.(type) {
+	case *ast.PropertyAccess:
+		if super, ok := callee.Object.(*ast.SuperExpression); ok {
+			return evalSuperProperty(callee.Property, super.Pos, env), evalThisExpression(super.Pos, env)
+		}
+		object := Eval(callee.Object, env)
+		if object == shortCircuit || (callee.Optional && internal.IsNullish(object)) {
+			return shortCircuit, nil
+		}
+		if internal.IsNullish(object) {
+			throwNullishAccess(false, object, callee.Property, callee.Pos, env)
+		}
+		return getMember(object, callee.Property, callee.Pos, env), object
+	case *ast.IndexExpression:
+		object := Eval(callee.Left, env)
+		if object == shortCircuit || (callee.Optional && internal.IsNullish(object)) {
+			return shortCircuit, nil
+		}
+		index := Eval(callee.Index, env)
+		if internal.IsNullish(object) {
+			throwNullishAccess(false, object, internal.ToPropertyKey(index), callee.Pos, env)
 		}
-		return builtin.Fn(args...)
+		return getIndex(object, index), object
 	}
 
-	fn, ok := function.(*Function)
-	if !ok {
-		return nil
-	}
+	return Eval(node, env), nil
+}
 
+func evalArguments(nodes []ast.Expression, env *environment.Environment) []Value {
 	args := []Value{}
-	for _, arg := range node.Arguments {
+	for _, arg := range nodes {
 		args = append(args, Eval(arg, env))
 	}
+	return args
+}
+
+// applyFunction calls any callable runtime value
+// Values that can't be called (including classes without new) produce nil
+func applyFunction(function Value, this Value, args []Value) Value {
+	switch fn := function.(type) {
+	case *internal.Builtin:
+		return fn.Call(this, args...)
+	case *Function:
+		return callFunction(fn, this, args)
+	}
+
+	return nil
+}
+
+// callFunction runs a user defined function and unwraps its return value
+// Generator functions don't run their body yet; they return a generator object.
+// Async functions return a promise for the body's result.
+func callFunction(fn *Function, this Value, args []Value) Value {
+	if fn.Generator {
+		return newGeneratorObject(fn, this, args)
+	}
+	if fn.Async {
+		return callAsyncFunction(fn, this, args)
+	}
+
+	result := Eval(fn.Body, newFunctionEnvironment(fn, this, args))
 
-	// Create new environment for function execution
-	// Parent is the function's closure environment (where it was defined)
+	if returnValue, ok := result.(*ReturnValue); ok {
+		return returnValue.Value
+	}
+
+	return result
+}
+
+// newFunctionEnvironment creates the scope a function body runs in
+// Parent is the function's closure environment (where it was defined)
+//
+// Example: calling function(a, b) { ... } with (1) on obj
+//
+//	Creates: { this: obj, a: 1, b: nil } → closure env → ... → global
+//
+// Arrow functions don't bind this or super, so lookups fall through to the
+// closure environment.
+func newFunctionEnvironment(fn *Function, this Value, args []Value) *environment.Environment {
 	fnEnv := environment.New(fn.Env)
+	fnEnv.SetStrict(fn.Strict)
+
+	if !fn.Arrow {
+		fnEnv.Set("this", this)
+
+		if fn.HomeObject != nil || fn.Class != nil {
+			fnEnv.Set("super", &superBinding{home: fn.HomeObject, class: fn.Class})
+		}
+	}
 
 	// Bind parameters to argument values
 	for i, param := range fn.Parameters {
@@ -362,32 +661,84 @@ func evalCallExpression(node *ast.CallExpression, env *environment.Environment)
 		}
 	}
 
-	result := Eval(fn.Body, fnEnv)
+	return fnEnv
+}
 
-	if returnValue, ok := result.(*ReturnValue); ok {
-		return returnValue.Value
+// hasUseStrict reports whether a program or function body starts with a
+// "use strict" directive. The directive prologue is the run of string
+// literal statements at the start; "use strict" may be any of them.
+//
+// Example: "use strict"; x.y = 1; → true
+func hasUseStrict(statements []ast.Statement) bool {
+	for _, statement := range statements {
+		expression, ok := statement.(*ast.ExpressionStatement)
+		if !ok {
+			return false
+		}
+		literal, ok := expression.Expression.(*ast.StringLiteral)
+		if !ok {
+			return false
+		}
+		if literal.Value == "use strict" {
+			return true
+		}
 	}
-
-	return result
+	return false
 }
 
 // evalObjectLiteral evaluates an object literal
+// Properties are evaluated in source order; a repeated key overwrites the earlier one
 //
 // Example:
 //
-//	{ name: "John", age: 30 }
-//	→ Object{"name": "John", "age": 30.0}
+//	{ name: "John", [prefix + "Id"]: 7, greet() { return "hi"; } }
+//	→ Object{"name": "John", "userId": 7.0, "greet": Function{...}}
 func evalObjectLiteral(node *ast.ObjectLiteral, env *environment.Environment) Value {
-	obj := make(Object)
+	obj := internal.NewObject()
+
+	for _, prop := range node.Properties {
+		var key internal.PropertyKey = prop.Key
+		if prop.Computed != nil {
+			key = internal.ToPropertyKey(Eval(prop.Computed, env))
+		}
+
+		value := Eval(prop.Value, env)
+
+		// Methods and accessors can use super, which looks past this object
+		if fn, ok := value.(*Function); ok && (prop.Method || prop.Kind != ast.PropertyInit) {
+			fn.HomeObject = obj
+		}
 
-	for key, valueNode := range node.Pairs {
-		value := Eval(valueNode, env)
-		obj[key] = value
+		switch prop.Kind {
+		case ast.PropertyGetter:
+			defineAccessor(obj, key, value, nil)
+		case ast.PropertySetter:
+			defineAccessor(obj, key, nil, value)
+		default:
+			obj.Define(key, &internal.Property{Value: value})
+		}
 	}
 
 	return obj
 }
 
+// defineAccessor installs a getter and/or setter on an object
+// A getter and setter with the same name share one accessor property.
+func defineAccessor(obj *Object, key internal.PropertyKey, getter Value, setter Value) {
+	prop, exists := obj.GetOwnProperty(key)
+	if !exists || !prop.IsAccessor() {
+		prop = &internal.Property{}
+		obj.Define(key, prop)
+	}
+
+	if getter != nil {
+		prop.Getter = getter
+	}
+	if setter != nil {
+		prop.Setter = setter
+	}
+}
+
 // evalArrayLiteral evaluates an array literal
 //
 // Example:
@@ -413,34 +764,26 @@ func evalArrayLiteral(node *ast.ArrayLiteral, env *environment.Environment) Valu
 //	obj["key"] → gets "key" property of object
 func evalIndexExpression(node *ast.IndexExpression, env *environment.Environment) Value {
 	left := Eval(node.Left, env)
-	if left == nil {
-		return nil
+	if left == shortCircuit || (node.Optional && internal.IsNullish(left)) {
+		return shortCircuit
 	}
 
 	index := Eval(node.Index, env)
-	if index == nil {
-		return nil
+	if internal.IsNullish(left) {
+		throwNullishAccess(false, left, internal.ToPropertyKey(index), node.Pos, env)
 	}
 
-	// Handle ArrayReference type (mutable arrays)
-	if arr, ok := left.(*array.ArrayReference); ok {
-		idx, ok := index.(float64)
-		if !ok {
-			return nil
-		}
-		return arr.Get(int(idx))
-	}
+	return getIndex(left, index)
+}
 
-	// Handle Object type (string indexing)
-	if obj, ok := left.(Object); ok {
-		key, ok := index.(string)
-		if !ok {
-			return nil
-		}
-		return obj[key]
+// getIndex reads object[index]
+// The index is converted to a property key first, so arr[1], arr["1"] and
+// arr[1.0] name the same element while arr[0.5] names no element at all.
+func getIndex(object Value, index Value) Value {
+	if index == nil {
+		return nil
 	}
-
-	return nil
+	return getProperty(object, internal.ToPropertyKey(index))
 }
 
 // evalPropertyAccess evaluates object property access
@@ -449,89 +792,311 @@ func evalIndexExpression(node *ast.IndexExpression, env *environment.Environment
 //
 //	person.name → looks up "name" property in person object
 //	obj.x → looks up "x" property in obj
+//	this.#count → looks up the private #count member
 func evalPropertyAccess(node *ast.PropertyAccess, env *environment.Environment) Value {
+	if super, ok := node.Object.(*ast.SuperExpression); ok {
+		return evalSuperProperty(node.Property, super.Pos, env)
+	}
+
 	object := Eval(node.Object, env)
+	if object == shortCircuit || (node.Optional && internal.IsNullish(object)) {
+		return shortCircuit
+	}
+	if internal.IsNullish(object) {
+		throwNullishAccess(false, object, node.Property, node.Pos, env)
+	}
+
+	return getMember(object, node.Property, node.Pos, env)
+}
+
+// chainBroken is the type of shortCircuit, the value links of an optional
+// chain hand to their parent once a ?. meets null or undefined
+type chainBroken struct{}
 
-	// Handle ArrayReference type - support array properties and methods
-	if arr, ok := object.(*array.ArrayReference); ok {
-		return GetArrayProperty(arr, node.Property)
+var shortCircuit = chainBroken{}
+
+// evalOptionalChain evaluates a chain containing ?. links
+// A short-circuit anywhere inside skips the rest of the chain and yields undefined.
+//
+// Examples:
+//
+//	user?.address.street  (user is null)     → nil, .street is never read
+//	user?.greet()         (user is an object) → result of user.greet()
+//	callback?.(value)     (callback is nil)   → nil, value is never evaluated
+func evalOptionalChain(node *ast.OptionalChain, env *environment.Environment) Value {
+	result := Eval(node.Expression, env)
+	if result == shortCircuit {
+		return nil
 	}
+	return result
+}
 
-	// Handle Object type (map[string]Value)
-	if obj, ok := object.(Object); ok {
-		return obj[node.Property]
+// getMember reads a property named after a dot, which may be a #private name
+func getMember(object Value, name string, pos token.Position, env *environment.Environment) Value {
+	if isPrivateName(name) {
+		return getPrivateMember(object, name, pos, env)
 	}
+	return getProperty(object, name)
+}
 
-	// Handle map[string]interface{} (e.g., from builtin functions like fetch)
-	if obj, ok := object.(map[string]interface{}); ok {
-		return obj[node.Property]
+// getProperty reads a property from any runtime value
+// The key is a string or a *Symbol (see internal.PropertyKey).
+//
+// Example:
+//
+//	getProperty(arr, "length")            → array length
+//	getProperty(arr, Symbol.iterator)     → function returning an array iterator
+//	getProperty(obj, "name")              → own or inherited property, running getters
+//	getProperty(Point, "origin")          → static class member
+//	getProperty(Symbol, "iterator")       → the well-known Symbol.iterator
+//
+// Instances of classes that extend a builtin find the class's fields and
+// methods before the builtin's own members.
+func getProperty(object Value, key internal.PropertyKey) Value {
+	if instance, ok := object.(internal.Subclassable); ok && instance.SubclassObject() != nil {
+		if prop, ok := instance.SubclassObject().Lookup(key); ok {
+			return prop.Read(object)
+		}
+	}
+	return builtinProperty(object, key)
+}
+
+// builtinProperty reads the members every value of a type has, like an
+// array's length or a Map's get method, and the properties of objects
+func builtinProperty(object Value, key internal.PropertyKey) Value {
+	switch obj := object.(type) {
+	case *array.ArrayReference:
+		// Handle ArrayReference type - support array properties and methods
+		if key == internal.SymbolIterator {
+			return createIteratorMethod(arrayValues(obj))
+		}
+		if index, ok := internal.ArrayIndex(key); ok {
+			return obj.Get(index)
+		}
+		if name, ok := key.(string); ok {
+			return GetArrayProperty(obj, name)
+		}
+	case string:
+		return GetStringProperty(obj, key)
+	case float64:
+		return GetNumberProperty(obj, key)
+	case bool:
+		return GetBooleanProperty(obj, key)
+	case *regexp.RegExp:
+		return GetRegExpProperty(obj, key)
+	case *date.Date:
+		return GetDateProperty(obj, key)
+	case *collection.Map:
+		return GetMapProperty(obj, key)
+	case *collection.Set:
+		return GetSetProperty(obj, key)
+	case *collection.WeakMap:
+		return GetWeakMapProperty(obj, key)
+	case *collection.WeakSet:
+		return GetWeakSetProperty(obj, key)
+	case *Object:
+		return obj.Get(key)
+	case *Class:
+		return obj.Statics.GetWithThis(key, obj)
+	case *internal.Builtin:
+		if obj.Properties != nil {
+			return obj.Properties.GetWithThis(key, obj)
+		}
+	case *Function:
+		if obj.properties != nil {
+			return obj.properties.GetWithThis(key, obj)
+		}
+	case *internal.Symbol:
+		return GetSymbolProperty(obj, key)
+	case *internal.Promise:
+		return GetPromiseProperty(obj, key)
 	}
 
 	return nil
 }
 
-func isTruthy(val Value) bool {
-	if val == nil {
-		return false
+// setProperty assigns a property on any runtime value, reporting whether
+// the value took it. Every object can hold properties: those that aren't
+// plain Objects keep them on their OwnProperties, which reads try before
+// the members the value's type provides.
+//
+// Array elements are written by index; writing past the end grows the
+// array, and assigning length truncates or grows it. Other keys, including
+// numbers too large to be indexes, are named properties.
+//
+// An assignment fails, changing nothing, for primitives, read-only
+// properties, accessors without a setter (like a Map's size) and new
+// properties of non-extensible objects. See assignProperty for what
+// happens then.
+func setProperty(object Value, key internal.PropertyKey, val Value) bool {
+	switch obj := object.(type) {
+	case *Object:
+		return obj.Set(key, val)
+	case *Class:
+		return obj.Statics.SetWithThis(key, val, obj)
+	case *array.ArrayReference:
+		if index, ok := internal.ArrayIndex(key); ok {
+			return obj.Set(index, val)
+		}
+		if key == "length" {
+			return obj.SetLength(val)
+		}
+	case *regexp.RegExp:
+		if key == "lastIndex" {
+			obj.LastIndex = val
+			return true
+		}
 	}
 
-	switch v := val.(type) {
-	case bool:
-		return v
-	case float64:
-		return v != 0
-	case string:
-		return v != ""
-	default:
-		return true // Objects or functions are truthy by default
+	holder, ok := object.(internal.PropertyHolder)
+	if !ok {
+		return false
+	}
+	props := holder.OwnProperties()
+	if _, ok := props.Lookup(key); !ok && computedMember(object, key) != nil {
+		return false
 	}
+	return props.SetWithThis(key, val, object)
 }
 
-func toFloat(val Value) float64 {
-	if val == nil {
-		return 0
+// assignProperty performs an assignment like obj.key = val: it sets the
+// property, and if the value doesn't take it, strict-mode code gets a
+// TypeError while sloppy-mode code carries on
+//
+// Examples:
+//
+//	"use strict"; Object.freeze(o).x = 1 → TypeError: Cannot add property x, object is not extensible
+//	Object.freeze(o).x = 1               → o is unchanged
+func assignProperty(object Value, key internal.PropertyKey, val Value, pos token.Position, env *environment.Environment) {
+	if !setProperty(object, key, val) && env.Strict() {
+		throwError("TypeError", internal.AssignError(object, key, findProperty(object, key)), pos, env)
 	}
+}
 
-	switch v := val.(type) {
-	case float64:
-		return v
-	case bool:
-		if v {
-			return 1
+// findProperty returns the property an assignment to key meets on a value,
+// own or inherited, or nil if there is none: array elements and length,
+// and the members built-ins compute, are described as properties too
+func findProperty(object Value, key internal.PropertyKey) *internal.Property {
+	switch obj := object.(type) {
+	case *Object:
+		prop, _ := obj.Lookup(key)
+		return prop
+	case *Class:
+		prop, _ := obj.Statics.Lookup(key)
+		return prop
+	case *array.ArrayReference:
+		if index, ok := internal.ArrayIndex(key); ok && index < len(*obj.Elements) {
+			return &internal.Property{Value: obj.Get(index)}
+		}
+		if key == "length" {
+			return &internal.Property{Value: obj.Length()}
+		}
+	}
+	if holder, ok := object.(internal.PropertyHolder); ok {
+		if prop, ok := holder.OwnProperties().Lookup(key); ok {
+			return prop
 		}
-		return 0
-	case string:
-		// Try to parse string as number
-		var num float64
-		fmt.Sscanf(v, "%f", &num)
-		return num
-	default:
-		return 0
 	}
+	return computedMember(object, key)
 }
 
-func equals(a, b Value) bool {
-	if a == nil && b == nil {
-		return true
+// computedMember describes the members built-ins compute from their state
+// on every read, like a Map's size or a RegExp's flags, as the accessors
+// without setters they are: assigning one fails instead of shadowing it.
+// It returns nil for other keys.
+func computedMember(object Value, key internal.PropertyKey) *internal.Property {
+	name, _ := key.(string)
+	computed := false
+	switch object.(type) {
+	case *collection.Map, *collection.Set:
+		computed = name == "size"
+	case *regexp.RegExp:
+		switch name {
+		case "source", "flags", "hasIndices", "global", "ignoreCase", "multiline", "dotAll", "unicode", "sticky":
+			computed = true
+		}
 	}
-	if a == nil || b == nil {
-		return false
+	if !computed {
+		return nil
 	}
+	return &internal.Property{Getter: &internal.Builtin{
+		Name: "get " + name,
+		Method: func(this interface{}, args ...interface{}) interface{} {
+			return builtinProperty(this, key)
+		},
+	}}
+}
 
-	switch aVal := a.(type) {
-	case float64:
-		if bVal, ok := b.(float64); ok {
-			return aVal == bVal
+// evalMemberAssignExpression evaluates assignment to a property
+//
+// Examples:
+//
+//	"this.x = 5"     → sets property x of the current object
+//	"arr[0] = 1"     → replaces the first element
+//	"this.#count = 0" → sets the private #count member
+//	"super.x = 1"    → runs an inherited setter, or sets x on this
+func evalMemberAssignExpression(node *ast.MemberAssignExpression, env *environment.Environment) Value {
+	switch target := node.Target.(type) {
+	case *ast.PropertyAccess:
+		if super, ok := target.Object.(*ast.SuperExpression); ok {
+			val := Eval(node.Value, env)
+			evalSuperAssignment(target.Property, val, super.Pos, env)
+			return val
 		}
-	case string:
-		if bVal, ok := b.(string); ok {
-			return aVal == bVal
+		object := Eval(target.Object, env)
+		val := Eval(node.Value, env)
+		if internal.IsNullish(object) {
+			throwNullishAccess(true, object, target.Property, target.Pos, env)
 		}
-	case bool:
-		if bVal, ok := b.(bool); ok {
-			return aVal == bVal
+		if isPrivateName(target.Property) {
+			setPrivateMember(object, target.Property, val, target.Pos, env)
+		} else {
+			assignProperty(object, target.Property, val, target.Pos, env)
+		}
+		return val
+	case *ast.IndexExpression:
+		if super, ok := target.Left.(*ast.SuperExpression); ok {
+			key := internal.ToPropertyKey(Eval(target.Index, env))
+			val := Eval(node.Value, env)
+			evalSuperAssignment(key, val, super.Pos, env)
+			return val
 		}
+		object := Eval(target.Left, env)
+		index := Eval(target.Index, env)
+		val := Eval(node.Value, env)
+		if internal.IsNullish(object) {
+			throwNullishAccess(true, object, internal.ToPropertyKey(index), target.Pos, env)
+		}
+		assignProperty(object, internal.ToPropertyKey(index), val, target.Pos, env)
+		return val
 	}
 
-	return false
+	return nil
+}
+
+// evalThisExpression returns the receiver of the current function call
+// At the top level `this` is nil. In a derived constructor it can't be
+// used before super(...) has run; pos locates the this (or super) that
+// tried.
+func evalThisExpression(pos token.Position, env *environment.Environment) Value {
+	this, _ := env.Get("this")
+	if this == uninitializedThis {
+		throwError("ReferenceError", "Must call super constructor in derived class before accessing 'this' or returning from derived constructor", pos, env)
+	}
+	return this
+}
+
+func isTruthy(val Value) bool {
+	return internal.ToBoolean(val)
+}
+
+// equals evaluates ==: null and undefined equal each other, everything
+// else is compared without conversion (see internal.StrictEquals)
+//
+// Examples: null == undefined → true, 1 == "1" → false, [] == [] → false
+func equals(a, b Value) bool {
+	if internal.IsNullish(a) && internal.IsNullish(b) {
+		return true
+	}
+	return internal.StrictEquals(a, b)
 }
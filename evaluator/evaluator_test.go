@@ -3,6 +3,7 @@ package evaluator
 import (
 	"go-script/environment"
 	"go-script/evaluator/builtins/array"
+	"go-script/internal"
 	"go-script/parser"
 	"testing"
 )
@@ -14,6 +15,15 @@ func testEval(input string) Value {
 	return Eval(program, env)
 }
 
+// thrown returns what an exception threw, with error objects written as
+// "Name: message" so tests can compare them to a string
+func thrown(exception *internal.Exception) Value {
+	if internal.IsError(exception.Value) {
+		return internal.ErrorString(exception.Value)
+	}
+	return exception.Value
+}
+
 func TestEvalNumberLiteral(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -342,27 +352,27 @@ func TestEvalObjectLiterals(t *testing.T) {
 	input := `var person = { name: "John", age: 30 }; person;`
 	result := testEval(input)
 
-	obj, ok := result.(Object)
+	obj, ok := result.(*Object)
 	if !ok {
-		t.Fatalf("Expected Object, got %T", result)
+		t.Fatalf("Expected *Object, got %T", result)
 	}
 
-	if len(obj) != 2 {
-		t.Errorf("Expected 2 properties, got %d", len(obj))
+	if obj.Len() != 2 {
+		t.Errorf("Expected 2 properties, got %d", obj.Len())
 	}
 
-	name, exists := obj["name"]
-	if !exists {
+	if !obj.Has("name") {
 		t.Error("Property 'name' not found")
 	}
+	name := obj.Get("name")
 	if str, ok := name.(string); !ok || str != "John" {
 		t.Errorf("Expected name 'John', got %v", name)
 	}
 
-	age, exists := obj["age"]
-	if !exists {
+	if !obj.Has("age") {
 		t.Error("Property 'age' not found")
 	}
+	age := obj.Get("age")
 	if num, ok := age.(float64); !ok || num != 30 {
 		t.Errorf("Expected age 30, got %v", age)
 	}
@@ -623,6 +633,7 @@ func TestIsTruthy(t *testing.T) {
 		{"", false},
 		{"hello", true},
 		{nil, false},
+		{internal.Null, false},
 	}
 
 	for _, tt := range tests {
@@ -633,7 +644,7 @@ func TestIsTruthy(t *testing.T) {
 	}
 }
 
-func TestToFloat(t *testing.T) {
+func TestToNumber(t *testing.T) {
 	tests := []struct {
 		value    Value
 		expected float64
@@ -642,15 +653,16 @@ func TestToFloat(t *testing.T) {
 		{3.14, 3.14},
 		{true, 1.0},
 		{false, 0.0},
-		{nil, 0.0},
+		{internal.Null, 0.0},
 		{"5", 5.0},
 		{"3.14", 3.14},
+		{array.NewArrayReference(Array{7.0}), 7.0},
 	}
 
 	for _, tt := range tests {
-		result := toFloat(tt.value)
+		result := internal.ToNumber(tt.value)
 		if result != tt.expected {
-			t.Errorf("toFloat(%v) = %v, expected %v", tt.value, result, tt.expected)
+			t.Errorf("ToNumber(%v) = %v, expected %v", tt.value, result, tt.expected)
 		}
 	}
 }
@@ -670,6 +682,10 @@ func TestEquals(t *testing.T) {
 		{nil, nil, true},
 		{5.0, "5", false},
 		{nil, 0.0, false},
+		{internal.Null, internal.Null, true},
+		{internal.Null, nil, true},
+		{internal.Null, 0.0, false},
+		{false, internal.Null, false},
 	}
 
 	for _, tt := range tests {
@@ -706,6 +722,41 @@ func TestDivisionByZero(t *testing.T) {
 	}
 }
 
+func TestImplicitConversions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`var u; "" + u;`, "undefined"},
+		{`"" + null;`, "null"},
+		{`"x" + true;`, "xtrue"},
+		{`"" + [1, [2, 3], null];`, "1,2,3,"},
+		{`[1, 2] + [3];`, "1,23"},
+		{`"5" * "2";`, 10.0},
+		{`"8" - 3;`, 5.0},
+		{`[5] * 2;`, 10.0},
+		{`-"3";`, -3.0},
+		{`true + 1;`, 2.0},
+		{`null + 1;`, 1.0},
+		{`"a" < "b";`, true},
+		{`"10" < "9";`, true},
+		{`"10" < 9;`, false},
+		{`"b" >= "b";`, true},
+		{`var o = {}; o[[1, 2]] = "pair"; o["1,2"];`, "pair"},
+		{`var o = {}; o[undefined] = 1; o["undefined"];`, 1.0},
+		{`1 == "1";`, false},
+		{`null == undefined;`, true},
+		{`try { "" + Symbol("id"); } catch (e) { e.name + ": " + e.message; }`, "TypeError: Cannot convert a Symbol value to a string"},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
 func TestArrayIndexing(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -720,6 +771,21 @@ func TestArrayIndexing(t *testing.T) {
 		{"var arr = [true, false]; arr[0];", true},
 		{"var arr = [1, 2, 3]; arr[5];", nil},
 		{"var arr = [1, 2, 3]; arr[-1];", nil},
+		{"[10, 20][0.5];", nil},
+		{"[10, 20][\"1\"];", 20.0},
+		{"[10, 20][1.0];", 20.0},
+		{"[10, 20][\"01\"];", nil},
+		{"var a = [1, 2]; a[\"0\"] = 7; a[0];", 7.0},
+		{"var a = [1]; a[a.length] = 2; a.length + a[1];", 4.0},
+		{"var a = []; a[2] = \"x\"; a.length;", 3.0},
+		{"var a = []; a[2] = \"x\"; a[0];", nil},
+		{"var a = [1]; a[0.5] = 2; a.length;", 1.0},
+		{"var i = { toString() { return \"1\"; } }; [5, 6][i];", 6.0},
+		// 2^32-1 and above aren't array indexes, just property names
+		{"var a = []; a[4294967295] = 1; a.length + a[4294967295];", 1.0},
+		{"var a = []; a[\"4294967296\"] = \"x\"; a.length;", 0.0},
+		// A write far past the end would need a huge dense allocation
+		{"var a = []; try { a[4294967294] = 1; } catch (e) { e.name + \" \" + a.length; }", "RangeError 0"},
 	}
 
 	for _, tt := range tests {
@@ -783,6 +849,30 @@ func TestArrayLengthProperty(t *testing.T) {
 	}
 }
 
+func TestArrayLengthAssignment(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`var a = [1, 2, 3, 4]; a.length = 2; a.join() + " " + a.length;`, "1,2 2"},
+		{`var a = [1, 2]; a.length = 4; a.length + " " + a[3];`, "4 undefined"},
+		{`var a = [1, 2, 3]; a.length = 0; a.push(9); a.join();`, "9"},
+		{`var a = [1, 2, 3]; a["length"] = "1"; a.join();`, "1"},
+		{`var a = [1]; try { a.length = -1; } catch (e) { e.name + ": " + e.message; }`, "RangeError: Invalid array length"},
+		{`var a = [1]; try { a.length = 1.5; } catch (e) { e.name + ": " + e.message; }`, "RangeError: Invalid array length"},
+		{`var a = Object.freeze([1, 2]); a.length = 0; a.join();`, "1,2"},
+		{`var a = Object.seal([1, 2]); a.length = 1; a.join();`, "1,2"},
+		{`var a = Object.preventExtensions([1, 2]); a.length = 1; a.length = 3; a.length;`, 1.0},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
 func TestObjectIndexing(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -826,3 +916,514 @@ func TestArrayIndexingInExpressions(t *testing.T) {
 		}
 	}
 }
+
+func TestObjectLiteralPropertyForms(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`var name = "Ann"; var age = 30; var obj = {name, age}; obj.name + obj.age;`, "Ann30"},
+		{`var obj = { greet(who) { return "hi " + who; } }; obj.greet("Bob");`, "hi Bob"},
+		{`var obj = { count: 1, next() { return this.count + 1; } }; obj.next();`, 2.0},
+		{`var key = "dyn"; var obj = { [key + "amic"]: 5 }; obj.dynamic;`, 5.0},
+		{`var obj = { [1 + 1]: "two" }; obj[2];`, "two"},
+		{`var obj = { 1: "one", 2.50: "two and a half" }; obj[1] + "," + obj["2.5"];`, "one,two and a half"},
+		{`var obj = { a: 1, b: 2, }; obj.a + obj.b;`, 3.0},
+		{`var obj = { a: 1, a: 2 }; obj.a;`, 2.0},
+		{`var obj = { first: "Ada", last: "Lovelace", get full() { return this.first + " " + this.last; } }; obj.full;`, "Ada Lovelace"},
+		{`var obj = { _v: 0, get v() { return this._v; }, set v(x) { this._v = x * 2; } }; obj.v = 21; obj.v;`, 42.0},
+		{`var obj = { get: 1, set: 2 }; obj.get + obj.set;`, 3.0},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestObjectLiteralEvaluationOrder(t *testing.T) {
+	input := `
+		var calls = [];
+		var record = function(name) { calls.push(name); return name; };
+		var obj = { z: record("z"), [record("key")]: record("value"), a: record("a") };
+		calls;
+	`
+	result := testEval(input)
+
+	arrRef, ok := result.(*array.ArrayReference)
+	if !ok {
+		t.Fatalf("Expected *ArrayReference, got %T", result)
+	}
+
+	expected := []string{"z", "key", "value", "a"}
+	elements := *arrRef.Elements
+	if len(elements) != len(expected) {
+		t.Fatalf("Expected %d calls, got %d (%v)", len(expected), len(elements), elements)
+	}
+	for i, name := range expected {
+		if elements[i] != name {
+			t.Errorf("Call %d: expected %q, got %v", i, name, elements[i])
+		}
+	}
+}
+
+func TestJSONParse(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`JSON.parse("[1,2]")[0];`, 1.0},
+		{`JSON.parse("[1,2,3]").map(x => x * 2).join();`, "2,4,6"},
+		{`JSON.parse("[1,2]").length;`, 2.0},
+		{`Array.isArray(JSON.parse("[]"));`, true},
+		{`var data = JSON.parse('{"user":{"name":"Ann","tags":["a","b"]}}'); data.user.tags[1];`, "b"},
+		{`Object.keys(JSON.parse('{"b":1,"a":2}')).join();`, "b,a"},
+		{`var o = JSON.parse('{"a":1}'); o.b = 2; JSON.stringify(o);`, `{"a":1,"b":2}`},
+		{`JSON.parse("null");`, internal.Null},
+		{`typeof JSON.parse('{"a":null}').a;`, "object"},
+		{`JSON.parse(" 42 ");`, 42.0},
+		{`JSON.parse('"text"');`, "text"},
+		{`JSON.parse("9007199254740993");`, 9007199254740992.0},
+		{`JSON.stringify(JSON.parse('{"a":[1,{"b":true}]}'));`, `{"a":[1,{"b":true}]}`},
+		{`JSON.parse('{"a":1,"b":2}', (key, value) => key == "a" ? undefined : value).a;`, nil},
+		{`JSON.parse('{"a":1,"b":[2]}', (key, value) => typeof value == "number" ? value * 10 : value).b[0];`, 20.0},
+		{`JSON.parse('{"a":{"b":1}}', function (key, value) { if (key == "b") { return this.b + 1; } return value; }).a.b;`, 2.0},
+		{`var keys = []; JSON.parse('{"a":[1],"b":2}', (key, value) => { keys.push(key); return value; }); keys.join("|");`, "0|a|b|"},
+		{`try { JSON.parse('{"a":}'); } catch (e) { e.name + ": " + e.message; }`, "SyntaxError: Unexpected token } in JSON at position 5"},
+		{`try { JSON.parse("[1,2"); } catch (e) { e.name + ": " + e.message; }`, "SyntaxError: Unexpected end of JSON input"},
+		{`try { JSON.parse(); } catch (e) { e.name + ": " + e.message; }`, "SyntaxError: Unexpected token u in JSON at position 0"},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestObjectPropertyOrder(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`JSON.stringify({ zebra: 1, apple: 2, mango: 3 });`, `{"zebra":1,"apple":2,"mango":3}`},
+		{`JSON.stringify({ b: 1, 10: "ten", a: 2, 2: "two" });`, `{"2":"two","10":"ten","b":1,"a":2}`},
+		{`var obj = { first: 1 }; obj.second = 2; obj.first = 3; JSON.stringify(obj);`, `{"first":3,"second":2}`},
+		{`class P { y = 1; constructor() { this.x = 2; } } JSON.stringify(new P());`, `{"y":1,"x":2}`},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if str, ok := result.(string); !ok || str != tt.expected {
+			t.Errorf("For input %q: expected %s, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestJSONStringify(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`JSON.stringify([1, "a", true, null]);`, `[1,"a",true,null]`},
+		{`JSON.stringify({ list: [1, 2], nested: { ok: true } });`, `{"list":[1,2],"nested":{"ok":true}}`},
+		{`JSON.stringify({ a: undefined, f: function () {}, g: x => x, b: 1 });`, `{"b":1}`},
+		{`JSON.stringify([undefined, function () {}, NaN, Infinity]);`, `[null,null,null,null]`},
+		{`JSON.stringify(undefined);`, nil},
+		{`JSON.stringify(x => x);`, nil},
+		{`JSON.stringify("héllo <b>");`, `"héllo <b>"`},
+		{`JSON.stringify({ a: 1, b: 2, c: 3 }, ["c", "a"]);`, `{"c":3,"a":1}`},
+		{`JSON.stringify({ a: 1, b: "x" }, (key, value) => typeof value == "number" ? value * 10 : value);`, `{"a":10,"b":"x"}`},
+		{`JSON.stringify({ a: 1, b: "x" }, (key, value) => typeof value == "string" ? undefined : value);`, `{"a":1}`},
+		{`var keys = []; JSON.stringify({ a: { b: 1 } }, function (key, value) { keys.push(key); return value; }); keys.join(",");`, ",a,b"},
+		{`var holder; JSON.stringify({ a: 1 }, function (key, value) { if (key == "a") { holder = this; } return value; }); holder.a;`, 1.0},
+		{`JSON.stringify({ a: [1] }, null, 2);`, "{\n  \"a\": [\n    1\n  ]\n}"},
+		{`JSON.stringify({ a: 1 }, null, "--");`, "{\n--\"a\": 1\n}"},
+		{`JSON.stringify({ a: [] }, null, 4);`, "{\n    \"a\": []\n}"},
+		{`JSON.stringify({ toJSON: function (key) { return "custom"; } });`, `"custom"`},
+		{`JSON.stringify({ inner: { toJSON: key => key } });`, `{"inner":"inner"}`},
+		{`JSON.stringify(new Map([["a", 1]]));`, `{}`},
+		{`try { var o = {}; o.self = o; JSON.stringify(o); } catch (e) { e.name + ": " + e.message; }`, "TypeError: Converting circular structure to JSON"},
+		{`try { var a = []; a.push(a); JSON.stringify(a); } catch (e) { e.name + ": " + e.message; }`, "TypeError: Converting circular structure to JSON"},
+		{`var shared = { x: 1 }; JSON.stringify([shared, shared]);`, `[{"x":1},{"x":1}]`},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestOptionalChaining(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`var user = { address: { street: "Main" } }; user?.address?.street;`, "Main"},
+		{`var user = null; user?.address;`, nil},
+		{`var user; user?.address;`, nil},
+		{`var user = null; user?.address.street.name;`, nil},
+		{`var user = { address: null }; user.address?.street;`, nil},
+		{`var arr = [1, 2, 3]; arr?.[1];`, 2.0},
+		{`var arr = null; arr?.[1];`, nil},
+		{`var key = "a"; var obj = { a: 5 }; obj?.[key];`, 5.0},
+		{`var f = function() { return 42; }; f?.();`, 42.0},
+		{`var f = null; f?.();`, nil},
+		{`var obj = { count: 1, get() { return this.count; } }; obj?.get();`, 1.0},
+		{`var obj = { count: 1, get() { return this.count; } }; obj.missing?.();`, nil},
+		{`var obj = null; obj?.method();`, nil},
+		{`var obj = null; obj?.[0] == undefined;`, true},
+		{`var obj = null; obj?.a == null;`, true},
+		{`var obj = { a: { b: 2 } }; obj?.a.b + 1;`, 3.0},
+		{`var obj = { n: 0 }; obj?.n;`, 0.0},
+		{`var obj = { n: false }; obj?.n?.x;`, nil},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestOptionalChainingShortCircuit(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		// Arguments are not evaluated when the call is skipped
+		{`var calls = 0; var f = null; f?.(calls = 1); calls;`, 0.0},
+		// Index expressions are not evaluated either
+		{`var calls = 0; var obj = null; obj?.[calls = 1]; calls;`, 0.0},
+		{`var calls = 0; var obj = null; obj?.a.b(calls = 1); calls;`, 0.0},
+		// Parentheses end the chain
+		{`var obj = null; (obj?.a) == undefined;`, true},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestNullLiteral(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`null;`, internal.Null},
+		{`var x = null; x;`, internal.Null},
+		{`null == null;`, true},
+		{`null == undefined;`, true},
+		{`null == 0;`, false},
+		{`!null;`, true},
+		{`JSON.stringify({ a: null });`, `{"a":null}`},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestConditionalExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`true ? 1 : 2;`, 1.0},
+		{`false ? 1 : 2;`, 2.0},
+		{`var x = 5; x > 3 ? "big" : "small";`, "big"},
+		{`var x = 0; x ? "yes" : "no";`, "no"},
+		{`var n = 15; n < 10 ? "low" : n < 20 ? "mid" : "high";`, "mid"},
+		{`var n = 25; n < 10 ? "low" : n < 20 ? "mid" : "high";`, "high"},
+		{`true ? false ? 1 : 2 : 3;`, 2.0},
+		{`var r = 1 + 1 == 2 ? "ok" : "bad"; r;`, "ok"},
+		{`var a = 0; var b = 0; true ? a = 1 : b = 1; a + b;`, 1.0},
+		// Only the chosen branch is evaluated
+		{`var calls = 0; false ? calls = 1 : 5; calls;`, 0.0},
+		{`var f = function(x) { return x ? "t" : "f"; }; f(1) + f(0);`, "tf"},
+		{`var obj = null; obj?.a ? 1 : 2;`, 2.0},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestSequenceExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`(1, 2, 3);`, 3.0},
+		{`var x = 0; var y = (x = 5, x * 2); y;`, 10.0},
+		{`var a = 1; var b = 2; a = 10, b = 20; a + b;`, 30.0},
+		// Commas inside calls, arrays and objects still separate items
+		{`var f = function(a, b) { return b; }; f((1, 2), 3);`, 3.0},
+		{`var arr = [1, (2, 3)]; arr[1];`, 3.0},
+		{`var obj = { a: (1, 2), b: 3 }; obj.a;`, 2.0},
+		{`var x = 0; var f = function(v) { return v; }; f(x = 4, 0); x;`, 4.0},
+		{`true ? (1, 2) : 3;`, 2.0},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestMathNamespace(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`Math.floor(3.7);`, 3.0},
+		{`Math.round(-2.5);`, -2.0},
+		{`Math.max(1, 5, 3);`, 5.0},
+		{`Math.min(4, 2, 8);`, 2.0},
+		{`Math.abs("-3");`, 3.0},
+		{`Math.PI > 3.14;`, true},
+		{`var r = Math.random(); r >= 0 ? r < 1 : false;`, true},
+		{`[1.5, 2.5].map(Math.round).join();`, "2,3"},
+		{`typeof Math;`, "object"},
+		{`typeof Math.sqrt;`, "function"},
+		{`"" + Math.max();`, "-Infinity"},
+		{`Math.PI = 3; Math.PI;`, 3.141592653589793},
+		{`Math.E = 1; Math.E;`, 2.718281828459045},
+		{`Math.SQRT2 = 0; Math.SQRT2 > 1.41;`, true},
+		{`Object.keys(Math).includes("PI");`, false},
+		{`Object.getOwnPropertyDescriptor(Math, "LN2").writable;`, false},
+		{`"use strict"; try { Math.PI = 3; } catch (e) { e.name + ": " + e.message; }`,
+			"TypeError: Cannot assign to read only property 'PI' of object '#<Object>'"},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestObjectStaticMethods(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`Object.keys({ b: 1, a: 2, 1: 3 }).join();`, "1,b,a"},
+		{`Object.values({ a: 1, b: 2 }).join();`, "1,2"},
+		{`Object.entries({ a: 1, b: 2 })[1].join("=");`, "b=2"},
+		{`Object.keys(["x", "y"]).join();`, "0,1"},
+		{`Object.keys("hi").length;`, 2.0},
+		{`var total = 0; for (let e of Object.entries({ a: 1, b: 2 })) { total = total + e[1]; } total;`, 3.0},
+		{`Object.fromEntries([["a", 1], ["b", 2]]).b;`, 2.0},
+		{`Object.fromEntries(Object.entries({ a: 1 }).map(function(e) { return [e[0], e[1] * 10]; })).a;`, 10.0},
+		{`var o = Object.assign({ a: 1 }, { b: 2 }, null, { a: 3 }); o.a + o.b;`, 5.0},
+		{`var t = {}; Object.assign(t, { a: 1 }) == t;`, true},
+		{`Object.is(NaN, NaN);`, true},
+		{`Object.is(0, -0);`, false},
+		{`Object.is("a", "a");`, true},
+		{`Object.hasOwn({ a: undefined }, "a");`, true},
+		{`Object.hasOwn({}, "a");`, false},
+		{`Object.hasOwn([1, 2], 1);`, true},
+		{`var g = Object.groupBy([1, 2, 3, 4], function(n) { return n > 2 ? "big" : "small"; }); g.small.join() + "|" + g.big.join();`, "1,2|3,4"},
+		{`Object.keys(Object.groupBy(["b", "a", "b"], function(s) { return s; })).join();`, "b,a"},
+		{`Object.getOwnPropertyNames([1]).join();`, "0,length"},
+		{`typeof Object;`, "function"},
+		{`({}) instanceof Object;`, true},
+		{`[] instanceof Object;`, true},
+		{`try { Object.keys(null); } catch (e) { e.name + ": " + e.message; }`, "TypeError: Cannot convert undefined or null to object"},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestPropertyDescriptors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`var o = {}; Object.defineProperty(o, "id", { value: 7 }); o.id;`, 7.0},
+		{`var o = {}; Object.defineProperty(o, "id", { value: 7 }); o.id = 8; o.id;`, 7.0},
+		{`var o = {}; Object.defineProperty(o, "id", { value: 7, writable: true }); o.id = 8; o.id;`, 8.0},
+		{`var o = { a: 1 }; Object.defineProperty(o, "id", { value: 7 }); Object.keys(o).join();`, "a"},
+		{`var o = { a: 1 }; Object.defineProperty(o, "id", { value: 7 }); JSON.stringify(o);`, `{"a":1}`},
+		{`var o = { a: 1 }; Object.defineProperty(o, "id", { value: 7 }); Object.getOwnPropertyNames(o).join();`, "a,id"},
+		{`var o = {}; Object.defineProperty(o, "id", { value: 7, enumerable: true }); JSON.stringify(o);`, `{"id":7}`},
+		{`var o = {}; Object.defineProperty(o, "id", { value: 7 }); try { Object.defineProperty(o, "id", { value: 8 }); } catch (e) { e.name + ": " + e.message; }`, "TypeError: Cannot redefine property: id"},
+		{`var o = {}; Object.defineProperty(o, "id", { value: 7, configurable: true }); Object.defineProperty(o, "id", { value: 8 }); o.id;`, 8.0},
+		{`var o = { a: 1, b: 2 }; Object.defineProperty(o, "sum", { get() { return this.a + this.b; } }); o.a = 5; o.sum;`, 7.0},
+		{`var o = {}; var log = ""; Object.defineProperty(o, "x", { set(v) { log = log + v; } }); o.x = 1; o.x = 2; log;`, "12"},
+		{`var o = Object.defineProperties({}, { a: { value: 1, enumerable: true }, b: { value: 2 } }); Object.keys(o).join() + o.b;`, "a2"},
+		{`var d = Object.getOwnPropertyDescriptor({ a: 1 }, "a"); [d.value, d.writable, d.enumerable, d.configurable].join();`, "1,true,true,true"},
+		{`var o = {}; Object.defineProperty(o, "a", { value: 1 }); var d = Object.getOwnPropertyDescriptor(o, "a"); [d.writable, d.enumerable, d.configurable].join();`, "false,false,false"},
+		{`var d = Object.getOwnPropertyDescriptor({ get a() { return 1; } }, "a"); typeof d.get + typeof d.set + typeof d.value;`, "functionundefinedundefined"},
+		{`Object.getOwnPropertyDescriptor({}, "a");`, nil},
+		{`Object.getOwnPropertyDescriptor([1, 2], "length").value;`, 2.0},
+		{`try { Object.defineProperty({}, "a", { get() {}, value: 1 }); } catch (e) { e.name + ": " + e.message; }`, "TypeError: Invalid property descriptor. Cannot both specify accessors and a value or writable attribute"},
+		{`try { Object.defineProperty({}, "a", 1); } catch (e) { e.name + ": " + e.message; }`, "TypeError: Property description must be an object: 1"},
+		{`try { Object.defineProperty(1, "a", {}); } catch (e) { e.name + ": " + e.message; }`, "TypeError: Object.defineProperty called on non-object"},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestPropertiesOfBuiltinObjects(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`var f = function() {}; f.x = 1; f.x;`, 1.0},
+		{`var f = function() {}; f.cache = {}; f.cache.a = 2; Object.keys(f).join() + " " + f.cache.a;`, "cache 2"},
+		{`var f = () => 1; f["y"] = 2; f.y + f();`, 3.0},
+		{`var m = new Map(); m.foo = 1; m.foo + " " + m.size;`, "1 0"},
+		{`var m = new Map(); m.get = 5; m.get;`, 5.0},
+		{`var s = new Set([1]); s.size = 9; s.size;`, 1.0},
+		{`var d = new Date(0); d.foo = 1; d.foo + " " + Object.keys(d).join();`, "1 foo"},
+		{`var a = [1]; a.foo = 1; a.foo + " " + Object.keys(a).join();`, "1 0,foo"},
+		{`var r = /a/g; r.k = 1; r.source = "b"; r.k + " " + r.source;`, "1 a"},
+		{`var p = Promise.resolve(1); p.z = 2; p.z;`, 2.0},
+		{`parseInt.x = 3; parseInt.x;`, 3.0},
+		{`Math.foo = 3; Math.foo;`, 3.0},
+		{`"abc".x = 1; "abc".x;`, nil},
+		{`var f = function() {}; Object.defineProperty(f, "id", { get() { return 7; } }); f.id = 1; f.id;`, 7.0},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestStrictModeAssignments(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		// Sloppy mode ignores assignments that aren't allowed
+		{`var o = Object.freeze({ a: 1 }); o.a = 2; o.b = 3; o.a + " " + o.b;`, "1 undefined"},
+
+		// Strict mode throws for each kind
+		{`"use strict"; var o = Object.freeze({ a: 1 }); try { o.a = 2; } catch (e) { e.name + ": " + e.message; }`, "TypeError: Cannot assign to read only property 'a' of object '#<Object>'"},
+		{`"use strict"; var o = Object.preventExtensions({}); try { o.b = 2; } catch (e) { e.name + ": " + e.message; }`, "TypeError: Cannot add property b, object is not extensible"},
+		{`"use strict"; var o = { get a() { return 1; } }; try { o.a = 2; } catch (e) { e.name + ": " + e.message; }`, "TypeError: Cannot set property a of #<Object> which has only a getter"},
+		{`"use strict"; try { "abc".x = 1; } catch (e) { e.name + ": " + e.message; }`, "TypeError: Cannot create property 'x' on string 'abc'"},
+		{`"use strict"; var a = Object.freeze([1]); try { a[0] = 2; } catch (e) { e.name + ": " + e.message; }`, "TypeError: Cannot assign to read only property '0' of object '[object Array]'"},
+		{`"use strict"; var a = Object.freeze([1]); try { a.length = 0; } catch (e) { e.name + ": " + e.message; }`, "TypeError: Cannot assign to read only property 'length' of object '[object Array]'"},
+		{`"use strict"; var m = new Map(); try { m.size = 1; } catch (e) { e.name + ": " + e.message; }`, "TypeError: Cannot set property size of #<Object> which has only a getter"},
+		{`"use strict"; var o = Object.freeze({}); try { o.a = 1; } catch (e) { e.stack; }`, "TypeError: Cannot add property a, object is not extensible\n    at 1:50"},
+
+		// Functions: a directive in the body, or being defined in strict code
+		{`var f = function() { "use strict"; Object.freeze({}).a = 1; }; try { f(); } catch (e) { e.name; }`, "TypeError"},
+		{`var f = function() { "a"; "use strict"; Object.freeze({}).a = 1; }; try { f(); } catch (e) { e.name; }`, "TypeError"},
+		{`var f = function() { var x = 1; "use strict"; Object.freeze({}).a = 1; return "ignored"; }; f();`, "ignored"},
+		{`"use strict"; var f = function() { return () => { Object.freeze({}).a = 1; }; }; try { f()(); } catch (e) { e.name; }`, "TypeError"},
+		{`var f = function() { "use strict"; }; Object.freeze({}).a = 1; "sloppy";`, "sloppy"},
+
+		// Class bodies are strict
+		{`class A { static set() { Object.freeze({}).a = 1; } } try { A.set(); } catch (e) { e.name; }`, "TypeError"},
+		{`class A { m() { super.x = 1; } } Object.freeze(A.prototype); var a = Object.freeze(new A()); try { a.m(); } catch (e) { e.name; }`, "TypeError"},
+
+		// Object.assign always throws, like a strict assignment
+		{`try { Object.assign(Object.freeze({ a: 1 }), { a: 2 }); } catch (e) { e.name + ": " + e.message; }`, "TypeError: Cannot assign to read only property 'a' of object '#<Object>'"},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestStrictModeEndsWithTheProgram(t *testing.T) {
+	env := environment.NewGlobalEnvironment()
+	program := func(input string) Value {
+		return Eval(parser.New(input).ParseProgram(), env)
+	}
+
+	program(`"use strict"; var frozen = Object.freeze({});`)
+	if result := program(`frozen.a = 1; "sloppy";`); result != "sloppy" {
+		t.Errorf("Expected the next program to run in sloppy mode, got %v", result)
+	}
+}
+
+func TestFreezeAndSeal(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`var o = Object.freeze({ port: 80 }); o.port = 8080; o.port;`, 80.0},
+		{`var o = Object.freeze({ port: 80 }); o.host = "x"; o.host;`, nil},
+		{`Object.isFrozen(Object.freeze({ a: 1 }));`, true},
+		{`Object.isFrozen({ a: 1 });`, false},
+		{`Object.isFrozen(5);`, true},
+		{`var o = Object.seal({ a: 1 }); o.a = 2; o.b = 3; o.a + typeof o.b;`, "2undefined"},
+		{`Object.isSealed(Object.seal({}));`, true},
+		{`Object.isSealed(Object.freeze({ a: 1 }));`, true},
+		{`var o = Object.preventExtensions({ a: 1 }); o.b = 1; Object.isExtensible(o) + typeof o.b;`, "falseundefined"},
+		{`var o = Object.freeze({}); try { Object.defineProperty(o, "a", { value: 1 }); } catch (e) { e.name + ": " + e.message; }`, "TypeError: Cannot define property a, object is not extensible"},
+		{`var o = Object.freeze({ inner: { n: 1 } }); o.inner.n = 2; o.inner.n;`, 2.0},
+		{`class P { constructor() { this.x = 1; } } var p = Object.freeze(new P()); p.x = 2; p.x;`, 1.0},
+
+		// Arrays
+		{`var a = Object.freeze([1, 2]); Object.isFrozen(a) + " " + Object.isSealed(a) + " " + Object.isExtensible(a);`, "true true false"},
+		{`var a = Object.freeze([1, 2]); a[0] = 9; a[2] = 3; a.join();`, "1,2"},
+		{`var a = Object.freeze([1, 2, 3]); try { a.push(4); } catch (e) { e.name + ": " + e.message; }`, "TypeError: Cannot add property 3, object is not extensible"},
+		{`var a = Object.freeze([1, 2, 3]); try { a.pop(); } catch (e) { e.name + ": " + e.message; }`, "TypeError: Cannot delete property '2' of [object Array]"},
+		{`var a = Object.freeze([2, 1]); try { a.sort(); } catch (e) { e.name + ": " + e.message; }`, "TypeError: Cannot assign to read only property '0' of object '[object Array]'"},
+		{`var a = Object.freeze([2, 1]); a.toSorted().join() + " " + a.join();`, "1,2 2,1"},
+		{`var a = Object.seal([1, 2]); a[0] = 9; a[2] = 3; a.join() + " " + Object.isFrozen(a);`, "9,2 false"},
+		{`var a = Object.seal([1, 2]); a.reverse(); try { a.splice(0, 1); } catch (e) { a.join() + " " + e.name; }`, "2,1 TypeError"},
+		{`var a = Object.preventExtensions([1, 2]); a.pop(); a[5] = 1; a.length + " " + Object.isSealed(a);`, "1 false"},
+		{`var a = Object.preventExtensions([1]); try { a.unshift(0); } catch (e) { e.message; }`, "Cannot add property 1, object is not extensible"},
+		{`Object.isFrozen(Object.preventExtensions([]));`, true},
+		{`class List extends Array { constructor() { super(); this.tag = "a"; } } var l = Object.freeze(new List()); l.tag = "b"; l.tag + " " + Object.isFrozen(l);`, "a true"},
+
+		// Functions and classes
+		{`var f = function() {}; Object.isFrozen(Object.freeze(f)) + " " + Object.isExtensible(f);`, "true false"},
+		{`var f = function() {}; Object.isExtensible(f) + " " + Object.isFrozen(f);`, "true false"},
+		{`class C { static n = 1; } Object.freeze(C); C.n = 2; C.m = 3; C.n + " " + typeof C.m + " " + Object.isFrozen(C);`, "1 undefined true"},
+		{`class C { static n = 1; } Object.seal(C); C.n = 2; C.n + " " + Object.isSealed(C) + " " + Object.isFrozen(C);`, "2 true false"},
+
+		// Built-in objects lock their own properties, not their contents
+		{`var m = Object.freeze(new Map()); m.tag = "a"; m.set(1, 2); Object.isFrozen(m) + " " + typeof m.tag + " " + m.size;`, "true undefined 1"},
+		{`Object.isFrozen(new Map()) + " " + Object.isExtensible(new Set());`, "false true"},
+		{`var d = new Date(0); d.label = "epoch"; Object.freeze(d); d.label = "x"; d.label + " " + Object.isFrozen(d);`, "epoch true"},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package evaluator
+
+import (
+	"go-script/ast"
+	"go-script/environment"
+	"go-script/internal"
+)
+
+// evalThrowStatement evaluates "throw value"
+// The value travels up the Go stack as an *internal.Exception panic until
+// a try statement or the program itself recovers it.
+func evalThrowStatement(node *ast.ThrowStatement, env *environment.Environment) Value {
+	internal.Throw(Eval(node.Value, env))
+	return nil
+}
+
+// evalTryStatement evaluates try/catch/finally
+//
+// The finally block always runs: after the try block, after the catch block,
+// and while an exception or a generator return is unwinding through it.
+// A return inside finally replaces whatever was in flight.
+//
+// Example:
+//
+//	try { throw "boom"; } catch (e) { print("caught " + e); } finally { print("done"); }
+//	→ prints "caught boom", then "done"
+func evalTryStatement(node *ast.TryStatement, env *environment.Environment) (result Value) {
+	if node.Finally != nil {
+		defer func() {
+			pending := recover()
+
+			finalResult := Eval(node.Finally, env)
+			if returnValue, ok := finalResult.(*ReturnValue); ok {
+				result = returnValue
+				return
+			}
+
+			if pending != nil {
+				panic(pending)
+			}
+		}()
+	}
+
+	return evalTryCatch(node, env)
+}
+
+// evalTryCatch runs the try block and, if a JavaScript exception escapes it,
+// the catch block with the thrown value bound to the catch parameter.
+// Other panics (generator returns, interpreter bugs) are not caught.
+func evalTryCatch(node *ast.TryStatement, env *environment.Environment) (result Value) {
+	if node.Catch == nil {
+		return Eval(node.Block, env)
+	}
+
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+
+		exception, ok := r.(*internal.Exception)
+		if !ok {
+			panic(r)
+		}
+
+		catchEnv := environment.New(env)
+		if node.CatchParam != "" {
+			catchEnv.Set(node.CatchParam, exception.Value)
+		}
+		result = Eval(node.Catch, catchEnv)
+	}()
+
+	return Eval(node.Block, env)
+}
@@ -0,0 +1,178 @@
+package evaluator
+
+import (
+	"go-script/internal"
+	"go-script/module"
+	"testing"
+)
+
+func TestTryCatch(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`try { throw "boom"; } catch (e) { "caught " + e; }`, "caught boom"},
+		{`try { 1; } catch (e) { 2; }`, 1.0},
+		{`var r; try { throw { code: 42 }; } catch (err) { r = err.code; } r;`, 42.0},
+		{`var r = "none"; try { throw 1; } catch { r = "no binding"; } r;`, "no binding"},
+		{`var fail = function() { throw "deep"; };
+		var call = function() { return fail(); };
+		try { call(); } catch (e) { e; }`, "deep"},
+		{`try { try { throw "inner"; } catch (e) { throw e + "!"; } } catch (e) { e; }`, "inner!"},
+		{`var e = "outer"; try { throw "inner"; } catch (e) {} e;`, "outer"},
+		{`var f = function() { try { return "try"; } catch (e) { return "catch"; } };
+		f();`, "try"},
+		{`var f = function() { try { throw 1; } catch (e) { return "catch"; } };
+		f();`, "catch"},
+		{`[1, 2].map(function(x) { try { throw x; } catch (e) { return e * 10; } })[1];`, 20.0},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestTryFinally(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`var log = ""; try { log = log + "t"; } finally { log = log + "f"; } log;`, "tf"},
+		{`var log = ""; try { throw 1; } catch (e) { log = log + "c"; } finally { log = log + "f"; } log;`, "cf"},
+		{`var log = "";
+		try { try { throw "x"; } finally { log = log + "f"; } } catch (e) { log = log + "c" + e; }
+		log;`, "fcx"},
+		{`var log = "";
+		var f = function() { try { return "value"; } finally { log = "ran"; } };
+		f() + " " + log;`, "value ran"},
+		// return in finally wins over the pending return or exception
+		{`var f = function() { try { return "try"; } finally { return "finally"; } }; f();`, "finally"},
+		{`var f = function() { try { throw "lost"; } finally { return "finally"; } }; f();`, "finally"},
+		{`var log = "";
+		try { try { throw 1; } catch (e) { throw 2; } finally { log = "f"; } } catch (e) { log = log + e; }
+		log;`, "f2"},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestUncaughtException(t *testing.T) {
+	result := testEval(`var x = 1; throw "fatal " + x; x = 2;`)
+
+	exception, ok := result.(*internal.Exception)
+	if !ok {
+		t.Fatalf("Expected *internal.Exception, got %T (%v)", result, result)
+	}
+	if exception.Value != "fatal 1" {
+		t.Errorf("Expected thrown value 'fatal 1', got %v", exception.Value)
+	}
+	if exception.Error() != "Uncaught fatal 1" {
+		t.Errorf("Unexpected error message: %s", exception.Error())
+	}
+}
+
+func TestRuntimeErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`try { totl + 1; } catch (e) { e.name + ": " + e.message; }`, "ReferenceError: totl is not defined"},
+		{`typeof missing;`, "undefined"},
+		{`var p = print; typeof p;`, "function"},
+		{`var user = {}; try { user.save(); } catch (e) { e.name + ": " + e.message; }`, "TypeError: user.save is not a function"},
+		{`var cfg = { a: {} }; try { cfg.a.load(1); } catch (e) { e.name + ": " + e.message; }`, "TypeError: cfg.a.load is not a function"},
+		{`var handlers = {}; try { handlers["click"](); } catch (e) { e.name + ": " + e.message; }`, `TypeError: handlers["click"] is not a function`},
+		{`var n = 5; try { n(); } catch (e) { e.name + ": " + e.message; }`, "TypeError: n is not a function"},
+		{`var user; try { user.name; } catch (e) { e.name + ": " + e.message; }`, "TypeError: Cannot read properties of undefined (reading 'name')"},
+		{`var user = null; try { user.name.first; } catch (e) { e.name + ": " + e.message; }`, "TypeError: Cannot read properties of null (reading 'name')"},
+		{`var a = {}; try { a.b.c; } catch (e) { e.name + ": " + e.message; }`, "TypeError: Cannot read properties of undefined (reading 'c')"},
+		{`var list; try { list[0]; } catch (e) { e.name + ": " + e.message; }`, "TypeError: Cannot read properties of undefined (reading '0')"},
+		{`var user; try { user.greet(); } catch (e) { e.name + ": " + e.message; }`, "TypeError: Cannot read properties of undefined (reading 'greet')"},
+		{`var cfg = null; try { cfg.x = 1; } catch (e) { e.name + ": " + e.message; }`, "TypeError: Cannot set properties of null (setting 'x')"},
+		{`var f = function() {}; try { new f(); } catch (e) { e.name + ": " + e.message; }`, "TypeError: f is not a constructor"},
+		{`var user; user?.name;`, nil},
+		{`var user; user?.greet();`, nil},
+		{`var obj = {}; obj.missing?.();`, nil},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestErrorObjects(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`try { totl; } catch (e) { e instanceof ReferenceError; }`, true},
+		{`try { totl; } catch (e) { e instanceof Error; }`, true},
+		{`try { totl; } catch (e) { e instanceof TypeError; }`, false},
+		{`try { null.x; } catch (e) { e.stack; }`, "TypeError: Cannot read properties of null (reading 'x')\n    at 1:12"},
+		{`try { [].reduce(function() {}); } catch (e) { e instanceof TypeError; }`, true},
+		{`try { JSON.parse("{"); } catch (e) { e.name; }`, "SyntaxError"},
+		{`var e = new TypeError("bad input"); e.name + ": " + e.message;`, "TypeError: bad input"},
+		{`"" + new RangeError("too big");`, "RangeError: too big"},
+		{`new Error("x").stack;`, "Error: x\n    at 1:1"},
+		{`Error("called").message;`, "called"},
+		{`new Error().message;`, ""},
+		{`new Error("x", { cause: 42 }).cause;`, 42.0},
+		{`new SyntaxError("s") instanceof Error;`, true},
+		{`Object.keys(new Error("x")).length;`, 0.0},
+		{`var e = new AggregateError([1, 2], "all failed"); e.message + " " + e.errors.length;`, "all failed 2"},
+		{`try { throw new TypeError("mine"); } catch (e) { e.message; }`, "mine"},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+
+	if result := testEvalLog(`var log; Promise.any([]).catch(function(e) { log = e instanceof AggregateError; });`); result != true {
+		t.Errorf("Expected Promise.any to reject with an AggregateError, got %v", result)
+	}
+}
+
+func TestRuntimeErrorLocation(t *testing.T) {
+	result := testEval("var total = 1;\nprint(totl);")
+
+	exception, ok := result.(*internal.Exception)
+	if !ok {
+		t.Fatalf("Expected *internal.Exception, got %T (%v)", result, result)
+	}
+	if exception.Location != "2:7" {
+		t.Errorf("Expected the error at 2:7, got %q", exception.Location)
+	}
+	expected := "Uncaught ReferenceError: totl is not defined\n    at 2:7"
+	if exception.Error() != expected {
+		t.Errorf("Expected %q, got %q", expected, exception.Error())
+	}
+}
+
+func TestRuntimeErrorLocationInModule(t *testing.T) {
+	files := module.MapLoader{
+		"main.js": `import { run } from "./lib.js"; run();`,
+		"lib.js":  "export var run = function() {\n  return config.port;\n};",
+	}
+
+	exception, ok := testEvalModule(files).(*internal.Exception)
+	if !ok {
+		t.Fatalf("Expected an exception")
+	}
+	if thrown(exception) != "ReferenceError: config is not defined" || exception.Location != "lib.js:2:10" {
+		t.Errorf("Expected a ReferenceError at lib.js:2:10, got %v at %q", thrown(exception), exception.Location)
+	}
+}
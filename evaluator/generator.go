@@ -0,0 +1,306 @@
+package evaluator
+
+import (
+	"go-script/ast"
+	"go-script/environment"
+	"go-script/internal"
+)
+
+// Generator is the state behind a generator object
+//
+// The evaluator is a recursive tree walker, so a generator body can't simply
+// return halfway through and pick up later. Instead each generator body runs
+// on its own goroutine, used as a coroutine: the caller and the body hand
+// control back and forth over two unbuffered channels, so exactly one side
+// runs at any time and the script still behaves as if single threaded.
+//
+// Example:
+//
+//	var gen = function*() { var x = yield 1; yield x * 2; };
+//	var it = gen();
+//	it.next()   → { value: 1, done: false }   body runs until the first yield
+//	it.next(5)  → { value: 10, done: false }  x = 5, body runs to the second yield
+//	it.next()   → { value: undefined, done: true }
+//
+// A generator that is abandoned before it finishes keeps its goroutine parked
+// until the program exits.
+//
+// Async functions run on the same machinery: await hands a promise to the
+// driver in callAsyncFunction instead of a value to next()'s caller.
+type Generator struct {
+	fn      *Function
+	this    Value
+	args    []Value
+	state   generatorState
+	keyword string // binding the body finds its coroutine under: "yield" or "await"
+
+	resumes chan generatorResume // caller → body: next(v), throw(e) or return(v)
+	yields  chan generatorYield  // body → caller: a yielded value or the outcome
+}
+
+type generatorState int
+
+const (
+	generatorSuspendedStart generatorState = iota // created, body not started
+	generatorSuspendedYield                       // paused at a yield
+	generatorRunning                              // body is executing
+	generatorCompleted                            // body finished, threw or was returned
+)
+
+type resumeMode int
+
+const (
+	resumeNext resumeMode = iota
+	resumeThrow
+	resumeReturn
+)
+
+// generatorResume is sent to the body to continue it
+type generatorResume struct {
+	mode  resumeMode
+	value Value
+}
+
+// generatorYield is sent back to the caller when the body pauses or ends
+type generatorYield struct {
+	value     Value
+	done      bool
+	exception *internal.Exception // the body threw
+	panic     interface{}         // the body hit an interpreter error
+}
+
+// generatorReturn unwinds a paused body when return() is called, running its
+// finally blocks on the way out. catch blocks never see it.
+type generatorReturn struct {
+	value Value
+}
+
+// newGeneratorObject is what calling a generator function returns: an
+// iterator whose next, return and throw methods drive the body
+func newGeneratorObject(fn *Function, this Value, args []Value) *Object {
+	gen := &Generator{fn: fn, this: this, args: args, keyword: "yield"}
+
+	methods := internal.NewObject()
+	methods.Set("next", &internal.Builtin{
+		Name: "next",
+		Fn: func(args ...interface{}) interface{} {
+			return gen.resume(resumeNext, firstArg(args))
+		},
+	})
+	methods.Set("return", &internal.Builtin{
+		Name: "return",
+		Fn: func(args ...interface{}) interface{} {
+			return gen.resume(resumeReturn, firstArg(args))
+		},
+	})
+	methods.Set("throw", &internal.Builtin{
+		Name: "throw",
+		Fn: func(args ...interface{}) interface{} {
+			return gen.resume(resumeThrow, firstArg(args))
+		},
+	})
+	methods.Set(internal.SymbolToStringTag, "Generator")
+
+	obj := internal.NewObject()
+	obj.Prototype = methods
+	methods.Set(internal.SymbolIterator, &internal.Builtin{
+		Name: "[Symbol.iterator]",
+		Fn: func(args ...interface{}) interface{} {
+			return obj
+		},
+	})
+
+	return obj
+}
+
+func firstArg(args []interface{}) Value {
+	if len(args) == 0 {
+		return nil
+	}
+	return args[0]
+}
+
+// resume continues the body and waits until it yields or finishes
+// It returns the { value, done } result, or rethrows what the body threw.
+func (g *Generator) resume(mode resumeMode, value Value) Value {
+	switch g.state {
+	case generatorRunning:
+		internal.ThrowError("TypeError", "Generator is already running")
+	case generatorCompleted:
+		return g.resumeCompleted(mode, value)
+	case generatorSuspendedStart:
+		// return() and throw() before the first next() never run the body
+		if mode != resumeNext {
+			g.state = generatorCompleted
+			return g.resumeCompleted(mode, value)
+		}
+	}
+
+	out := g.step(mode, value)
+	return internal.IterResult(out.value, out.done)
+}
+
+// step hands control to the body and waits until it yields or finishes
+// Whatever the body threw is rethrown here.
+func (g *Generator) step(mode resumeMode, value Value) generatorYield {
+	if g.state == generatorSuspendedStart {
+		g.start()
+	}
+
+	g.state = generatorRunning
+	g.resumes <- generatorResume{mode: mode, value: value}
+	out := <-g.yields
+
+	if out.done || out.exception != nil || out.panic != nil {
+		g.state = generatorCompleted
+	} else {
+		g.state = generatorSuspendedYield
+	}
+
+	if out.panic != nil {
+		panic(out.panic)
+	}
+	if out.exception != nil {
+		panic(out.exception)
+	}
+
+	return out
+}
+
+// resumeCompleted handles next/return/throw on a generator that has finished
+func (g *Generator) resumeCompleted(mode resumeMode, value Value) Value {
+	switch mode {
+	case resumeReturn:
+		return internal.IterResult(value, true)
+	case resumeThrow:
+		internal.Throw(value)
+	}
+	return internal.IterResult(nil, true)
+}
+
+// start launches the body on its own goroutine. It waits for the first
+// resume message; the value passed to the first next() is ignored.
+func (g *Generator) start() {
+	g.resumes = make(chan generatorResume)
+	g.yields = make(chan generatorYield)
+
+	go g.run()
+}
+
+func (g *Generator) run() {
+	defer func() {
+		switch r := recover().(type) {
+		case nil:
+		case *internal.Exception:
+			g.yields <- generatorYield{exception: r}
+		case *generatorReturn:
+			g.yields <- generatorYield{value: r.value, done: true}
+		default:
+			g.yields <- generatorYield{panic: r}
+		}
+	}()
+
+	<-g.resumes
+
+	env := newFunctionEnvironment(g.fn, g.this, g.args)
+	env.Set(g.keyword, g)
+
+	// Unlike ordinary functions here, a generator only produces a final
+	// value through an explicit return
+	var value Value
+	if returnValue, ok := Eval(g.fn.Body, env).(*ReturnValue); ok {
+		value = returnValue.Value
+	}
+
+	g.yields <- generatorYield{value: value, done: true}
+}
+
+// yield runs on the body's goroutine: it hands a value to the caller and
+// pauses until the next resume message arrives
+func (g *Generator) yield(value Value) Value {
+	g.yields <- generatorYield{value: value}
+	return g.receive(<-g.resumes)
+}
+
+// receive turns a resume message into the result of a yield expression
+// throw(e) raises e at the paused yield and return(v) unwinds the body.
+func (g *Generator) receive(msg generatorResume) Value {
+	switch msg.mode {
+	case resumeThrow:
+		internal.Throw(msg.value)
+	case resumeReturn:
+		panic(&generatorReturn{value: msg.value})
+	}
+	return msg.value
+}
+
+// delegate implements yield*: every value of the inner iterator is yielded
+// as if by this generator, and next/throw/return calls are forwarded to it.
+// The result of yield* is the inner iterator's return value.
+//
+// Example:
+//
+//	var inner = function*() { yield 1; yield 2; return "inner done"; };
+//	var outer = function*() { var r = yield* inner(); yield r; };
+//	→ yields 1, 2, "inner done"
+func (g *Generator) delegate(iterable Value) Value {
+	iterator, ok := getIterator(iterable)
+	if !ok {
+		internal.ThrowError("TypeError", "yield* target is not iterable")
+	}
+
+	received := generatorResume{mode: resumeNext}
+
+	for {
+		var result Value
+
+		switch received.mode {
+		case resumeNext:
+			result = applyFunction(getProperty(iterator, "next"), iterator, []Value{received.value})
+		case resumeThrow:
+			method, ok := getProperty(iterator, "throw").(internal.Callable)
+			if !ok {
+				closeIterator(iterator)
+				internal.ThrowError("TypeError", "The iterator does not provide a 'throw' method")
+			}
+			result = method.Call(iterator, received.value)
+		case resumeReturn:
+			method, ok := getProperty(iterator, "return").(internal.Callable)
+			if !ok {
+				panic(&generatorReturn{value: received.value})
+			}
+			result = method.Call(iterator, received.value)
+			if isTruthy(getProperty(result, "done")) {
+				panic(&generatorReturn{value: getProperty(result, "value")})
+			}
+		}
+
+		if isTruthy(getProperty(result, "done")) {
+			return getProperty(result, "value")
+		}
+
+		g.yields <- generatorYield{value: getProperty(result, "value")}
+		received = <-g.resumes
+	}
+}
+
+// evalYieldExpression evaluates yield and yield* inside a generator body
+// The generator is found through the "yield" binding set up by Generator.run;
+// yield is a keyword, so scripts can't shadow it.
+func evalYieldExpression(node *ast.YieldExpression, env *environment.Environment) Value {
+	val, _ := env.Get("yield")
+	gen, ok := val.(*Generator)
+	if !ok {
+		return nil
+	}
+
+	var value Value
+	if node.Argument != nil {
+		value = Eval(node.Argument, env)
+	}
+
+	if node.Delegate {
+		return gen.delegate(value)
+	}
+	return gen.yield(value)
+}
@@ -0,0 +1,176 @@
+package evaluator
+
+import (
+	"go-script/internal"
+	"testing"
+)
+
+func TestGeneratorNext(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`var gen = function*() { yield 1; yield 2; };
+		var it = gen();
+		it.next().value + it.next().value;`, 3.0},
+		{`var gen = function*() { yield 1; };
+		var it = gen();
+		it.next();
+		it.next().done;`, true},
+		{`var gen = function*() { yield 1; return "end"; };
+		var it = gen();
+		it.next();
+		it.next().value;`, "end"},
+		{`var gen = function*() { var x = yield "first"; yield x * 2; };
+		var it = gen();
+		it.next("ignored");
+		it.next(21).value;`, 42.0},
+		{`var gen = function*() { yield; };
+		gen().next().value;`, nil},
+		{`var gen = function*(a, b) { yield a + b; };
+		gen(2, 3).next().value;`, 5.0},
+		// Nothing runs until the first next()
+		{`var started = false;
+		var gen = function*() { started = true; yield 1; };
+		var it = gen();
+		started;`, false},
+		// Body finishing without return has value undefined
+		{`var gen = function*() { yield 1; 5; };
+		var it = gen(); it.next();
+		it.next().value;`, nil},
+		{`var obj = { base: 10, *values() { yield this.base; yield this.base + 1; } };
+		var it = obj.values(); it.next(); it.next().value;`, 11.0},
+		{`class Tree {
+			constructor() { this.items = ["a", "b"]; }
+			*[Symbol.iterator]() { for (let i of this.items) { yield i; } }
+		}
+		var out = ""; for (let x of new Tree()) { out = out + x; } out;`, "ab"},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestGeneratorIteration(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`var range = function*(from, to) { var i = from; while (i <= to) { yield i; i = i + 1; } };
+		var sum = 0; for (let n of range(1, 4)) { sum = sum + n; } sum;`, 10.0},
+		// Infinite generators are fine as long as the consumer stops
+		{`var naturals = function*() { var n = 0; while (true) { yield n; n = n + 1; } };
+		var take = function(it, count) { var out = []; for (let v of it) { if (out.length == count) { return out; } out.push(v); } };
+		var first = take(naturals(), 3);
+		first.length + " " + first[2];`, "3 2"},
+		{`var gen = function*() { yield 1; };
+		var it = gen();
+		it[Symbol.iterator]() == it;`, true},
+		// Pages are fetched lazily: only the pages the consumer asks for
+		{`var fetched = 0;
+		var pages = function*() { var page = 1; while (true) { fetched = fetched + 1; yield "page" + page; page = page + 1; } };
+		var it = pages(); it.next(); it.next();
+		fetched;`, 2.0},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestGeneratorDelegation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`var inner = function*() { yield 1; yield 2; };
+		var outer = function*() { yield 0; yield* inner(); yield 3; };
+		var out = ""; for (let v of outer()) { out = out + v; } out;`, "0123"},
+		{`var gen = function*() { yield* [4, 5]; yield* "ab"; };
+		var out = ""; for (let v of gen()) { out = out + v; } out;`, "45ab"},
+		{`var inner = function*() { yield 1; return "result"; };
+		var outer = function*() { var r = yield* inner(); yield r; };
+		var it = outer(); it.next();
+		it.next().value;`, "result"},
+		{`var inner = function*() { var x = yield 1; yield x; };
+		var outer = function*() { yield* inner(); };
+		var it = outer(); it.next();
+		it.next("passed").value;`, "passed"},
+		{`var inner = function*() { try { yield 1; } catch (e) { yield "inner caught " + e; } };
+		var outer = function*() { yield* inner(); };
+		var it = outer(); it.next();
+		it.throw("x").value;`, "inner caught x"},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestGeneratorReturnAndThrow(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`var gen = function*() { yield 1; yield 2; };
+		var it = gen(); it.next();
+		var r = it.return("stop");
+		r.value + " " + r.done + " " + it.next().done;`, "stop true true"},
+		{`var log = "";
+		var gen = function*() { try { yield 1; yield 2; } finally { log = log + "cleanup"; } };
+		var it = gen(); it.next(); it.return();
+		log;`, "cleanup"},
+		{`var gen = function*() { try { yield 1; } catch (e) { yield "caught " + e; } };
+		var it = gen(); it.next();
+		it.throw("oops").value;`, "caught oops"},
+		{`var gen = function*() { yield 1; };
+		var it = gen(); it.next();
+		try { it.throw("fatal"); } catch (e) { "rethrown " + e; }`, "rethrown fatal"},
+		{`var gen = function*() { yield 1; };
+		var it = gen();
+		try { it.throw("early"); } catch (e) { e + " " + it.next().done; }`, "early true"},
+		{`var gen = function*() { yield 1; };
+		var it = gen();
+		it.return(7).value;`, 7.0},
+		{`var gen = function*() { throw "from body"; };
+		try { gen().next(); } catch (e) { e; }`, "from body"},
+		// Leaving for...of early calls return(), which runs finally blocks
+		{`var log = "";
+		var gen = function*() { try { yield 1; yield 2; } finally { log = "closed"; } };
+		var first = function() { for (let v of gen()) { return v; } };
+		first() + " " + log;`, "1 closed"},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestGeneratorAlreadyRunning(t *testing.T) {
+	input := `var it;
+	var gen = function*() { it.next(); };
+	it = gen();
+	it.next();`
+
+	result := testEval(input)
+	exception, ok := result.(*internal.Exception)
+	if !ok {
+		t.Fatalf("Expected an uncaught exception, got %v", result)
+	}
+	if thrown(exception) != "TypeError: Generator is already running" {
+		t.Errorf("Unexpected exception value: %v", thrown(exception))
+	}
+}
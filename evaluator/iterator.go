@@ -0,0 +1,177 @@
+package evaluator
+
+import (
+	"go-script/ast"
+	"go-script/environment"
+	"go-script/evaluator/builtins/array"
+	"go-script/internal"
+)
+
+// evalForOfStatement evaluates a for...of loop using the iteration protocol:
+// it calls iterable[Symbol.iterator]() and then next() until done is true.
+// Declared loop variables get a fresh binding per iteration, so closures
+// created in the body see the value of their own iteration.
+//
+// "for await" gets the iterator from iterable[Symbol.asyncIterator]() and
+// awaits each result of next(). Iterables without that method are walked
+// with Symbol.iterator instead, and each of their values is awaited.
+//
+// Examples:
+//
+//	for (let x of [1, 2, 3]) { print(x); }
+//	→ prints 1, 2, 3
+//
+//	for await (let x of [Promise.resolve(1), 2]) { print(x); }
+//	→ prints 1, 2
+func evalForOfStatement(node *ast.ForOfStatement, env *environment.Environment) Value {
+	iterable := Eval(node.Iterable, env)
+
+	var iterator Value
+	var ok, async bool
+	if node.Await {
+		iterator, async = getAsyncIterator(iterable)
+		if !async {
+			iterator, ok = getIterator(iterable)
+			if !ok {
+				internal.ThrowError("TypeError", internal.Inspect(iterable)+" is not async iterable")
+			}
+		}
+	} else if iterator, ok = getIterator(iterable); !ok {
+		return nil // not iterable
+	}
+
+	var result Value
+
+	for {
+		var value Value
+		if async {
+			value, ok = asyncIteratorStep(iterator, env)
+		} else {
+			value, ok = iteratorStep(iterator)
+			if ok && node.Await {
+				value = await(value, env)
+			}
+		}
+		if !ok {
+			break
+		}
+
+		loopEnv := env
+		if node.Kind != "" {
+			loopEnv = environment.New(env)
+			loopEnv.Set(node.Name, value)
+		} else {
+			env.Update(node.Name, value)
+		}
+
+		result = Eval(node.Body, loopEnv)
+
+		if _, ok := result.(*ReturnValue); ok {
+			if async {
+				closeAsyncIterator(iterator, env)
+			} else {
+				closeIterator(iterator)
+			}
+			return result
+		}
+	}
+
+	return result
+}
+
+// getIterator calls value[Symbol.iterator]() and returns the iterator
+// Returns false when the value isn't iterable.
+func getIterator(iterable Value) (Value, bool) {
+	method := getProperty(iterable, internal.SymbolIterator)
+	if _, ok := method.(internal.Callable); !ok {
+		return nil, false
+	}
+	return applyFunction(method, iterable, nil), true
+}
+
+// iteratorStep calls iterator.next() and unpacks the { value, done } result
+// Returns false once the iterator is done.
+func iteratorStep(iterator Value) (Value, bool) {
+	result := applyFunction(getProperty(iterator, "next"), iterator, nil)
+	if isTruthy(getProperty(result, "done")) {
+		return nil, false
+	}
+	return getProperty(result, "value"), true
+}
+
+// closeIterator tells an iterator that the loop stopped early by calling
+// its return() method, if it has one
+func closeIterator(iterator Value) {
+	if method, ok := getProperty(iterator, "return").(internal.Callable); ok {
+		method.Call(iterator)
+	}
+}
+
+// getAsyncIterator calls value[Symbol.asyncIterator]() and returns the
+// iterator. Returns false when the value has no such method.
+func getAsyncIterator(iterable Value) (Value, bool) {
+	method := getProperty(iterable, internal.SymbolAsyncIterator)
+	if _, ok := method.(internal.Callable); !ok {
+		return nil, false
+	}
+	return applyFunction(method, iterable, nil), true
+}
+
+// asyncIteratorStep calls iterator.next(), awaits the promise it returns and
+// unpacks the { value, done } result. Returns false once the iterator is done.
+func asyncIteratorStep(iterator Value, env *environment.Environment) (Value, bool) {
+	result := await(applyFunction(getProperty(iterator, "next"), iterator, nil), env)
+	if isTruthy(getProperty(result, "done")) {
+		return nil, false
+	}
+	return getProperty(result, "value"), true
+}
+
+// closeAsyncIterator is closeIterator for async iterators: the promise
+// return() gives back is awaited before the loop is left
+func closeAsyncIterator(iterator Value, env *environment.Environment) {
+	if method, ok := getProperty(iterator, "return").(internal.Callable); ok {
+		await(method.Call(iterator), env)
+	}
+}
+
+// createIteratorMethod returns a [Symbol.iterator] method for a built-in
+// type. Every call starts a new iterator from the function returned by start.
+func createIteratorMethod(start func() func() (Value, bool)) Value {
+	return &internal.Builtin{
+		Name: "[Symbol.iterator]",
+		Fn: func(args ...interface{}) interface{} {
+			return internal.NewIterator(start())
+		},
+	}
+}
+
+// arrayValues iterates over array elements by index, so elements pushed
+// during the loop are visited too
+func arrayValues(arr *array.ArrayReference) func() func() (Value, bool) {
+	return func() func() (Value, bool) {
+		index := 0
+		return func() (Value, bool) {
+			if index >= len(*arr.Elements) {
+				return nil, false
+			}
+			index++
+			return arr.Get(index - 1), true
+		}
+	}
+}
+
+// stringValues iterates over a string one character (code point) at a time
+func stringValues(str string) func() func() (Value, bool) {
+	return func() func() (Value, bool) {
+		chars := []rune(str)
+		index := 0
+		return func() (Value, bool) {
+			if index >= len(chars) {
+				return nil, false
+			}
+			index++
+			return string(chars[index-1]), true
+		}
+	}
+}
@@ -0,0 +1,631 @@
+package evaluator
+
+import (
+	"fmt"
+	"go-script/ast"
+	"go-script/environment"
+	"go-script/internal"
+	"go-script/module"
+	"go-script/parser"
+	"sort"
+	"strings"
+)
+
+// Module is one instance of an ES module: its code, its scope and what it
+// exports. A module is loaded once per loader, however often it is imported.
+//
+// Modules go through three steps:
+//  1. load: the source is parsed and every imported module is loaded
+//  2. link: import declarations become live bindings to the exporters' variables
+//  3. evaluate: dependencies run first, then the module's own statements
+//
+// Imports are bound before any code runs, so modules that import each other
+// (cycles) work: a module that reads an import before its exporter has run
+// sees undefined, and sees the value once the exporter has run.
+//
+// CommonJS modules (see commonjs.go) and JSON files live in the same
+// registry; to ES modules they export a single default value.
+type Module struct {
+	Key string // What the loader resolved the module to, e.g. its path
+
+	kind         moduleKind
+	program      *ast.Program
+	source       string           // JSON modules keep their text until they are evaluated
+	moduleObject *internal.Object // CommonJS modules: the "module" binding
+	env          *environment.Environment
+	registry     *moduleRegistry
+	status       moduleStatus
+	err          *internal.Exception // What the module threw, if it failed
+
+	requested       []string                  // Specifiers of imported modules, in source order
+	deps            map[string]*Module        // Imported modules by specifier
+	localExports    map[string]string         // Exported name → variable in env
+	indirectExports map[string]indirectExport // Exported name → export of another module
+	starExports     []string                  // Specifiers of "export * from" modules
+
+	namespace *internal.Object
+}
+
+// moduleKind is how a module's source is run
+type moduleKind int
+
+const (
+	esModule   moduleKind = iota // import/export
+	commonJS                     // require/module.exports, see commonjs.go
+	jsonModule                   // a .json file; its value is the default export
+)
+
+type moduleStatus int
+
+const (
+	moduleLoaded     moduleStatus = iota // Parsed, dependencies loaded
+	moduleLinked                         // Imports bound
+	moduleEvaluating                     // Running, or waiting for a dependency in a cycle
+	moduleEvaluated
+	moduleErrored
+)
+
+// indirectExport re-exports a name of another module
+// name is "*" for "export * as ns from", which exports the whole namespace.
+type indirectExport struct {
+	specifier string
+	name      string
+}
+
+// exportBinding is where an exported name leads: a variable of a module,
+// or the module's namespace object when name is namespaceBinding
+type exportBinding struct {
+	module *Module
+	name   string
+}
+
+const (
+	// defaultBinding holds the value of "export default <expression>";
+	// it can't clash with a variable because it isn't a valid identifier
+	defaultBinding = "*default*"
+	// namespaceBinding marks an export that is another module's namespace
+	namespaceBinding = "*namespace*"
+)
+
+// moduleRegistry holds every module loaded through one loader, keyed by the
+// loader's resolved keys. It is bound as "import" in the global scope, and
+// each module binds its own *Module as "import" in its scope, which is how
+// import() knows what the specifier is relative to.
+type moduleRegistry struct {
+	loader  module.ModuleLoader
+	global  *environment.Environment
+	modules map[string]*Module
+}
+
+// SetModuleLoader enables modules for code run in env: import() and
+// require() in scripts, and EvalModule. Module scopes are created inside env, so all modules share
+// its globals.
+//
+// Example:
+//
+//	env := environment.NewGlobalEnvironment()
+//	evaluator.SetModuleLoader(env, module.MapLoader{"main.js": "export var x = 1;"})
+//	evaluator.EvalModule("main.js", env)
+func SetModuleLoader(env *environment.Environment, loader module.ModuleLoader) {
+	registry := &moduleRegistry{
+		loader:  loader,
+		global:  env,
+		modules: make(map[string]*Module),
+	}
+	env.Set("import", registry)
+	env.Set("require", newRequire(registry, ""))
+}
+
+// EvalModule loads the module at specifier through env's loader, evaluates
+// it and everything it imports, then runs the event loop like Eval does for
+// a program. Returns the module's namespace object, or the
+// *internal.Exception that stopped it.
+//
+// Example:
+//
+//	ns := evaluator.EvalModule("main.js", env)
+//	ns.(*internal.Object).Get("x") → 1.0
+func EvalModule(specifier string, env *environment.Environment) Value {
+	return runToCompletion(env, func() Value {
+		registry, referrer := importerOf(env)
+		if registry == nil {
+			internal.ThrowError("Error", "Cannot load module '"+specifier+"': no module loader is set")
+		}
+		return registry.importModule(specifier, referrer).Namespace()
+	})
+}
+
+// evalImportExpression evaluates import(specifier)
+// The module is loaded and evaluated in a microtask, so code after the
+// import() call runs first. Returns a promise for the namespace object that
+// rejects if the module can't be loaded or throws.
+//
+// Example:
+//
+//	import("./math.js").then(m => print(m.add(1, 2)));  → 3
+func evalImportExpression(node *ast.ImportExpression, env *environment.Environment) Value {
+	specifier := internal.ToString(Eval(node.Source, env))
+	registry, referrer := importerOf(env)
+	loop := env.EventLoop()
+	promise := loop.NewPromise()
+
+	loop.QueueMicrotask(func() {
+		exception := internal.Catch(func() {
+			if registry == nil {
+				internal.ThrowError("Error", "Cannot import '"+specifier+"': no module loader is set")
+			}
+			promise.Resolve(registry.importModule(specifier, referrer).Namespace())
+		})
+		if exception != nil {
+			promise.Reject(exception.Value)
+		}
+	})
+
+	return promise
+}
+
+// importerOf finds the module registry for code running in env, and the key
+// of the module the code belongs to ("" outside of modules)
+func importerOf(env *environment.Environment) (*moduleRegistry, string) {
+	binding, _ := env.Get("import")
+	switch importer := binding.(type) {
+	case *Module:
+		return importer.registry, importer.Key
+	case *moduleRegistry:
+		return importer, ""
+	}
+	return nil, ""
+}
+
+// checkScript rejects import and export declarations in a program that
+// isn't run as a module
+func checkScript(program *ast.Program) {
+	for _, statement := range program.Statements {
+		switch statement.(type) {
+		case *ast.ImportDeclaration:
+			internal.ThrowError("SyntaxError", "Cannot use import statement outside a module")
+		case *ast.ExportDeclaration, *ast.ExportDefaultDeclaration:
+			internal.ThrowError("SyntaxError", "Unexpected token 'export'")
+		}
+	}
+}
+
+// importModule loads, links and evaluates a module and its dependencies.
+// If loading or linking fails, the modules loaded by this call are
+// forgotten, so a later import tries them again.
+func (r *moduleRegistry) importModule(specifier string, referrer string) *Module {
+	var fresh []*Module
+	var m *Module
+
+	exception := internal.Catch(func() {
+		m = r.load(specifier, referrer, &fresh)
+		for _, loaded := range fresh {
+			loaded.link()
+		}
+	})
+	if exception != nil {
+		for _, loaded := range fresh {
+			delete(r.modules, loaded.Key)
+		}
+		panic(exception)
+	}
+
+	m.evaluate()
+	return m
+}
+
+// load returns the module for a specifier, loading and parsing it and
+// its dependencies if this is the first time it is imported.
+// Newly loaded modules are appended to fresh.
+func (r *moduleRegistry) load(specifier string, referrer string, fresh *[]*Module) *Module {
+	key, err := r.loader.Resolve(specifier, referrer)
+	if err != nil {
+		internal.ThrowError("Error", err.Error())
+	}
+	if m, ok := r.modules[key]; ok {
+		return m
+	}
+
+	source, err := r.loader.Load(key)
+	if err != nil {
+		internal.ThrowError("Error", err.Error())
+	}
+
+	m := &Module{
+		Key:             key,
+		env:             environment.New(r.global),
+		registry:        r,
+		deps:            make(map[string]*Module),
+		localExports:    make(map[string]string),
+		indirectExports: make(map[string]indirectExport),
+	}
+	m.env.SetStrict(true) // module code is always strict mode code
+	m.env.Set("import", m)
+	m.env.Set("require", newRequire(r, key))
+
+	if strings.HasSuffix(key, ".json") {
+		m.kind = jsonModule
+		m.source = source
+		m.localExports["default"] = defaultBinding
+		r.modules[key] = m
+		*fresh = append(*fresh, m)
+		return m
+	}
+
+	p := parser.New(source)
+	m.program = p.ParseProgram()
+	if len(p.Errors()) > 0 {
+		internal.ThrowError("SyntaxError", fmt.Sprintf("%s: %s", key, strings.Join(p.Errors(), "; ")))
+	}
+
+	// Registered before its dependencies load, so a cycle finds it
+	r.modules[key] = m
+	*fresh = append(*fresh, m)
+
+	if isCommonJS(key, m.program) {
+		m.kind = commonJS
+		m.localExports["default"] = defaultBinding
+		m.defineCommonJSScope()
+		return m
+	}
+
+	m.collectEntries()
+	for _, dep := range m.requested {
+		m.deps[dep] = r.load(dep, key, fresh)
+	}
+
+	return m
+}
+
+// collectEntries records what the module imports and exports
+// An exported name that is itself an import becomes an indirect export, so
+// every route to a variable resolves to the module that declares it.
+//
+// Example: "import { a } from './x.js'; export { a as b };"
+//
+//	requested: ["./x.js"], indirectExports: {b → ./x.js's a}
+func (m *Module) collectEntries() {
+	imported := make(map[string]indirectExport) // local name → import
+	var exportList []*ast.ExportSpecifier
+
+	for _, statement := range m.program.Statements {
+		switch node := statement.(type) {
+		case *ast.ImportDeclaration:
+			m.request(node.Source)
+			if node.Default != "" {
+				imported[node.Default] = indirectExport{node.Source, "default"}
+			}
+			if node.Namespace != "" {
+				imported[node.Namespace] = indirectExport{node.Source, "*"}
+			}
+			for _, spec := range node.Specifiers {
+				imported[spec.Local] = indirectExport{node.Source, spec.Imported}
+			}
+
+		case *ast.ExportDeclaration:
+			switch {
+			case node.Declaration != nil:
+				name := declaredName(node.Declaration)
+				m.addExport(name, name)
+			case node.Source == "":
+				exportList = append(exportList, node.Specifiers...)
+			case node.All && node.Namespace == "":
+				m.request(node.Source)
+				m.starExports = append(m.starExports, node.Source)
+			case node.All:
+				m.request(node.Source)
+				m.addIndirectExport(node.Namespace, indirectExport{node.Source, "*"})
+			default:
+				m.request(node.Source)
+				for _, spec := range node.Specifiers {
+					m.addIndirectExport(spec.Exported, indirectExport{node.Source, spec.Local})
+				}
+			}
+
+		case *ast.ExportDefaultDeclaration:
+			if node.Declaration != nil {
+				m.addExport("default", declaredName(node.Declaration))
+			} else {
+				m.addExport("default", defaultBinding)
+			}
+		}
+	}
+
+	// Imports may come after the export list that names them
+	for _, spec := range exportList {
+		if source, ok := imported[spec.Local]; ok {
+			m.addIndirectExport(spec.Exported, source)
+		} else {
+			m.addExport(spec.Exported, spec.Local)
+		}
+	}
+}
+
+func (m *Module) request(specifier string) {
+	for _, existing := range m.requested {
+		if existing == specifier {
+			return
+		}
+	}
+	m.requested = append(m.requested, specifier)
+}
+
+func (m *Module) addExport(name string, local string) {
+	m.checkDuplicateExport(name)
+	m.localExports[name] = local
+}
+
+func (m *Module) addIndirectExport(name string, export indirectExport) {
+	m.checkDuplicateExport(name)
+	m.indirectExports[name] = export
+}
+
+func (m *Module) checkDuplicateExport(name string) {
+	_, local := m.localExports[name]
+	_, indirect := m.indirectExports[name]
+	if local || indirect {
+		internal.ThrowError("SyntaxError", fmt.Sprintf("%s: Duplicate export of '%s'", m.Key, name))
+	}
+}
+
+// declaredName returns the variable an exported declaration creates
+func declaredName(declaration ast.Statement) string {
+	switch node := declaration.(type) {
+	case *ast.VarStatement:
+		return node.Name
+	case *ast.ClassDeclaration:
+		return node.Class.Name
+	}
+	return ""
+}
+
+// link binds the module's imports to the variables they refer to
+//
+// Example: "import { count as c } from './counter.js'"
+//
+//	→ c in this module's scope is a live binding to count in counter.js's scope
+func (m *Module) link() {
+	m.status = moduleLinked
+	if m.kind != esModule {
+		return // CommonJS and JSON modules import nothing
+	}
+
+	for _, statement := range m.program.Statements {
+		node, ok := statement.(*ast.ImportDeclaration)
+		if !ok {
+			continue
+		}
+
+		dep := m.deps[node.Source]
+		if node.Namespace != "" {
+			m.env.Set(node.Namespace, dep.Namespace())
+		}
+		if node.Default != "" {
+			m.bindImport(node.Default, dep, "default", node.Source)
+		}
+		for _, spec := range node.Specifiers {
+			m.bindImport(spec.Local, dep, spec.Imported, node.Source)
+		}
+	}
+}
+
+// bindImport makes local in this module's scope read the export name of dep
+func (m *Module) bindImport(local string, dep *Module, name string, specifier string) {
+	binding, ambiguous := dep.resolveExport(name, nil)
+	if ambiguous {
+		internal.ThrowError("SyntaxError", fmt.Sprintf("The requested module '%s' contains conflicting star exports for name '%s'", specifier, name))
+	}
+	if binding == nil && dep.kind != esModule {
+		internal.ThrowError("SyntaxError", fmt.Sprintf("Named export '%s' not found. The requested module '%s' only provides a default export", name, specifier))
+	}
+	if binding == nil {
+		internal.ThrowError("SyntaxError", fmt.Sprintf("The requested module '%s' does not provide an export named '%s'", specifier, name))
+	}
+
+	if binding.name == namespaceBinding {
+		m.env.Set(local, binding.module.Namespace())
+		return
+	}
+	m.env.Import(local, binding.module.env, binding.name)
+}
+
+// moduleExport identifies one name of one module while resolving exports,
+// so re-export cycles end instead of recursing forever
+type moduleExport struct {
+	module *Module
+	name   string
+}
+
+// resolveExport follows re-exports to find where an exported name is
+// declared. Returns nil if the module doesn't export the name, and
+// ambiguous = true if two "export *" modules export different bindings
+// under it (such names are left out of the namespace and can't be imported).
+//
+// Example: main.js has "export * from './a.js'" and a.js has "export var x"
+//
+//	main.resolveExport("x") → exportBinding{a.js, "x"}
+func (m *Module) resolveExport(name string, visited map[moduleExport]bool) (binding *exportBinding, ambiguous bool) {
+	if visited == nil {
+		visited = make(map[moduleExport]bool)
+	}
+	if visited[moduleExport{m, name}] {
+		return nil, false // a re-export cycle
+	}
+	visited[moduleExport{m, name}] = true
+
+	if local, ok := m.localExports[name]; ok {
+		return &exportBinding{module: m, name: local}, false
+	}
+
+	if export, ok := m.indirectExports[name]; ok {
+		dep := m.deps[export.specifier]
+		if export.name == "*" {
+			return &exportBinding{module: dep, name: namespaceBinding}, false
+		}
+		return dep.resolveExport(export.name, visited)
+	}
+
+	// export * never re-exports default
+	if name == "default" {
+		return nil, false
+	}
+
+	var found *exportBinding
+	for _, specifier := range m.starExports {
+		binding, ambiguous := m.deps[specifier].resolveExport(name, visited)
+		if ambiguous {
+			return nil, true
+		}
+		if binding == nil {
+			continue
+		}
+		if found != nil && *found != *binding {
+			return nil, true
+		}
+		found = binding
+	}
+
+	return found, false
+}
+
+// exportedNames lists every name the module exports, including names
+// re-exported with export *, in no particular order
+func (m *Module) exportedNames(visited map[*Module]bool) []string {
+	if visited[m] {
+		return nil
+	}
+	visited[m] = true
+
+	seen := make(map[string]bool)
+	var names []string
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	for name := range m.localExports {
+		add(name)
+	}
+	for name := range m.indirectExports {
+		add(name)
+	}
+	for _, specifier := range m.starExports {
+		for _, name := range m.deps[specifier].exportedNames(visited) {
+			if name != "default" {
+				add(name)
+			}
+		}
+	}
+
+	return names
+}
+
+// Namespace returns the module namespace object: one read-only property per
+// export, sorted by name, that always reads the export's current value.
+// It is what "import * as ns" and import() produce.
+//
+// Example: for "export var b = 2; export default 1;"
+//
+//	Namespace() → { b: 2, default: 1 }, with [Symbol.toStringTag] "Module"
+func (m *Module) Namespace() *internal.Object {
+	if m.namespace != nil {
+		return m.namespace
+	}
+
+	names := m.exportedNames(make(map[*Module]bool))
+	sort.Strings(names)
+
+	// The namespace reads through a scope of live bindings, exactly like
+	// the variables an import declaration creates
+	bindings := environment.New(nil)
+	namespace := internal.NewObject()
+	m.namespace = namespace
+
+	for _, name := range names {
+		binding, ambiguous := m.resolveExport(name, nil)
+		if binding == nil || ambiguous {
+			continue
+		}
+
+		if binding.name == namespaceBinding {
+			namespace.Set(name, binding.module.Namespace())
+			continue
+		}
+
+		bindings.Import(name, binding.module.env, binding.name)
+		exportName := name
+		namespace.Define(name, &internal.Property{Getter: &internal.Builtin{
+			Name: exportName,
+			Fn: func(args ...interface{}) interface{} {
+				val, _ := bindings.Get(exportName)
+				return val
+			},
+		}})
+	}
+
+	namespace.Set(internal.SymbolToStringTag, "Module")
+	return namespace
+}
+
+// evaluate runs the module's dependencies and then its own statements, once.
+// A module that is already running is part of an import cycle and is
+// skipped; a module that failed throws the same exception again.
+func (m *Module) evaluate() {
+	switch m.status {
+	case moduleEvaluating, moduleEvaluated:
+		return
+	case moduleErrored:
+		panic(m.err)
+	}
+
+	m.status = moduleEvaluating
+	exception := internal.Catch(func() {
+		for _, specifier := range m.requested {
+			m.deps[specifier].evaluate()
+		}
+		m.run()
+	})
+	if exception != nil {
+		m.status = moduleErrored
+		m.err = exception
+		panic(exception)
+	}
+	m.status = moduleEvaluated
+}
+
+// run evaluates the module's statements in its scope
+// Import declarations were handled by link; export declarations evaluate
+// the declaration they wrap.
+func (m *Module) run() {
+	switch m.kind {
+	case commonJS:
+		m.runCommonJS()
+		return
+	case jsonModule:
+		m.env.Set(defaultBinding, parseJSONModule(m.Key, m.source))
+		return
+	}
+
+	for _, statement := range m.program.Statements {
+		switch node := statement.(type) {
+		case *ast.ImportDeclaration:
+			// bound by link
+		case *ast.ExportDeclaration:
+			if node.Declaration != nil {
+				Eval(node.Declaration, m.env)
+			}
+		case *ast.ExportDefaultDeclaration:
+			if node.Declaration != nil {
+				Eval(node.Declaration, m.env)
+			} else {
+				m.env.Set(defaultBinding, Eval(node.Value, m.env))
+			}
+		default:
+			if _, ok := Eval(statement, m.env).(*ReturnValue); ok {
+				return
+			}
+		}
+	}
+}
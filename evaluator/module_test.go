@@ -0,0 +1,390 @@
+package evaluator
+
+import (
+	"go-script/environment"
+	"go-script/internal"
+	"go-script/module"
+	"go-script/parser"
+	"testing"
+)
+
+// testEvalModule runs main.js from an in-memory set of modules and returns
+// its namespace object, or the exception that stopped it
+func testEvalModule(files module.MapLoader) Value {
+	env := environment.NewGlobalEnvironment()
+	SetModuleLoader(env, files)
+	return EvalModule("main.js", env)
+}
+
+// testModuleResult runs main.js and returns its "result" export
+func testModuleResult(t *testing.T, files module.MapLoader) Value {
+	t.Helper()
+	result := testEvalModule(files)
+	namespace, ok := result.(*internal.Object)
+	if !ok {
+		t.Fatalf("Expected a module namespace, got %v", result)
+	}
+	return namespace.Get("result")
+}
+
+func TestModuleImports(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    module.MapLoader
+		expected Value
+	}{
+		{"named", module.MapLoader{
+			"main.js": `import { add, two } from "./math.js"; export var result = add(two, 3);`,
+			"math.js": `export var add = (a, b) => a + b; export var two = 2;`,
+		}, 5.0},
+		{"renamed", module.MapLoader{
+			"main.js": `import { value as v } from "./lib.js"; export var result = v;`,
+			"lib.js":  `var internal = "x"; export { internal as value };`,
+		}, "x"},
+		{"default expression", module.MapLoader{
+			"main.js":  `import greet from "./greet.js"; export var result = greet("Ada");`,
+			"greet.js": `export default function(name) { return "Hello, " + name; }`,
+		}, "Hello, Ada"},
+		{"default class", module.MapLoader{
+			"main.js":  `import Point, { origin } from "./point.js"; export var result = new Point(3).x + origin.x;`,
+			"point.js": `export default class Point { constructor(x) { this.x = x; } } export var origin = new Point(1);`,
+		}, 4.0},
+		{"default by name", module.MapLoader{
+			"main.js": `import { default as d } from "./lib.js"; export var result = d;`,
+			"lib.js":  `export default 42;`,
+		}, 42.0},
+		{"namespace", module.MapLoader{
+			"main.js":     `import * as math from "./lib/math.js"; export var result = math.square(4) + math.default;`,
+			"lib/math.js": `export var square = (x) => x * x; export default 1;`,
+		}, 17.0},
+		{"relative to the importer", module.MapLoader{
+			"main.js":  `import { a } from "./lib/a.js"; export var result = a;`,
+			"lib/a.js": `import { b } from "./b.js"; export var a = "a" + b;`,
+			"lib/b.js": `import { c } from "../c.js"; export var b = "b" + c;`,
+			"c.js":     `export var c = "c";`,
+		}, "abc"},
+		{"side effects only", module.MapLoader{
+			"main.js":   `import "./setup.js"; import { config } from "./config.js"; export var result = config.ready;`,
+			"setup.js":  `import { config } from "./config.js"; config.ready = true;`,
+			"config.js": `export var config = { ready: false };`,
+		}, true},
+		{"top-level await", module.MapLoader{
+			"main.js": `import { value } from "./lib.js"; export var result = value;`,
+			"lib.js":  `export var value = await Promise.resolve("awaited");`,
+		}, "awaited"},
+	}
+
+	for _, tt := range tests {
+		result := testModuleResult(t, tt.files)
+		if result != tt.expected {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, result)
+		}
+	}
+}
+
+func TestModuleReExports(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    module.MapLoader
+		expected Value
+	}{
+		{"named re-export", module.MapLoader{
+			"main.js":  `import { sum } from "./index.js"; export var result = sum;`,
+			"index.js": `export { total as sum } from "./math.js";`,
+			"math.js":  `export var total = 10;`,
+		}, 10.0},
+		{"star re-export", module.MapLoader{
+			"main.js":  `import { a, b } from "./index.js"; export var result = a + b;`,
+			"index.js": `export * from "./a.js"; export * from "./b.js";`,
+			"a.js":     `export var a = 1;`,
+			"b.js":     `export var b = 2;`,
+		}, 3.0},
+		{"star re-export skips default", module.MapLoader{
+			"main.js":  `import * as ns from "./index.js"; export var result = typeof ns.default + " " + ns.x;`,
+			"index.js": `export * from "./lib.js";`,
+			"lib.js":   `export var x = 1; export default 2;`,
+		}, "undefined 1"},
+		{"namespace re-export", module.MapLoader{
+			"main.js":  `import { utils } from "./index.js"; export var result = utils.twice(4);`,
+			"index.js": `export * as utils from "./utils.js";`,
+			"utils.js": `export var twice = (x) => x * 2;`,
+		}, 8.0},
+		{"import then export", module.MapLoader{
+			"main.js":    `import { count, increment } from "./index.js"; increment(); export var result = count;`,
+			"index.js":   `export { count, increment }; import { count, increment } from "./counter.js";`,
+			"counter.js": `export var count = 0; export var increment = () => { count = count + 1; };`,
+		}, 1.0},
+		{"local shadows star", module.MapLoader{
+			"main.js":  `import { x } from "./index.js"; export var result = x;`,
+			"index.js": `export * from "./lib.js"; export var x = "local";`,
+			"lib.js":   `export var x = "star";`,
+		}, "local"},
+		{"same binding through two stars", module.MapLoader{
+			"main.js":   `import { x } from "./index.js"; export var result = x;`,
+			"index.js":  `export * from "./a.js"; export * from "./b.js";`,
+			"a.js":      `export * from "./shared.js";`,
+			"b.js":      `export * from "./shared.js";`,
+			"shared.js": `export var x = "shared";`,
+		}, "shared"},
+	}
+
+	for _, tt := range tests {
+		result := testModuleResult(t, tt.files)
+		if result != tt.expected {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, result)
+		}
+	}
+}
+
+func TestModuleLiveBindings(t *testing.T) {
+	files := module.MapLoader{
+		"main.js": `import { count, increment } from "./counter.js";
+			import * as counter from "./counter.js";
+			var before = count;
+			increment();
+			increment();
+			export var result = before + " " + count + " " + counter.count;`,
+		"counter.js": `export var count = 0;
+			export var increment = () => { count = count + 1; };`,
+	}
+
+	if result := testModuleResult(t, files); result != "0 2 2" {
+		t.Errorf("Expected imports to see updates, got %v", result)
+	}
+}
+
+func TestModuleCycles(t *testing.T) {
+	// main.js imports b.js, which imports main.js back. b.js runs first and
+	// sees main's exports uninitialized; its functions see them later.
+	files := module.MapLoader{
+		"main.js": `import { b, readA } from "./b.js";
+			export var a = "A";
+			export var result = b + " " + readA();`,
+		"b.js": `import { a } from "./main.js";
+			export var b = "b saw " + typeof a;
+			export var readA = () => a;`,
+	}
+
+	if result := testModuleResult(t, files); result != "b saw undefined A" {
+		t.Errorf("Expected the cycle to resolve, got %v", result)
+	}
+}
+
+func TestModuleEvaluatedOnce(t *testing.T) {
+	files := module.MapLoader{
+		"main.js": `import { log } from "./log.js";
+			import "./a.js";
+			import "./b.js";
+			import "./a.js";
+			export var result = log.text;`,
+		"a.js":   `import { log } from "./log.js"; import "./b.js"; log.text = log.text + "a";`,
+		"b.js":   `import { log } from "./log.js"; log.text = log.text + "b";`,
+		"log.js": `export var log = { text: "" };`,
+	}
+
+	// Dependencies run first, in import order, and only once
+	if result := testModuleResult(t, files); result != "ba" {
+		t.Errorf("Expected \"ba\", got %v", result)
+	}
+}
+
+func TestModuleNamespaceObject(t *testing.T) {
+	files := module.MapLoader{
+		"main.js": `export var zeta = 1; export var alpha = 2; export default 3; export { alpha as beta };`,
+	}
+
+	namespace, ok := testEvalModule(files).(*internal.Object)
+	if !ok {
+		t.Fatalf("Expected a namespace object")
+	}
+
+	keys := namespace.Keys()
+	expected := []string{"alpha", "beta", "default", "zeta"}
+	if len(keys) != len(expected) {
+		t.Fatalf("Expected keys %v, got %v", expected, keys)
+	}
+	for i := range expected {
+		if keys[i] != expected[i] {
+			t.Errorf("Expected keys %v, got %v", expected, keys)
+			break
+		}
+	}
+
+	if tag := namespace.Get(internal.SymbolToStringTag); tag != "Module" {
+		t.Errorf("Expected [Symbol.toStringTag] Module, got %v", tag)
+	}
+
+	// Namespace properties are read-only views of the exports, and module
+	// code is strict, so assigning one throws
+	inner := module.MapLoader{
+		"main.js": `import * as ns from "./lib.js"; var error; try { ns.x = 5; } catch (e) { error = e.name; } export var result = error + " " + ns.x;`,
+		"lib.js":  `export var x = 1;`,
+	}
+	if result := testModuleResult(t, inner); result != "TypeError 1" {
+		t.Errorf("Expected assigning to a namespace property to throw, got %v", result)
+	}
+}
+
+func TestDynamicImport(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    module.MapLoader
+		expected Value
+	}{
+		{"then", module.MapLoader{
+			"main.js": `export var result = "";
+				import("./lib.js").then((m) => { result = result + m.value; });
+				result = result + "sync ";`,
+			"lib.js": `export var value = "loaded";`,
+		}, "sync loaded"},
+		{"await", module.MapLoader{
+			"main.js":     `var lib = await import("./lib/math.js"); export var result = lib.default(3);`,
+			"lib/math.js": `export default (x) => x * 10;`,
+		}, 30.0},
+		{"computed specifier", module.MapLoader{
+			"main.js": `var name = "b"; var m = await import("./" + name + ".js"); export var result = m.b;`,
+			"b.js":    `export var b = "dynamic";`,
+		}, "dynamic"},
+		{"relative to the importing module", module.MapLoader{
+			"main.js":       `import { load } from "./lib/loader.js"; var m = await load(); export var result = m.x;`,
+			"lib/loader.js": `export var load = () => import("./data.js");`,
+			"lib/data.js":   `export var x = "nested";`,
+		}, "nested"},
+		{"same instance as static import", module.MapLoader{
+			"main.js": `import * as ns from "./lib.js"; var m = await import("./lib.js"); export var result = m == ns;`,
+			"lib.js":  `export var x = 1;`,
+		}, true},
+		{"module error rejects", module.MapLoader{
+			"main.js": `export var result = "";
+				import("./broken.js").catch((e) => { result = e; });`,
+			"broken.js": `throw "broken module";`,
+		}, "broken module"},
+		{"missing module rejects", module.MapLoader{
+			"main.js": `export var result = "";
+				import("./missing.js").catch((e) => { result = e.name + ": " + e.message; });`,
+		}, "Error: cannot find module './missing.js' imported from main.js"},
+	}
+
+	for _, tt := range tests {
+		result := testModuleResult(t, tt.files)
+		if result != tt.expected {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, result)
+		}
+	}
+}
+
+func TestDynamicImportFromScript(t *testing.T) {
+	env := environment.NewGlobalEnvironment()
+	SetModuleLoader(env, module.MapLoader{"lib.js": `export var value = 7;`})
+
+	program := parser.New(`var log = 0; import("./lib.js").then((m) => { log = m.value; });`).ParseProgram()
+	Eval(program, env)
+
+	if log, _ := env.Get("log"); log != 7.0 {
+		t.Errorf("Expected scripts to import modules, got %v", log)
+	}
+
+	// Without a loader, import() rejects
+	result := testEval(`import("./lib.js");`)
+	exception, ok := result.(*internal.Exception)
+	if !ok || !internal.IsError(exception.Value) || internal.ErrorString(exception.Value) != "Error: Cannot import './lib.js': no module loader is set" {
+		t.Errorf("Expected a rejection without a loader, got %v", result)
+	}
+}
+
+func TestModuleErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    module.MapLoader
+		expected Value
+	}{
+		{"missing module", module.MapLoader{
+			"main.js": `import { x } from "./missing.js";`,
+		}, "Error: cannot find module './missing.js' imported from main.js"},
+		{"missing export", module.MapLoader{
+			"main.js": `import { nope } from "./lib.js";`,
+			"lib.js":  `export var x = 1;`,
+		}, "SyntaxError: The requested module './lib.js' does not provide an export named 'nope'"},
+		{"missing default", module.MapLoader{
+			"main.js": `import lib from "./lib.js";`,
+			"lib.js":  `export var x = 1;`,
+		}, "SyntaxError: The requested module './lib.js' does not provide an export named 'default'"},
+		{"conflicting stars", module.MapLoader{
+			"main.js":  `import { x } from "./index.js";`,
+			"index.js": `export * from "./a.js"; export * from "./b.js";`,
+			"a.js":     `export var x = 1;`,
+			"b.js":     `export var x = 2;`,
+		}, "SyntaxError: The requested module './index.js' contains conflicting star exports for name 'x'"},
+		{"duplicate export", module.MapLoader{
+			"main.js": `import "./lib.js";`,
+			"lib.js":  `export var x = 1; var y = 2; export { y as x };`,
+		}, "SyntaxError: lib.js: Duplicate export of 'x'"},
+		{"syntax error", module.MapLoader{
+			"main.js": `import "./lib.js";`,
+			"lib.js":  `export var = 1;`,
+		}, "SyntaxError: lib.js: expected next token to be IDENT, got = instead; no prefix parse function for = found"},
+		{"throwing dependency", module.MapLoader{
+			"main.js": `import "./lib.js"; export var result = "unreachable";`,
+			"lib.js":  `throw "lib failed";`,
+		}, "lib failed"},
+		{"assignment to import", module.MapLoader{
+			"main.js": `import { x } from "./lib.js"; x = 2;`,
+			"lib.js":  `export var x = 1;`,
+		}, "TypeError: Assignment to constant variable."},
+		{"unhandled dynamic import failure", module.MapLoader{
+			"main.js": `import("./missing.js");`,
+		}, "Error: cannot find module './missing.js' imported from main.js"},
+	}
+
+	for _, tt := range tests {
+		exception, ok := testEvalModule(tt.files).(*internal.Exception)
+		if !ok {
+			t.Errorf("%s: expected an exception", tt.name)
+			continue
+		}
+		if thrown(exception) != tt.expected {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, exception)
+		}
+	}
+}
+
+func TestModuleDeclarationsInScripts(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`var x = 1; import { a } from "./a.js";`, "SyntaxError: Cannot use import statement outside a module"},
+		{`export var x = 1;`, "SyntaxError: Unexpected token 'export'"},
+	}
+
+	for _, tt := range tests {
+		exception, ok := testEval(tt.input).(*internal.Exception)
+		if !ok {
+			t.Errorf("For input %q: expected an exception", tt.input)
+			continue
+		}
+		if thrown(exception) != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, exception)
+		}
+	}
+}
+
+func TestFailedImportCanBeRetried(t *testing.T) {
+	files := module.MapLoader{
+		"main.js": `import { x } from "./lib.js";`,
+		"lib.js":  `import { y } from "./missing.js"; export var x = y;`,
+	}
+	env := environment.NewGlobalEnvironment()
+	SetModuleLoader(env, files)
+
+	if _, ok := EvalModule("main.js", env).(*internal.Exception); !ok {
+		t.Fatalf("Expected the first import to fail")
+	}
+
+	files["missing.js"] = `export var y = "found";`
+	result := EvalModule("lib.js", env)
+	namespace, ok := result.(*internal.Object)
+	if !ok || namespace.Get("x") != "found" {
+		t.Errorf("Expected the import to succeed once the module exists, got %v", result)
+	}
+}
@@ -0,0 +1,79 @@
+package evaluator
+
+import (
+	"go-script/evaluator/builtins/number"
+	"go-script/internal"
+)
+
+// GetNumberProperty returns the methods of number values
+// A number is boxed on the fly when a property is read from it, so methods
+// are bound to the number itself.
+//
+// Examples:
+//
+//	(1234.5678).toFixed(2)      → "1234.57"
+//	(1234.5678).toPrecision(6)  → "1234.57"
+//	(1234.5678).toExponential(1) → "1.2e+3"
+//	(255).toString(16)          → "ff"
+//	var n = 7; n.toString(2)    → "111"
+func GetNumberProperty(num float64, key internal.PropertyKey) Value {
+	switch key {
+	case "toFixed":
+		return numberMethod("toFixed", func(args []interface{}) Value {
+			return number.ToFixed(num, argAt(args, 0))
+		})
+	case "toPrecision":
+		return numberMethod("toPrecision", func(args []interface{}) Value {
+			return number.ToPrecision(num, argAt(args, 0))
+		})
+	case "toExponential":
+		return numberMethod("toExponential", func(args []interface{}) Value {
+			return number.ToExponential(num, argAt(args, 0))
+		})
+	case "toString":
+		return numberMethod("toString", func(args []interface{}) Value {
+			return number.ToRadixString(num, argAt(args, 0))
+		})
+	case "toLocaleString":
+		return numberMethod("toLocaleString", func(args []interface{}) Value {
+			return internal.FormatNumber(num)
+		})
+	case "valueOf":
+		return numberMethod("valueOf", func(args []interface{}) Value {
+			return num
+		})
+	default:
+		return nil
+	}
+}
+
+// GetBooleanProperty returns the methods of true and false
+//
+// Examples:
+//
+//	true.toString() → "true"
+//	false.valueOf() → false
+func GetBooleanProperty(b bool, key internal.PropertyKey) Value {
+	switch key {
+	case "toString":
+		return numberMethod("toString", func(args []interface{}) Value {
+			return internal.ToString(b)
+		})
+	case "valueOf":
+		return numberMethod("valueOf", func(args []interface{}) Value {
+			return b
+		})
+	default:
+		return nil
+	}
+}
+
+// numberMethod wraps the body of a number or boolean method as a builtin
+func numberMethod(name string, fn func(args []interface{}) Value) Value {
+	return &internal.Builtin{
+		Name: name,
+		Fn: func(args ...interface{}) interface{} {
+			return fn(args)
+		},
+	}
+}
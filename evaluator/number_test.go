@@ -0,0 +1,86 @@
+package evaluator
+
+import (
+	"testing"
+)
+
+func TestNumberMethods(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`(1234.5678).toFixed(2);`, "1234.57"},
+		{`var price = 19.5; price.toFixed(2);`, "19.50"},
+		{`(2.5).toFixed();`, "3"},
+		{`(-1.5).toFixed(0);`, "-2"},
+		{`(1234.5678).toPrecision(6);`, "1234.57"},
+		{`(0.000123).toPrecision(2);`, "0.00012"},
+		{`(123456).toExponential(2);`, "1.23e+5"},
+		{`(255).toString(16);`, "ff"},
+		{`(255).toString();`, "255"},
+		{`var id = 35; id.toString(36);`, "z"},
+		{`(0.5).toString(2);`, "0.1"},
+		{`(42).valueOf();`, 42.0},
+		{`typeof (5).toFixed;`, "function"},
+		{`(5).missing;`, nil},
+		{`try { (1).toFixed(101); } catch (e) { e.name + ": " + e.message; }`, "RangeError: toFixed() digits argument must be between 0 and 100"},
+		{`try { (1).toString(37); } catch (e) { e.name + ": " + e.message; }`, "RangeError: toString() radix must be between 2 and 36"},
+		{`true.toString();`, "true"},
+		{`var b = false; b.valueOf();`, false},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestNumberAndBooleanGlobals(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`Number("42");`, 42.0},
+		{`Number("  12.5  ");`, 12.5},
+		{`Number(true);`, 1.0},
+		{`Number(null);`, 0.0},
+		{`Number();`, 0.0},
+		{`isNaN(Number("12px"));`, true},
+		{`isNaN(Number(undefined));`, true},
+		{`Number.isInteger(5);`, true},
+		{`Number.isInteger(5.5);`, false},
+		{`Number.isSafeInteger(Number.MAX_SAFE_INTEGER);`, true},
+		{`Number.isSafeInteger(Number.MAX_SAFE_INTEGER + 1);`, false},
+		{`Number.MAX_SAFE_INTEGER;`, 9007199254740991.0},
+		{`Number.EPSILON > 0;`, true},
+		{`Number.isNaN("abc");`, false},
+		{`isNaN("abc");`, true},
+		{`Number.parseInt == parseInt;`, true},
+		{`parseInt("42px");`, 42.0},
+		{`parseInt("ff", 16);`, 255.0},
+		{`parseInt("0x1F");`, 31.0},
+		{`parseFloat("3.14abc");`, 3.14},
+		{`["1", "2", "3"].map(parseFloat).join();`, "1,2,3"},
+		{`["10", "10", "10"].map(parseInt).join();`, "10,NaN,2"},
+		{`Boolean("");`, false},
+		{`Boolean("false");`, true},
+		{`Boolean({});`, true},
+		{`[0, 1, "", "a"].filter(Boolean).length;`, 2.0},
+		{`"" + NaN;`, "NaN"},
+		{`"" + Infinity;`, "Infinity"},
+		{`NaN ? "truthy" : "falsy";`, "falsy"},
+		{`"" + (0.1 + 0.2);`, "0.30000000000000004"},
+		{`"" + Number("1e21");`, "1e+21"},
+		{`"" + 0.0000001;`, "1e-7"},
+		{`typeof Number;`, "function"},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package evaluator
+
+import (
+	"go-script/internal"
+)
+
+// GetPromiseProperty returns the methods every promise has
+// Each access returns a function bound to the promise, like array methods.
+//
+// Examples:
+//
+//	p.then(onFulfilled, onRejected) → new promise for the handler's result
+//	p.catch(onRejected)            → same as p.then(undefined, onRejected)
+//	p.finally(onFinally)           → runs onFinally either way, keeps the outcome
+func GetPromiseProperty(p *internal.Promise, key internal.PropertyKey) Value {
+	switch key {
+	case "then":
+		return &internal.Builtin{
+			Name: "then",
+			Fn: func(args ...interface{}) interface{} {
+				return p.Then(argAt(args, 0), argAt(args, 1))
+			},
+		}
+	case "catch":
+		return &internal.Builtin{
+			Name: "catch",
+			Fn: func(args ...interface{}) interface{} {
+				return p.Then(nil, argAt(args, 0))
+			},
+		}
+	case "finally":
+		return &internal.Builtin{
+			Name: "finally",
+			Fn: func(args ...interface{}) interface{} {
+				return promiseFinally(p, argAt(args, 0))
+			},
+		}
+	case internal.SymbolToStringTag:
+		return "Promise"
+	default:
+		return nil
+	}
+}
+
+// promiseFinally implements p.finally(onFinally)
+// The callback gets no arguments and its result is ignored, unless it throws
+// or returns a rejected promise: then that rejection replaces the outcome.
+// A promise returned by the callback is waited for first.
+//
+// Example:
+//
+//	Promise.resolve(1).finally(function() { print("done"); })
+//	→ prints "done", still fulfills with 1
+func promiseFinally(p *internal.Promise, onFinally Value) *internal.Promise {
+	callback, ok := onFinally.(internal.Callable)
+	if !ok {
+		return p.Then(onFinally, onFinally)
+	}
+
+	thenFinally := &internal.Builtin{
+		Fn: func(args ...interface{}) interface{} {
+			value := argAt(args, 0)
+			result := p.Loop().PromiseResolve(callback.Call(nil))
+			return result.Then(&internal.Builtin{
+				Fn: func(args ...interface{}) interface{} {
+					return value
+				},
+			}, nil)
+		},
+	}
+	catchFinally := &internal.Builtin{
+		Fn: func(args ...interface{}) interface{} {
+			reason := argAt(args, 0)
+			result := p.Loop().PromiseResolve(callback.Call(nil))
+			return result.Then(&internal.Builtin{
+				Fn: func(args ...interface{}) interface{} {
+					internal.Throw(reason)
+					return nil
+				},
+			}, nil)
+		},
+	}
+
+	return p.Then(thenFinally, catchFinally)
+}
+
+func argAt(args []interface{}, index int) Value {
+	if index >= len(args) {
+		return nil
+	}
+	return args[index]
+}
@@ -0,0 +1,217 @@
+package evaluator
+
+import (
+	"go-script/environment"
+	"go-script/internal"
+	"go-script/parser"
+	"testing"
+)
+
+// testEvalLog runs a program and returns its "log" variable afterwards, once
+// the microtask queue has drained
+func testEvalLog(input string) Value {
+	p := parser.New(input)
+	program := p.ParseProgram()
+	env := environment.NewGlobalEnvironment()
+	Eval(program, env)
+	log, _ := env.Get("log")
+	return log
+}
+
+func TestPromiseOrdering(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		// The executor runs synchronously, reactions run after the script
+		{`var log = "";
+		var p = new Promise(function(resolve) { log = log + "executor "; resolve(1); });
+		p.then(function(v) { log = log + "then" + v + " "; });
+		log = log + "sync ";`, "executor sync then1 "},
+		// Reactions of one promise run in registration order
+		{`var log = "";
+		var p = Promise.resolve("x");
+		p.then(function(v) { log = log + "a"; });
+		p.then(function(v) { log = log + "b"; });`, "ab"},
+		// Chains interleave one step at a time
+		{`var log = "";
+		Promise.resolve().then(function() { log = log + "a1"; }).then(function() { log = log + "a2"; });
+		Promise.resolve().then(function() { log = log + "b1"; }).then(function() { log = log + "b2"; });`, "a1b1a2b2"},
+		// Resolving with a promise takes two extra ticks
+		{`var log = "";
+		Promise.resolve().then(function() { return Promise.resolve("inner"); }).then(function(v) { log = log + v; });
+		Promise.resolve().then(function() { log = log + "1"; }).then(function() { log = log + "2"; }).then(function() { log = log + "3"; }).then(function() { log = log + "4"; });`, "123inner4"},
+	}
+
+	for _, tt := range tests {
+		result := testEvalLog(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestPromiseSettlement(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`var log;
+		new Promise(function(resolve) { resolve(1); resolve(2); }).then(function(v) { log = v; });`, 1.0},
+		{`var log;
+		new Promise(function(resolve, reject) { reject("no"); resolve(2); }).catch(function(e) { log = e; });`, "no"},
+		{`var log;
+		new Promise(function() { throw "thrown"; }).catch(function(e) { log = e; });`, "thrown"},
+		// Throwing after resolving is ignored
+		{`var log;
+		new Promise(function(resolve) { resolve("kept"); throw "ignored"; }).then(function(v) { log = v; });`, "kept"},
+		{`var log;
+		Promise.resolve(1).then(function(v) { return v + 1; }).then(function(v) { log = v; });`, 2.0},
+		{`var log;
+		Promise.resolve(1).then(function(v) { throw "bad " + v; }).catch(function(e) { log = e; });`, "bad 1"},
+		// Non-function handlers pass values and reasons through
+		{`var log;
+		Promise.resolve("through").then(null).then(function(v) { log = v; });`, "through"},
+		{`var log;
+		Promise.reject("skip").then(function() { log = "wrong"; }).catch(function(e) { log = e; });`, "skip"},
+		{`var log;
+		Promise.reject("x").catch(function(e) { return "recovered"; }).then(function(v) { log = v; });`, "recovered"},
+		// Thenables are adopted
+		{`var log;
+		var thenable = { then(resolve) { resolve("adopted"); } };
+		Promise.resolve(thenable).then(function(v) { log = v; });`, "adopted"},
+		{`var log;
+		new Promise(function(resolve) { resolve(Promise.reject("followed")); }).catch(function(e) { log = e; });`, "followed"},
+		{`var log; var p = Promise.resolve(1); log = Promise.resolve(p) == p;`, true},
+		{`var log;
+		var p = new Promise(function(resolve) { resolve(1); });
+		var q = p.then(function() { return q; });
+		q.catch(function(e) { log = e.name + ": " + e.message; });`, "TypeError: Chaining cycle detected for promise"},
+	}
+
+	for _, tt := range tests {
+		result := testEvalLog(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestPromiseFinally(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`var log = "";
+		Promise.resolve("v").finally(function() { log = log + "finally "; return "ignored"; }).then(function(v) { log = log + v; });`, "finally v"},
+		{`var log = "";
+		Promise.reject("r").finally(function() { log = log + "finally "; }).catch(function(e) { log = log + e; });`, "finally r"},
+		{`var log = "";
+		Promise.resolve("v").finally(function() { throw "replaced"; }).catch(function(e) { log = e; });`, "replaced"},
+		{`var log = "";
+		Promise.resolve("v").finally(function() { return Promise.reject("rejected"); }).catch(function(e) { log = e; });`, "rejected"},
+	}
+
+	for _, tt := range tests {
+		result := testEvalLog(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestPromiseCombinators(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`var log;
+		Promise.all([1, Promise.resolve(2), new Promise(function(r) { r(3); })]).then(function(v) { log = v[0] + v[1] + v[2]; });`, 6.0},
+		{`var log;
+		Promise.all([]).then(function(v) { log = v.length; });`, 0.0},
+		{`var log;
+		Promise.all([Promise.resolve(1), Promise.reject("first"), Promise.reject("second")]).catch(function(e) { log = e; });`, "first"},
+		{`var log;
+		var gen = function*() { yield 1; yield 2; };
+		Promise.all(gen()).then(function(v) { log = v.length; });`, 2.0},
+		{`var log;
+		Promise.all(5).catch(function(e) { log = e.name + ": " + e.message; });`, "TypeError: 5 is not iterable"},
+		{`var log;
+		Promise.allSettled([1, Promise.reject("x")]).then(function(r) { log = r[0].status + " " + r[0].value + " " + r[1].status + " " + r[1].reason; });`, "fulfilled 1 rejected x"},
+		{`var log;
+		Promise.race([new Promise(function() {}), Promise.resolve("fast")]).then(function(v) { log = v; });`, "fast"},
+		{`var log;
+		Promise.race([Promise.reject("lost"), Promise.resolve("late")]).catch(function(e) { log = e; });`, "lost"},
+		{`var log;
+		Promise.any([Promise.reject("a"), Promise.resolve("b")]).then(function(v) { log = v; });`, "b"},
+		{`var log;
+		Promise.any([Promise.reject("a"), Promise.reject("b")]).catch(function(e) { log = e.name + ": " + e.errors[0] + e.errors[1]; });`, "AggregateError: ab"},
+	}
+
+	for _, tt := range tests {
+		result := testEvalLog(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestPromiseValues(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`typeof Promise;`, "function"},
+		{`typeof Promise.resolve(1);`, "object"},
+		{`Promise.resolve(1) instanceof Promise;`, true},
+		{`({}) instanceof Promise;`, false},
+		{`"" + Promise.resolve(1);`, "Promise { 1 }"},
+		{`"" + new Promise(function() {});`, "Promise { <pending> }"},
+		{`try { Promise(function() {}); } catch (e) { e.name + ": " + e.message; }`, "TypeError: Promise constructor cannot be invoked without 'new'"},
+		{`try { new Promise(1); } catch (e) { e.name + ": " + e.message; }`, "TypeError: Promise resolver 1 is not a function"},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestUnhandledRejection(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value // thrown value of the reported rejection, or nil if none
+	}{
+		{`Promise.reject("lost");`, "lost"},
+		{`Promise.resolve(1).then(function() { throw "in handler"; });`, "in handler"},
+		{`Promise.reject("handled").catch(function() {});`, nil},
+		// A handler attached while the queue drains still counts
+		{`var p = Promise.reject("late");
+		Promise.resolve().then(function() { p.catch(function() {}); });`, nil},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		exception, ok := result.(*internal.Exception)
+
+		if tt.expected == nil {
+			if ok {
+				t.Errorf("For input %q: expected no unhandled rejection, got %v", tt.input, exception.Value)
+			}
+			continue
+		}
+
+		if !ok || !exception.InPromise {
+			t.Errorf("For input %q: expected an unhandled rejection, got %v", tt.input, result)
+			continue
+		}
+		if exception.Value != tt.expected {
+			t.Errorf("For input %q: expected reason %v, got %v", tt.input, tt.expected, exception.Value)
+		}
+		if exception.Error() != "Uncaught (in promise) "+internal.Inspect(tt.expected) {
+			t.Errorf("For input %q: unexpected message %q", tt.input, exception.Error())
+		}
+	}
+}
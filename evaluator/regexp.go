@@ -0,0 +1,65 @@
+package evaluator
+
+import (
+	"go-script/evaluator/builtins/regexp"
+	"go-script/internal"
+)
+
+// GetRegExpProperty returns the methods and properties every RegExp has
+// Methods are bound to the expression, like array methods.
+//
+// Examples:
+//
+//	/a(b)/.exec("cab")    → ["ab", "b"] with index 1
+//	/\d/.test("x1")       → true
+//	/a/gi.flags           → "gi"
+//	/a/g.global           → true
+//	/a\/b/.source         → "a\/b"
+//	/a/g.toString()       → "/a/g"
+func GetRegExpProperty(r *regexp.RegExp, key internal.PropertyKey) Value {
+	switch key {
+	case "exec":
+		return &internal.Builtin{
+			Name: "exec",
+			Fn: func(args ...interface{}) interface{} {
+				return r.Exec(internal.ToString(argAt(args, 0)))
+			},
+		}
+	case "test":
+		return &internal.Builtin{
+			Name: "test",
+			Fn: func(args ...interface{}) interface{} {
+				return r.Test(internal.ToString(argAt(args, 0)))
+			},
+		}
+	case "toString":
+		return &internal.Builtin{
+			Name: "toString",
+			Fn: func(args ...interface{}) interface{} {
+				return r.String()
+			},
+		}
+	case "lastIndex":
+		return r.LastIndex
+	case "source":
+		return r.Source
+	case "flags":
+		return r.Flags.String()
+	case "hasIndices":
+		return r.Flags.HasIndices
+	case "global":
+		return r.Flags.Global
+	case "ignoreCase":
+		return r.Flags.IgnoreCase
+	case "multiline":
+		return r.Flags.Multiline
+	case "dotAll":
+		return r.Flags.DotAll
+	case "unicode":
+		return r.Flags.Unicode
+	case "sticky":
+		return r.Flags.Sticky
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,240 @@
+package evaluator
+
+import (
+	"go-script/internal"
+	"testing"
+)
+
+func TestRegExpExec(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`/b+/.exec("abbbc")[0];`, "bbb"},
+		{`/b+/.exec("abbbc").index;`, 1.0},
+		{`/b+/.exec("abbbc").input;`, "abbbc"},
+		{`/x/.exec("abc");`, internal.Null},
+		{`/x/.exec("abc") == null;`, true},
+		{`/(\d+)-(\d+)/.exec("on 10-20")[2];`, "20"},
+		{`/(a)|(b)/.exec("b")[1];`, nil},
+		{`/(a)|(b)/.exec("b").length;`, 3.0},
+		{`/(?<year>\d{4})-(?<month>\d\d)/.exec("2024-05").groups.month;`, "05"},
+		{`/(\d)/.exec("1").groups;`, nil},
+		{`/(?<=\$)\d+/.exec("cost: $42")[0];`, "42"},
+		{`/(\w)\1/.exec("abccd")[0];`, "cc"},
+		{`/HELLO/i.exec("say hello")[0];`, "hello"},
+		{"/^b/m.exec(\"a\nb\").index;", 2.0},
+		{"/a.c/.test(\"a\nc\");", false},
+		{"/a.c/s.test(\"a\nc\");", true},
+		{`/^.$/u.test("😀");`, true},
+		{`/^.$/.test("😀");`, false},
+		{`/b/d.exec("ab").indices[0][0];`, 1.0},
+		{`/(?<x>b)/d.exec("ab").indices.groups.x[1];`, 2.0},
+		{`/\d/.test("a1");`, true},
+		{`/\d/.test("ab");`, false},
+		{`/\d/.test(123);`, true},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestRegExpLongInput(t *testing.T) {
+	// "ab" doubled 19 times: a million characters
+	long := `var s = "ab"; var i = 0; while (i < 19) { s = s + s; i = i + 1; } `
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{long + `/^(?:a|b)*$/.test(s);`, true},
+		{long + `/^[ab]+$/.test(s);`, true},
+		{long + `try { /^(ab)*$/.test(s); } catch (e) { e.name + ": " + e.message; }`, "RangeError: Maximum call stack size exceeded"},
+		{long + `try { s.replace(/(ab)+/g, "x"); } catch (e) { e.name; }`, "RangeError"},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestRegExpLastIndex(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`var re = /o/g; re.exec("foo"); re.lastIndex;`, 2.0},
+		{`var re = /o/g; re.exec("foo"); re.exec("foo").index;`, 2.0},
+		{`var re = /o/g; re.exec("foo"); re.exec("foo"); re.exec("foo");`, internal.Null},
+		{`var re = /o/g; re.exec("foo"); re.exec("foo"); re.exec("foo"); re.lastIndex;`, 0.0},
+		{`var re = /o/; re.exec("foo"); re.lastIndex;`, 0.0},
+		{`var re = /o/g; re.lastIndex = 2; re.exec("foo").index;`, 2.0},
+		{`var re = /o/y; re.test("foo");`, false},
+		{`var re = /o/y; re.lastIndex = 1; re.test("foo");`, true},
+		{`var re = /o/y; re.lastIndex = 1; re.test("foo"); re.lastIndex;`, 2.0},
+		{`var re = /a/g; re.test("a"); re.test("a");`, false},
+		{`var re = /a/g; re.lastIndex = 5; re.test("a");`, false},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestRegExpProperties(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`/a+/gi.source;`, "a+"},
+		{`/a/yigm.flags;`, "gimy"},
+		{`/a/g.global;`, true},
+		{`/a/g.ignoreCase;`, false},
+		{`/a/i.ignoreCase;`, true},
+		{`/a/m.multiline;`, true},
+		{`/a/s.dotAll;`, true},
+		{`/a/u.unicode;`, true},
+		{`/a/y.sticky;`, true},
+		{`/a/d.hasIndices;`, true},
+		{`/a\/b/g.toString();`, "/a\\/b/g"},
+		{`"" + /a/i;`, "/a/i"},
+		{`typeof /a/;`, "object"},
+		{`/a/ == /a/;`, false},
+		{`var re = /a/; re == re;`, true},
+		{`/a/ instanceof RegExp;`, true},
+		{`({}) instanceof RegExp;`, false},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestRegExpConstructor(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`new RegExp("a+", "g").toString();`, "/a+/g"},
+		{`new RegExp("[0-9]+").exec("ab12")[0];`, "12"},
+		{`new RegExp("a/b").source;`, "a\\/b"},
+		{`new RegExp("[/]").source;`, "[/]"},
+		{`new RegExp("").source;`, "(?:)"},
+		{`new RegExp().test("anything");`, true},
+		{`new RegExp(/ab/i).flags;`, "i"},
+		{`new RegExp(/ab/i, "g").toString();`, "/ab/g"},
+		{`RegExp("x", "y").sticky;`, true},
+		{`var re = /a/; RegExp(re) == re;`, true},
+		{`var re = /a/; new RegExp(re) == re;`, false},
+		{`try { new RegExp("("); } catch (e) { e.name + ": " + e.message; }`, "SyntaxError: Invalid regular expression: /(/: Unterminated group"},
+		{`try { new RegExp("a", "gg"); } catch (e) { e.name + ": " + e.message; }`, "SyntaxError: Invalid flags supplied to RegExp constructor 'gg'"},
+		{`try { new RegExp("(?<1>a)", "u"); } catch (e) { e.name + ": " + e.message; }`, "SyntaxError: Invalid regular expression: /(?<1>a)/u: Invalid capture group name"},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestStringRegExpMethods(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		// match
+		{`"a1b22c333".match(/\d+/g).length;`, 3.0},
+		{`"a1b22c333".match(/\d+/g)[2];`, "333"},
+		{`"abc".match(/\d/g);`, internal.Null},
+		{`"a1b2".match(/[a-z](\d)/)[1];`, "1"},
+		{`"a1b2".match(/[a-z](\d)/).index;`, 0.0},
+		{`"a.b".match(".")[0];`, "a"},
+		{`"abc".match(/(?:)/g).length;`, 4.0},
+		{`"😀😀".match(/(?:)/gu).length;`, 3.0},
+
+		// matchAll
+		{`var out = ""; for (let m of "a1b2".matchAll(/[a-z](\d)/g)) { out = out + m[1] + m.index; } out;`, "1022"},
+		{`var n = 0; for (let m of "aaa".matchAll(/a*?/g)) { n = n + 1; } n;`, 4.0},
+		{`var out = ""; for (let m of "x.y".matchAll(".")) { out = out + m[0]; } out;`, "x.y"},
+		{`try { "a".matchAll(/a/); } catch (e) { e.name + ": " + e.message; }`, "TypeError: String.prototype.matchAll called with a non-global RegExp argument"},
+
+		// replace with a RegExp
+		{`"a-b-c".replace(/-/, "+");`, "a+b-c"},
+		{`"a-b-c".replace(/-/g, "+");`, "a+b+c"},
+		{`"2024-05".replace(/(\d+)-(\d+)/, "$2/$1");`, "05/2024"},
+		{`"2024-05".replace(/(?<y>\d+)-(?<m>\d+)/, "$<m>/$<y>");`, "05/2024"},
+		{`"abc".replace(/b/, "[$&|$` + "`" + `|$']");`, "a[b|a|c]c"},
+		{`"abc".replace(/b/, "$$");`, "a$c"},
+		{`"abc".replace(/b/, "$1");`, "a$1c"},
+		{`"abc".replace(/(b)/, "$01$2");`, "ab$2c"},
+		{`"abc".replace(/b/, function(m) { return m + m; });`, "abbc"},
+		{`"x1y2".replace(/\d/g, function(d, pos) { return "<" + pos + ">"; });`, "x<1>y<3>"},
+		{`"a-b".replace(/(?<l>\w)-(?<r>\w)/, function(m, l, r, pos, s, groups) { return groups.r + groups.l; });`, "ba"},
+		{`"aaa".replace(/a*?/g, "-");`, "-a-a-a-"},
+		{`"xAx".replace(/a/i, "b");`, "xbx"},
+
+		// replace with a string
+		{`"a.b.c".replace(".", "-");`, "a-b.c"},
+		{`"abc".replace("b", "[$&]");`, "a[b]c"},
+		{`"abc".replace("x", "y");`, "abc"},
+		{`"abc".replace("", "-");`, "-abc"},
+		{`"abc".replace("b", function(m, pos) { return pos; });`, "a1c"},
+
+		// split
+		{`"a1b22c".split(/\d+/).length;`, 3.0},
+		{`"a1b22c".split(/\d+/)[2];`, "c"},
+		{`"a1b2c".split(/(\d)/).length;`, 5.0},
+		{`"a1b2c".split(/(\d)/)[3];`, "2"},
+		{`"abc".split(/(?:)/).length;`, 3.0},
+		{`"abc".split(/(?:)/, 2)[1];`, "b"},
+		{`"".split(/,/).length;`, 1.0},
+		{`"".split(/(?:)/).length;`, 0.0},
+		{`"a, b,c".split(/,\s*/)[1];`, "b"},
+		{`"a,b,c".split(",").length;`, 3.0},
+		{`"a,b,c".split(",", 2).length;`, 2.0},
+		{`"abc".split("")[2];`, "c"},
+		{`"abc".split()[0];`, "abc"},
+		{`"abc".split(",", 0).length;`, 0.0},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestRegExpLiteralDivision(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`var a = 8; var b = 2; var g = 2; a / b / g;`, 2.0},
+		{`var x = (6) / 2 / 3; x;`, 1.0},
+		{`var arr = [10]; arr[0] / 5;`, 2.0},
+		{`var f = function() { return /x/.test("x"); }; f();`, true},
+		{`var parts = ["a/b"]; parts[0].split(/\//).length;`, 2.0},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
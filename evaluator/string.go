@@ -0,0 +1,533 @@
+package evaluator
+
+import (
+	"cmp"
+	"go-script/evaluator/builtins/array"
+	"go-script/evaluator/builtins/regexp"
+	"go-script/internal"
+	"go-script/norm"
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+)
+
+// GetStringProperty returns the length, characters and methods of string values
+// Methods are bound to the string, like array methods.
+//
+// Strings are indexed the way JavaScript indexes them, by UTF-16 code unit,
+// so "😀".length is 2 and positions agree with other engines. Go strings
+// can't hold half of a surrogate pair, so reading one on its own (as
+// "😀"[0] does) gives U+FFFD instead.
+//
+// Examples:
+//
+//	"hello".length          → 5
+//	"hello"[1]              → "e"
+//	"hello".at(-1)          → "o"
+//	"hello".slice(1, -1)    → "ell"
+//	"a-b".replace("-", "+") → "a+b"
+//	"  hi ".trim()          → "hi"
+//	"7".padStart(3, "0")    → "007"
+func GetStringProperty(str string, key internal.PropertyKey) Value {
+	if key == internal.SymbolIterator {
+		return createIteratorMethod(stringValues(str))
+	}
+	name, ok := key.(string)
+	if !ok {
+		return nil
+	}
+
+	switch name {
+	case "length":
+		return float64(len(utf16.Encode([]rune(str))))
+	case "at":
+		return stringMethod(name, func(args []interface{}) Value {
+			units := utf16.Encode([]rune(str))
+			index := internal.ToIntegerOrInfinity(argAt(args, 0))
+			if index < 0 {
+				index += float64(len(units))
+			}
+			if index < 0 || index >= float64(len(units)) {
+				return nil
+			}
+			return decode(units[int(index) : int(index)+1])
+		})
+	case "charAt":
+		return stringMethod(name, func(args []interface{}) Value {
+			units := utf16.Encode([]rune(str))
+			index := internal.ToIntegerOrInfinity(argAt(args, 0))
+			if index < 0 || index >= float64(len(units)) {
+				return ""
+			}
+			return decode(units[int(index) : int(index)+1])
+		})
+	case "charCodeAt":
+		return stringMethod(name, func(args []interface{}) Value {
+			units := utf16.Encode([]rune(str))
+			index := internal.ToIntegerOrInfinity(argAt(args, 0))
+			if index < 0 || index >= float64(len(units)) {
+				return math.NaN()
+			}
+			return float64(units[int(index)])
+		})
+	case "codePointAt":
+		return stringMethod(name, func(args []interface{}) Value {
+			units := utf16.Encode([]rune(str))
+			index := internal.ToIntegerOrInfinity(argAt(args, 0))
+			if index < 0 || index >= float64(len(units)) {
+				return nil
+			}
+			i := int(index)
+			if utf16.IsSurrogate(rune(units[i])) && i+1 < len(units) {
+				if ch := utf16.DecodeRune(rune(units[i]), rune(units[i+1])); ch != unicode.ReplacementChar {
+					return float64(ch)
+				}
+			}
+			return float64(units[i])
+		})
+	case "indexOf":
+		return stringMethod(name, func(args []interface{}) Value {
+			units := utf16.Encode([]rune(str))
+			from := clampIndex(internal.ToIntegerOrInfinity(argAt(args, 1)), len(units))
+			return float64(indexOfUnits(units, utf16.Encode([]rune(internal.ToString(argAt(args, 0)))), from))
+		})
+	case "lastIndexOf":
+		return stringMethod(name, func(args []interface{}) Value {
+			units := utf16.Encode([]rune(str))
+			search := utf16.Encode([]rune(internal.ToString(argAt(args, 0))))
+			from := len(units)
+			if position, ok := argAt(args, 1).(float64); ok && !math.IsNaN(position) {
+				from = clampIndex(math.Trunc(position), len(units))
+			}
+			for i := min(from, len(units)-len(search)); i >= 0; i-- {
+				if indexOfUnits(units[i:i+len(search)], search, 0) == 0 {
+					return float64(i)
+				}
+			}
+			return -1.0
+		})
+	case "includes":
+		return stringMethod(name, func(args []interface{}) Value {
+			search := nonRegExpArgument(name, argAt(args, 0))
+			units := utf16.Encode([]rune(str))
+			from := clampIndex(internal.ToIntegerOrInfinity(argAt(args, 1)), len(units))
+			return indexOfUnits(units, utf16.Encode([]rune(search)), from) >= 0
+		})
+	case "startsWith":
+		return stringMethod(name, func(args []interface{}) Value {
+			search := utf16.Encode([]rune(nonRegExpArgument(name, argAt(args, 0))))
+			units := utf16.Encode([]rune(str))
+			start := clampIndex(internal.ToIntegerOrInfinity(argAt(args, 1)), len(units))
+			return start+len(search) <= len(units) && indexOfUnits(units[start:start+len(search)], search, 0) == 0
+		})
+	case "endsWith":
+		return stringMethod(name, func(args []interface{}) Value {
+			search := utf16.Encode([]rune(nonRegExpArgument(name, argAt(args, 0))))
+			units := utf16.Encode([]rune(str))
+			end := len(units)
+			if argAt(args, 1) != nil {
+				end = clampIndex(internal.ToIntegerOrInfinity(argAt(args, 1)), len(units))
+			}
+			start := end - len(search)
+			return start >= 0 && indexOfUnits(units[start:end], search, 0) == 0
+		})
+	case "slice":
+		return stringMethod(name, func(args []interface{}) Value {
+			units := utf16.Encode([]rune(str))
+			start := relativeIndex(argAt(args, 0), len(units), 0)
+			end := relativeIndex(argAt(args, 1), len(units), len(units))
+			if start >= end {
+				return ""
+			}
+			return decode(units[start:end])
+		})
+	case "substring":
+		return stringMethod(name, func(args []interface{}) Value {
+			units := utf16.Encode([]rune(str))
+			start := clampIndex(internal.ToIntegerOrInfinity(argAt(args, 0)), len(units))
+			end := len(units)
+			if argAt(args, 1) != nil {
+				end = clampIndex(internal.ToIntegerOrInfinity(argAt(args, 1)), len(units))
+			}
+			if start > end {
+				start, end = end, start
+			}
+			return decode(units[start:end])
+		})
+	case "concat":
+		return stringMethod(name, func(args []interface{}) Value {
+			result := str
+			for _, arg := range args {
+				result += internal.ToString(arg)
+			}
+			return result
+		})
+	case "match":
+		return stringMethod(name, func(args []interface{}) Value {
+			return toRegExp(argAt(args, 0), "").Match(str)
+		})
+	case "matchAll":
+		return stringMethod(name, func(args []interface{}) Value {
+			pattern := argAt(args, 0)
+			if r, ok := pattern.(*regexp.RegExp); ok && !r.Flags.Global {
+				internal.ThrowError("TypeError", "String.prototype.matchAll called with a non-global RegExp argument")
+			}
+			return toRegExp(pattern, "g").MatchAll(str)
+		})
+	case "search":
+		return stringMethod(name, func(args []interface{}) Value {
+			return toRegExp(argAt(args, 0), "").Search(str)
+		})
+	case "replace":
+		return stringMethod(name, func(args []interface{}) Value {
+			if r, ok := argAt(args, 0).(*regexp.RegExp); ok {
+				return r.Replace(str, argAt(args, 1))
+			}
+			return regexp.ReplaceString(str, internal.ToString(argAt(args, 0)), argAt(args, 1), false)
+		})
+	case "replaceAll":
+		return stringMethod(name, func(args []interface{}) Value {
+			if r, ok := argAt(args, 0).(*regexp.RegExp); ok {
+				if !r.Flags.Global {
+					internal.ThrowError("TypeError", "replaceAll must be called with a global RegExp")
+				}
+				return r.Replace(str, argAt(args, 1))
+			}
+			return regexp.ReplaceString(str, internal.ToString(argAt(args, 0)), argAt(args, 1), true)
+		})
+	case "split":
+		return stringMethod(name, func(args []interface{}) Value {
+			return splitString(str, argAt(args, 0), argAt(args, 1))
+		})
+	case "trim":
+		return stringMethod(name, func(args []interface{}) Value {
+			return strings.TrimFunc(str, isWhiteSpace)
+		})
+	case "trimStart", "trimLeft":
+		return stringMethod(name, func(args []interface{}) Value {
+			return strings.TrimLeftFunc(str, isWhiteSpace)
+		})
+	case "trimEnd", "trimRight":
+		return stringMethod(name, func(args []interface{}) Value {
+			return strings.TrimRightFunc(str, isWhiteSpace)
+		})
+	case "padStart", "padEnd":
+		return stringMethod(name, func(args []interface{}) Value {
+			return pad(str, argAt(args, 0), argAt(args, 1), name == "padStart")
+		})
+	case "repeat":
+		return stringMethod(name, func(args []interface{}) Value {
+			count := internal.ToIntegerOrInfinity(argAt(args, 0))
+			if count < 0 || math.IsInf(count, 1) {
+				internal.ThrowError("RangeError", "Invalid count value: "+internal.ToString(argAt(args, 0)))
+			}
+			return strings.Repeat(str, int(count))
+		})
+	case "toUpperCase", "toLocaleUpperCase":
+		return stringMethod(name, func(args []interface{}) Value {
+			return toUpperCase(str)
+		})
+	case "toLowerCase", "toLocaleLowerCase":
+		return stringMethod(name, func(args []interface{}) Value {
+			return toLowerCase(str)
+		})
+	case "localeCompare":
+		return stringMethod(name, func(args []interface{}) Value {
+			return localeCompare(str, internal.ToString(argAt(args, 0)))
+		})
+	case "normalize":
+		return stringMethod(name, func(args []interface{}) Value {
+			name := "NFC"
+			if argAt(args, 0) != nil {
+				name = internal.ToString(argAt(args, 0))
+			}
+			form, ok := norm.ParseForm(name)
+			if !ok {
+				internal.ThrowError("RangeError", "The normalization form should be one of NFC, NFD, NFKC, NFKD.")
+			}
+			return form.String(str)
+		})
+	case "toString", "valueOf":
+		return stringMethod(name, func(args []interface{}) Value {
+			return str
+		})
+	}
+
+	// "abc"[1] arrives here as the key "1"
+	if index, ok := isArrayIndex(name); ok {
+		units := utf16.Encode([]rune(str))
+		if index < len(units) {
+			return decode(units[index : index+1])
+		}
+	}
+	return nil
+}
+
+// stringMethod wraps the body of a string method as a builtin
+func stringMethod(name string, fn func(args []interface{}) Value) Value {
+	return &internal.Builtin{
+		Name: name,
+		Fn: func(args ...interface{}) interface{} {
+			return fn(args)
+		},
+	}
+}
+
+func decode(units []uint16) string {
+	return string(utf16.Decode(units))
+}
+
+// clampIndex limits a position to the string, 0 to length
+func clampIndex(n float64, length int) int {
+	return int(math.Max(0, math.Min(n, float64(length))))
+}
+
+// relativeIndex resolves a slice() position, where negative values count
+// back from the end. Undefined gives the fallback.
+//
+// Examples (length 5):
+//
+//	relativeIndex(1, 5, 0)   → 1
+//	relativeIndex(-2, 5, 0)  → 3
+//	relativeIndex(nil, 5, 5) → 5
+func relativeIndex(val Value, length int, fallback int) int {
+	if val == nil {
+		return fallback
+	}
+	n := internal.ToIntegerOrInfinity(val)
+	if n < 0 {
+		n += float64(length)
+	}
+	return clampIndex(n, length)
+}
+
+// indexOfUnits finds search in units at or after from, or returns -1
+func indexOfUnits(units []uint16, search []uint16, from int) int {
+	for i := from; i+len(search) <= len(units); i++ {
+		found := true
+		for j := range search {
+			if units[i+j] != search[j] {
+				found = false
+				break
+			}
+		}
+		if found {
+			return i
+		}
+	}
+	return -1
+}
+
+// nonRegExpArgument converts the search string of includes, startsWith or
+// endsWith, which throw rather than treat a RegExp as text
+func nonRegExpArgument(method string, val Value) string {
+	if _, ok := val.(*regexp.RegExp); ok {
+		internal.ThrowError("TypeError", "First argument to String.prototype."+method+" must not be a regular expression")
+	}
+	return internal.ToString(val)
+}
+
+// isWhiteSpace reports whether trim() removes ch: JavaScript white space
+// and line terminators
+func isWhiteSpace(ch rune) bool {
+	switch ch {
+	case '\t', '\n', '\v', '\f', '\r', ' ', 0xA0, 0x2028, 0x2029, 0xFEFF:
+		return true
+	}
+	return unicode.Is(unicode.Zs, ch)
+}
+
+// pad implements padStart and padEnd
+// The filler is repeated and cut to reach the target length in code units.
+//
+// Examples:
+//
+//	pad("7", 3, "0", true)     → "007"
+//	pad("abc", 6, "12", false) → "abc121"
+//	pad("abc", 2, " ", true)   → "abc"
+func pad(str string, targetLength Value, filler Value, atStart bool) string {
+	units := utf16.Encode([]rune(str))
+	target := internal.ToIntegerOrInfinity(targetLength)
+	if target <= float64(len(units)) {
+		return str
+	}
+
+	fill := " "
+	if filler != nil {
+		fill = internal.ToString(filler)
+	}
+	fillUnits := utf16.Encode([]rune(fill))
+	if len(fillUnits) == 0 {
+		return str
+	}
+
+	padding := make([]uint16, 0, int(target)-len(units))
+	for len(padding) < int(target)-len(units) {
+		padding = append(padding, fillUnits[:min(len(fillUnits), int(target)-len(units)-len(padding))]...)
+	}
+
+	if atStart {
+		return decode(padding) + str
+	}
+	return str + decode(padding)
+}
+
+// toUpperCase uppercases a string, including the characters whose
+// uppercase form is longer than one character
+//
+// Example: "straße" → "STRASSE"
+func toUpperCase(str string) string {
+	var b strings.Builder
+	for _, ch := range str {
+		switch ch {
+		case 'ß':
+			b.WriteString("SS")
+		case 'ŉ':
+			b.WriteString("ʼN")
+		case 'ﬀ':
+			b.WriteString("FF")
+		case 'ﬁ':
+			b.WriteString("FI")
+		case 'ﬂ':
+			b.WriteString("FL")
+		default:
+			b.WriteRune(unicode.ToUpper(ch))
+		}
+	}
+	return b.String()
+}
+
+// toLowerCase lowercases a string. A capital sigma at the end of a word
+// becomes the final form "ς", and "İ" keeps its dot as a combining mark.
+//
+// Example: "ΟΔΟΣ" → "οδος" with a final "ς"
+func toLowerCase(str string) string {
+	chars := []rune(str)
+	var b strings.Builder
+	for i, ch := range chars {
+		switch {
+		case ch == 'Σ' && endsWord(chars, i):
+			b.WriteRune('ς')
+		case ch == 'İ':
+			b.WriteString("i\u0307")
+		default:
+			b.WriteRune(unicode.ToLower(ch))
+		}
+	}
+	return b.String()
+}
+
+// endsWord reports whether the sigma at i follows a letter and isn't
+// followed by one
+func endsWord(chars []rune, i int) bool {
+	return i > 0 && unicode.IsLetter(chars[i-1]) && (i+1 == len(chars) || !unicode.IsLetter(chars[i+1]))
+}
+
+// localeCompare orders two strings the way a language-neutral collation
+// does, returning -1, 0 or 1. Strings are compared first ignoring case and
+// accents, then by accents, then with lowercase before uppercase, and
+// finally code unit by code unit.
+//
+// Examples:
+//
+//	localeCompare("a", "B")     → -1 (unlike "a" < "B" by code unit)
+//	localeCompare("résumé", "resume") → 1
+//	localeCompare("a", "A")     → -1
+func localeCompare(a string, b string) float64 {
+	levels := []func(string) string{
+		func(s string) string { return strings.ToLower(stripMarks(norm.NFD.String(s))) },
+		func(s string) string { return strings.ToLower(norm.NFD.String(s)) },
+		func(s string) string { return strings.Map(swapCase, norm.NFD.String(s)) },
+	}
+	for _, key := range levels {
+		if result := strings.Compare(key(a), key(b)); result != 0 {
+			return float64(result)
+		}
+	}
+
+	unitsA, unitsB := utf16.Encode([]rune(a)), utf16.Encode([]rune(b))
+	for i := 0; i < len(unitsA) && i < len(unitsB); i++ {
+		if unitsA[i] != unitsB[i] {
+			if unitsA[i] < unitsB[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return float64(cmp.Compare(len(unitsA), len(unitsB)))
+}
+
+// stripMarks removes combining marks, leaving base letters
+func stripMarks(s string) string {
+	return strings.Map(func(ch rune) rune {
+		if unicode.Is(unicode.Mn, ch) {
+			return -1
+		}
+		return ch
+	}, s)
+}
+
+// swapCase exchanges upper and lower case, so that sorting the result puts
+// lowercase letters first
+func swapCase(ch rune) rune {
+	if unicode.IsUpper(ch) {
+		return unicode.ToLower(ch)
+	}
+	return unicode.ToUpper(ch)
+}
+
+// toRegExp returns the RegExp a string method searches with: the argument
+// itself if it is one, otherwise a new expression compiled from it, as
+// "abc".match("b.") does
+func toRegExp(pattern Value, flags string) *regexp.RegExp {
+	if r, ok := pattern.(*regexp.RegExp); ok {
+		return r
+	}
+	if pattern == nil {
+		return regexp.New("", flags)
+	}
+	return regexp.New(internal.ToString(pattern), flags)
+}
+
+// splitString implements String.prototype.split
+// A string separator splits at each occurrence; the empty string splits
+// between characters. Without a separator the result is the whole string.
+//
+// Examples:
+//
+//	"a,b,c".split(",")    → ["a", "b", "c"]
+//	"abc".split("")       → ["a", "b", "c"]
+//	"a,b,c".split(",", 2) → ["a", "b"]
+//	"abc".split()         → ["abc"]
+func splitString(str string, separator Value, limit Value) Value {
+	if r, ok := separator.(*regexp.RegExp); ok {
+		return r.Split(str, limit)
+	}
+
+	lim := -1
+	if limit != nil {
+		lim = int(uint32(int64(internal.ToIntegerOrInfinity(limit))))
+	}
+
+	var parts []string
+	switch {
+	case separator == nil:
+		parts = []string{str}
+	case internal.ToString(separator) == "":
+		for _, ch := range str {
+			parts = append(parts, string(ch))
+		}
+	default:
+		parts = strings.Split(str, internal.ToString(separator))
+	}
+
+	elements := make(internal.Array, 0, len(parts))
+	for _, part := range parts {
+		if len(elements) == lim {
+			break
+		}
+		elements = append(elements, part)
+	}
+	return array.NewArrayReference(elements)
+}
@@ -0,0 +1,143 @@
+package evaluator
+
+import (
+	"math"
+	"testing"
+)
+
+func TestStringIndexing(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`"hello".length;`, 5.0},
+		{`"".length;`, 0.0},
+		{`"😀".length;`, 2.0},
+		{`var s = "héllo"; s.length;`, 5.0},
+		{`"hello"[0];`, "h"},
+		{`"hello"[4];`, "o"},
+		{`"hello"[5];`, nil},
+		{`"hello"["1"];`, "e"},
+		{`var i = 2; "hello"[i];`, "l"},
+		{`"hello"["length"];`, 5.0},
+		{`"hello".nope;`, nil},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestStringMethods(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		// Characters
+		{`"hello".at(1);`, "e"},
+		{`"hello".at(-1);`, "o"},
+		{`"hello".at(5);`, nil},
+		{`"hello".charAt(1);`, "e"},
+		{`"hello".charAt(9);`, ""},
+		{`"hello".charAt();`, "h"},
+		{`"ABC".charCodeAt(1);`, 66.0},
+		{`"😀".charCodeAt(0);`, 55357.0},
+		{`"😀".codePointAt(0);`, 128512.0},
+		{`"😀".codePointAt(1);`, 56832.0},
+		{`"a".codePointAt(3);`, nil},
+
+		// Searching
+		{`"hello".indexOf("l");`, 2.0},
+		{`"hello".indexOf("l", 3);`, 3.0},
+		{`"hello".indexOf("x");`, -1.0},
+		{`"hello".indexOf("");`, 0.0},
+		{`"hello".lastIndexOf("l");`, 3.0},
+		{`"hello".lastIndexOf("l", 2);`, 2.0},
+		{`"hello".lastIndexOf("");`, 5.0},
+		{`"hello".includes("ell");`, true},
+		{`"hello".includes("h", 1);`, false},
+		{`"hello".startsWith("he");`, true},
+		{`"hello".startsWith("l", 2);`, true},
+		{`"hello".endsWith("lo");`, true},
+		{`"hello".endsWith("l", 4);`, true},
+		{`"hello".endsWith("hello!");`, false},
+		{`"abc1".search(/\d/);`, 3.0},
+		{`"abc".search("x");`, -1.0},
+		{`try { "abc".includes(/b/); } catch (e) { e.name + ": " + e.message; }`, "TypeError: First argument to String.prototype.includes must not be a regular expression"},
+
+		// Substrings
+		{`"hello".slice(1, 3);`, "el"},
+		{`"hello".slice(-3);`, "llo"},
+		{`"hello".slice(1, -1);`, "ell"},
+		{`"hello".slice(3, 1);`, ""},
+		{`"hello".substring(1, 3);`, "el"},
+		{`"hello".substring(3, 1);`, "el"},
+		{`"hello".substring(-2, 2);`, "he"},
+		{`"hello".substring(2);`, "llo"},
+		{`"ab".concat("c", 1);`, "abc1"},
+
+		// Replacing and splitting
+		{`"a-b-c".replace("-", "+");`, "a+b-c"},
+		{`"a-b-c".replaceAll("-", "+");`, "a+b+c"},
+		{`"abc".replaceAll("", "-");`, "-a-b-c-"},
+		{`"a1b2".replaceAll(/\d/g, "#");`, "a#b#"},
+		{`"a.b".replaceAll(".", function(m, pos) { return pos; });`, "a1b"},
+		{`try { "a1".replaceAll(/\d/, "#"); } catch (e) { e.name + ": " + e.message; }`, "TypeError: replaceAll must be called with a global RegExp"},
+		{`"a,b".split(",")[1];`, "b"},
+
+		// Whitespace and padding
+		{`"  hi  ".trim();`, "hi"},
+		{`"  hi  ".trimStart();`, "hi  "},
+		{`"  hi  ".trimEnd();`, "  hi"},
+		{"\"\\t hi\\n\".trim();", "\\t hi\\n"},
+		{"\"\t hi \".trim();", "hi"},
+		{`"7".padStart(3, "0");`, "007"},
+		{`"abc".padEnd(6, "12");`, "abc121"},
+		{`"abc".padStart(5);`, "  abc"},
+		{`"abc".padStart(2, "0");`, "abc"},
+		{`"abc".padStart(6, "");`, "abc"},
+		{`"ab".repeat(3);`, "ababab"},
+		{`"ab".repeat(0);`, ""},
+		{`try { "ab".repeat(-1); } catch (e) { e.name + ": " + e.message; }`, "RangeError: Invalid count value: -1"},
+
+		// Case and comparison
+		{`"Hello".toUpperCase();`, "HELLO"},
+		{`"Hello".toLowerCase();`, "hello"},
+		{`"straße".toUpperCase();`, "STRASSE"},
+		{`"ΟΔΟΣ ΕΝΑ".toLowerCase();`, "οδος ενα"},
+		{`"ÉTÉ".toLowerCase();`, "été"},
+		{`"a".localeCompare("b");`, -1.0},
+		{`"b".localeCompare("a");`, 1.0},
+		{`"a".localeCompare("a");`, 0.0},
+		{`"a".localeCompare("B");`, -1.0},
+		{`"a".localeCompare("A");`, -1.0},
+		{`"résumé".localeCompare("resume");`, 1.0},
+		{`"résumé".localeCompare("resumf");`, -1.0},
+
+		// Normalization
+		{"\"e\u0301\".normalize() == \"\u00e9\";", true},
+		{"\"\u00e9\".normalize(\"NFD\").length;", 2.0},
+		{"\"ﬁ\".normalize(\"NFKC\");", "fi"},
+		{`try { "a".normalize("nfc"); } catch (e) { e.name + ": " + e.message; }`, "RangeError: The normalization form should be one of NFC, NFD, NFKC, NFKD."},
+
+		{`"abc".toString();`, "abc"},
+		{`"abc".valueOf();`, "abc"},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestStringCharCodeAtOutOfRange(t *testing.T) {
+	result, ok := testEval(`"abc".charCodeAt(5);`).(float64)
+	if !ok || !math.IsNaN(result) {
+		t.Errorf("Expected NaN, got %v", result)
+	}
+}
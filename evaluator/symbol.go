@@ -0,0 +1,27 @@
+package evaluator
+
+import (
+	"go-script/internal"
+)
+
+// GetSymbolProperty returns the members every symbol value has
+//
+// Examples:
+//
+//	Symbol("id").description → "id"
+//	Symbol("id").toString()  → "Symbol(id)"
+func GetSymbolProperty(sym *internal.Symbol, key internal.PropertyKey) Value {
+	switch key {
+	case "description":
+		return sym.Description
+	case "toString":
+		return &internal.Builtin{
+			Name: "toString",
+			Fn: func(args ...interface{}) interface{} {
+				return sym.String()
+			},
+		}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,214 @@
+package evaluator
+
+import (
+	"testing"
+)
+
+func TestSymbolValues(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`typeof Symbol("id");`, "symbol"},
+		{`Symbol("id").description;`, "id"},
+		{`Symbol("id").toString();`, "Symbol(id)"},
+		{`Symbol("a") == Symbol("a");`, false},
+		{`var s = Symbol(); s == s;`, true},
+		{`Symbol.for("app") == Symbol.for("app");`, true},
+		{`Symbol.keyFor(Symbol.for("app"));`, "app"},
+		{`Symbol.keyFor(Symbol("app"));`, nil},
+		{`Symbol.iterator.description;`, "Symbol.iterator"},
+		{`typeof Symbol.asyncIterator;`, "symbol"},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestSymbolKeyedProperties(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`var id = Symbol("id"); var user = { [id]: 7, id: "text" }; user[id];`, 7.0},
+		{`var id = Symbol("id"); var user = { [id]: 7, id: "text" }; user.id;`, "text"},
+		{`var id = Symbol("id"); var user = {}; user[id] = 1; user[Symbol("id")];`, nil},
+		{`var id = Symbol("id"); var user = { [id]: 7, name: "Ann" }; JSON.stringify(user);`, `{"name":"Ann"}`},
+		{`var key = Symbol("k"); class Box { [key]() { return "computed"; } } new Box()[key]();`, "computed"},
+		{`var key = Symbol("k"); class Box { static [key] = 5; } Box[key];`, 5.0},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestTypeof(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`typeof 1;`, "number"},
+		{`typeof "s";`, "string"},
+		{`typeof true;`, "boolean"},
+		{`typeof undefined;`, "undefined"},
+		{`typeof null;`, "object"},
+		{`typeof {};`, "object"},
+		{`typeof [];`, "object"},
+		{`typeof function() {};`, "function"},
+		{`typeof JSON.stringify;`, "function"},
+		{`class A {} typeof A;`, "function"},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestForOfIteration(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`var sum = 0; for (let x of [1, 2, 3]) { sum = sum + x; } sum;`, 6.0},
+		{`var out = ""; for (var c of "abc") { out = c + out; } out;`, "cba"},
+		{`var last; for (last of [1, 2, 3]) {} last;`, 3.0},
+		{`var arr = [1]; var n = 0; for (let x of arr) { n = n + 1; if (x < 3) { arr.push(x + 1); } } n;`, 3.0},
+		{`var range = {
+			from: 1, to: 4,
+			[Symbol.iterator]() {
+				var current = this.from;
+				var last = this.to;
+				return { next() {
+					if (current <= last) { current = current + 1; return { value: current - 1, done: false }; }
+					return { value: undefined, done: true };
+				} };
+			}
+		};
+		var total = 0;
+		for (let n of range) { total = total + n; }
+		total;`, 10.0},
+		{`class Countdown {
+			constructor(n) { this.n = n; }
+			[Symbol.iterator]() {
+				var n = this.n;
+				return { next() { n = n - 1; return { value: n + 1, done: n < 0 }; } };
+			}
+		}
+		var out = "";
+		for (let v of new Countdown(3)) { out = out + v; }
+		out;`, "321"},
+		{`var it = [7, 8][Symbol.iterator](); it.next().value + it.next().value;`, 15.0},
+		{`var it = [7][Symbol.iterator](); it.next(); it.next().done;`, true},
+		{`var fns = []; for (let x of [1, 2]) { fns.push(function() { return x; }); } fns[0]() + fns[1]();`, 3.0},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestForOfClosesIteratorOnReturn(t *testing.T) {
+	input := `
+	var closed = false;
+	var iterable = {
+		[Symbol.iterator]() {
+			return {
+				next() { return { value: 1, done: false }; },
+				return() { closed = true; return { done: true }; }
+			};
+		}
+	};
+	var first = function() { for (let v of iterable) { return v; } };
+	first();
+	closed;`
+
+	if result := testEval(input); result != true {
+		t.Errorf("Expected return() to be called when leaving the loop early, got %v", result)
+	}
+}
+
+func TestSymbolToPrimitive(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`var money = { [Symbol.toPrimitive](hint) { return hint == "number" ? 42 : "$42"; } };
+		money * 2;`, 84.0},
+		{`var money = { [Symbol.toPrimitive](hint) { return hint == "number" ? 42 : "$42"; } };
+		"cost: " + money;`, "cost: $42"},
+		{`var hints = "";
+		var spy = { [Symbol.toPrimitive](hint) { hints = hints + hint + " "; return 1; } };
+		spy + 1; spy - 1; spy < 2;
+		hints;`, "default number number "},
+		{`var v = { valueOf() { return 10; } }; v + 5;`, 15.0},
+		{`var v = { toString() { return "str"; } }; v + "!";`, "str!"},
+		{`class Temperature {
+			constructor(c) { this.c = c; }
+			[Symbol.toPrimitive](hint) { return this.c; }
+		}
+		new Temperature(20) > new Temperature(10);`, true},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestSymbolToStringTag(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`var tagged = { [Symbol.toStringTag]: "Validator" }; "" + tagged;`, "[object Validator]"},
+		{`class Stream { get [Symbol.toStringTag]() { return "Stream"; } } "" + new Stream();`, "[object Stream]"},
+		{`var plain = { a: 1 }; "" + plain;`, "{a: 1}"},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestInstanceof(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`class Animal {} class Dog extends Animal {} new Dog() instanceof Animal;`, true},
+		{`class Animal {} class Dog extends Animal {} new Dog() instanceof Dog;`, true},
+		{`class Animal {} class Dog extends Animal {} new Animal() instanceof Dog;`, false},
+		{`class Animal {} ({}) instanceof Animal;`, false},
+		{`class Animal {} 5 instanceof Animal;`, false},
+		{`class Even { static [Symbol.hasInstance](n) { return n == 2 ? true : n == 4; } } 4 instanceof Even;`, true},
+		{`var Even = { [Symbol.hasInstance](n) { return n == 2 ? true : n == 4; } }; 3 instanceof Even;`, false},
+		{`var Even = { [Symbol.hasInstance](n) { return n == 2 ? true : n == 4; } }; 4 instanceof Even;`, true},
+		{`class Base { static [Symbol.hasInstance](v) { return v == "yes"; } } class Child extends Base {} "yes" instanceof Child;`, true},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package evaluator
+
+import (
+	"go-script/environment"
+	"go-script/internal"
+	"go-script/parser"
+	"testing"
+)
+
+func TestTimerOrdering(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		// Timers run after the script and after every microtask
+		{`var log = "";
+		setTimeout(function() { log = log + "timeout "; }, 0);
+		Promise.resolve().then(function() { log = log + "promise "; });
+		queueMicrotask(function() { log = log + "microtask "; });
+		log = log + "sync ";`, "sync promise microtask timeout "},
+		// Shorter delays run first; equal delays run in creation order
+		{`var log = "";
+		setTimeout(function() { log = log + "c"; }, 20);
+		setTimeout(function() { log = log + "a"; }, 0);
+		setTimeout(function() { log = log + "b"; }, 0);`, "abc"},
+		// Microtasks queued by a timer run before the next timer
+		{`var log = "";
+		setTimeout(function() {
+			log = log + "t1 ";
+			Promise.resolve().then(function() { log = log + "p1 "; });
+		}, 0);
+		setTimeout(function() { log = log + "t2 "; }, 0);`, "t1 p1 t2 "},
+		// Extra arguments are passed to the callback
+		{`var log;
+		setTimeout(function(a, b) { log = a + b; }, 0, 2, 3);`, 5.0},
+		// Timers scheduled from a timer run too
+		{`var log = "";
+		setTimeout(function() {
+			log = log + "outer ";
+			setTimeout(function() { log = log + "inner"; }, 0);
+		}, 0);`, "outer inner"},
+	}
+
+	for _, tt := range tests {
+		result := testEvalLog(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestClearTimers(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`var log = "none";
+		var id = setTimeout(function() { log = "ran"; }, 0);
+		clearTimeout(id);`, "none"},
+		{`var log = 0;
+		var id = setInterval(function() {
+			log = log + 1;
+			if (log == 3) { clearInterval(id); }
+		}, 1);`, 3.0},
+		// A timer can cancel one that is due after it
+		{`var log = "";
+		var later = setTimeout(function() { log = log + "later"; }, 10);
+		setTimeout(function() { log = log + "first "; clearTimeout(later); }, 0);`, "first "},
+		{`var log = typeof setTimeout(function() {}, 0);`, "number"},
+	}
+
+	for _, tt := range tests {
+		result := testEvalLog(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestTimerBindings(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		{`var log = typeof setTimeout + " " + typeof queueMicrotask;`, "function function"},
+		// The timer functions are ordinary globals that scripts can shadow
+		{`var log;
+		var setTimeout = function(callback) { return "mine"; };
+		log = setTimeout(function() {});`, "mine"},
+		{`var log;
+		var schedule = function(setTimeout) { return setTimeout(); };
+		log = schedule(function() { return "parameter"; });`, "parameter"},
+		{`var log;
+		var run = function() { var queueMicrotask = 1; return queueMicrotask; };
+		log = run();`, 1.0},
+		// They can be passed around as values
+		{`var log = "";
+		var later = setTimeout;
+		later(function() { log = "ran"; }, 0);`, "ran"},
+	}
+
+	for _, tt := range tests {
+		result := testEvalLog(tt.input)
+		if result != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestAwaitTimer(t *testing.T) {
+	input := `var log = "";
+	var sleep = function(ms) {
+		return new Promise(function(resolve) { setTimeout(resolve, ms); });
+	};
+	var run = async function() {
+		log = log + "start ";
+		await sleep(5);
+		log = log + "end";
+	};
+	run();`
+
+	if result := testEvalLog(input); result != "start end" {
+		t.Errorf("Expected the async function to resume after the timer, got %v", result)
+	}
+}
+
+func TestTimerExceptions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Value
+	}{
+		// A throw inside a timer ends the program, and later timers never run
+		{`setTimeout(function() { throw "boom"; }, 0);
+		setTimeout(function() { throw "never"; }, 5);`, "boom"},
+		{`setTimeout(42, 0);`, "TypeError: setTimeout callback must be a function, got 42"},
+		{`queueMicrotask("nope");`, "TypeError: queueMicrotask callback must be a function, got nope"},
+	}
+
+	for _, tt := range tests {
+		exception, ok := testEval(tt.input).(*internal.Exception)
+		if !ok {
+			t.Errorf("For input %q: expected an uncaught exception", tt.input)
+			continue
+		}
+		if thrown(exception) != tt.expected {
+			t.Errorf("For input %q: expected %v, got %v", tt.input, tt.expected, exception)
+		}
+	}
+}
+
+func TestProgramsHaveSeparateEventLoops(t *testing.T) {
+	// The waiting program tells the test once its timer is scheduled
+	scheduled := make(chan struct{})
+	waiting := environment.NewGlobalEnvironment()
+	waiting.Set("scheduled", &internal.Builtin{
+		Name: "scheduled",
+		Fn: func(args ...interface{}) interface{} {
+			close(scheduled)
+			return nil
+		},
+	})
+
+	done := make(chan Value)
+	go func() {
+		program := parser.New(`var log = "waiting";
+		setTimeout(function() { log = "ran"; }, 20);
+		scheduled();`).ParseProgram()
+		Eval(program, waiting)
+		log, _ := waiting.Get("log")
+		done <- log
+	}()
+
+	<-scheduled
+	if _, ok := testEval(`setTimeout(function() {}, 0); throw "boom";`).(*internal.Exception); !ok {
+		t.Fatalf("Expected the other program to end with an uncaught exception")
+	}
+
+	if log := <-done; log != "ran" {
+		t.Errorf("Expected an uncaught exception elsewhere to leave the timer alone, got %v", log)
+	}
+}
@@ -1,39 +1,64 @@
 package internal
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
 
-func ToString(val interface{}) string {
+// Inspect writes a value for people to read, the way print shows it:
+// objects as {key: value} and arrays as [a, b], with strings unquoted.
+// Unlike ToString it never runs script code and never throws, which also
+// makes it the format of error messages.
+//
+// Examples:
+//
+//	Inspect("text")     → text
+//	Inspect({a: 1})     → {a: 1}
+//	Inspect([1, "a"])   → [1, a]
+//	Inspect(nil)        → nil
+func Inspect(val Value) string {
+	return Inspector{Undefined: "nil"}.Inspect(val)
+}
+
+// Inspector writes values like Inspect, spelling undefined its own way at
+// every depth: print writes nil, while console.log writes undefined.
+//
+// Example: Inspector{Undefined: "undefined"}.Inspect([nil, {a: nil}]) → [undefined, {a: undefined}]
+type Inspector struct {
+	Undefined string
+}
+
+// Inspectable is implemented by objects that hold other values and write
+// themselves for Inspect, like a Map or a Promise; they write the values
+// they hold with inspect, so an Inspector reaches inside them too
+type Inspectable interface {
+	InspectWith(inspect func(Value) string) string
+}
+
+// Inspect writes a value, see the package function Inspect
+func (in Inspector) Inspect(val Value) string {
 	if val == nil {
-		return "nil"
+		return in.Undefined
 	}
 
 	switch v := val.(type) {
 	case string:
 		return v
 	case float64:
-		// Format integers without decimal point
-		if v == float64(int64(v)) {
-			return fmt.Sprintf("%d", int64(v))
-		}
-		return fmt.Sprintf("%v", v)
+		return FormatNumber(v)
 	case bool:
 		if v {
 			return "true"
 		}
 		return "false"
-	case Object:
-		// Format object as {key: value, ...}
-		result := "{"
-		first := true
-		for k, val := range v {
-			if !first {
-				result += ", "
-			}
-			result += k + ": " + ToString(val)
-			first = false
+	case *Object:
+		if IsError(v) {
+			return in.inspectError(v)
 		}
-		result += "}"
-		return result
+		return in.inspectProperties(v)
 	case ArrayLike:
 		// Format array-like types (e.g., ArrayReference)
 		elements := v.GetElements()
@@ -42,12 +67,224 @@ func ToString(val interface{}) string {
 			if i > 0 {
 				result += ", "
 			}
-			result += ToString(elem)
+			result += in.Inspect(elem)
 		}
 		result += "]"
 		return result
+	case Inspectable:
+		return v.InspectWith(in.Inspect)
 	default:
 		// Handle other types (functions, etc.)
 		return fmt.Sprintf("%v", v)
 	}
 }
+
+// inspectProperties formats an object's own enumerable properties as
+// {key: value, ...}
+func (in Inspector) inspectProperties(obj *Object) string {
+	result := "{"
+	first := true
+	for _, k := range obj.OwnKeys() {
+		prop, _ := obj.GetOwnProperty(k)
+		if prop.NonEnumerable {
+			continue
+		}
+		if !first {
+			result += ", "
+		}
+		first = false
+		result += formatKey(k) + ": " + in.formatProperty(prop)
+	}
+	result += "}"
+	return result
+}
+
+// inspectError shows an error by its stack, like Node does, followed by
+// any properties the script added to it
+//
+// Example: e = new RangeError("too big"); e.limit = 10 → RangeError: too big {limit: 10}
+func (in Inspector) inspectError(err *Object) string {
+	result := ErrorString(err)
+	if prop, ok := err.Lookup("stack"); ok {
+		if stack, ok := prop.Value.(string); ok && !prop.IsAccessor() {
+			result = stack
+		} else if getter, ok := prop.Getter.(*Builtin); ok {
+			result = in.Inspect(getter.Call(err))
+		}
+	}
+	if properties := in.inspectProperties(err); properties != "{}" {
+		result += " " + properties
+	}
+	return result
+}
+
+// formatKey writes symbol keys in brackets: {name: Ann, [Symbol(id)]: 7}
+func formatKey(key PropertyKey) string {
+	if sym, ok := key.(*Symbol); ok {
+		return "[" + sym.String() + "]"
+	}
+	return Inspect(key)
+}
+
+// formatProperty shows accessors the way Node does instead of running the getter
+func (in Inspector) formatProperty(prop *Property) string {
+	switch {
+	case prop.Getter != nil && prop.Setter != nil:
+		return "[Getter/Setter]"
+	case prop.Getter != nil:
+		return "[Getter]"
+	case prop.Setter != nil:
+		return "[Setter]"
+	default:
+		return in.Inspect(prop.Value)
+	}
+}
+
+// StringToNumber parses a numeric string literal: surrounding whitespace is
+// ignored, an empty string is 0, and anything that isn't a whole decimal,
+// hex (0x), octal (0o) or binary (0b) literal or Infinity is NaN.
+func StringToNumber(s string) float64 {
+	s = strings.TrimFunc(s, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '\ufeff'
+	})
+	if s == "" {
+		return 0
+	}
+
+	if len(s) > 2 && s[0] == '0' {
+		base := 0
+		switch s[1] {
+		case 'x', 'X':
+			base = 16
+		case 'o', 'O':
+			base = 8
+		case 'b', 'B':
+			base = 2
+		}
+		if base != 0 {
+			return parseRadix(s[2:], base)
+		}
+	}
+
+	unsigned := strings.TrimLeft(s, "+-")
+	if len(s)-len(unsigned) > 1 {
+		return math.NaN()
+	}
+	if unsigned == "Infinity" {
+		if s[0] == '-' {
+			return math.Inf(-1)
+		}
+		return math.Inf(1)
+	}
+	if !isDecimalLiteral(unsigned) {
+		return math.NaN()
+	}
+
+	// Out of range values come back as ±Inf or 0 with an error, as wanted
+	num, _ := strconv.ParseFloat(s, 64)
+	return num
+}
+
+// isDecimalLiteral matches digits [. digits] [e [+-] digits], where either
+// side of the point may be empty but not both. Unlike strconv it rejects
+// underscores, "inf", "nan" and hex floats.
+func isDecimalLiteral(s string) bool {
+	i, digits := 0, 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+		digits++
+	}
+	if i < len(s) && s[i] == '.' {
+		i++
+		for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+			i++
+			digits++
+		}
+	}
+	if digits == 0 {
+		return false
+	}
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		i++
+		if i < len(s) && (s[i] == '+' || s[i] == '-') {
+			i++
+		}
+		start := i
+		for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+			i++
+		}
+		if i == start {
+			return false
+		}
+	}
+	return i == len(s)
+}
+
+// parseRadix reads unsigned digits in base 2, 8 or 16, NaN if there are none
+// or one is invalid. Long literals lose precision instead of overflowing.
+func parseRadix(digits string, base int) float64 {
+	if digits == "" {
+		return math.NaN()
+	}
+	num := 0.0
+	for _, ch := range strings.ToLower(digits) {
+		digit := strings.IndexRune("0123456789abcdef", ch)
+		if digit < 0 || digit >= base {
+			return math.NaN()
+		}
+		num = num*float64(base) + float64(digit)
+	}
+	return num
+}
+
+// FormatNumber writes a number the way JavaScript's String(number) does:
+// the shortest digits that read back as the same number, in plain notation
+// from 1e-7 up to 1e21 and in exponential notation outside that range.
+//
+// Examples:
+//
+//	FormatNumber(42)        → "42"
+//	FormatNumber(0.1)       → "0.1"
+//	FormatNumber(1e21)      → "1e+21"
+//	FormatNumber(1.5e-7)    → "1.5e-7"
+//	FormatNumber(2 ** 70)   → "1180591620717411303424"
+//	FormatNumber(-0)        → "0"
+func FormatNumber(x float64) string {
+	switch {
+	case math.IsNaN(x):
+		return "NaN"
+	case math.IsInf(x, 1):
+		return "Infinity"
+	case math.IsInf(x, -1):
+		return "-Infinity"
+	case x == 0:
+		return "0"
+	case x < 0:
+		return "-" + FormatNumber(-x)
+	}
+
+	// Shortest round-tripping digits d1 d2 ... dk with the value 0.d1...dk × 10^n
+	mantissa, exponent, _ := strings.Cut(strconv.FormatFloat(x, 'e', -1, 64), "e")
+	digits := strings.Replace(mantissa, ".", "", 1)
+	k := len(digits)
+	n, _ := strconv.Atoi(exponent)
+	n++
+
+	switch {
+	case k <= n && n <= 21:
+		return digits + strings.Repeat("0", n-k)
+	case 0 < n && n <= 21:
+		return digits[:n] + "." + digits[n:]
+	case -6 < n && n <= 0:
+		return "0." + strings.Repeat("0", -n) + digits
+	}
+
+	result := digits[:1]
+	if k > 1 {
+		result += "." + digits[1:]
+	}
+	if n-1 >= 0 {
+		return result + "e+" + strconv.Itoa(n-1)
+	}
+	return result + "e-" + strconv.Itoa(1-n)
+}
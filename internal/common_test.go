@@ -1,10 +1,11 @@
 package internal
 
 import (
+	"math"
 	"testing"
 )
 
-func TestToString(t *testing.T) {
+func TestInspect(t *testing.T) {
 	tests := []struct {
 		input    interface{}
 		expected string
@@ -20,30 +21,28 @@ func TestToString(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		result := ToString(tt.input)
+		result := Inspect(tt.input)
 		if result != tt.expected {
-			t.Errorf("ToString(%v) = %q, expected %q", tt.input, result, tt.expected)
+			t.Errorf("Inspect(%v) = %q, expected %q", tt.input, result, tt.expected)
 		}
 	}
 }
 
-func TestToStringObject(t *testing.T) {
-	obj := Object{
-		"name": "Alice",
-		"age":  30.0,
-	}
+func TestInspectObject(t *testing.T) {
+	obj := NewObject()
+	obj.Set("name", "Alice")
+	obj.Set("age", 30.0)
 
-	result := ToString(obj)
-	// map iteration order is not constant, so we check for both possible orderings
-	valid1 := "{name: Alice, age: 30}"
-	valid2 := "{age: 30, name: Alice}"
+	result := Inspect(obj)
+	expected := "{name: Alice, age: 30}"
 
-	if result != valid1 && result != valid2 {
-		t.Errorf("ToString(object) = %q, expected %q or %q", result, valid1, valid2)
+	if result != expected {
+		t.Errorf("Inspect(object) = %q, expected %q", result, expected)
 	}
 }
 
 type MockArrayLike struct {
+	ObjectKind
 	Elements Array
 }
 
@@ -51,20 +50,20 @@ func (m MockArrayLike) GetElements() Array {
 	return m.Elements
 }
 
-func TestToStringArray(t *testing.T) {
+func TestInspectArray(t *testing.T) {
 	arr := MockArrayLike{
 		Elements: Array{"hello", 5.0, true},
 	}
 
-	result := ToString(arr)
+	result := Inspect(arr)
 	expected := "[hello, 5, true]"
 
 	if result != expected {
-		t.Errorf("ToString(array) = %q, expected %q", result, expected)
+		t.Errorf("Inspect(array) = %q, expected %q", result, expected)
 	}
 }
 
-func TestToStringWithFloat(t *testing.T) {
+func TestInspectWithFloat(t *testing.T) {
 	tests := []struct {
 		input    float64
 		expected string
@@ -79,9 +78,64 @@ func TestToStringWithFloat(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		result := ToString(tt.input)
+		result := Inspect(tt.input)
 		if result != tt.expected {
-			t.Errorf("ToString(%v) = %q, expected %q", tt.input, result, tt.expected)
+			t.Errorf("Inspect(%v) = %q, expected %q", tt.input, result, tt.expected)
+		}
+	}
+}
+
+func TestToNumber(t *testing.T) {
+	tests := []struct {
+		input    Value
+		expected float64
+	}{
+		{42.0, 42},
+		{true, 1},
+		{false, 0},
+		{Null, 0},
+		{"", 0},
+		{"  12  ", 12},
+		{"\n3.5\t", 3.5},
+		{"-7", -7},
+		{"+.5", 0.5},
+		{"5.", 5},
+		{"1e3", 1000},
+		{"1E-2", 0.01},
+		{"0x1F", 31},
+		{"0o17", 15},
+		{"0b101", 5},
+		{"Infinity", math.Inf(1)},
+		{"-Infinity", math.Inf(-1)},
+		{"1e400", math.Inf(1)},
+	}
+
+	for _, tt := range tests {
+		if result := ToNumber(tt.input); result != tt.expected {
+			t.Errorf("ToNumber(%q) = %v, expected %v", tt.input, result, tt.expected)
+		}
+	}
+
+	for _, input := range []Value{nil, "12px", "1_000", "inf", "NaN", "0x", "0x1G", "-0x10", "--1", ".", "e5", "1e", "0x1p3", NewObject()} {
+		if result := ToNumber(input); !math.IsNaN(result) {
+			t.Errorf("ToNumber(%q) = %v, expected NaN", input, result)
+		}
+	}
+}
+
+func TestInspectSpecialNumbers(t *testing.T) {
+	tests := []struct {
+		input    float64
+		expected string
+	}{
+		{math.Inf(1), "Infinity"},
+		{math.Inf(-1), "-Infinity"},
+		{math.NaN(), "NaN"},
+	}
+
+	for _, tt := range tests {
+		if result := Inspect(tt.input); result != tt.expected {
+			t.Errorf("Inspect(%v) = %q, expected %q", tt.input, result, tt.expected)
 		}
 	}
 }
@@ -0,0 +1,353 @@
+package internal
+
+import (
+	"math"
+	"reflect"
+)
+
+// PropertyDescriptor describes a property for Object.defineProperty and
+// Object.getOwnPropertyDescriptor. The Has fields record which attributes
+// were given: redefining a property only changes those.
+//
+// Example: Object.defineProperty(obj, "id", { value: 7, enumerable: true })
+//
+//	PropertyDescriptor{Value: 7, HasValue: true, Enumerable: true, HasEnumerable: true}
+type PropertyDescriptor struct {
+	Value        Value
+	Get          Value
+	Set          Value
+	Writable     bool
+	Enumerable   bool
+	Configurable bool
+
+	HasValue        bool
+	HasGet          bool
+	HasSet          bool
+	HasWritable     bool
+	HasEnumerable   bool
+	HasConfigurable bool
+}
+
+// IsAccessor reports whether the descriptor has a get or set
+func (d *PropertyDescriptor) IsAccessor() bool {
+	return d.HasGet || d.HasSet
+}
+
+// IsData reports whether the descriptor has a value or writable
+func (d *PropertyDescriptor) IsData() bool {
+	return d.HasValue || d.HasWritable
+}
+
+// ToPropertyDescriptor reads a descriptor object like
+// { value: 1, writable: false } or { get() { ... }, enumerable: true }
+// Throws a TypeError for anything but an object, for a get or set that
+// isn't a function, and for a mix of accessor and data attributes.
+func ToPropertyDescriptor(val Value) *PropertyDescriptor {
+	obj, ok := val.(*Object)
+	if !ok {
+		ThrowError("TypeError", "Property description must be an object: "+Inspect(val))
+	}
+
+	desc := &PropertyDescriptor{}
+	if obj.Has("enumerable") {
+		desc.Enumerable, desc.HasEnumerable = ToBoolean(obj.Get("enumerable")), true
+	}
+	if obj.Has("configurable") {
+		desc.Configurable, desc.HasConfigurable = ToBoolean(obj.Get("configurable")), true
+	}
+	if obj.Has("value") {
+		desc.Value, desc.HasValue = obj.Get("value"), true
+	}
+	if obj.Has("writable") {
+		desc.Writable, desc.HasWritable = ToBoolean(obj.Get("writable")), true
+	}
+	if obj.Has("get") {
+		desc.Get, desc.HasGet = obj.Get("get"), true
+		if _, callable := desc.Get.(Callable); !callable && desc.Get != nil {
+			ThrowError("TypeError", "Getter must be a function: "+Inspect(desc.Get))
+		}
+	}
+	if obj.Has("set") {
+		desc.Set, desc.HasSet = obj.Get("set"), true
+		if _, callable := desc.Set.(Callable); !callable && desc.Set != nil {
+			ThrowError("TypeError", "Setter must be a function: "+Inspect(desc.Set))
+		}
+	}
+
+	if desc.IsAccessor() && desc.IsData() {
+		ThrowError("TypeError", "Invalid property descriptor. Cannot both specify accessors and a value or writable attribute")
+	}
+	return desc
+}
+
+// Descriptor returns the descriptor of a property, the way
+// Object.getOwnPropertyDescriptor reports it
+func (p *Property) Descriptor() *PropertyDescriptor {
+	desc := &PropertyDescriptor{
+		Enumerable:      !p.NonEnumerable,
+		Configurable:    !p.NonConfigurable,
+		HasEnumerable:   true,
+		HasConfigurable: true,
+	}
+	if p.IsAccessor() {
+		desc.Get, desc.Set = p.Getter, p.Setter
+		desc.HasGet, desc.HasSet = true, true
+	} else {
+		desc.Value, desc.Writable = p.Value, !p.NonWritable
+		desc.HasValue, desc.HasWritable = true, true
+	}
+	return desc
+}
+
+// ToObject turns the descriptor into a plain object with its attributes
+//
+// Examples:
+//
+//	{ value: 1, writable: true, enumerable: true, configurable: true }
+//	{ get: [Function], set: undefined, enumerable: false, configurable: true }
+func (d *PropertyDescriptor) ToObject() *Object {
+	obj := NewObject()
+	if d.HasValue {
+		obj.Set("value", d.Value)
+	}
+	if d.HasWritable {
+		obj.Set("writable", d.Writable)
+	}
+	if d.HasGet {
+		obj.Set("get", d.Get)
+	}
+	if d.HasSet {
+		obj.Set("set", d.Set)
+	}
+	if d.HasEnumerable {
+		obj.Set("enumerable", d.Enumerable)
+	}
+	if d.HasConfigurable {
+		obj.Set("configurable", d.Configurable)
+	}
+	return obj
+}
+
+// DefineOwnProperty creates or changes an own property from a descriptor,
+// the way Object.defineProperty does. Attributes missing from a new
+// property's descriptor are false (or undefined); an existing property only
+// changes the attributes given.
+//
+// Throws a TypeError when the object isn't extensible and the property is
+// new, or when the property is non-configurable and the descriptor would
+// change it (other than making a writable property read-only or setting
+// the value of a writable one).
+//
+// Example: obj.DefineOwnProperty("id", {Value: 7, HasValue: true})
+//
+//	→ obj.id is 7, read-only, hidden from Object.keys and can't be redefined
+func (o *Object) DefineOwnProperty(key PropertyKey, desc *PropertyDescriptor) {
+	current, exists := o.properties.get(key)
+	if !exists {
+		if o.NonExtensible {
+			ThrowError("TypeError", "Cannot define property "+formatKeyName(key)+", object is not extensible")
+		}
+		prop := &Property{
+			NonEnumerable:   !desc.Enumerable,
+			NonConfigurable: !desc.Configurable,
+		}
+		if desc.IsAccessor() {
+			prop.Getter, prop.Setter = desc.Get, desc.Set
+		} else {
+			prop.Value, prop.NonWritable = desc.Value, !desc.Writable
+		}
+		o.properties.set(key, prop)
+		return
+	}
+
+	if current.NonConfigurable && !canRedefine(current, desc) {
+		ThrowError("TypeError", "Cannot redefine property: "+formatKeyName(key))
+	}
+
+	// Switching between data and accessor starts over with the default
+	// attributes, keeping enumerable and configurable
+	if desc.IsAccessor() && !current.IsAccessor() {
+		current.Value, current.NonWritable = nil, false
+	} else if desc.IsData() && current.IsAccessor() {
+		current.Getter, current.Setter = nil, nil
+		current.NonWritable = true
+	}
+
+	if desc.HasValue {
+		current.Value = desc.Value
+	}
+	if desc.HasWritable {
+		current.NonWritable = !desc.Writable
+	}
+	if desc.HasGet {
+		current.Getter = desc.Get
+	}
+	if desc.HasSet {
+		current.Setter = desc.Set
+	}
+	if desc.HasEnumerable {
+		current.NonEnumerable = !desc.Enumerable
+	}
+	if desc.HasConfigurable {
+		current.NonConfigurable = !desc.Configurable
+	}
+}
+
+// canRedefine reports whether a descriptor leaves a non-configurable
+// property as it is, apart from the changes JavaScript still allows
+func canRedefine(current *Property, desc *PropertyDescriptor) bool {
+	if desc.HasConfigurable && desc.Configurable {
+		return false
+	}
+	if desc.HasEnumerable && desc.Enumerable == current.NonEnumerable {
+		return false
+	}
+	if !desc.IsAccessor() && !desc.IsData() {
+		return true
+	}
+	if desc.IsAccessor() != current.IsAccessor() {
+		return false
+	}
+
+	if current.IsAccessor() {
+		return (!desc.HasGet || SameValue(desc.Get, current.Getter)) &&
+			(!desc.HasSet || SameValue(desc.Set, current.Setter))
+	}
+	if current.NonWritable {
+		return !(desc.HasWritable && desc.Writable) &&
+			(!desc.HasValue || SameValue(desc.Value, current.Value))
+	}
+	return true
+}
+
+// SameValue is the equality of Object.is: like ===, except that NaN equals
+// NaN and 0 doesn't equal -0
+func SameValue(a, b Value) bool {
+	if x, ok := a.(float64); ok {
+		y, ok := b.(float64)
+		if !ok {
+			return false
+		}
+		if math.IsNaN(x) && math.IsNaN(y) {
+			return true
+		}
+		return x == y && math.Signbit(x) == math.Signbit(y)
+	}
+	if a == nil || b == nil {
+		return a == b
+	}
+	// Values like Go maps can't be compared with ==
+	if !reflect.TypeOf(a).Comparable() || !reflect.TypeOf(b).Comparable() {
+		return false
+	}
+	return a == b
+}
+
+// Freezable is implemented by values whose integrity level Object.freeze,
+// Object.seal and Object.preventExtensions can change: plain objects, and
+// values that keep their state elsewhere, like arrays and classes
+type Freezable interface {
+	PreventExtensions()
+	Seal()
+	Freeze()
+	IsExtensible() bool
+	IsSealed() bool
+	IsFrozen() bool
+}
+
+// PreventExtensions stops new properties from being added
+// (Object.preventExtensions)
+func (o *Object) PreventExtensions() {
+	o.NonExtensible = true
+}
+
+// IsExtensible reports whether new properties can be added
+func (o *Object) IsExtensible() bool {
+	return !o.NonExtensible
+}
+
+// Freeze makes every property read-only and non-configurable and stops new
+// ones from being added (Object.freeze)
+func (o *Object) Freeze() {
+	o.Seal()
+	for _, prop := range o.properties.values {
+		if !prop.IsAccessor() {
+			prop.NonWritable = true
+		}
+	}
+}
+
+// Seal makes every property non-configurable and stops new ones from being
+// added; existing writable properties can still change (Object.seal)
+func (o *Object) Seal() {
+	o.NonExtensible = true
+	for _, prop := range o.properties.values {
+		prop.NonConfigurable = true
+	}
+}
+
+// IsFrozen reports whether Freeze, or the equivalent property changes, left
+// the object unchangeable
+func (o *Object) IsFrozen() bool {
+	if !o.IsSealed() {
+		return false
+	}
+	for _, prop := range o.properties.values {
+		if !prop.IsAccessor() && !prop.NonWritable {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSealed reports whether the object is non-extensible and every property
+// is non-configurable
+func (o *Object) IsSealed() bool {
+	if !o.NonExtensible {
+		return false
+	}
+	for _, prop := range o.properties.values {
+		if !prop.NonConfigurable {
+			return false
+		}
+	}
+	return true
+}
+
+// formatKeyName writes a key for error messages: name or Symbol(desc)
+func formatKeyName(key PropertyKey) string {
+	if sym, ok := key.(*Symbol); ok {
+		return sym.String()
+	}
+	return Inspect(key)
+}
+
+// AssignError writes the message of the TypeError an assignment throws
+// when the target doesn't let it happen: strict-mode code and
+// Object.assign throw it, sloppy-mode assignments fail silently.
+// prop is the property the assignment found, own or inherited, or nil.
+//
+// Examples:
+//
+//	"abc".x = 1                    → Cannot create property 'x' on string 'abc'
+//	Object.freeze({ a: 1 }).a = 2  → Cannot assign to read only property 'a' of object '#<Object>'
+//	({ get a() {} }).a = 2         → Cannot set property a of #<Object> which has only a getter
+//	Object.freeze({}).b = 2        → Cannot add property b, object is not extensible
+func AssignError(object Value, key PropertyKey, prop *Property) string {
+	name := formatKeyName(key)
+	tag := "#<Object>"
+	if _, ok := object.(ArrayLike); ok {
+		tag = "[object Array]"
+	}
+
+	switch {
+	case IsPrimitive(object):
+		kind := map[Type]string{TypeBoolean: "boolean", TypeNumber: "number", TypeString: "string", TypeSymbol: "symbol"}[TypeOf(object)]
+		return "Cannot create property '" + name + "' on " + kind + " '" + Inspect(object) + "'"
+	case prop != nil && prop.IsAccessor():
+		return "Cannot set property " + name + " of " + tag + " which has only a getter"
+	case prop != nil:
+		return "Cannot assign to read only property '" + name + "' of object '" + tag + "'"
+	}
+	return "Cannot add property " + name + ", object is not extensible"
+}
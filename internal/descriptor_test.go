@@ -0,0 +1,207 @@
+package internal
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPropertyAttributes(t *testing.T) {
+	obj := NewObject()
+	obj.Set("visible", 1.0)
+	obj.DefineOwnProperty("hidden", &PropertyDescriptor{Value: 2.0, HasValue: true})
+
+	if got := obj.Get("hidden"); got != 2.0 {
+		t.Errorf("Expected hidden to be 2, got %v", got)
+	}
+
+	// Defined without writable, so assignments are ignored
+	obj.Set("hidden", 3.0)
+	if got := obj.Get("hidden"); got != 2.0 {
+		t.Errorf("Assignment to a read-only property should be ignored, got %v", got)
+	}
+
+	if keys := obj.Keys(); len(keys) != 1 || keys[0] != "visible" {
+		t.Errorf("Keys should skip non-enumerable properties, got %v", keys)
+	}
+	if names := obj.OwnPropertyNames(); len(names) != 2 {
+		t.Errorf("OwnPropertyNames should list every property, got %v", names)
+	}
+	if str := Inspect(obj); str != "{visible: 1}" {
+		t.Errorf("Printing should skip non-enumerable properties, got %q", str)
+	}
+	if data, _ := obj.MarshalJSON(); string(data) != `{"visible":1}` {
+		t.Errorf("JSON should skip non-enumerable properties, got %s", data)
+	}
+	if obj.Delete("hidden") {
+		t.Error("Deleting a non-configurable property should fail")
+	}
+}
+
+func TestReadOnlyInheritedProperty(t *testing.T) {
+	parent := NewObject()
+	parent.DefineOwnProperty("x", &PropertyDescriptor{Value: 1.0, HasValue: true})
+
+	child := NewObject()
+	child.Prototype = parent
+	child.Set("x", 2.0)
+
+	if _, ok := child.GetOwnProperty("x"); ok {
+		t.Error("An inherited read-only property should block the assignment")
+	}
+}
+
+func TestDefineOwnPropertyRedefinition(t *testing.T) {
+	tests := []struct {
+		name    string
+		current *PropertyDescriptor
+		next    *PropertyDescriptor
+		allowed bool
+	}{
+		{"same value", &PropertyDescriptor{Value: 1.0, HasValue: true}, &PropertyDescriptor{Value: 1.0, HasValue: true}, true},
+		{"new value", &PropertyDescriptor{Value: 1.0, HasValue: true}, &PropertyDescriptor{Value: 2.0, HasValue: true}, false},
+		{"NaN again", &PropertyDescriptor{Value: math.NaN(), HasValue: true}, &PropertyDescriptor{Value: math.NaN(), HasValue: true}, true},
+		{"make writable", &PropertyDescriptor{HasValue: true}, &PropertyDescriptor{Writable: true, HasWritable: true}, false},
+		{"make configurable", &PropertyDescriptor{HasValue: true}, &PropertyDescriptor{Configurable: true, HasConfigurable: true}, false},
+		{"make enumerable", &PropertyDescriptor{HasValue: true}, &PropertyDescriptor{Enumerable: true, HasEnumerable: true}, false},
+		{"empty", &PropertyDescriptor{HasValue: true}, &PropertyDescriptor{}, true},
+		{"writable value", &PropertyDescriptor{Writable: true, HasWritable: true}, &PropertyDescriptor{Value: 5.0, HasValue: true}, true},
+		{"make read-only", &PropertyDescriptor{Writable: true, HasWritable: true}, &PropertyDescriptor{HasWritable: true}, true},
+		{"to accessor", &PropertyDescriptor{HasValue: true}, &PropertyDescriptor{HasGet: true}, false},
+	}
+
+	for _, tt := range tests {
+		obj := NewObject()
+		obj.DefineOwnProperty("p", tt.current)
+		exception := Catch(func() { obj.DefineOwnProperty("p", tt.next) })
+		if allowed := exception == nil; allowed != tt.allowed {
+			t.Errorf("%s: expected allowed=%v, got exception %v", tt.name, tt.allowed, exception)
+		}
+	}
+}
+
+func TestDefineOwnPropertyConfigurable(t *testing.T) {
+	getter := recordingFunc{fn: func(this Value, args ...Value) Value { return "got" }}
+
+	obj := NewObject()
+	obj.DefineOwnProperty("p", &PropertyDescriptor{Value: 1.0, HasValue: true, Configurable: true, HasConfigurable: true})
+	obj.DefineOwnProperty("p", &PropertyDescriptor{Get: getter, HasGet: true})
+
+	if got := obj.Get("p"); got != "got" {
+		t.Errorf("A configurable property should become an accessor, got %v", got)
+	}
+	desc := obj.properties.values["p"].Descriptor()
+	if !desc.Configurable || desc.Enumerable || desc.HasValue {
+		t.Errorf("Expected a configurable, non-enumerable accessor, got %+v", desc)
+	}
+}
+
+func TestDefineOwnPropertyNonExtensible(t *testing.T) {
+	obj := NewObject()
+	obj.Set("a", 1.0)
+	obj.NonExtensible = true
+
+	obj.Set("b", 2.0)
+	if obj.Has("b") {
+		t.Error("Assignment should not add properties to a non-extensible object")
+	}
+
+	exception := Catch(func() { obj.DefineOwnProperty("b", &PropertyDescriptor{}) })
+	if exception == nil || !IsError(exception.Value) || ErrorString(exception.Value) != "TypeError: Cannot define property b, object is not extensible" {
+		t.Errorf("Expected a TypeError, got %v", exception)
+	}
+}
+
+func TestFreezeAndSeal(t *testing.T) {
+	sealed := NewObject()
+	sealed.Set("a", 1.0)
+	sealed.Seal()
+	sealed.Set("a", 2.0)
+	if sealed.Get("a") != 2.0 || !sealed.IsSealed() || sealed.IsFrozen() {
+		t.Errorf("A sealed object should stay writable: a=%v sealed=%v frozen=%v", sealed.Get("a"), sealed.IsSealed(), sealed.IsFrozen())
+	}
+
+	frozen := NewObject()
+	frozen.Set("a", 1.0)
+	frozen.Freeze()
+	frozen.Set("a", 2.0)
+	if frozen.Get("a") != 1.0 || !frozen.IsFrozen() {
+		t.Errorf("A frozen object should not change: a=%v frozen=%v", frozen.Get("a"), frozen.IsFrozen())
+	}
+
+	// An empty non-extensible object is frozen as well
+	empty := NewObject()
+	empty.NonExtensible = true
+	if !empty.IsFrozen() {
+		t.Error("An empty non-extensible object should be frozen")
+	}
+
+	// Freezing a builtin freezes its static members
+	builtin := &Builtin{Name: "f", Fn: func(args ...interface{}) interface{} { return nil }}
+	if !builtin.IsExtensible() || builtin.IsFrozen() {
+		t.Error("A builtin should start out extensible")
+	}
+	builtin.Freeze()
+	builtin.Properties.Set("a", 1.0)
+	if builtin.IsExtensible() || !builtin.IsFrozen() || builtin.Properties.Has("a") {
+		t.Error("A frozen builtin should not take new static members")
+	}
+}
+
+func TestToPropertyDescriptor(t *testing.T) {
+	getter := recordingFunc{fn: func(this Value, args ...Value) Value { return nil }}
+
+	mixed := NewObject()
+	mixed.Set("get", getter)
+	mixed.Set("value", 1.0)
+
+	badGetter := NewObject()
+	badGetter.Set("get", 5.0)
+
+	tests := []struct {
+		input    Value
+		expected string
+	}{
+		{5.0, "TypeError: Property description must be an object: 5"},
+		{mixed, "TypeError: Invalid property descriptor. Cannot both specify accessors and a value or writable attribute"},
+		{badGetter, "TypeError: Getter must be a function: 5"},
+	}
+
+	for _, tt := range tests {
+		exception := Catch(func() { ToPropertyDescriptor(tt.input) })
+		if exception == nil || !IsError(exception.Value) || ErrorString(exception.Value) != tt.expected {
+			t.Errorf("For %v: expected %q, got %v", tt.input, tt.expected, exception)
+		}
+	}
+
+	plain := NewObject()
+	plain.Set("value", 1.0)
+	plain.Set("enumerable", 1.0)
+	desc := ToPropertyDescriptor(plain)
+	if !desc.HasValue || !desc.Enumerable || desc.HasWritable || desc.HasConfigurable {
+		t.Errorf("Unexpected descriptor %+v", desc)
+	}
+}
+
+func TestSameValue(t *testing.T) {
+	obj := NewObject()
+	tests := []struct {
+		a, b     Value
+		expected bool
+	}{
+		{math.NaN(), math.NaN(), true},
+		{0.0, math.Copysign(0, -1), false},
+		{1.0, 1.0, true},
+		{"a", "a", true},
+		{obj, obj, true},
+		{obj, NewObject(), false},
+		{nil, Null, false},
+		{nil, nil, true},
+		{map[string]interface{}{}, map[string]interface{}{}, false},
+	}
+
+	for _, tt := range tests {
+		if result := SameValue(tt.a, tt.b); result != tt.expected {
+			t.Errorf("SameValue(%v, %v): expected %v, got %v", tt.a, tt.b, tt.expected, result)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package internal
+
+// Error objects are plain Objects whose prototype chain leads to
+// ErrorPrototype. The constructor's prototype holds the name, the instance
+// holds the message and a stack property that reads "Name: message",
+// followed by where the error was raised when that is known.
+//
+// Example: the TypeError thrown by user.save() when save is undefined
+//
+//	error instance { message, stack } → TypeError.prototype { name: "TypeError" }
+//	                                  → Error.prototype { name: "Error", message: "", toString }
+//
+// Builtins raise them with ThrowError, so that scripts can catch them and
+// read e.name and e.message, or test e instanceof TypeError.
+var ErrorPrototype = NewObject()
+
+// ErrorNames lists the native error types, in the order they are installed
+// as globals
+var ErrorNames = []string{
+	"Error",
+	"TypeError",
+	"RangeError",
+	"ReferenceError",
+	"SyntaxError",
+	"EvalError",
+	"URIError",
+	"AggregateError",
+}
+
+var errorPrototypes = map[string]*Object{}
+
+func init() {
+	for _, name := range ErrorNames {
+		proto := ErrorPrototype
+		if name != "Error" {
+			proto = NewObject()
+			proto.Prototype = ErrorPrototype
+		}
+		proto.Define("name", &Property{Value: name, NonEnumerable: true})
+		proto.Define("message", &Property{Value: "", NonEnumerable: true})
+		errorPrototypes[name] = proto
+	}
+	ErrorPrototype.Define("toString", &Property{
+		Value:         &Builtin{Name: "toString", Method: errorToString},
+		NonEnumerable: true,
+	})
+}
+
+// ErrorPrototypeFor returns the prototype of a native error type, like
+// TypeError.prototype, or nil for names that aren't one
+func ErrorPrototypeFor(name string) *Object {
+	return errorPrototypes[name]
+}
+
+// NewError creates an error object of one of the ErrorNames types
+//
+// Example: NewError("RangeError", "Invalid array length") → RangeError: Invalid array length
+func NewError(name string, message string) *Object {
+	err := NewObject()
+	err.Prototype = errorPrototypes[name]
+	if err.Prototype == nil {
+		err.Prototype = ErrorPrototype
+	}
+	err.Define("message", &Property{Value: message, NonEnumerable: true})
+	SetErrorLocation(err, "")
+	return err
+}
+
+// ThrowError raises a new error object as a JavaScript exception
+//
+// Example: ThrowError("TypeError", "5 is not a function")
+func ThrowError(name string, message string) {
+	panic(&Exception{Value: NewError(name, message)})
+}
+
+// SetErrorLocation records where an error was raised, as file:line:column
+// or line:column. Its stack then ends with a line naming the location.
+//
+// Example: stack → "ReferenceError: x is not defined\n    at main.js:3:5"
+func SetErrorLocation(err *Object, location string) {
+	stack := &Builtin{
+		Name: "stack",
+		Method: func(this interface{}, args ...interface{}) interface{} {
+			stack := ErrorString(this)
+			if location != "" {
+				stack += "\n    at " + location
+			}
+			return stack
+		},
+	}
+	err.Define("stack", &Property{Getter: stack, NonEnumerable: true})
+}
+
+// IsError reports whether a value is an error object: one whose prototype
+// chain includes Error.prototype
+func IsError(val Value) bool {
+	obj, ok := val.(*Object)
+	if !ok {
+		return false
+	}
+	for proto := obj.Prototype; proto != nil; proto = proto.Prototype {
+		if proto == ErrorPrototype {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrorString writes an error the way Error.prototype.toString does:
+// "Name: message", or just one of them if the other is empty.
+// Only data properties are read, so it never runs script code.
+//
+// Examples: TypeError "x is not a function" → "TypeError: x is not a function",
+// new Error() → "Error"
+func ErrorString(val Value) string {
+	obj, ok := val.(*Object)
+	if !ok {
+		return Inspect(val)
+	}
+	name := errorField(obj, "name", "Error")
+	message := errorField(obj, "message", "")
+	switch {
+	case name == "":
+		return message
+	case message == "":
+		return name
+	}
+	return name + ": " + message
+}
+
+// errorField reads a name or message data property for ErrorString
+func errorField(obj *Object, key string, fallback string) string {
+	prop, ok := obj.Lookup(key)
+	if !ok || prop.IsAccessor() || prop.Value == nil {
+		return fallback
+	}
+	if s, ok := prop.Value.(string); ok {
+		return s
+	}
+	return Inspect(prop.Value)
+}
+
+// errorToString is Error.prototype.toString
+func errorToString(this interface{}, args ...interface{}) interface{} {
+	if TypeOf(this) != TypeObject {
+		ThrowError("TypeError", "Error.prototype.toString requires that 'this' be an Object")
+	}
+	return ErrorString(this)
+}
@@ -0,0 +1,76 @@
+package internal
+
+import "testing"
+
+func TestNewError(t *testing.T) {
+	err := NewError("TypeError", "x is not a function")
+
+	if !IsError(err) {
+		t.Fatal("Expected an error object")
+	}
+	if err.Get("name") != "TypeError" || err.Get("message") != "x is not a function" {
+		t.Errorf("Expected name and message, got %v and %v", err.Get("name"), err.Get("message"))
+	}
+	if err.Get("stack") != "TypeError: x is not a function" {
+		t.Errorf("Unexpected stack %q", err.Get("stack"))
+	}
+	if Inspect(err) != "TypeError: x is not a function" {
+		t.Errorf("Unexpected inspect output %q", Inspect(err))
+	}
+
+	// Unknown names fall back to Error.prototype
+	if other := NewError("Oops", "m"); other.Prototype != ErrorPrototype {
+		t.Error("Expected an unknown error type to inherit from Error.prototype")
+	}
+}
+
+func TestSetErrorLocation(t *testing.T) {
+	err := NewError("ReferenceError", "x is not defined")
+	SetErrorLocation(err, "main.js:3:5")
+
+	expected := "ReferenceError: x is not defined\n    at main.js:3:5"
+	if err.Get("stack") != expected {
+		t.Errorf("Expected %q, got %q", expected, err.Get("stack"))
+	}
+
+	exception := &Exception{Value: err, Location: "main.js:3:5"}
+	if exception.Error() != "Uncaught "+expected {
+		t.Errorf("Expected the location once, got %q", exception.Error())
+	}
+}
+
+func TestThrowError(t *testing.T) {
+	exception := Catch(func() { ThrowError("RangeError", "too big") })
+	if exception == nil || !IsError(exception.Value) || ErrorString(exception.Value) != "RangeError: too big" {
+		t.Errorf("Expected a RangeError, got %v", exception)
+	}
+}
+
+func TestErrorString(t *testing.T) {
+	named := NewObject()
+	named.Prototype = ErrorPrototype
+	named.Set("name", "")
+	named.Set("message", "only message")
+
+	tests := []struct {
+		input    Value
+		expected string
+	}{
+		{NewError("Error", ""), "Error"},
+		{NewError("URIError", "bad"), "URIError: bad"},
+		{named, "only message"},
+		{"not an error", "not an error"},
+	}
+
+	for _, tt := range tests {
+		if got := ErrorString(tt.input); got != tt.expected {
+			t.Errorf("Expected %q, got %q", tt.expected, got)
+		}
+	}
+}
+
+func TestIsError(t *testing.T) {
+	if IsError(NewObject()) || IsError("Error: x") || IsError(nil) {
+		t.Error("Expected only error objects to be errors")
+	}
+}
@@ -0,0 +1,279 @@
+package internal
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+)
+
+// EventLoop keeps a program alive while there is still work that will call
+// back into it: asynchronous operations such as HTTP requests, and timers.
+//
+// Operations run on their own goroutines; when one finishes, its callback is
+// queued as a macrotask. Timers are kept here, ordered by deadline. The loop
+// runs one macrotask at a time on the script's thread and drains the
+// microtask queue after each one.
+//
+// Each global environment (a realm) owns one loop, and its promises, timers
+// and operations belong to it, so programs never see each other's work.
+//
+// Example: two requests overlap
+//
+//	var a = fetch(urlA);  → request A starts on a goroutine
+//	var b = fetch(urlB);  → request B starts while A is still running
+//	await Promise.all([a, b]);
+//	→ the loop runs each completion as it arrives, then the script continues
+type EventLoop struct {
+	mu         sync.Mutex
+	wake       chan struct{} // signalled when a completion is queued
+	pending    int           // operations started and not completed yet
+	tasks      []func()      // completions waiting to run on the script's thread
+	timers     timerQueue    // scheduled timers, earliest deadline first
+	timerIDs   map[int]*timer
+	lastTimer  int // timer ids count up from 1
+	generation int // bumped by Reset so stale completions are dropped
+
+	microtasks microtaskQueue
+}
+
+func NewEventLoop() *EventLoop {
+	return &EventLoop{
+		wake:     make(chan struct{}, 1),
+		timerIDs: map[int]*timer{},
+	}
+}
+
+// Operation is an asynchronous operation that keeps the event loop running
+// until it completes
+type Operation struct {
+	loop       *EventLoop
+	generation int
+	once       sync.Once
+}
+
+// StartOperation registers an asynchronous operation. Its Complete method
+// may be called from any goroutine, once, with the callback to run on the
+// script's thread.
+//
+// Example:
+//
+//	op := loop.StartOperation()
+//	go func() {
+//	    result := doWork()
+//	    op.Complete(func() { promise.Resolve(result) })
+//	}()
+func (l *EventLoop) StartOperation() *Operation {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.pending++
+	return &Operation{loop: l, generation: l.generation}
+}
+
+// Complete queues the operation's callback as a macrotask
+func (op *Operation) Complete(callback func()) {
+	op.once.Do(func() {
+		l := op.loop
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		if op.generation != l.generation {
+			return // the program that started it is gone
+		}
+		l.pending--
+		l.tasks = append(l.tasks, callback)
+
+		select {
+		case l.wake <- struct{}{}:
+		default: // the loop already has a wake-up pending
+		}
+	})
+}
+
+// timer is a callback scheduled on the event loop
+// Repeating timers are scheduled again, interval after they fire.
+type timer struct {
+	id       int
+	deadline time.Time
+	interval time.Duration
+	repeat   bool
+	callback func()
+	index    int // position in the timer queue, maintained by container/heap
+}
+
+// AddTimer runs callback on the script's thread after delay, and then every
+// delay if repeat is set, until ClearTimer is called with the returned id.
+// Timers with the same deadline run in the order they were added.
+//
+// Example:
+//
+//	id := loop.AddTimer(100*time.Millisecond, true, tick) → tick every 100ms
+//	loop.ClearTimer(id)                                   → no more ticks
+func (l *EventLoop) AddTimer(delay time.Duration, repeat bool, callback func()) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if delay < 0 {
+		delay = 0
+	}
+
+	l.lastTimer++
+	t := &timer{
+		id:       l.lastTimer,
+		deadline: time.Now().Add(delay),
+		interval: delay,
+		repeat:   repeat,
+		callback: callback,
+	}
+	l.timerIDs[t.id] = t
+	heap.Push(&l.timers, t)
+
+	return t.id
+}
+
+// ClearTimer cancels a timer; unknown or already finished ids are ignored
+func (l *EventLoop) ClearTimer(id int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	t, ok := l.timerIDs[id]
+	if !ok {
+		return
+	}
+	delete(l.timerIDs, id)
+	if t.index >= 0 {
+		heap.Remove(&l.timers, t.index)
+	}
+}
+
+// Run drains the microtask queue, then runs macrotasks one at a time
+// (draining microtasks after each) until nothing is left to wait for
+func (l *EventLoop) Run() {
+	for {
+		l.RunMicrotasks()
+
+		task, ok := l.nextTask()
+		if !ok {
+			return
+		}
+		task()
+	}
+}
+
+// RunUntilSettled runs the event loop until the promise settles
+// Returns false if the loop ran out of work while the promise was still
+// pending, which means it will never settle.
+func (l *EventLoop) RunUntilSettled(p *Promise) bool {
+	for {
+		l.RunMicrotasks()
+		if p.State != PromisePending {
+			return true
+		}
+
+		task, ok := l.nextTask()
+		if !ok {
+			return false
+		}
+		task()
+	}
+}
+
+// Reset forgets all queued work, timers and in-flight operations.
+// It is used when an uncaught exception ends the program; operations that
+// complete afterwards are ignored. Other loops are not affected.
+func (l *EventLoop) Reset() {
+	l.mu.Lock()
+	l.pending = 0
+	l.tasks = nil
+	l.timers = nil
+	l.timerIDs = map[int]*timer{}
+	l.generation++
+	l.mu.Unlock()
+
+	l.resetMicrotasks()
+}
+
+// nextTask waits for the next macrotask: a completed operation, or else the
+// timer with the earliest deadline once it is due.
+// Returns false when no operation is pending and no timer is scheduled.
+func (l *EventLoop) nextTask() (func(), bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for {
+		if len(l.tasks) > 0 {
+			task := l.tasks[0]
+			l.tasks = l.tasks[1:]
+			return task, true
+		}
+
+		var wait <-chan time.Time
+		if len(l.timers) > 0 {
+			next := l.timers[0]
+			delay := time.Until(next.deadline)
+			if delay <= 0 {
+				return l.fireTimer(next), true
+			}
+			wait = time.After(delay)
+		} else if l.pending == 0 {
+			return nil, false
+		}
+
+		l.mu.Unlock()
+		select {
+		case <-l.wake:
+		case <-wait:
+		}
+		l.mu.Lock()
+	}
+}
+
+// fireTimer takes a due timer off the queue, or moves a repeating one to its
+// next deadline, and returns the callback to run
+func (l *EventLoop) fireTimer(t *timer) func() {
+	if t.repeat {
+		t.deadline = t.deadline.Add(t.interval)
+		if now := time.Now(); t.deadline.Before(now) {
+			t.deadline = now // don't replay ticks missed while the script was busy
+		}
+		heap.Fix(&l.timers, t.index)
+	} else {
+		heap.Remove(&l.timers, t.index)
+		delete(l.timerIDs, t.id)
+	}
+	return t.callback
+}
+
+// timerQueue is a min-heap of timers by deadline, then by id, so timers
+// due at the same time run in the order they were created
+type timerQueue []*timer
+
+func (q timerQueue) Len() int { return len(q) }
+
+func (q timerQueue) Less(i, j int) bool {
+	if q[i].deadline.Equal(q[j].deadline) {
+		return q[i].id < q[j].id
+	}
+	return q[i].deadline.Before(q[j].deadline)
+}
+
+func (q timerQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *timerQueue) Push(x interface{}) {
+	t := x.(*timer)
+	t.index = len(*q)
+	*q = append(*q, t)
+}
+
+func (q *timerQueue) Pop() interface{} {
+	old := *q
+	t := old[len(old)-1]
+	old[len(old)-1] = nil
+	t.index = -1
+	*q = old[:len(old)-1]
+	return t
+}
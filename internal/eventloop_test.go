@@ -0,0 +1,127 @@
+package internal
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRunWaitsForOperations(t *testing.T) {
+	loop := NewEventLoop()
+	p := loop.NewPromise()
+	op := loop.StartOperation()
+	go op.Complete(func() { p.Resolve("done") })
+
+	var got Value
+	p.Subscribe(func(v Value) { got = v }, func(Value) {})
+
+	loop.Run()
+	if got != "done" {
+		t.Errorf("Expected the loop to run the completion and its reactions, got %v", got)
+	}
+}
+
+func TestRunUntilSettled(t *testing.T) {
+	loop := NewEventLoop()
+	p := loop.NewPromise()
+	op := loop.StartOperation()
+	go op.Complete(func() { p.Resolve(1.0) })
+
+	if !loop.RunUntilSettled(p) || p.Value != 1.0 {
+		t.Errorf("Expected the promise to settle with 1, got %v", p)
+	}
+
+	if loop.RunUntilSettled(loop.NewPromise()) {
+		t.Errorf("Expected a promise nothing can settle to report false")
+	}
+}
+
+func TestResetDropsStaleCompletions(t *testing.T) {
+	loop := NewEventLoop()
+	ran := false
+	op := loop.StartOperation()
+	loop.Reset()
+
+	op.Complete(func() { ran = true })
+	loop.Run()
+
+	if ran {
+		t.Errorf("Expected a completion from before the reset to be ignored")
+	}
+}
+
+func TestTimersRunInDeadlineOrder(t *testing.T) {
+	loop := NewEventLoop()
+	var order []int
+	loop.AddTimer(20*time.Millisecond, false, func() { order = append(order, 3) })
+	loop.AddTimer(0, false, func() { order = append(order, 1) })
+	loop.AddTimer(0, false, func() { order = append(order, 2) })
+
+	loop.Run()
+
+	expected := []int{1, 2, 3}
+	if len(order) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, order)
+	}
+	for i := range expected {
+		if order[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, order)
+			break
+		}
+	}
+}
+
+func TestRepeatingTimer(t *testing.T) {
+	loop := NewEventLoop()
+	ticks := 0
+	var id int
+	id = loop.AddTimer(time.Millisecond, true, func() {
+		ticks++
+		if ticks == 3 {
+			loop.ClearTimer(id)
+		}
+	})
+
+	loop.Run()
+	if ticks != 3 {
+		t.Errorf("Expected the timer to tick 3 times before it was cleared, got %d", ticks)
+	}
+}
+
+func TestClearTimer(t *testing.T) {
+	loop := NewEventLoop()
+	ran := false
+	id := loop.AddTimer(0, false, func() { ran = true })
+	loop.ClearTimer(id)
+	loop.ClearTimer(id)    // clearing twice is harmless
+	loop.ClearTimer(12345) // so is an unknown id
+
+	loop.Run()
+	if ran {
+		t.Errorf("Expected a cleared timer not to run")
+	}
+}
+
+func TestResetClearsTimers(t *testing.T) {
+	loop := NewEventLoop()
+	ran := false
+	loop.AddTimer(0, false, func() { ran = true })
+	loop.Reset()
+
+	loop.Run()
+	if ran {
+		t.Errorf("Expected timers from before the reset to be dropped")
+	}
+}
+
+func TestResetLeavesOtherLoopsAlone(t *testing.T) {
+	failed, other := NewEventLoop(), NewEventLoop()
+	ran := false
+	other.AddTimer(0, false, func() { ran = true })
+	failed.AddTimer(0, false, func() {})
+	failed.Reset()
+
+	other.Run()
+	if !ran {
+		t.Errorf("Expected resetting one loop to keep the other loop's timers")
+	}
+}
@@ -0,0 +1,56 @@
+package internal
+
+// Exception carries a thrown JavaScript value up the Go call stack.
+// throw panics with an *Exception and try/catch recovers it, so exceptions
+// pass through builtins and callbacks without every function checking for them.
+//
+// Errors raised by builtins and by the evaluator are error objects (see
+// ThrowError); scripts can throw any value. Errors the evaluator raises
+// itself, like a ReferenceError for an undeclared variable, also record
+// where in the source they happened, both here and in the error's stack.
+//
+// Examples:
+//
+//	throw "boom"  → panic(&Exception{Value: "boom"})
+//	print(x)      → panic(&Exception{Value: <ReferenceError: x is not defined>, Location: "main.js:1:7"})
+type Exception struct {
+	Value     Value
+	InPromise bool   // an unhandled promise rejection rather than a throw
+	Location  string // file:line:column or line:column of an error raised by the evaluator, if known
+}
+
+// Error writes the exception the way Node reports an uncaught one.
+// Error objects are written with their stack, which names the location.
+func (e *Exception) Error() string {
+	message := "Uncaught " + Inspect(e.Value)
+	if e.InPromise {
+		message = "Uncaught (in promise) " + Inspect(e.Value)
+	}
+	if e.Location != "" && !IsError(e.Value) {
+		message += "\n    at " + e.Location
+	}
+	return message
+}
+
+// Throw raises a JavaScript exception with the given value.
+// Builtins use it to report errors that scripts can catch.
+func Throw(val Value) {
+	panic(&Exception{Value: val})
+}
+
+// Catch runs fn and returns the JavaScript exception it threw, if any.
+// Other panics are not recovered.
+func Catch(fn func()) (exception *Exception) {
+	defer func() {
+		if r := recover(); r != nil {
+			e, ok := r.(*Exception)
+			if !ok {
+				panic(r)
+			}
+			exception = e
+		}
+	}()
+
+	fn()
+	return nil
+}
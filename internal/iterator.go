@@ -0,0 +1,100 @@
+package internal
+
+// IterResult creates the { value, done } object returned by an iterator's next()
+func IterResult(value Value, done bool) *Object {
+	result := NewObject()
+	result.Set("value", value)
+	result.Set("done", done)
+	return result
+}
+
+// NewIterator wraps a Go function in a JavaScript iterator object.
+// next is called for every step and reports false once the sequence is
+// exhausted. The iterator is itself iterable, like built-in iterators are.
+//
+// Example: iterating over a Go slice
+//
+//	i := 0
+//	NewIterator(func() (Value, bool) {
+//	    if i >= len(items) {
+//	        return nil, false
+//	    }
+//	    i++
+//	    return items[i-1], true
+//	})
+func NewIterator(next func() (Value, bool)) *Object {
+	iterator := NewObject()
+	done := false
+
+	iterator.Set("next", &Builtin{
+		Name: "next",
+		Fn: func(args ...interface{}) interface{} {
+			if done {
+				return IterResult(nil, true)
+			}
+			value, ok := next()
+			if !ok {
+				done = true
+				return IterResult(nil, true)
+			}
+			return IterResult(value, false)
+		},
+	})
+	iterator.Set(SymbolIterator, &Builtin{
+		Name: "[Symbol.iterator]",
+		Fn: func(args ...interface{}) interface{} {
+			return iterator
+		},
+	})
+	iterator.Set(SymbolToStringTag, "Iterator")
+
+	return iterator
+}
+
+// IterableToList collects the values of an iterable: arrays, strings,
+// built-in collections and objects with a [Symbol.iterator] method. Returns false if the value isn't
+// iterable.
+//
+// Example:
+//
+//	IterableToList(generatorObject) → every value the generator yields
+func IterableToList(iterable Value) ([]Value, bool) {
+	switch v := iterable.(type) {
+	case ArrayLike:
+		return append([]Value{}, v.GetElements()...), true
+	case Iterable:
+		return v.IterationValues(), true
+	case string:
+		values := []Value{}
+		for _, ch := range v {
+			values = append(values, string(ch))
+		}
+		return values, true
+	case *Object:
+		method, ok := v.Get(SymbolIterator).(Callable)
+		if !ok {
+			return nil, false
+		}
+		iterator, ok := method.Call(v).(*Object)
+		if !ok {
+			return nil, false
+		}
+		next, ok := iterator.Get("next").(Callable)
+		if !ok {
+			return nil, false
+		}
+
+		values := []Value{}
+		for {
+			result, ok := next.Call(iterator).(*Object)
+			if !ok {
+				ThrowError("TypeError", "Iterator result is not an object")
+			}
+			if done, _ := result.Get("done").(bool); done {
+				return values, true
+			}
+			values = append(values, result.Get("value"))
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,89 @@
+package internal
+
+import "sync"
+
+// The microtask queue holds jobs that must run as soon as the current
+// piece of script finishes, before anything else happens: promise
+// reactions are the main example.
+//
+// The runtime drains the queue after every macrotask (a program, a REPL line,
+// a timer callback) and microtasks queued while draining run in the same pass,
+// so a chain of then() callbacks always settles before the next macrotask.
+//
+// Every EventLoop has its own queue, so programs running side by side never
+// run each other's jobs.
+//
+// Example:
+//
+//	Promise.resolve(1).then(v => print("micro", v));
+//	print("sync");
+//	→ prints "sync", then "micro 1"
+type microtaskQueue struct {
+	sync.Mutex
+	queue             []func()
+	pendingRejections []*Promise // rejected promises that had no handler when rejected
+}
+
+// QueueMicrotask adds a job to the end of the microtask queue.
+// Goroutines may call it, the job itself always runs on the script's thread.
+func (l *EventLoop) QueueMicrotask(job func()) {
+	l.microtasks.Lock()
+	defer l.microtasks.Unlock()
+	l.microtasks.queue = append(l.microtasks.queue, job)
+}
+
+// RunMicrotasks runs queued jobs until the queue is empty
+// An exception thrown by a job propagates to the caller; jobs still queued
+// stay queued.
+func (l *EventLoop) RunMicrotasks() {
+	for {
+		l.microtasks.Lock()
+		if len(l.microtasks.queue) == 0 {
+			l.microtasks.Unlock()
+			return
+		}
+		job := l.microtasks.queue[0]
+		l.microtasks.queue = l.microtasks.queue[1:]
+		l.microtasks.Unlock()
+
+		job()
+	}
+}
+
+// HasMicrotasks reports whether any job is waiting to run
+func (l *EventLoop) HasMicrotasks() bool {
+	l.microtasks.Lock()
+	defer l.microtasks.Unlock()
+	return len(l.microtasks.queue) > 0
+}
+
+// TakeUnhandledRejections returns the promises that were rejected and still
+// have no handler, and forgets them. Call it after draining the microtask
+// queue: a handler attached later in the same pass still counts as handled.
+func (l *EventLoop) TakeUnhandledRejections() []*Promise {
+	l.microtasks.Lock()
+	defer l.microtasks.Unlock()
+
+	var unhandled []*Promise
+	for _, p := range l.microtasks.pendingRejections {
+		if !p.handled {
+			unhandled = append(unhandled, p)
+		}
+	}
+	l.microtasks.pendingRejections = nil
+	return unhandled
+}
+
+// resetMicrotasks drops every queued job and tracked rejection
+func (l *EventLoop) resetMicrotasks() {
+	l.microtasks.Lock()
+	defer l.microtasks.Unlock()
+	l.microtasks.queue = nil
+	l.microtasks.pendingRejections = nil
+}
+
+func (l *EventLoop) trackRejection(p *Promise) {
+	l.microtasks.Lock()
+	defer l.microtasks.Unlock()
+	l.microtasks.pendingRejections = append(l.microtasks.pendingRejections, p)
+}
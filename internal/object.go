@@ -0,0 +1,336 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Property is a single slot of an Object.
+// Data properties keep their value in Value, accessor properties
+// (get x() {...} / set x(v) {...}) keep their functions in Getter and Setter.
+//
+// The attributes are stored negated so that the zero value describes a
+// property created by assignment: writable, enumerable and configurable.
+// Object.defineProperty is what usually turns them off.
+type Property struct {
+	Value  Value
+	Getter Value
+	Setter Value
+
+	NonWritable     bool // Assignments are ignored (data properties only)
+	NonEnumerable   bool // Left out of Object.keys, printing and JSON
+	NonConfigurable bool // Can't be redefined or deleted
+}
+
+// IsAccessor reports whether the property is backed by a getter and/or setter
+func (p *Property) IsAccessor() bool {
+	return p.Getter != nil || p.Setter != nil
+}
+
+// PrivateName identifies a #private class member.
+// Every evaluation of a class body creates fresh PrivateNames, so two classes
+// that both declare #x never see each other's fields.
+type PrivateName struct {
+	Name string
+}
+
+// Object is a JavaScript object: properties keyed by strings or symbols,
+// plus a link to a prototype.
+// Property lookups that miss on the object itself continue up the prototype chain.
+//
+// Example: for class instances
+//
+//	instance (own fields) → Class.prototype (methods) → Parent.prototype → nil
+//
+// Own properties keep JavaScript enumeration order (see propertyMap), so
+// printing, JSON and iteration always list keys the same way.
+type Object struct {
+	ObjectKind
+	properties    propertyMap
+	Prototype     *Object
+	Private       map[*PrivateName]*Property // #private fields and methods
+	NonExtensible bool                       // New properties can't be added (Object.preventExtensions, seal, freeze)
+}
+
+func NewObject() *Object {
+	return &Object{
+		properties: newPropertyMap(),
+	}
+}
+
+// GetOwnProperty returns the property stored directly on the object,
+// without looking at the prototype chain
+func (o *Object) GetOwnProperty(key PropertyKey) (*Property, bool) {
+	return o.properties.get(key)
+}
+
+// Lookup finds a property on the object or on its prototype chain
+func (o *Object) Lookup(key PropertyKey) (*Property, bool) {
+	for current := o; current != nil; current = current.Prototype {
+		if prop, ok := current.properties.get(key); ok {
+			return prop, true
+		}
+	}
+	return nil, false
+}
+
+// Has reports whether the property exists on the object or its prototype chain
+func (o *Object) Has(key PropertyKey) bool {
+	_, ok := o.Lookup(key)
+	return ok
+}
+
+// Get reads a property, calling its getter with the object as `this`
+func (o *Object) Get(key PropertyKey) Value {
+	return o.GetWithThis(key, o)
+}
+
+// GetWithThis reads a property, calling its getter with the given receiver.
+// The receiver differs from the object for super.x lookups, where the
+// property lives on a parent prototype but `this` is still the instance.
+func (o *Object) GetWithThis(key PropertyKey, this Value) Value {
+	prop, ok := o.Lookup(key)
+	if !ok {
+		return nil
+	}
+	return prop.Read(this)
+}
+
+// Set assigns a property. If an accessor with a setter is found on the
+// prototype chain it is called; otherwise an own data property is written.
+//
+// Assignments that aren't allowed change nothing and report false: to a
+// read-only property (own or inherited), to an accessor without a setter,
+// and of a new property to a non-extensible object. Sloppy-mode code
+// ignores the result; strict-mode code throws a TypeError.
+func (o *Object) Set(key PropertyKey, val Value) bool {
+	return o.SetWithThis(key, val, o)
+}
+
+// SetWithThis assigns a property like Set, but calls setters with the given
+// receiver (e.g. a class whose static members live on this object)
+func (o *Object) SetWithThis(key PropertyKey, val Value, this Value) bool {
+	if prop, ok := o.Lookup(key); ok {
+		if prop.IsAccessor() {
+			return prop.Write(this, val)
+		}
+		if prop.NonWritable {
+			return false
+		}
+	}
+	if prop, ok := o.properties.get(key); ok {
+		prop.Value = val
+		return true
+	}
+	if o.NonExtensible {
+		return false
+	}
+	o.properties.set(key, &Property{Value: val})
+	return true
+}
+
+// Define stores a property directly on the object, replacing any existing one
+// An existing key keeps its position in the enumeration order.
+func (o *Object) Define(key PropertyKey, prop *Property) {
+	o.properties.set(key, prop)
+}
+
+// Delete removes an own property and reports whether it is gone.
+// Non-configurable properties stay.
+func (o *Object) Delete(key PropertyKey) bool {
+	if prop, ok := o.properties.get(key); ok && prop.NonConfigurable {
+		return false
+	}
+	o.properties.delete(key)
+	return true
+}
+
+// Keys returns the names of the object's own enumerable string-keyed
+// properties in enumeration order: integer-like keys ascending, then the
+// remaining keys in insertion order. Symbol keys are left out, as in
+// Object.keys.
+func (o *Object) Keys() []string {
+	keys := []string{}
+	for _, key := range o.properties.keys() {
+		if prop, _ := o.properties.get(key); !prop.NonEnumerable {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// OwnPropertyNames returns the names of all own string-keyed properties,
+// enumerable or not, in the same order as Keys
+func (o *Object) OwnPropertyNames() []string {
+	return o.properties.keys()
+}
+
+// SymbolKeys returns the object's own symbol keys in insertion order
+func (o *Object) SymbolKeys() []*Symbol {
+	return o.properties.symbolKeys()
+}
+
+// OwnKeys returns every own key, enumerable or not: the string keys from
+// OwnPropertyNames, then the symbols
+func (o *Object) OwnKeys() []PropertyKey {
+	keys := []PropertyKey{}
+	for _, key := range o.OwnPropertyNames() {
+		keys = append(keys, key)
+	}
+	for _, sym := range o.SymbolKeys() {
+		keys = append(keys, sym)
+	}
+	return keys
+}
+
+func (o *Object) Len() int {
+	return o.properties.len()
+}
+
+// MarshalJSON serializes the object's own properties in enumeration order,
+// so encoding/json produces {"key":value} rather than the Go struct layout
+func (o *Object) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+
+	for i, key := range o.Keys() {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		keyJSON, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		valueJSON, err := json.Marshal(o.Get(key))
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(keyJSON)
+		buf.WriteByte(':')
+		buf.Write(valueJSON)
+	}
+
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// GetPrivate returns the #private member stored on the object
+func (o *Object) GetPrivate(name *PrivateName) (*Property, bool) {
+	prop, ok := o.Private[name]
+	return prop, ok
+}
+
+// DefinePrivate adds a #private member to the object
+func (o *Object) DefinePrivate(name *PrivateName, prop *Property) {
+	if o.Private == nil {
+		o.Private = make(map[*PrivateName]*Property)
+	}
+	o.Private[name] = prop
+}
+
+// Subclassable is implemented by built-in values that classes can extend,
+// like Map, Promise and Array. An instance created through a subclass
+// carries an object for everything the built-in can't store itself: its
+// prototype is the subclass prototype, and it holds the instance's fields
+// and #private members. Lookups try it before the built-in's own members.
+//
+// Instances of the built-in itself get one, without a prototype, once a
+// property is assigned to them (see SubclassSlot.OwnProperties).
+//
+// Example: class Cache extends Map { hits = 0; hit(k) { ... } }
+//
+//	new Cache() → *collection.Map whose SubclassObject is { hits: 0 } → Cache.prototype
+//	cache.hit("a") → Cache.prototype.hit; cache.get("a") → the Map method
+type Subclassable interface {
+	SubclassObject() *Object // nil until the instance is subclassed or given a property
+	SetSubclassObject(obj *Object)
+}
+
+// PropertyHolder is implemented by values that aren't plain Objects but
+// keep their own properties on one, like a class does its static members.
+// Object.keys, Object.getOwnPropertyDescriptor and the other reflection
+// functions read and define those properties through it.
+//
+// Example: class A { static s = 1; }
+//
+//	A.OwnProperties() → { prototype, name, s }, the same object A.s reads
+type PropertyHolder interface {
+	OwnProperties() *Object
+}
+
+// SubclassSlot implements Subclassable for built-in types that embed it.
+// It also makes them a PropertyHolder and Freezable: the object it holds
+// keeps their own properties, and freezing them locks those.
+//
+// Example: var m = new Map(); m.label = "cache"; → m's own properties are { label }
+type SubclassSlot struct {
+	subclass *Object
+}
+
+func (s *SubclassSlot) SubclassObject() *Object {
+	return s.subclass
+}
+
+func (s *SubclassSlot) SetSubclassObject(obj *Object) {
+	s.subclass = obj
+}
+
+// OwnProperties returns the object holding the instance's own properties,
+// creating one without a prototype for instances that have none yet
+func (s *SubclassSlot) OwnProperties() *Object {
+	if s.subclass == nil {
+		s.subclass = NewObject()
+	}
+	return s.subclass
+}
+
+func (s *SubclassSlot) PreventExtensions() {
+	s.OwnProperties().PreventExtensions()
+}
+
+func (s *SubclassSlot) Seal() {
+	s.OwnProperties().Seal()
+}
+
+func (s *SubclassSlot) Freeze() {
+	s.OwnProperties().Freeze()
+}
+
+func (s *SubclassSlot) IsExtensible() bool {
+	return s.subclass == nil || s.subclass.IsExtensible()
+}
+
+func (s *SubclassSlot) IsSealed() bool {
+	return s.subclass != nil && s.subclass.IsSealed()
+}
+
+func (s *SubclassSlot) IsFrozen() bool {
+	return s.subclass != nil && s.subclass.IsFrozen()
+}
+
+// Read returns the property's value, calling the getter for accessors
+func (p *Property) Read(this Value) Value {
+	if !p.IsAccessor() {
+		return p.Value
+	}
+	if getter, ok := p.Getter.(Callable); ok {
+		return getter.Call(this)
+	}
+	return nil
+}
+
+// Write stores a value, calling the setter for accessors.
+// A getter-only accessor takes no value and Write reports false.
+func (p *Property) Write(this Value, val Value) bool {
+	if !p.IsAccessor() {
+		p.Value = val
+		return true
+	}
+	setter, ok := p.Setter.(Callable)
+	if ok {
+		setter.Call(this, val)
+	}
+	return ok
+}
@@ -0,0 +1,192 @@
+package internal
+
+import (
+	"testing"
+)
+
+type recordingFunc struct {
+	ObjectKind
+	fn func(this Value, args ...Value) Value
+}
+
+func (r recordingFunc) Call(this Value, args ...Value) Value {
+	return r.fn(this, args...)
+}
+
+func TestObjectPrototypeLookup(t *testing.T) {
+	parent := NewObject()
+	parent.Set("greeting", "hello")
+
+	child := NewObject()
+	child.Prototype = parent
+	child.Set("name", "child")
+
+	if got := child.Get("greeting"); got != "hello" {
+		t.Errorf("Expected inherited 'hello', got %v", got)
+	}
+
+	if _, ok := child.GetOwnProperty("greeting"); ok {
+		t.Error("'greeting' should not be an own property of child")
+	}
+
+	// Writing creates an own property and leaves the prototype untouched
+	child.Set("greeting", "hi")
+	if got := parent.Get("greeting"); got != "hello" {
+		t.Errorf("Prototype should still say 'hello', got %v", got)
+	}
+	if got := child.Get("greeting"); got != "hi" {
+		t.Errorf("Expected own 'hi', got %v", got)
+	}
+}
+
+func TestObjectAccessors(t *testing.T) {
+	var stored Value
+	proto := NewObject()
+	proto.Define("value", &Property{
+		Getter: recordingFunc{fn: func(this Value, args ...Value) Value {
+			return this.(*Object).Get("raw")
+		}},
+		Setter: recordingFunc{fn: func(this Value, args ...Value) Value {
+			stored = args[0]
+			this.(*Object).Set("raw", args[0])
+			return nil
+		}},
+	})
+
+	obj := NewObject()
+	obj.Prototype = proto
+	obj.Set("value", 10.0)
+
+	if stored != 10.0 {
+		t.Errorf("Setter should have been called with 10, got %v", stored)
+	}
+
+	if _, ok := obj.GetOwnProperty("value"); ok {
+		t.Error("Setter should not create an own 'value' property")
+	}
+
+	if got := obj.Get("value"); got != 10.0 {
+		t.Errorf("Getter should return 10, got %v", got)
+	}
+}
+
+func TestObjectPrivateMembers(t *testing.T) {
+	first := &PrivateName{Name: "#x"}
+	second := &PrivateName{Name: "#x"}
+
+	obj := NewObject()
+	obj.DefinePrivate(first, &Property{Value: 1.0})
+
+	if prop, ok := obj.GetPrivate(first); !ok || prop.Value != 1.0 {
+		t.Errorf("Expected private #x = 1, got %v", prop)
+	}
+
+	// Same spelling, different class: a different private name
+	if _, ok := obj.GetPrivate(second); ok {
+		t.Error("Private names with the same spelling must not collide")
+	}
+
+	if obj.Len() != 0 {
+		t.Errorf("Private members should not count as properties, got %d", obj.Len())
+	}
+}
+
+func TestObjectMarshalJSON(t *testing.T) {
+	obj := NewObject()
+	obj.Set("b", 2.0)
+	obj.Set("a", "x")
+
+	data, err := obj.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON returned error: %v", err)
+	}
+
+	// Keys come out in insertion order, not sorted
+	if string(data) != `{"b":2,"a":"x"}` {
+		t.Errorf("Expected {\"b\":2,\"a\":\"x\"}, got %s", data)
+	}
+}
+
+func TestObjectKeyOrder(t *testing.T) {
+	obj := NewObject()
+	for _, key := range []string{"b", "10", "a", "2", "-1", "01", "1.5", "0"} {
+		obj.Set(key, key)
+	}
+
+	// Integer keys ascending, then everything else in insertion order
+	expected := []string{"0", "2", "10", "b", "a", "-1", "01", "1.5"}
+	keys := obj.Keys()
+
+	if len(keys) != len(expected) {
+		t.Fatalf("Expected %d keys, got %d (%v)", len(expected), len(keys), keys)
+	}
+	for i, key := range expected {
+		if keys[i] != key {
+			t.Errorf("Keys()[%d] = %q, expected %q (all keys: %v)", i, keys[i], key, keys)
+		}
+	}
+}
+
+func TestObjectKeyOrderAfterUpdateAndDelete(t *testing.T) {
+	obj := NewObject()
+	obj.Set("first", 1.0)
+	obj.Set("second", 2.0)
+	obj.Set("third", 3.0)
+
+	// Reassigning keeps the original position
+	obj.Set("first", 10.0)
+	// Deleting and re-adding moves the key to the end
+	obj.Delete("second")
+	obj.Set("second", 20.0)
+
+	expected := []string{"first", "third", "second"}
+	keys := obj.Keys()
+	for i, key := range expected {
+		if keys[i] != key {
+			t.Errorf("Keys()[%d] = %q, expected %q (all keys: %v)", i, keys[i], key, keys)
+		}
+	}
+}
+
+func TestArrayIndex(t *testing.T) {
+	tests := []struct {
+		key      PropertyKey
+		index    int
+		expected bool
+	}{
+		{"0", 0, true},
+		{"17", 17, true},
+		{"017", 0, false},
+		{"0.5", 0, false},
+		{SymbolIterator, 0, false},
+	}
+
+	for _, tt := range tests {
+		if index, ok := ArrayIndex(tt.key); index != tt.index || ok != tt.expected {
+			t.Errorf("ArrayIndex(%v) = %d, %v, expected %d, %v", tt.key, index, ok, tt.index, tt.expected)
+		}
+	}
+}
+
+func TestIsArrayIndex(t *testing.T) {
+	tests := []struct {
+		key      string
+		expected bool
+	}{
+		{"0", true},
+		{"42", true},
+		{"4294967294", true},
+		{"4294967295", false},
+		{"042", false},
+		{"-1", false},
+		{"1.5", false},
+		{"", false},
+		{"abc", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsArrayIndex(tt.key); got != tt.expected {
+			t.Errorf("IsArrayIndex(%q) = %v, expected %v", tt.key, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,288 @@
+package internal
+
+// PromiseState is the settlement state of a Promise
+type PromiseState int
+
+const (
+	PromisePending PromiseState = iota
+	PromiseFulfilled
+	PromiseRejected
+)
+
+// Promise is the state behind a JavaScript promise: pending until it is
+// fulfilled with a value or rejected with a reason, after which it never
+// changes again.
+//
+// Callbacks registered with Then never run synchronously. Once the promise
+// settles each reaction is queued as a microtask, in registration order.
+//
+// Example:
+//
+//	p := loop.NewPromise()
+//	p.Then(onFulfilled, onRejected) → reaction stored while pending
+//	p.Resolve(42)                   → queues onFulfilled(42)
+//	loop.RunMicrotasks()            → onFulfilled runs
+type Promise struct {
+	ObjectKind
+	State     PromiseState
+	Value     Value // fulfillment value or rejection reason
+	reactions []promiseReaction
+	handled   bool       // a rejection handler is attached, so rejecting isn't reported
+	loop      *EventLoop // whose microtask queue runs the reactions
+	SubclassSlot
+}
+
+// promiseReaction is one then() registration: the handlers and the promise
+// that then() returned, which settles with the handler's outcome
+type promiseReaction struct {
+	onFulfilled Value
+	onRejected  Value
+	derived     *Promise
+}
+
+// NewPromise returns a pending promise whose reactions run on the loop
+func (l *EventLoop) NewPromise() *Promise {
+	return &Promise{loop: l}
+}
+
+// PromiseResolve returns value itself if it's already a promise, otherwise a
+// new promise resolved with it (the behaviour of Promise.resolve)
+func (l *EventLoop) PromiseResolve(value Value) *Promise {
+	if p, ok := value.(*Promise); ok {
+		return p
+	}
+	p := l.NewPromise()
+	p.Resolve(value)
+	return p
+}
+
+// PromiseReject returns a new promise rejected with reason
+func (l *EventLoop) PromiseReject(reason Value) *Promise {
+	p := l.NewPromise()
+	p.Reject(reason)
+	return p
+}
+
+// Loop returns the event loop the promise belongs to
+func (p *Promise) Loop() *EventLoop {
+	return p.loop
+}
+
+// Resolve settles the promise with value. If value is a promise or another
+// thenable, this promise follows it instead and settles when it does.
+//
+// Examples:
+//
+//	p.Resolve(1)                      → fulfilled with 1
+//	p.Resolve(loop.PromiseReject("x")) → rejected with "x" (a couple of ticks later)
+//	p.Resolve(p)                      → rejected with a TypeError
+func (p *Promise) Resolve(value Value) {
+	if p.State != PromisePending {
+		return
+	}
+
+	if value == p {
+		p.Reject(NewError("TypeError", "Chaining cycle detected for promise"))
+		return
+	}
+
+	switch v := value.(type) {
+	case *Promise:
+		p.loop.QueueMicrotask(func() {
+			resolve, reject := p.resolvingFunctions()
+			v.Subscribe(resolve, reject)
+		})
+		return
+	case *Object:
+		var then Value
+		if exception := Catch(func() { then = v.Get("then") }); exception != nil {
+			p.Reject(exception.Value)
+			return
+		}
+		if callable, ok := then.(Callable); ok {
+			p.loop.QueueMicrotask(func() {
+				resolve, reject := p.ResolvingFunctions()
+				if exception := Catch(func() { callable.Call(v, resolve, reject) }); exception != nil {
+					reject.Call(nil, exception.Value)
+				}
+			})
+			return
+		}
+	}
+
+	p.settle(PromiseFulfilled, value)
+}
+
+// Reject settles the promise with a rejection reason
+// A rejection nobody handles is reported once the microtask queue drains.
+func (p *Promise) Reject(reason Value) {
+	if p.State != PromisePending {
+		return
+	}
+	if !p.handled {
+		p.loop.trackRejection(p)
+	}
+	p.settle(PromiseRejected, reason)
+}
+
+func (p *Promise) settle(state PromiseState, value Value) {
+	p.State = state
+	p.Value = value
+
+	reactions := p.reactions
+	p.reactions = nil
+	for _, reaction := range reactions {
+		p.queueReaction(reaction)
+	}
+}
+
+// ResolvingFunctions returns the resolve and reject functions handed to a
+// promise executor. Only the first call of either one has any effect, even
+// if it resolves with a thenable that hasn't settled yet.
+func (p *Promise) ResolvingFunctions() (resolve *Builtin, reject *Builtin) {
+	resolveFn, rejectFn := p.resolvingFunctions()
+
+	resolve = &Builtin{
+		Name: "resolve",
+		Fn: func(args ...interface{}) interface{} {
+			resolveFn(firstValue(args))
+			return nil
+		},
+	}
+	reject = &Builtin{
+		Name: "reject",
+		Fn: func(args ...interface{}) interface{} {
+			rejectFn(firstValue(args))
+			return nil
+		},
+	}
+	return resolve, reject
+}
+
+func (p *Promise) resolvingFunctions() (resolve func(Value), reject func(Value)) {
+	alreadyResolved := false
+
+	resolve = func(value Value) {
+		if alreadyResolved {
+			return
+		}
+		alreadyResolved = true
+		p.Resolve(value)
+	}
+	reject = func(reason Value) {
+		if alreadyResolved {
+			return
+		}
+		alreadyResolved = true
+		p.Reject(reason)
+	}
+	return resolve, reject
+}
+
+// Then registers fulfillment and rejection handlers and returns a new promise
+// for their result. Handlers that aren't callable pass the value or reason
+// through unchanged, and a handler that throws rejects the new promise.
+//
+// Example:
+//
+//	loop.PromiseResolve(1).Then(addOne, nil) → promise fulfilled with 2
+func (p *Promise) Then(onFulfilled Value, onRejected Value) *Promise {
+	derived := p.loop.NewPromise()
+	p.addReaction(promiseReaction{onFulfilled: onFulfilled, onRejected: onRejected, derived: derived})
+	return derived
+}
+
+// Subscribe registers Go callbacks for when the promise settles. Like Then,
+// it marks the promise as handled and the callbacks run as microtasks.
+func (p *Promise) Subscribe(onFulfilled func(Value), onRejected func(Value)) {
+	p.Then(
+		&Builtin{Fn: func(args ...interface{}) interface{} {
+			onFulfilled(firstValue(args))
+			return nil
+		}},
+		&Builtin{Fn: func(args ...interface{}) interface{} {
+			onRejected(firstValue(args))
+			return nil
+		}},
+	)
+}
+
+func (p *Promise) addReaction(reaction promiseReaction) {
+	p.handled = true
+
+	if p.State == PromisePending {
+		p.reactions = append(p.reactions, reaction)
+		return
+	}
+	p.queueReaction(reaction)
+}
+
+// queueReaction schedules a reaction for the settled promise
+func (p *Promise) queueReaction(reaction promiseReaction) {
+	state, value := p.State, p.Value
+
+	p.loop.QueueMicrotask(func() {
+		handler := reaction.onFulfilled
+		if state == PromiseRejected {
+			handler = reaction.onRejected
+		}
+
+		callable, ok := handler.(Callable)
+		if !ok {
+			if state == PromiseRejected {
+				reaction.derived.Reject(value)
+			} else {
+				reaction.derived.Resolve(value)
+			}
+			return
+		}
+
+		var result Value
+		if exception := Catch(func() { result = callable.Call(nil, value) }); exception != nil {
+			reaction.derived.Reject(exception.Value)
+			return
+		}
+		reaction.derived.Resolve(result)
+	})
+}
+
+// String formats the promise the way Node's console does
+//
+// Examples:
+//
+//	Promise { <pending> }
+//	Promise { 42 }
+//	Promise { <rejected> boom }
+func (p *Promise) String() string {
+	return p.InspectWith(Inspect)
+}
+
+// InspectWith writes the promise like String, with its value written by
+// inspect
+func (p *Promise) InspectWith(inspect func(Value) string) string {
+	switch p.State {
+	case PromiseFulfilled:
+		return "Promise { " + inspect(p.Value) + " }"
+	case PromiseRejected:
+		return "Promise { <rejected> " + inspect(p.Value) + " }"
+	default:
+		return "Promise { <pending> }"
+	}
+}
+
+// ToPrimitive converts a promise to the string it prints as
+func (p *Promise) ToPrimitive(hint string) Value {
+	return p.String()
+}
+
+// MarshalJSON serializes promises as empty objects, like JSON.stringify does
+func (p *Promise) MarshalJSON() ([]byte, error) {
+	return []byte("{}"), nil
+}
+
+func firstValue(args []interface{}) Value {
+	if len(args) == 0 {
+		return nil
+	}
+	return args[0]
+}
@@ -0,0 +1,81 @@
+package internal
+
+import "testing"
+
+func TestPromiseThenRunsAsMicrotask(t *testing.T) {
+	loop := NewEventLoop()
+	var got Value
+	p := loop.NewPromise()
+	p.Then(recordingFunc{fn: func(this Value, args ...Value) Value { got = args[0]; return nil }}, nil)
+
+	p.Resolve("value")
+	if got != nil {
+		t.Fatalf("Expected the reaction to wait for the microtask queue")
+	}
+	if !loop.HasMicrotasks() {
+		t.Fatalf("Expected a queued microtask")
+	}
+
+	loop.RunMicrotasks()
+	if got != "value" {
+		t.Errorf("Expected the reaction to receive value, got %v", got)
+	}
+}
+
+func TestPromiseSettlesOnce(t *testing.T) {
+	loop := NewEventLoop()
+	p := loop.NewPromise()
+	p.Resolve(1.0)
+	p.Reject("ignored")
+	p.Resolve(2.0)
+	loop.RunMicrotasks()
+
+	if p.State != PromiseFulfilled || p.Value != 1.0 {
+		t.Errorf("Expected the first resolution to win, got %v", p)
+	}
+}
+
+func TestPromiseString(t *testing.T) {
+	loop := NewEventLoop()
+	tests := []struct {
+		promise  *Promise
+		expected string
+	}{
+		{loop.NewPromise(), "Promise { <pending> }"},
+		{loop.PromiseResolve(1.0), "Promise { 1 }"},
+		{loop.PromiseReject("err"), "Promise { <rejected> err }"},
+	}
+
+	for _, tt := range tests {
+		if got := Inspect(tt.promise); got != tt.expected {
+			t.Errorf("Expected %s, got %s", tt.expected, got)
+		}
+	}
+}
+
+func TestUnhandledRejections(t *testing.T) {
+	loop := NewEventLoop()
+	handled := loop.PromiseReject("handled")
+	handled.Then(nil, recordingFunc{fn: func(this Value, args ...Value) Value { return nil }})
+	unhandled := loop.PromiseReject("unhandled")
+	loop.RunMicrotasks()
+
+	rejections := loop.TakeUnhandledRejections()
+	if len(rejections) != 1 || rejections[0] != unhandled {
+		t.Fatalf("Expected only the unhandled promise to be reported, got %v", rejections)
+	}
+	if len(loop.TakeUnhandledRejections()) != 0 {
+		t.Errorf("Expected reported rejections to be forgotten")
+	}
+}
+
+func TestCatch(t *testing.T) {
+	exception := Catch(func() { Throw("boom") })
+	if exception == nil || exception.Value != "boom" {
+		t.Fatalf("Expected to catch boom, got %v", exception)
+	}
+
+	if Catch(func() {}) != nil {
+		t.Errorf("Expected no exception")
+	}
+}
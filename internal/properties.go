@@ -0,0 +1,159 @@
+package internal
+
+import (
+	"sort"
+	"strconv"
+)
+
+// PropertyKey names a property: either a string or a *Symbol.
+// Use ToPropertyKey to turn an arbitrary value into a key.
+//
+// Examples:
+//
+//	obj.Get("name")
+//	obj.Get(SymbolIterator)
+type PropertyKey interface{}
+
+// ToPropertyKey converts a value used as a property name, such as the
+// result of obj[expr] or a computed key { [expr]: ... }
+// Objects are converted with ToPrimitive first; symbols stay symbols and
+// everything else becomes a string.
+//
+// Examples:
+//
+//	ToPropertyKey(1.0)            → "1"
+//	ToPropertyKey(nil)            → "undefined"
+//	ToPropertyKey([1, 2])         → "1,2"
+//	ToPropertyKey(SymbolIterator) → SymbolIterator
+func ToPropertyKey(val Value) PropertyKey {
+	key := ToPrimitive(val, "string")
+	if sym, ok := key.(*Symbol); ok {
+		return sym
+	}
+	return ToString(key)
+}
+
+// propertyMap stores an object's own properties and remembers their order.
+// Enumeration follows JavaScript rules rather than Go's random map order:
+//  1. integer-like keys ("0", "1", "42") in ascending numeric order
+//  2. all other string keys in the order they were first added
+//  3. symbol keys in the order they were first added
+//
+// Example: adding b, 2, a, 1 enumerates as 1, 2, b, a
+type propertyMap struct {
+	values map[PropertyKey]*Property
+	order  []PropertyKey // Insertion order of all keys
+}
+
+func newPropertyMap() propertyMap {
+	return propertyMap{values: make(map[PropertyKey]*Property)}
+}
+
+func (m *propertyMap) get(key PropertyKey) (*Property, bool) {
+	prop, ok := m.values[key]
+	return prop, ok
+}
+
+// set adds or replaces a property. Replacing keeps the original position,
+// just like reassigning a property in JavaScript.
+func (m *propertyMap) set(key PropertyKey, prop *Property) {
+	if _, exists := m.values[key]; !exists {
+		m.order = append(m.order, key)
+	}
+	m.values[key] = prop
+}
+
+func (m *propertyMap) delete(key PropertyKey) {
+	if _, exists := m.values[key]; !exists {
+		return
+	}
+	delete(m.values, key)
+	for i, k := range m.order {
+		if k == key {
+			m.order = append(m.order[:i], m.order[i+1:]...)
+			break
+		}
+	}
+}
+
+func (m *propertyMap) len() int {
+	return len(m.values)
+}
+
+// keys returns the string property names in JavaScript enumeration order
+func (m *propertyMap) keys() []string {
+	indexes := []string{}
+	names := make([]string, 0, len(m.order))
+
+	for _, k := range m.order {
+		key, ok := k.(string)
+		if !ok {
+			continue
+		}
+		if IsArrayIndex(key) {
+			indexes = append(indexes, key)
+		} else {
+			names = append(names, key)
+		}
+	}
+
+	if len(indexes) == 0 {
+		return names
+	}
+
+	sort.Slice(indexes, func(i, j int) bool {
+		a, _ := strconv.ParseUint(indexes[i], 10, 32)
+		b, _ := strconv.ParseUint(indexes[j], 10, 32)
+		return a < b
+	})
+
+	return append(indexes, names...)
+}
+
+// symbolKeys returns the symbol keys in insertion order
+func (m *propertyMap) symbolKeys() []*Symbol {
+	symbols := []*Symbol{}
+	for _, k := range m.order {
+		if sym, ok := k.(*Symbol); ok {
+			symbols = append(symbols, sym)
+		}
+	}
+	return symbols
+}
+
+// IsArrayIndex reports whether a property key is a canonical array index:
+// a non-negative integer below 2^32 - 1 without leading zeros or signs
+//
+// Examples:
+//
+//	IsArrayIndex("0")   → true
+//	IsArrayIndex("42")  → true
+//	IsArrayIndex("042") → false (not canonical)
+//	IsArrayIndex("-1")  → false
+//	IsArrayIndex("1.5") → false
+func IsArrayIndex(key string) bool {
+	if key == "" || (len(key) > 1 && key[0] == '0') {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] < '0' || key[i] > '9' {
+			return false
+		}
+	}
+	n, err := strconv.ParseUint(key, 10, 64)
+	return err == nil && n < 1<<32-1
+}
+
+// ArrayIndex returns the element position a property key names, if it is
+// an array index (see IsArrayIndex)
+//
+// Examples: ArrayIndex("3") → 3, true; ArrayIndex("0.5") → 0, false;
+// ArrayIndex(Symbol.iterator) → 0, false
+func ArrayIndex(key PropertyKey) (int, bool) {
+	name, ok := key.(string)
+	if !ok || !IsArrayIndex(name) {
+		return 0, false
+	}
+	n, _ := strconv.Atoi(name)
+	return n, true
+}
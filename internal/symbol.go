@@ -0,0 +1,70 @@
+package internal
+
+import "sync"
+
+// Symbol is a unique primitive value, mostly used as a property key.
+// Every call to Symbol() creates a new one, so symbols are compared by
+// pointer: two symbols with the same description are still different keys.
+//
+// Example:
+//
+//	var id = Symbol("id");
+//	var user = { [id]: 7, name: "Ann" };
+//	user[id]   → 7
+//	user["id"] → undefined (a different key)
+type Symbol struct {
+	Description string
+}
+
+func NewSymbol(description string) *Symbol {
+	return &Symbol{Description: description}
+}
+
+func (s *Symbol) String() string {
+	return "Symbol(" + s.Description + ")"
+}
+
+// Well-known symbols. The runtime looks them up on objects to let scripts
+// customize built-in behaviour.
+var (
+	SymbolIterator      = NewSymbol("Symbol.iterator")      // for...of and other iteration
+	SymbolAsyncIterator = NewSymbol("Symbol.asyncIterator") // for await...of
+	SymbolToPrimitive   = NewSymbol("Symbol.toPrimitive")   // obj + 1, obj < 2, "" + obj
+	SymbolToStringTag   = NewSymbol("Symbol.toStringTag")   // "[object Tag]"
+	SymbolHasInstance   = NewSymbol("Symbol.hasInstance")   // x instanceof C
+)
+
+// symbolRegistry backs Symbol.for: the same key always returns the same symbol
+var symbolRegistry = struct {
+	sync.Mutex
+	symbols map[string]*Symbol
+}{symbols: make(map[string]*Symbol)}
+
+// SymbolFor returns the registered symbol for key, creating it on first use
+//
+// Example:
+//
+//	Symbol.for("app") == Symbol.for("app") → true
+func SymbolFor(key string) *Symbol {
+	symbolRegistry.Lock()
+	defer symbolRegistry.Unlock()
+
+	if sym, ok := symbolRegistry.symbols[key]; ok {
+		return sym
+	}
+	sym := NewSymbol(key)
+	symbolRegistry.symbols[key] = sym
+	return sym
+}
+
+// SymbolKeyFor returns the key a symbol was registered under with Symbol.for.
+// Symbols created with Symbol() are not registered.
+func SymbolKeyFor(sym *Symbol) (string, bool) {
+	symbolRegistry.Lock()
+	defer symbolRegistry.Unlock()
+
+	if registered, ok := symbolRegistry.symbols[sym.Description]; ok && registered == sym {
+		return sym.Description, true
+	}
+	return "", false
+}
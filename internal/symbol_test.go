@@ -0,0 +1,116 @@
+package internal
+
+import (
+	"testing"
+)
+
+func TestSymbolsAreUnique(t *testing.T) {
+	a := NewSymbol("id")
+	b := NewSymbol("id")
+
+	if a == b {
+		t.Error("Two symbols with the same description should be different")
+	}
+	if a.String() != "Symbol(id)" {
+		t.Errorf("Expected Symbol(id), got %s", a.String())
+	}
+}
+
+func TestSymbolFor(t *testing.T) {
+	a := SymbolFor("app.id")
+	b := SymbolFor("app.id")
+
+	if a != b {
+		t.Error("SymbolFor should return the same symbol for the same key")
+	}
+
+	if key, ok := SymbolKeyFor(a); !ok || key != "app.id" {
+		t.Errorf("Expected key app.id, got %q (%v)", key, ok)
+	}
+
+	if _, ok := SymbolKeyFor(NewSymbol("app.id")); ok {
+		t.Error("Symbols not created by SymbolFor should not have a registry key")
+	}
+}
+
+func TestToPropertyKey(t *testing.T) {
+	tests := []struct {
+		input    Value
+		expected PropertyKey
+	}{
+		{"name", "name"},
+		{1.0, "1"},
+		{true, "true"},
+		{SymbolIterator, SymbolIterator},
+	}
+
+	for _, tt := range tests {
+		if got := ToPropertyKey(tt.input); got != tt.expected {
+			t.Errorf("ToPropertyKey(%v) = %v, expected %v", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestSymbolKeyedProperties(t *testing.T) {
+	id := NewSymbol("id")
+	other := NewSymbol("id")
+
+	obj := NewObject()
+	obj.Set(id, 7.0)
+	obj.Set("id", "string key")
+	obj.Set("name", "Ann")
+
+	if got := obj.Get(id); got != 7.0 {
+		t.Errorf("Expected 7 under the symbol key, got %v", got)
+	}
+	if got := obj.Get("id"); got != "string key" {
+		t.Errorf("Expected the string key to be separate, got %v", got)
+	}
+	if obj.Has(other) {
+		t.Error("A different symbol with the same description should not be found")
+	}
+
+	keys := obj.Keys()
+	if len(keys) != 2 || keys[0] != "id" || keys[1] != "name" {
+		t.Errorf("Keys() should only list string keys, got %v", keys)
+	}
+
+	own := obj.OwnKeys()
+	if len(own) != 3 || own[2] != id {
+		t.Errorf("OwnKeys() should list symbols after strings, got %v", own)
+	}
+
+	if got := Inspect(obj); got != "{id: string key, name: Ann, [Symbol(id)]: 7}" {
+		t.Errorf("Unexpected object format: %s", got)
+	}
+}
+
+func TestNewIterator(t *testing.T) {
+	items := []Value{"a", "b"}
+	i := 0
+	iterator := NewIterator(func() (Value, bool) {
+		if i >= len(items) {
+			return nil, false
+		}
+		i++
+		return items[i-1], true
+	})
+
+	next := iterator.Get("next").(Callable)
+	for _, expected := range []Value{"a", "b"} {
+		result := next.Call(iterator).(*Object)
+		if result.Get("value") != expected || result.Get("done") != false {
+			t.Errorf("Expected {value: %v, done: false}, got %s", expected, Inspect(result))
+		}
+	}
+
+	result := next.Call(iterator).(*Object)
+	if result.Get("done") != true {
+		t.Errorf("Expected done iterator, got %s", Inspect(result))
+	}
+
+	self := iterator.Get(SymbolIterator).(Callable).Call(iterator)
+	if self != iterator {
+		t.Error("Iterators should return themselves from [Symbol.iterator]()")
+	}
+}
@@ -1,20 +1,129 @@
 package internal
 
 type Builtin struct {
-	Name string
-	Fn   func(args ...interface{}) interface{}
+	ObjectKind
+	Name       string
+	Fn         func(args ...interface{}) interface{}
+	Properties *Object                               // Static members of callable namespaces, e.g. Symbol.for
+	Construct  func(args ...interface{}) interface{} // Called by new; nil if the builtin can't be constructed
+
+	// Method replaces Fn for builtins that need their receiver, like
+	// methods on a prototype (Error.prototype.toString) and accessors
+	Method func(this interface{}, args ...interface{}) interface{}
+}
+
+// Call lets builtins be invoked through the Callable interface.
+// Only builtins with a Method see the receiver; Fn ignores it.
+func (b *Builtin) Call(this Value, args ...Value) Value {
+	values := make([]interface{}, len(args))
+	for i, arg := range args {
+		values[i] = arg
+	}
+	if b.Method != nil {
+		return b.Method(this, values...)
+	}
+	return b.Fn(values...)
+}
+
+// ToPrimitive converts a builtin the way Function.prototype.toString
+// writes native functions
+//
+// Example: "" + parseInt → "function parseInt() { [native code] }"
+func (b *Builtin) ToPrimitive(hint string) Value {
+	return "function " + b.Name + "() { [native code] }"
+}
+
+// New invokes the builtin as a constructor, like Call does for plain calls
+func (b *Builtin) New(args ...Value) Value {
+	values := make([]interface{}, len(args))
+	for i, arg := range args {
+		values[i] = arg
+	}
+	return b.Construct(values...)
+}
+
+// OwnProperties returns the object that holds the builtin's static
+// members and any property a script assigns to it, creating it for
+// builtins that have none yet
+func (b *Builtin) OwnProperties() *Object {
+	if b.Properties == nil {
+		b.Properties = NewObject()
+	}
+	return b.Properties
+}
+
+// PreventExtensions, Seal and Freeze lock the builtin's static members, so
+// that Object.freeze(fn) covers the properties a function carries
+func (b *Builtin) PreventExtensions() {
+	b.OwnProperties().PreventExtensions()
+}
+
+func (b *Builtin) Seal() {
+	b.OwnProperties().Seal()
+}
+
+func (b *Builtin) Freeze() {
+	b.OwnProperties().Freeze()
+}
+
+func (b *Builtin) IsExtensible() bool {
+	return b.Properties == nil || b.Properties.IsExtensible()
 }
 
+func (b *Builtin) IsSealed() bool {
+	return b.Properties != nil && b.Properties.IsSealed()
+}
+
+func (b *Builtin) IsFrozen() bool {
+	return b.Properties != nil && b.Properties.IsFrozen()
+}
+
+// Value is any JavaScript value. Primitives are plain Go types (nil, Null,
+// bool, float64, string and *Symbol), which can't carry methods, so Value
+// stays an empty interface; objects are the ObjectValue types, and TypeOf
+// rejects everything else (see Type).
 type Value interface{}
 
+// NullValue is the type of the JavaScript null value.
+// Go's nil stands for undefined, so null needs a value of its own.
+type NullValue struct{}
+
+// Null is the value of the null literal
+var Null = NullValue{}
+
+func (NullValue) String() string {
+	return "null"
+}
+
+func (NullValue) MarshalJSON() ([]byte, error) {
+	return []byte("null"), nil
+}
+
+// IsNullish reports whether a value is null or undefined
+func IsNullish(val Value) bool {
+	return val == nil || val == Null
+}
+
 type ReturnValue struct {
 	Value Value
 }
 
-type Object map[string]Value
-
 type Array []Value
 
 type ArrayLike interface {
 	GetElements() Array
 }
+
+// Iterable is implemented by built-in collections like Map and Set, so that
+// IterableToList (and with it Array.from, Promise.all and the collection
+// constructors) can walk them without looking up [Symbol.iterator]
+type Iterable interface {
+	IterationValues() []Value
+}
+
+// Callable is implemented by every value that can be invoked from script code
+// (user defined functions and builtins). It lets packages that can't import the
+// evaluator, like internal itself, call getters, setters and callbacks.
+type Callable interface {
+	Call(this Value, args ...Value) Value
+}
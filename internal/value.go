@@ -0,0 +1,296 @@
+package internal
+
+import (
+	"fmt"
+	"math"
+)
+
+// Type is the language type of a runtime value, as the specification
+// defines it. Every value the runtime produces has exactly one of them:
+//
+//	TypeUndefined  nil
+//	TypeNull       Null
+//	TypeBoolean    bool
+//	TypeNumber     float64
+//	TypeString     string
+//	TypeSymbol     *Symbol
+//	TypeObject     an ObjectValue: *Object, arrays, functions, builtins,
+//	               dates, regular expressions, collections and promises
+//
+// Builtins must only hand these Go types to script code. Anything else
+// (a Go map, a slice, an int) is not a value and has to be converted first,
+// e.g. into an *Object, so that every operator and builtin sees the same
+// representation. TypeOf panics on such values instead of guessing.
+type Type int
+
+const (
+	TypeUndefined Type = iota
+	TypeNull
+	TypeBoolean
+	TypeNumber
+	TypeString
+	TypeSymbol
+	TypeObject
+)
+
+// TypeOf returns the language type of a value
+//
+// Examples:
+//
+//	TypeOf(nil)     → TypeUndefined
+//	TypeOf(1.0)     → TypeNumber
+//	TypeOf(Null)    → TypeNull
+//	TypeOf(&Object) → TypeObject
+//	TypeOf(1)       → panics: an int is not a value
+func TypeOf(val Value) Type {
+	switch val.(type) {
+	case nil:
+		return TypeUndefined
+	case NullValue:
+		return TypeNull
+	case bool:
+		return TypeBoolean
+	case float64:
+		return TypeNumber
+	case string:
+		return TypeString
+	case *Symbol:
+		return TypeSymbol
+	case ObjectValue:
+		return TypeObject
+	}
+	panic(fmt.Sprintf("%T is not a JavaScript value", val))
+}
+
+// ObjectValue is implemented by the Go types that represent JavaScript
+// objects. Its method is unexported, so a type can only implement it by
+// embedding ObjectKind: the set of object types is closed to the ones the
+// runtime defines.
+type ObjectValue interface {
+	objectValue()
+}
+
+// ObjectKind marks the type that embeds it as a JavaScript object
+//
+// Example:
+//
+//	type Date struct {
+//		internal.ObjectKind
+//		Time time.Time
+//	}
+type ObjectKind struct{}
+
+func (ObjectKind) objectValue() {}
+
+func (k *ObjectKind) referent() *ObjectKind { return k }
+
+// Referent returns the ObjectKind embedded in an object. Every object type
+// embeds it first, so its address stands for the object itself: weak.Make
+// and runtime.AddCleanup can track an object through it without knowing
+// the object's Go type. ok is false for primitives.
+func Referent(val Value) (kind *ObjectKind, ok bool) {
+	r, ok := val.(interface{ referent() *ObjectKind })
+	if !ok {
+		return nil, false
+	}
+	return r.referent(), true
+}
+
+// IsPrimitive reports whether a value is not an object
+func IsPrimitive(val Value) bool {
+	return TypeOf(val) != TypeObject
+}
+
+// PrimitiveConverter is implemented by the built-in object types whose
+// conversion to a primitive is fixed, like dates and arrays, so that
+// ToPrimitive handles them without knowing their packages.
+// The hint is "number", "string" or "default".
+type PrimitiveConverter interface {
+	ToPrimitive(hint string) Value
+}
+
+// ToPrimitive converts an object to a primitive value; primitives are
+// returned as they are. The hint says what the caller prefers: "number",
+// "string" or "default" (used by +).
+//
+// Steps for an *Object:
+//  1. If it has a [Symbol.toPrimitive] method, use its result, which must
+//     be a primitive
+//  2. Otherwise try valueOf() then toString() (toString() first for "string")
+//  3. Otherwise an object with [Symbol.toStringTag] becomes "[object Tag]"
+//  4. Otherwise it becomes the string Inspect writes, so that plain objects
+//     concatenated with strings read as {key: value}
+//
+// Built-in objects implement PrimitiveConverter; other array-likes are
+// joined with commas like arrays.
+//
+// Examples:
+//
+//	var money = { [Symbol.toPrimitive](hint) { return hint == "number" ? 42 : "$42"; } };
+//	money * 2    → 84
+//	"" + money   → "$42"
+//	"" + {a: 1}  → "{a: 1}"
+//	"" + [1, 2]  → "1,2"
+func ToPrimitive(val Value, hint string) Value {
+	switch v := val.(type) {
+	case PrimitiveConverter:
+		return v.ToPrimitive(hint)
+	case *Object:
+		return objectToPrimitive(v, hint)
+	case ArrayLike:
+		return joinElements(v.GetElements())
+	}
+	if IsPrimitive(val) {
+		return val
+	}
+	return Inspect(val)
+}
+
+func objectToPrimitive(obj *Object, hint string) Value {
+	if exotic, ok := obj.Get(SymbolToPrimitive).(Callable); ok {
+		result := exotic.Call(obj, hint)
+		if !IsPrimitive(result) {
+			ThrowError("TypeError", "Cannot convert object to primitive value")
+		}
+		return result
+	}
+
+	methods := []string{"valueOf", "toString"}
+	if hint == "string" {
+		methods = []string{"toString", "valueOf"}
+	}
+	for _, name := range methods {
+		method, ok := obj.Get(name).(Callable)
+		if !ok {
+			continue
+		}
+		if result := method.Call(obj); IsPrimitive(result) {
+			return result
+		}
+	}
+
+	if tag, ok := obj.Get(SymbolToStringTag).(string); ok {
+		return "[object " + tag + "]"
+	}
+	return Inspect(obj)
+}
+
+// joinElements writes array-likes that don't convert themselves the way
+// Array.prototype.join does
+func joinElements(elements Array) string {
+	result := ""
+	for i, elem := range elements {
+		if i > 0 {
+			result += ","
+		}
+		if !IsNullish(elem) {
+			result += ToString(elem)
+		}
+	}
+	return result
+}
+
+// ToString converts a value to a string the way String(value) and
+// template literals do. Objects are converted with ToPrimitive first, which
+// may run their toString method; symbols can't be converted implicitly.
+//
+// Examples:
+//
+//	ToString(nil)      → "undefined"
+//	ToString(Null)     → "null"
+//	ToString(1e21)     → "1e+21"
+//	ToString([1, [2]]) → "1,2"
+//	ToString({a: 1})   → "{a: 1}"
+//	ToString(Symbol()) → throws TypeError
+func ToString(val Value) string {
+	switch v := val.(type) {
+	case nil:
+		return "undefined"
+	case NullValue:
+		return "null"
+	case bool:
+		if v {
+			return "true"
+		}
+		return "false"
+	case float64:
+		return FormatNumber(v)
+	case string:
+		return v
+	case *Symbol:
+		ThrowError("TypeError", "Cannot convert a Symbol value to a string")
+	}
+	return ToString(ToPrimitive(val, "string"))
+}
+
+// ToNumber converts a value to a number the way Number(value) does.
+// Objects are converted with ToPrimitive first.
+//
+// Examples:
+//
+//	ToNumber(" 42 ")     → 42
+//	ToNumber("0x1F")     → 31
+//	ToNumber("")         → 0
+//	ToNumber("12px")     → NaN
+//	ToNumber(true)       → 1
+//	ToNumber(Null)       → 0
+//	ToNumber(nil)        → NaN (undefined)
+//	ToNumber([5])        → 5
+//	ToNumber(new Date(0)) → 0
+func ToNumber(val Value) float64 {
+	switch v := val.(type) {
+	case nil:
+		return math.NaN()
+	case NullValue:
+		return 0
+	case bool:
+		if v {
+			return 1
+		}
+		return 0
+	case float64:
+		return v
+	case string:
+		return StringToNumber(v)
+	case *Symbol:
+		ThrowError("TypeError", "Cannot convert a Symbol value to a number")
+	}
+	return ToNumber(ToPrimitive(val, "number"))
+}
+
+// ToBoolean reports whether a value is truthy: false, 0, NaN, "", null and
+// undefined are falsy, everything else (every object included) is truthy
+func ToBoolean(val Value) bool {
+	switch v := val.(type) {
+	case nil, NullValue:
+		return false
+	case bool:
+		return v
+	case float64:
+		return v != 0 && !math.IsNaN(v)
+	case string:
+		return v != ""
+	default:
+		return true
+	}
+}
+
+// ToIntegerOrInfinity converts a value to a whole number for index and
+// count arguments: NaN (and so undefined) becomes 0, fractions are
+// truncated toward zero and infinities are kept
+//
+// Examples: 2.7 → 2, -2.7 → -2, "3" → 3, nil → 0
+func ToIntegerOrInfinity(val Value) float64 {
+	num := ToNumber(val)
+	if math.IsNaN(num) {
+		return 0
+	}
+	return math.Trunc(num)
+}
+
+// StrictEquals compares two values without converting them: primitives of
+// the same type are compared by value (NaN equals nothing), objects and
+// symbols by identity
+func StrictEquals(a, b Value) bool {
+	return TypeOf(a) == TypeOf(b) && a == b
+}
@@ -0,0 +1,208 @@
+package internal
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTypeOf(t *testing.T) {
+	tests := []struct {
+		input    Value
+		expected Type
+	}{
+		{nil, TypeUndefined},
+		{Null, TypeNull},
+		{true, TypeBoolean},
+		{1.5, TypeNumber},
+		{"", TypeString},
+		{NewSymbol("id"), TypeSymbol},
+		{NewObject(), TypeObject},
+		{MockArrayLike{}, TypeObject},
+		{&Builtin{Name: "f"}, TypeObject},
+	}
+
+	for _, tt := range tests {
+		if result := TypeOf(tt.input); result != tt.expected {
+			t.Errorf("TypeOf(%v) = %v, expected %v", tt.input, result, tt.expected)
+		}
+	}
+
+	// Go values that aren't JavaScript values are rejected, not taken for objects
+	for _, input := range []Value{1, []Value{}, map[string]interface{}{}, struct{}{}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("TypeOf(%#v) should panic", input)
+				}
+			}()
+			TypeOf(input)
+		}()
+	}
+}
+
+func TestToPrimitive(t *testing.T) {
+	withValueOf := NewObject()
+	withValueOf.Set("valueOf", recordingFunc{fn: func(this Value, args ...Value) Value { return 42.0 }})
+	withValueOf.Set("toString", recordingFunc{fn: func(this Value, args ...Value) Value { return "forty-two" }})
+
+	withHint := NewObject()
+	withHint.Set(SymbolToPrimitive, recordingFunc{fn: func(this Value, args ...Value) Value { return args[0] }})
+
+	tagged := NewObject()
+	tagged.Set(SymbolToStringTag, "Thing")
+
+	plain := NewObject()
+	plain.Set("a", 1.0)
+
+	tests := []struct {
+		input    Value
+		hint     string
+		expected Value
+	}{
+		{"text", "number", "text"},
+		{nil, "string", nil},
+		{withValueOf, "number", 42.0},
+		{withValueOf, "default", 42.0},
+		{withValueOf, "string", "forty-two"},
+		{withHint, "number", "number"},
+		{withHint, "default", "default"},
+		{tagged, "string", "[object Thing]"},
+		{plain, "default", "{a: 1}"},
+		{MockArrayLike{Elements: Array{1.0, nil, Null, MockArrayLike{Elements: Array{2.0, 3.0}}}}, "number", "1,,,2,3"},
+		{&Builtin{Name: "max"}, "string", "function max() { [native code] }"},
+	}
+
+	for _, tt := range tests {
+		if result := ToPrimitive(tt.input, tt.hint); result != tt.expected {
+			t.Errorf("ToPrimitive(%v, %q) = %v, expected %v", tt.input, tt.hint, result, tt.expected)
+		}
+	}
+}
+
+func TestToPrimitiveRejectsObjects(t *testing.T) {
+	obj := NewObject()
+	obj.Set(SymbolToPrimitive, recordingFunc{fn: func(this Value, args ...Value) Value { return NewObject() }})
+
+	exception := Catch(func() { ToPrimitive(obj, "default") })
+	if exception == nil || !IsError(exception.Value) || ErrorString(exception.Value) != "TypeError: Cannot convert object to primitive value" {
+		t.Errorf("Expected a TypeError, got %v", exception)
+	}
+}
+
+func TestToString(t *testing.T) {
+	tests := []struct {
+		input    Value
+		expected string
+	}{
+		{nil, "undefined"},
+		{Null, "null"},
+		{true, "true"},
+		{1e21, "1e+21"},
+		{-0.0, "0"},
+		{"text", "text"},
+		{MockArrayLike{Elements: Array{1.0, "a"}}, "1,a"},
+		{MockArrayLike{}, ""},
+	}
+
+	for _, tt := range tests {
+		if result := ToString(tt.input); result != tt.expected {
+			t.Errorf("ToString(%v) = %q, expected %q", tt.input, result, tt.expected)
+		}
+	}
+
+	exception := Catch(func() { ToString(NewSymbol("id")) })
+	if exception == nil || !IsError(exception.Value) || ErrorString(exception.Value) != "TypeError: Cannot convert a Symbol value to a string" {
+		t.Errorf("Expected converting a symbol to throw, got %v", exception)
+	}
+}
+
+func TestToNumberObjects(t *testing.T) {
+	withValueOf := NewObject()
+	withValueOf.Set("valueOf", recordingFunc{fn: func(this Value, args ...Value) Value { return "7" }})
+
+	tests := []struct {
+		input    Value
+		expected float64
+	}{
+		{MockArrayLike{}, 0},
+		{MockArrayLike{Elements: Array{"5"}}, 5},
+		{withValueOf, 7},
+	}
+
+	for _, tt := range tests {
+		if result := ToNumber(tt.input); result != tt.expected {
+			t.Errorf("ToNumber(%v) = %v, expected %v", tt.input, result, tt.expected)
+		}
+	}
+
+	for _, input := range []Value{nil, NewObject(), MockArrayLike{Elements: Array{1.0, 2.0}}} {
+		if result := ToNumber(input); !math.IsNaN(result) {
+			t.Errorf("ToNumber(%v) = %v, expected NaN", input, result)
+		}
+	}
+}
+
+func TestToIntegerOrInfinity(t *testing.T) {
+	tests := []struct {
+		input    Value
+		expected float64
+	}{
+		{2.7, 2},
+		{-2.7, -2},
+		{"3", 3},
+		{nil, 0},
+		{"abc", 0},
+		{math.Inf(1), math.Inf(1)},
+	}
+
+	for _, tt := range tests {
+		if result := ToIntegerOrInfinity(tt.input); result != tt.expected {
+			t.Errorf("ToIntegerOrInfinity(%v) = %v, expected %v", tt.input, result, tt.expected)
+		}
+	}
+}
+
+func TestToPropertyKeyConversions(t *testing.T) {
+	tests := []struct {
+		input    Value
+		expected PropertyKey
+	}{
+		{1.0, "1"},
+		{nil, "undefined"},
+		{Null, "null"},
+		{MockArrayLike{Elements: Array{1.0, 2.0}}, "1,2"},
+		{SymbolIterator, SymbolIterator},
+	}
+
+	for _, tt := range tests {
+		if result := ToPropertyKey(tt.input); result != tt.expected {
+			t.Errorf("ToPropertyKey(%v) = %v, expected %v", tt.input, result, tt.expected)
+		}
+	}
+}
+
+func TestStrictEquals(t *testing.T) {
+	obj := NewObject()
+	sym := NewSymbol("id")
+
+	tests := []struct {
+		a, b     Value
+		expected bool
+	}{
+		{1.0, 1.0, true},
+		{math.NaN(), math.NaN(), false},
+		{"1", 1.0, false},
+		{nil, Null, false},
+		{Null, Null, true},
+		{obj, obj, true},
+		{obj, NewObject(), false},
+		{sym, sym, true},
+		{sym, NewSymbol("id"), false},
+	}
+
+	for _, tt := range tests {
+		if result := StrictEquals(tt.a, tt.b); result != tt.expected {
+			t.Errorf("StrictEquals(%v, %v) = %v, expected %v", tt.a, tt.b, result, tt.expected)
+		}
+	}
+}
@@ -2,18 +2,23 @@ package lexer
 
 import (
 	"unicode"
+	"unicode/utf8"
 
 	"go-script/token"
 )
 
 type Lexer struct {
-	input    string // The source code
-	position int    // Current position in input (points to current char)
-	ch       byte   // Current character under examination
+	input     string         // The source code
+	position  int            // Current position in input (points to current char)
+	ch        byte           // Current character under examination
+	prev      token.Type     // Type of the last token returned, see regexAllowed
+	line      int            // Line of the current character, from 1
+	lineStart int            // Offset in input where that line starts
+	start     token.Position // Where the token being read starts
 }
 
 func New(input string) *Lexer {
-	l := &Lexer{input: input}
+	l := &Lexer{input: input, line: 1}
 	l.readChar() // Initialize, read the first character
 	return l
 }
@@ -29,6 +34,10 @@ func New(input string) *Lexer {
 //	After readChar(): position=2, ch='r'
 //	After readChar(): position=3, ch=0 (EOF)
 func (l *Lexer) readChar() {
+	if l.ch == '\n' {
+		l.line++
+		l.lineStart = l.position
+	}
 	if l.position >= len(l.input) {
 		l.ch = 0 // 0 represents EOF (end of file)
 	} else {
@@ -52,6 +61,14 @@ func (l *Lexer) peekChar() byte {
 	return l.input[l.position]
 }
 
+// peekCharAt looks further ahead than peekChar: offset 0 is the same as peekChar()
+func (l *Lexer) peekCharAt(offset int) byte {
+	if l.position+offset >= len(l.input) {
+		return 0
+	}
+	return l.input[l.position+offset]
+}
+
 // NextToken reads the next token from the input and returns it.
 // This is the main method of the lexer - it's called repeatedly to get all tokens.
 //
@@ -72,20 +89,33 @@ func (l *Lexer) peekChar() byte {
 //	  {SEMICOLON, ";"}
 //	]
 func (l *Lexer) NextToken() token.Token {
+	tok := l.readToken()
+	tok.Pos = l.start
+	l.prev = tok.Type
+	return tok
+}
+
+// readToken reads the token starting at the current character
+func (l *Lexer) readToken() token.Token {
 	var tok token.Token
 
 	l.skipWhitespace()
+	l.start = l.currentPosition()
 
 	// Examine the current character and create appropriate token
 	switch l.ch {
 	case 0: // end of the input
 		tok = token.Token{Type: token.EOF, Literal: ""}
 	case '=':
-		// Could be '=' (assignment) or '==' (equality check)
+		// Could be '=' (assignment), '==' (equality check) or '=>' (arrow)
 		if l.peekChar() == '=' {
 			ch := l.ch
 			l.readChar()
 			tok = token.Token{Type: token.EQ, Literal: string(ch) + string(l.ch)}
+		} else if l.peekChar() == '>' {
+			ch := l.ch
+			l.readChar()
+			tok = token.Token{Type: token.ARROW, Literal: string(ch) + string(l.ch)}
 		} else {
 			tok = newToken(token.ASSIGN, l.ch)
 		}
@@ -123,7 +153,12 @@ func (l *Lexer) NextToken() token.Token {
 		if l.peekChar() == '/' {
 			// It's a comment (//...), skip until end of line
 			l.skipComment()
-			return l.NextToken() // get the next real token
+			return l.readToken() // get the next real token
+		}
+		if l.regexAllowed() {
+			if literal, ok := l.readRegExp(); ok {
+				return token.Token{Type: token.REGEXP, Literal: literal}
+			}
 		}
 		tok = newToken(token.SLASH, l.ch)
 	case '(':
@@ -146,12 +181,29 @@ func (l *Lexer) NextToken() token.Token {
 		tok = newToken(token.COLON, l.ch)
 	case '.':
 		tok = newToken(token.DOT, l.ch)
+	case '?':
+		// "?." starts an optional chain, unless it's "?.5" (a ? .5 : ...)
+		if l.peekChar() == '.' && !isDigit(l.peekCharAt(1)) {
+			l.readChar()
+			tok = token.Token{Type: token.QUESTION_DOT, Literal: "?."}
+		} else {
+			tok = newToken(token.QUESTION, l.ch)
+		}
 	case '"':
 		tok.Type = token.STRING
 		tok.Literal = l.readString('"')
 	case '\'':
 		tok.Type = token.STRING
 		tok.Literal = l.readString('\'')
+	case '#':
+		// Private class member name: #count, #secret
+		if isLetter(l.peekChar()) {
+			l.readChar() // move past '#'
+			tok.Type = token.PRIVATE_IDENT
+			tok.Literal = "#" + l.readIdentifier()
+			return tok
+		}
+		tok = newToken(token.ILLEGAL, l.ch)
 	default:
 		// Not a single-character token, check for multi-character tokens
 		if isLetter(l.ch) {
@@ -173,6 +225,12 @@ func (l *Lexer) NextToken() token.Token {
 	return tok
 }
 
+// currentPosition returns the line and column of the current character
+func (l *Lexer) currentPosition() token.Position {
+	offset := min(max(l.position-1, l.lineStart), len(l.input))
+	return token.Position{Line: l.line, Column: utf8.RuneCountInString(l.input[l.lineStart:offset]) + 1}
+}
+
 func (l *Lexer) skipWhitespace() {
 	for l.ch == ' ' || l.ch == '\t' || l.ch == '\n' || l.ch == '\r' {
 		l.readChar()
@@ -240,6 +298,75 @@ func (l *Lexer) readString(quoteChar byte) string {
 	return l.input[startPos : l.position-1]
 }
 
+// regexAllowed reports whether a "/" starts a regular expression literal
+// rather than being the division operator. A value can't directly follow
+// another value, so after anything that ends an expression (a name, a
+// literal, ")" or "]") it is division, and everywhere else a regex.
+//
+// Examples:
+//
+//	a / b / c         → division twice
+//	x = /ab+c/g;      → regex after =
+//	return /\d+/.test(s) → regex after return
+//	(a + b) / 2       → division after )
+func (l *Lexer) regexAllowed() bool {
+	switch l.prev {
+	case token.IDENT, token.PRIVATE_IDENT, token.NUMBER, token.STRING, token.REGEXP,
+		token.RPAREN, token.RBRACKET, token.TRUE, token.FALSE, token.NULL, token.THIS, token.SUPER:
+		return false
+	}
+	return true
+}
+
+// readRegExp reads a regular expression literal, from the opening "/" to
+// the end of its flags. A "/" inside a character class or after a
+// backslash doesn't end the pattern. If the line or input ends first it
+// returns false and leaves the lexer on the "/", which is then read as the
+// division operator.
+//
+// Example: For input /[a/]+\/x/gi
+//
+//	Returns: "/[a/]+\/x/gi" (split into pattern and flags by the parser)
+func (l *Lexer) readRegExp() (string, bool) {
+	startPos := l.position - 1 // -1 because we're on the opening slash
+	line, lineStart := l.line, l.lineStart
+	inClass := false
+
+	unterminated := func() (string, bool) {
+		l.position = startPos
+		l.readChar()
+		l.line, l.lineStart = line, lineStart // Going back over a line break
+		return "", false
+	}
+
+	for {
+		l.readChar()
+		switch l.ch {
+		case 0, '\n', '\r':
+			return unterminated()
+		case '\\':
+			l.readChar()
+			if l.ch == 0 || l.ch == '\n' || l.ch == '\r' {
+				return unterminated()
+			}
+			continue
+		case '[':
+			inClass = true
+		case ']':
+			inClass = false
+		case '/':
+			if !inClass {
+				// Flags are letters right after the closing slash
+				l.readChar()
+				for isLetter(l.ch) || isDigit(l.ch) {
+					l.readChar()
+				}
+				return l.input[startPos : l.position-1], true
+			}
+		}
+	}
+}
+
 // Example:
 //
 //	isLetter('a') → true
@@ -542,3 +542,389 @@ func TestNextToken_Whitespace(t *testing.T) {
 		}
 	}
 }
+
+func TestNextToken_Class(t *testing.T) {
+	input := `class Dog extends Animal { #legs = 4; constructor() { super(); this.#legs; } }
+new Dog()`
+
+	tests := []struct {
+		expectedType    token.Type
+		expectedLiteral string
+	}{
+		{token.CLASS, "class"},
+		{token.IDENT, "Dog"},
+		{token.EXTENDS, "extends"},
+		{token.IDENT, "Animal"},
+		{token.LBRACE, "{"},
+		{token.PRIVATE_IDENT, "#legs"},
+		{token.ASSIGN, "="},
+		{token.NUMBER, "4"},
+		{token.SEMICOLON, ";"},
+		{token.IDENT, "constructor"},
+		{token.LPAREN, "("},
+		{token.RPAREN, ")"},
+		{token.LBRACE, "{"},
+		{token.SUPER, "super"},
+		{token.LPAREN, "("},
+		{token.RPAREN, ")"},
+		{token.SEMICOLON, ";"},
+		{token.THIS, "this"},
+		{token.DOT, "."},
+		{token.PRIVATE_IDENT, "#legs"},
+		{token.SEMICOLON, ";"},
+		{token.RBRACE, "}"},
+		{token.RBRACE, "}"},
+		{token.NEW, "new"},
+		{token.IDENT, "Dog"},
+		{token.LPAREN, "("},
+		{token.RPAREN, ")"},
+		{token.EOF, ""},
+	}
+
+	l := New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q",
+				i, tt.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q",
+				i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestNextToken_OptionalChaining(t *testing.T) {
+	input := `user?.name; arr?.[0]; fn?.(); x = null;`
+
+	tests := []struct {
+		expectedType    token.Type
+		expectedLiteral string
+	}{
+		{token.IDENT, "user"},
+		{token.QUESTION_DOT, "?."},
+		{token.IDENT, "name"},
+		{token.SEMICOLON, ";"},
+		{token.IDENT, "arr"},
+		{token.QUESTION_DOT, "?."},
+		{token.LBRACKET, "["},
+		{token.NUMBER, "0"},
+		{token.RBRACKET, "]"},
+		{token.SEMICOLON, ";"},
+		{token.IDENT, "fn"},
+		{token.QUESTION_DOT, "?."},
+		{token.LPAREN, "("},
+		{token.RPAREN, ")"},
+		{token.SEMICOLON, ";"},
+		{token.IDENT, "x"},
+		{token.ASSIGN, "="},
+		{token.NULL, "null"},
+		{token.SEMICOLON, ";"},
+		{token.EOF, ""},
+	}
+
+	l := New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q",
+				i, tt.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q",
+				i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestNextToken_Conditional(t *testing.T) {
+	input := `ok ? 1 : 2; ok?.5:1`
+
+	tests := []struct {
+		expectedType    token.Type
+		expectedLiteral string
+	}{
+		{token.IDENT, "ok"},
+		{token.QUESTION, "?"},
+		{token.NUMBER, "1"},
+		{token.COLON, ":"},
+		{token.NUMBER, "2"},
+		{token.SEMICOLON, ";"},
+		// "?." followed by a digit is a conditional, not optional chaining
+		{token.IDENT, "ok"},
+		{token.QUESTION, "?"},
+		{token.DOT, "."},
+		{token.NUMBER, "5"},
+		{token.COLON, ":"},
+		{token.NUMBER, "1"},
+		{token.EOF, ""},
+	}
+
+	l := New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q",
+				i, tt.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q",
+				i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestNextToken_SymbolOperators(t *testing.T) {
+	input := `for (let x of xs) { typeof x; x instanceof C; }`
+
+	tests := []struct {
+		expectedType    token.Type
+		expectedLiteral string
+	}{
+		{token.FOR, "for"},
+		{token.LPAREN, "("},
+		{token.LET, "let"},
+		{token.IDENT, "x"},
+		{token.IDENT, "of"},
+		{token.IDENT, "xs"},
+		{token.RPAREN, ")"},
+		{token.LBRACE, "{"},
+		{token.TYPEOF, "typeof"},
+		{token.IDENT, "x"},
+		{token.SEMICOLON, ";"},
+		{token.IDENT, "x"},
+		{token.INSTANCEOF, "instanceof"},
+		{token.IDENT, "C"},
+		{token.SEMICOLON, ";"},
+		{token.RBRACE, "}"},
+		{token.EOF, ""},
+	}
+
+	l := New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q",
+				i, tt.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q",
+				i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestNextToken_AsyncArrow(t *testing.T) {
+	input := `var f = async (x) => await x; y => y == 1;`
+
+	tests := []struct {
+		expectedType    token.Type
+		expectedLiteral string
+	}{
+		{token.VAR, "var"},
+		{token.IDENT, "f"},
+		{token.ASSIGN, "="},
+		{token.IDENT, "async"},
+		{token.LPAREN, "("},
+		{token.IDENT, "x"},
+		{token.RPAREN, ")"},
+		{token.ARROW, "=>"},
+		{token.AWAIT, "await"},
+		{token.IDENT, "x"},
+		{token.SEMICOLON, ";"},
+		{token.IDENT, "y"},
+		{token.ARROW, "=>"},
+		{token.IDENT, "y"},
+		{token.EQ, "=="},
+		{token.NUMBER, "1"},
+		{token.SEMICOLON, ";"},
+		{token.EOF, ""},
+	}
+
+	l := New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q",
+				i, tt.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q",
+				i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestNextToken_Modules(t *testing.T) {
+	input := `import def, { a as b } from "./mod.js"; export * from './all.js';`
+
+	tests := []struct {
+		expectedType    token.Type
+		expectedLiteral string
+	}{
+		{token.IMPORT, "import"},
+		{token.IDENT, "def"},
+		{token.COMMA, ","},
+		{token.LBRACE, "{"},
+		{token.IDENT, "a"},
+		{token.IDENT, "as"},
+		{token.IDENT, "b"},
+		{token.RBRACE, "}"},
+		{token.IDENT, "from"},
+		{token.STRING, "./mod.js"},
+		{token.SEMICOLON, ";"},
+		{token.EXPORT, "export"},
+		{token.STAR, "*"},
+		{token.IDENT, "from"},
+		{token.STRING, "./all.js"},
+		{token.SEMICOLON, ";"},
+		{token.EOF, ""},
+	}
+
+	l := New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q",
+				i, tt.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q",
+				i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestNextToken_RegExp(t *testing.T) {
+	input := `var re = /a\/b[/x]+/gi; a / b / c; x = (n) / 2; f(/=+/); return /\d/.test(s); [1] / 2`
+
+	tests := []struct {
+		expectedType    token.Type
+		expectedLiteral string
+	}{
+		{token.VAR, "var"},
+		{token.IDENT, "re"},
+		{token.ASSIGN, "="},
+		{token.REGEXP, `/a\/b[/x]+/gi`},
+		{token.SEMICOLON, ";"},
+		{token.IDENT, "a"},
+		{token.SLASH, "/"},
+		{token.IDENT, "b"},
+		{token.SLASH, "/"},
+		{token.IDENT, "c"},
+		{token.SEMICOLON, ";"},
+		{token.IDENT, "x"},
+		{token.ASSIGN, "="},
+		{token.LPAREN, "("},
+		{token.IDENT, "n"},
+		{token.RPAREN, ")"},
+		{token.SLASH, "/"},
+		{token.NUMBER, "2"},
+		{token.SEMICOLON, ";"},
+		{token.IDENT, "f"},
+		{token.LPAREN, "("},
+		{token.REGEXP, "/=+/"},
+		{token.RPAREN, ")"},
+		{token.SEMICOLON, ";"},
+		{token.RETURN, "return"},
+		{token.REGEXP, `/\d/`},
+		{token.DOT, "."},
+		{token.IDENT, "test"},
+		{token.LPAREN, "("},
+		{token.IDENT, "s"},
+		{token.RPAREN, ")"},
+		{token.SEMICOLON, ";"},
+		{token.LBRACKET, "["},
+		{token.NUMBER, "1"},
+		{token.RBRACKET, "]"},
+		{token.SLASH, "/"},
+		{token.NUMBER, "2"},
+		{token.EOF, ""},
+	}
+
+	l := New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q",
+				i, tt.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q",
+				i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestNextToken_UnterminatedRegExp(t *testing.T) {
+	// Without a closing slash on the same line it's not a regex, so the
+	// "/" is division and the parser reports the error
+	for _, input := range []string{"/abc", "/ab\nc/", `/abc\`} {
+		l := New(input)
+		tok := l.NextToken()
+		if tok.Type != token.SLASH {
+			t.Errorf("For input %q: expected /, got %q (%q)", input, tok.Type, tok.Literal)
+		}
+		if tok = l.NextToken(); tok.Type != token.IDENT {
+			t.Errorf("For input %q: expected lexing to continue after the slash, got %q (%q)", input, tok.Type, tok.Literal)
+		}
+	}
+}
+
+func TestNextToken_Positions(t *testing.T) {
+	input := "var x = 1;\n  // note\n\tprint(\"é\", y);\n/ab\nc/"
+
+	tests := []struct {
+		literal string
+		line    int
+		column  int
+	}{
+		{"var", 1, 1},
+		{"x", 1, 5},
+		{"=", 1, 7},
+		{"1", 1, 9},
+		{";", 1, 10},
+		{"print", 3, 2},
+		{"(", 3, 7},
+		{"é", 3, 8},
+		{",", 3, 11},
+		{"y", 3, 13},
+		{")", 3, 14},
+		{";", 3, 15},
+		{"/", 4, 1},
+		{"ab", 4, 2},
+		{"c", 5, 1},
+		{"/", 5, 2},
+		{"", 5, 3},
+	}
+
+	l := New(input)
+	for i, tt := range tests {
+		tok := l.NextToken()
+		if tok.Literal != tt.literal || tok.Pos.Line != tt.line || tok.Pos.Column != tt.column {
+			t.Errorf("tests[%d] - expected %q at %d:%d, got %q at %s", i, tt.literal, tt.line, tt.column, tok.Literal, tok.Pos)
+		}
+	}
+}
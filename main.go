@@ -8,11 +8,18 @@ import (
 
 	"go-script/environment"
 	"go-script/evaluator"
+	"go-script/evaluator/builtins/console"
+	"go-script/internal"
+	"go-script/module"
 	"go-script/parser"
 )
 
+// runCode parses and runs a REPL line as a script, reporting parser errors
+// and uncaught exceptions. Like Node, it only returns once the program's
+// event loop is empty: no timers, microtasks or fetch requests are left.
+// Modules can be loaded with import(), relative to the working directory.
 func runCode(code string) bool {
-	env := environment.NewGlobalEnvironment()
+	env := newEnvironment()
 
 	p := parser.New(code)
 	program := p.ParseProgram()
@@ -25,23 +32,51 @@ func runCode(code string) bool {
 		return false
 	}
 
-	evaluator.Eval(program, env)
-	return true
+	return report(evaluator.Eval(program, env))
 }
 
+// runFile runs a file as an ES module, so it can import other files.
+// Like runCode, it returns once the event loop is empty. A file that can't
+// be read is reported before the module loader sees it, so the error names
+// the file rather than an import.
 func runFile(filename string) {
-	content, err := os.ReadFile(filename)
-	if err != nil {
+	if _, err := os.ReadFile(filename); err != nil {
 		fmt.Fprintf(os.Stderr, "Error reading file '%s': %v\n", filename, err)
 		os.Exit(1)
 	}
 
-	code := string(content)
-	if !runCode(code) {
+	env := newEnvironment()
+
+	if !report(evaluator.EvalModule(filename, env)) {
 		os.Exit(1)
 	}
 }
 
+// logLevel is the minimum level console messages are written at, set with
+// --log-level
+var logLevel = console.LevelDebug
+
+// newEnvironment creates the global scope for a run: modules load from the
+// file system and console writes to stdout and stderr from logLevel up
+func newEnvironment() *environment.Environment {
+	env := environment.NewGlobalEnvironment()
+	evaluator.SetModuleLoader(env, module.FileLoader{})
+
+	c := console.New(os.Stdout, os.Stderr)
+	c.Level = logLevel
+	evaluator.SetConsole(env, c)
+	return env
+}
+
+// report prints an uncaught exception, returning false if there was one
+func report(result evaluator.Value) bool {
+	if exception, ok := result.(*internal.Exception); ok {
+		fmt.Fprintln(os.Stderr, exception.Error())
+		return false
+	}
+	return true
+}
+
 func runREPL() {
 	fmt.Println("╔═══════════════════════════════════════════════════════╗")
 	fmt.Println("║   go-script REPL - JavaScript Interpreter             ║")
@@ -85,6 +120,9 @@ func printUsage() {
 	fmt.Println("  go run main.go         # Start REPL")
 	fmt.Println("  go run main.go test.js # Run with go run")
 	fmt.Println()
+	fmt.Println("Options:")
+	fmt.Println("  --log-level=LEVEL      # Minimum console level: debug, info, warn, error or silent")
+	fmt.Println()
 }
 
 func main() {
@@ -100,6 +138,21 @@ func main() {
 		return
 	}
 
+	if name, ok := strings.CutPrefix(args[0], "--log-level="); ok {
+		level, err := console.ParseLevel(name)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		logLevel = level
+		args = args[1:]
+	}
+
+	if len(args) == 0 {
+		runREPL()
+		return
+	}
+
 	filename := args[0]
 	runFile(filename)
 }
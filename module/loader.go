@@ -0,0 +1,196 @@
+package module
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ModuleLoader finds and reads the source code of modules.
+// The interpreter never touches the file system itself: every import goes
+// through a loader, so embedders decide where modules come from.
+//
+// Resolve turns the specifier written in an import into a key that
+// identifies one module; the same key always means the same module instance.
+// referrer is the key of the importing module, or "" for the entry point
+// and for import() calls made by plain scripts.
+//
+// Example: for "import { add } from './math.js'" inside lib/main.js
+//
+//	key, err := loader.Resolve("./math.js", "lib/main.js") → "lib/math.js"
+//	source, err := loader.Load("lib/math.js")             → "export var add = ..."
+type ModuleLoader interface {
+	Resolve(specifier string, referrer string) (string, error)
+	Load(key string) (string, error)
+}
+
+// FileLoader loads modules from the operating system's file system.
+// Keys are absolute paths. Specifiers are resolved relative to the importing
+// file; the entry point and plain scripts resolve relative to Dir, or to
+// the working directory when Dir is empty.
+//
+// Example:
+//
+//	loader := module.FileLoader{}
+//	key, _ := loader.Resolve("app.js", "")              → "/home/me/project/app.js"
+//	key, _ = loader.Resolve("./util.js", key)           → "/home/me/project/util.js"
+type FileLoader struct {
+	Dir string
+}
+
+func (l FileLoader) Resolve(specifier string, referrer string) (string, error) {
+	var base string
+	switch {
+	case filepath.IsAbs(specifier):
+		base = specifier
+	case referrer != "":
+		base = filepath.Join(filepath.Dir(referrer), specifier)
+	default:
+		dir := l.Dir
+		if dir == "" {
+			wd, err := os.Getwd()
+			if err != nil {
+				return "", err
+			}
+			dir = wd
+		}
+		base = filepath.Join(dir, specifier)
+	}
+
+	abs, err := filepath.Abs(base)
+	if err != nil {
+		return "", err
+	}
+
+	return findModule(specifier, referrer, abs, func(name string) bool {
+		info, err := os.Stat(name)
+		return err == nil && info.Mode().IsRegular()
+	}, filepath.Join)
+}
+
+func (l FileLoader) Load(key string) (string, error) {
+	content, err := os.ReadFile(key)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+// FSLoader loads modules from an fs.FS, such as an embed.FS or os.DirFS.
+// Keys are slash-separated paths inside the file system. Specifiers starting
+// with "./" or "../" are relative to the importing module; any other
+// specifier is relative to the root.
+//
+// Example:
+//
+//	//go:embed scripts
+//	var scripts embed.FS
+//
+//	loader := module.FSLoader{FS: scripts}
+//	key, _ := loader.Resolve("scripts/main.js", "")  → "scripts/main.js"
+//	key, _ = loader.Resolve("./lib.js", key)         → "scripts/lib.js"
+type FSLoader struct {
+	FS fs.FS
+}
+
+func (l FSLoader) Resolve(specifier string, referrer string) (string, error) {
+	return resolvePath(specifier, referrer, func(name string) bool {
+		info, err := fs.Stat(l.FS, name)
+		return err == nil && info.Mode().IsRegular()
+	})
+}
+
+func (l FSLoader) Load(key string) (string, error) {
+	content, err := fs.ReadFile(l.FS, key)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+// MapLoader serves modules from memory, keyed by slash-separated path.
+// Specifiers resolve the same way as for FSLoader.
+//
+// Example:
+//
+//	loader := module.MapLoader{
+//	    "main.js": `import { double } from "./lib/math.js"; print(double(2));`,
+//	    "lib/math.js": `export var double = (x) => x * 2;`,
+//	}
+type MapLoader map[string]string
+
+func (l MapLoader) Resolve(specifier string, referrer string) (string, error) {
+	return resolvePath(specifier, referrer, func(name string) bool {
+		_, ok := l[name]
+		return ok
+	})
+}
+
+func (l MapLoader) Load(key string) (string, error) {
+	source, ok := l[key]
+	if !ok {
+		return "", fmt.Errorf("module %q not found", key)
+	}
+	return source, nil
+}
+
+// resolvePath resolves a specifier to a slash-separated key relative to
+// the root of a virtual file system
+//
+// Examples (referrer "lib/main.js"):
+//
+//	"./math.js"  → "lib/math.js"
+//	"../app.js"  → "app.js"
+//	"util/x.js"  → "util/x.js" (relative to the root)
+//	"/util/x.js" → "util/x.js"
+//	"./config"   → "lib/config.json", if there is no lib/config or lib/config.js
+//	"./helpers"  → "lib/helpers/index.js"
+func resolvePath(specifier string, referrer string, exists func(string) bool) (string, error) {
+	var name string
+	if isRelative(specifier) {
+		name = path.Join(path.Dir(referrer), specifier)
+	} else {
+		name = path.Clean(strings.TrimPrefix(specifier, "/"))
+	}
+
+	if !fs.ValidPath(name) || name == "." {
+		return "", notFound(specifier, referrer)
+	}
+
+	return findModule(specifier, referrer, name, exists, path.Join)
+}
+
+// findModule returns the first of these that exists, so extensions and
+// index files may be left out of specifiers:
+//
+//	name, name.js, name.json, name/index.js
+//
+// join builds the index path with the separator the keys use.
+func findModule(specifier string, referrer string, name string, exists func(string) bool, join func(...string) string) (string, error) {
+	candidates := []string{name, name + ".js", name + ".json", join(name, "index.js")}
+
+	for _, candidate := range candidates {
+		if exists(candidate) {
+			return candidate, nil
+		}
+	}
+	return "", notFound(specifier, referrer)
+}
+
+func isRelative(specifier string) bool {
+	return strings.HasPrefix(specifier, "./") || strings.HasPrefix(specifier, "../")
+}
+
+// ErrNotFound is wrapped by the errors loaders return for unknown modules
+var ErrNotFound = errors.New("cannot find module")
+
+func notFound(specifier string, referrer string) error {
+	if referrer == "" {
+		return fmt.Errorf("%w '%s'", ErrNotFound, specifier)
+	}
+	return fmt.Errorf("%w '%s' imported from %s", ErrNotFound, specifier, referrer)
+}
@@ -0,0 +1,152 @@
+package module
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+func TestMapLoaderResolve(t *testing.T) {
+	loader := MapLoader{
+		"main.js":       "",
+		"lib/math.js":   "",
+		"lib/util.js":   "",
+		"data":          "",
+		"config.json":   "",
+		"util/index.js": "",
+	}
+
+	tests := []struct {
+		specifier string
+		referrer  string
+		expected  string
+	}{
+		{"main.js", "", "main.js"},
+		{"./main.js", "", "main.js"},
+		{"/lib/math.js", "", "lib/math.js"},
+		{"./math.js", "lib/util.js", "lib/math.js"},
+		{"../main.js", "lib/util.js", "main.js"},
+		{"lib/util.js", "lib/math.js", "lib/util.js"}, // bare specifiers start at the root
+		{"./lib/math", "main.js", "lib/math.js"},      // .js may be left out
+		{"./data", "main.js", "data"},
+		{"./config", "main.js", "config.json"},
+		{"./util", "lib/../main.js", "util/index.js"},
+		{"util", "", "util/index.js"},
+	}
+
+	for _, tt := range tests {
+		key, err := loader.Resolve(tt.specifier, tt.referrer)
+		if err != nil {
+			t.Errorf("Resolve(%q, %q): unexpected error %v", tt.specifier, tt.referrer, err)
+			continue
+		}
+		if key != tt.expected {
+			t.Errorf("Resolve(%q, %q): expected %q, got %q", tt.specifier, tt.referrer, tt.expected, key)
+		}
+	}
+}
+
+func TestMapLoaderErrors(t *testing.T) {
+	loader := MapLoader{"lib/math.js": "export var x = 1;"}
+
+	tests := []struct {
+		specifier string
+		referrer  string
+		expected  string
+	}{
+		{"./missing.js", "", "cannot find module './missing.js'"},
+		{"./missing.js", "lib/math.js", "cannot find module './missing.js' imported from lib/math.js"},
+		{"../../etc/passwd", "lib/math.js", "cannot find module '../../etc/passwd' imported from lib/math.js"},
+	}
+
+	for _, tt := range tests {
+		_, err := loader.Resolve(tt.specifier, tt.referrer)
+		if err == nil {
+			t.Errorf("Resolve(%q, %q): expected an error", tt.specifier, tt.referrer)
+			continue
+		}
+		if !errors.Is(err, ErrNotFound) || err.Error() != tt.expected {
+			t.Errorf("Resolve(%q, %q): expected %q, got %q", tt.specifier, tt.referrer, tt.expected, err)
+		}
+	}
+
+	if source, err := loader.Load("lib/math.js"); err != nil || source != "export var x = 1;" {
+		t.Errorf("Load: expected the module source, got %q (%v)", source, err)
+	}
+	if _, err := loader.Load("nope.js"); err == nil {
+		t.Errorf("Load: expected an error for an unknown key")
+	}
+}
+
+func TestFSLoader(t *testing.T) {
+	loader := FSLoader{FS: fstest.MapFS{
+		"scripts/main.js":    {Data: []byte(`import "./lib/a.js";`)},
+		"scripts/lib/a.js":   {Data: []byte(`export var a = 1;`)},
+		"scripts/lib/b.js/x": {Data: []byte(``)},
+	}}
+
+	key, err := loader.Resolve("scripts/main.js", "")
+	if err != nil || key != "scripts/main.js" {
+		t.Fatalf("expected scripts/main.js, got %q (%v)", key, err)
+	}
+
+	key, err = loader.Resolve("./lib/a", key)
+	if err != nil || key != "scripts/lib/a.js" {
+		t.Fatalf("expected scripts/lib/a.js, got %q (%v)", key, err)
+	}
+
+	source, err := loader.Load(key)
+	if err != nil || source != "export var a = 1;" {
+		t.Errorf("expected the module source, got %q (%v)", source, err)
+	}
+
+	// Directories are not modules
+	if _, err := loader.Resolve("./lib/b.js", "scripts/main.js"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected a directory not to resolve, got %v", err)
+	}
+}
+
+func TestFileLoader(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "lib"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "main.js"), []byte(`import "./lib/util.js";`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "lib", "util.js"), []byte(`export var u = 1;`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	loader := FileLoader{Dir: dir}
+
+	main, err := loader.Resolve("main.js", "")
+	if err != nil || main != filepath.Join(dir, "main.js") {
+		t.Fatalf("expected %s, got %q (%v)", filepath.Join(dir, "main.js"), main, err)
+	}
+
+	util, err := loader.Resolve("./lib/util", main)
+	if err != nil || util != filepath.Join(dir, "lib", "util.js") {
+		t.Fatalf("expected lib/util.js, got %q (%v)", util, err)
+	}
+
+	back, err := loader.Resolve("../main.js", util)
+	if err != nil || back != main {
+		t.Errorf("expected %s, got %q (%v)", main, back, err)
+	}
+
+	abs, err := loader.Resolve(util, "")
+	if err != nil || abs != util {
+		t.Errorf("expected absolute specifiers to resolve to themselves, got %q (%v)", abs, err)
+	}
+
+	if source, err := loader.Load(util); err != nil || source != "export var u = 1;" {
+		t.Errorf("expected the module source, got %q (%v)", source, err)
+	}
+
+	if _, err := loader.Resolve("./lib", main); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected a directory not to resolve, got %v", err)
+	}
+}
@@ -0,0 +1,58 @@
+// Package norm implements the Unicode normalization forms behind
+// String.prototype.normalize on top of golang.org/x/text/unicode/norm,
+// whose tables are generated from the Unicode Character Database of the
+// same version as Go's unicode package (unicode.Version).
+//
+// Normalizing decomposes every character (into its canonical parts for NFD
+// and NFC, also into its compatibility parts for NFKD and NFKC), sorts runs
+// of combining marks into canonical order, and for NFC and NFKC composes
+// the result again.
+//
+// Examples:
+//
+//	NFC.String("é") → "é"
+//	NFD.String("é")       → "é"
+//	NFKC.String("ﬁ")      → "fi"
+//	NFKD.String("①")      → "1"
+package norm
+
+import "golang.org/x/text/unicode/norm"
+
+// Form is one of the four normalization forms
+type Form int
+
+const (
+	NFC Form = iota
+	NFD
+	NFKC
+	NFKD
+)
+
+// ParseForm looks up a form by the name normalize() takes
+// Returns false for anything but "NFC", "NFD", "NFKC" and "NFKD".
+func ParseForm(name string) (Form, bool) {
+	switch name {
+	case "NFC":
+		return NFC, true
+	case "NFD":
+		return NFD, true
+	case "NFKC":
+		return NFKC, true
+	case "NFKD":
+		return NFKD, true
+	}
+	return 0, false
+}
+
+// forms maps each Form to its implementation
+var forms = [...]norm.Form{
+	NFC:  norm.NFC,
+	NFD:  norm.NFD,
+	NFKC: norm.NFKC,
+	NFKD: norm.NFKD,
+}
+
+// String returns s in normalization form f
+func (f Form) String(s string) string {
+	return forms[f].String(s)
+}
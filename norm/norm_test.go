@@ -0,0 +1,63 @@
+package norm
+
+import (
+	"golang.org/x/text/unicode/norm"
+	"testing"
+	"unicode"
+)
+
+func TestForms(t *testing.T) {
+	tests := []struct {
+		input string
+		nfc   string
+		nfd   string
+		nfkc  string
+		nfkd  string
+	}{
+		{"e\u0301", "\u00e9", "e\u0301", "\u00e9", "e\u0301"},
+		{"\u00e9", "\u00e9", "e\u0301", "\u00e9", "e\u0301"},
+		{"\u00c5", "\u00c5", "A\u030a", "\u00c5", "A\u030a"},
+		{"\u212b", "\u00c5", "A\u030a", "\u00c5", "A\u030a"},
+		{"\ufb01", "\ufb01", "\ufb01", "fi", "fi"},
+		{"\u2460", "\u2460", "\u2460", "1", "1"},
+		{"\u1e9b\u0323", "\u1e9b\u0323", "\u017f\u0323\u0307", "\u1e69", "s\u0323\u0307"},
+		{"\ud55c\uad6d\uc5b4", "\ud55c\uad6d\uc5b4", "\u1112\u1161\u11ab\u1100\u116e\u11a8\u110b\u1165", "\ud55c\uad6d\uc5b4", "\u1112\u1161\u11ab\u1100\u116e\u11a8\u110b\u1165"},
+		{"\u1100\u1161\u11a8", "\uac01", "\u1100\u1161\u11a8", "\uac01", "\u1100\u1161\u11a8"},
+		{"a\u0323\u0302", "\u1ead", "a\u0323\u0302", "\u1ead", "a\u0323\u0302"},
+		{"q\u0307\u0323", "q\u0323\u0307", "q\u0323\u0307", "q\u0323\u0307", "q\u0323\u0307"},
+		{"caf\u00e9 \u00bd", "caf\u00e9 \u00bd", "cafe\u0301 \u00bd", "caf\u00e9 1\u20442", "cafe\u0301 1\u20442"},
+		{"\u0958", "\u0915\u093c", "\u0915\u093c", "\u0915\u093c", "\u0915\u093c"},
+		{"x\u0301\u0301", "x\u0301\u0301", "x\u0301\u0301", "x\u0301\u0301", "x\u0301\u0301"},
+		{"plain ascii", "plain ascii", "plain ascii", "plain ascii", "plain ascii"},
+	}
+
+	for _, tt := range tests {
+		for _, form := range []struct {
+			form     Form
+			expected string
+		}{{NFC, tt.nfc}, {NFD, tt.nfd}, {NFKC, tt.nfkc}, {NFKD, tt.nfkd}} {
+			if result := form.form.String(tt.input); result != form.expected {
+				t.Errorf("For input %+q form %d: expected %+q, got %+q", tt.input, form.form, form.expected, result)
+			}
+		}
+	}
+}
+
+func TestParseForm(t *testing.T) {
+	for _, name := range []string{"NFC", "NFD", "NFKC", "NFKD"} {
+		if _, ok := ParseForm(name); !ok {
+			t.Errorf("Expected %s to be a valid form", name)
+		}
+	}
+	for _, name := range []string{"", "nfc", "NFX"} {
+		if _, ok := ParseForm(name); ok {
+			t.Errorf("Expected %q to be rejected", name)
+		}
+	}
+}
+
+func TestUnicodeVersion(t *testing.T) {
+	if norm.Version != unicode.Version {
+		t.Errorf("Expected normalization data for Unicode %s like the unicode package, got %s", unicode.Version, norm.Version)
+	}
+}
@@ -4,14 +4,18 @@ import (
 	"fmt"
 	"go-script/ast"
 	"go-script/lexer"
+	"go-script/regex"
 	"go-script/token"
 	"strconv"
+	"strings"
 )
 
 const (
 	_           int = iota
 	LOWEST          // Lowest precedence
+	COMMA           // a, b (sequence)
 	ASSIGN          // = (assignment, right-associative)
+	TERNARY         // cond ? a : b (right-associative)
 	EQUALS          // == or !=
 	LESSGREATER     // < or > or <= or >=
 	SUM             // + or -
@@ -21,20 +25,26 @@ const (
 )
 
 var precedences = map[token.Type]int{
+	token.COMMA:    COMMA,
 	token.ASSIGN:   ASSIGN,
+	token.QUESTION: TERNARY,
 	token.EQ:       EQUALS,
 	token.NEQ:      EQUALS,
 	token.LT:       LESSGREATER,
 	token.GT:       LESSGREATER,
 	token.LTE:      LESSGREATER,
 	token.GTE:      LESSGREATER,
-	token.PLUS:     SUM,
-	token.MINUS:    SUM,
-	token.SLASH:    PRODUCT,
-	token.STAR:     PRODUCT,
-	token.LPAREN:   CALL,
-	token.DOT:      CALL,
-	token.LBRACKET: CALL,
+
+	token.INSTANCEOF: LESSGREATER,
+	token.PLUS:       SUM,
+	token.MINUS:      SUM,
+	token.SLASH:      PRODUCT,
+	token.STAR:       PRODUCT,
+	token.LPAREN:     CALL,
+	token.DOT:        CALL,
+	token.LBRACKET:   CALL,
+
+	token.QUESTION_DOT: CALL,
 }
 
 type Parser struct {
@@ -42,6 +52,9 @@ type Parser struct {
 	currentToken token.Token  // Current token we're examining
 	peekToken    token.Token  // Next token (for lookahead)
 	errors       []string     // List of parsing errors
+	inGenerator  bool         // Parsing the body of a generator function, where yield is allowed
+	inAsync      bool         // Parsing the body of an async function, where await is allowed
+	inFunction   bool         // Parsing any function body; await is also allowed outside of them
 }
 
 // New creates a new Parser for the given input source code
@@ -130,7 +143,7 @@ func (p *Parser) ParseProgram() *ast.Program {
 
 	// Keep parsing statements until we reach EOF
 	for !p.currentTokenIs(token.EOF) {
-		stmt := p.parseStatement()
+		stmt := p.parseModuleItem()
 		if stmt != nil {
 			program.Statements = append(program.Statements, stmt)
 		}
@@ -150,8 +163,25 @@ func (p *Parser) parseStatement() ast.Statement {
 		return p.parseIfStatement()
 	case token.WHILE:
 		return p.parseWhileStatement()
+	case token.FOR:
+		return p.parseForOfStatement()
+	case token.THROW:
+		return p.parseThrowStatement()
+	case token.TRY:
+		return p.parseTryStatement()
 	case token.LBRACE:
 		return p.parseBlockStatement()
+	case token.CLASS:
+		return p.parseClassDeclaration()
+	case token.EXPORT:
+		p.errors = append(p.errors, "export declarations may only appear at the top level of a module")
+		return nil
+	case token.IMPORT:
+		if !p.peekTokenIs(token.LPAREN) {
+			p.errors = append(p.errors, "import declarations may only appear at the top level of a module")
+			return nil
+		}
+		return p.parseExpressionStatement()
 	default:
 		return p.parseExpressionStatement()
 	}
@@ -182,7 +212,7 @@ func (p *Parser) parseVarStatement() *ast.VarStatement {
 		p.nextToken() // consume identifier
 		p.nextToken() // consume =
 
-		stmt.Value = p.parseExpression(LOWEST)
+		stmt.Value = p.parseAssignmentExpression()
 	}
 
 	if p.peekTokenIs(token.SEMICOLON) {
@@ -265,6 +295,141 @@ func (p *Parser) parseIfStatement() *ast.IfStatement {
 	return stmt
 }
 
+// parseThrowStatement parses a throw statement
+//
+// Example: "throw err;" → ThrowStatement{Value: Identifier{"err"}}
+func (p *Parser) parseThrowStatement() *ast.ThrowStatement {
+	stmt := &ast.ThrowStatement{}
+
+	p.nextToken() // move past 'throw'
+
+	stmt.Value = p.parseExpression(LOWEST)
+
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	return stmt
+}
+
+// parseTryStatement parses try/catch/finally
+//
+// Syntax:
+//
+//	try { ... } catch (e) { ... }
+//	try { ... } catch { ... }
+//	try { ... } finally { ... }
+//	try { ... } catch (e) { ... } finally { ... }
+func (p *Parser) parseTryStatement() *ast.TryStatement {
+	stmt := &ast.TryStatement{}
+
+	if !p.expectPeek(token.LBRACE) {
+		return nil
+	}
+	stmt.Block = p.parseBlockStatement()
+
+	if p.peekTokenIs(token.CATCH) {
+		p.nextToken() // move to 'catch'
+
+		// The binding is optional: catch { ... }
+		if p.peekTokenIs(token.LPAREN) {
+			p.nextToken() // move to '('
+			if !p.expectPeek(token.IDENT) {
+				return nil
+			}
+			stmt.CatchParam = p.currentToken.Literal
+			if !p.expectPeek(token.RPAREN) {
+				return nil
+			}
+		}
+
+		if !p.expectPeek(token.LBRACE) {
+			return nil
+		}
+		stmt.Catch = p.parseBlockStatement()
+	}
+
+	if p.peekTokenIs(token.FINALLY) {
+		p.nextToken() // move to 'finally'
+		if !p.expectPeek(token.LBRACE) {
+			return nil
+		}
+		stmt.Finally = p.parseBlockStatement()
+	}
+
+	if stmt.Catch == nil && stmt.Finally == nil {
+		p.errors = append(p.errors, "missing catch or finally after try")
+		return nil
+	}
+
+	return stmt
+}
+
+// parseForOfStatement parses a for...of loop
+//
+// Syntax: for [await] ([var|let] name of iterable) { ... }
+//
+// Like await, "for await" is only allowed in async functions and at the
+// top level of scripts.
+//
+// Example:
+//
+//	"for (let x of items) { print(x); }"
+//	→ ForOfStatement{
+//	    Kind: "let",
+//	    Name: "x",
+//	    Iterable: Identifier{"items"},
+//	    Body: BlockStatement{...}
+//	  }
+func (p *Parser) parseForOfStatement() *ast.ForOfStatement {
+	stmt := &ast.ForOfStatement{}
+
+	if p.peekTokenIs(token.AWAIT) {
+		p.nextToken()
+		if p.inFunction && !p.inAsync {
+			p.errors = append(p.errors, "for await is only valid in async functions and the top level of scripts")
+			return nil
+		}
+		stmt.Await = true
+	}
+
+	if !p.expectPeek(token.LPAREN) {
+		return nil
+	}
+
+	if p.peekTokenIs(token.VAR) || p.peekTokenIs(token.LET) {
+		p.nextToken()
+		stmt.Kind = p.currentToken.Literal
+	}
+
+	if !p.expectPeek(token.IDENT) {
+		return nil
+	}
+	stmt.Name = p.currentToken.Literal
+
+	// "of" is not a reserved word, so it arrives as an identifier
+	if !p.peekTokenIs(token.IDENT) || p.peekToken.Literal != "of" {
+		p.errors = append(p.errors, fmt.Sprintf("expected 'of' in for statement, got %s instead", p.peekToken.Literal))
+		return nil
+	}
+	p.nextToken() // move to 'of'
+
+	p.nextToken() // move past 'of'
+	stmt.Iterable = p.parseAssignmentExpression()
+
+	if !p.expectPeek(token.RPAREN) {
+		return nil
+	}
+
+	if !p.expectPeek(token.LBRACE) {
+		return nil
+	}
+
+	stmt.Body = p.parseBlockStatement()
+
+	return stmt
+}
+
 // parseWhileStatement parses a while loop
 //
 // Syntax: while (condition) { ... }
@@ -365,36 +530,74 @@ func (p *Parser) parseExpression(precedence int) ast.Expression {
 
 	switch p.currentToken.Type {
 	case token.IDENT:
-		leftExp = p.parseIdentifier()
+		switch {
+		case p.peekTokenIs(token.ARROW):
+			leftExp = p.parseArrowFunction([]string{p.currentToken.Literal}, false)
+		case p.currentToken.Literal == "async":
+			leftExp = p.parseAsyncExpression()
+		default:
+			leftExp = p.parseIdentifier()
+		}
 	case token.NUMBER:
 		leftExp = p.parseNumberLiteral()
 	case token.STRING:
 		leftExp = p.parseStringLiteral()
+	case token.REGEXP:
+		leftExp = p.parseRegExpLiteral()
 	case token.TRUE, token.FALSE:
 		leftExp = p.parseBooleanLiteral()
-	case token.BANG, token.MINUS:
+	case token.NULL:
+		leftExp = &ast.NullLiteral{}
+	case token.BANG, token.MINUS, token.TYPEOF:
 		leftExp = p.parsePrefixExpression()
 	case token.LPAREN:
 		leftExp = p.parseGroupedExpression()
 	case token.FUNC:
-		leftExp = p.parseFunctionLiteral()
+		leftExp = p.parseFunctionLiteral(false)
 	case token.LBRACE:
 		leftExp = p.parseObjectLiteral()
 	case token.LBRACKET:
 		leftExp = p.parseArrayLiteral()
+	case token.THIS:
+		leftExp = &ast.ThisExpression{Pos: p.currentToken.Pos}
+	case token.SUPER:
+		leftExp = &ast.SuperExpression{Pos: p.currentToken.Pos}
+	case token.NEW:
+		leftExp = p.parseNewExpression()
+	case token.CLASS:
+		leftExp = p.parseClassLiteral()
+	case token.YIELD:
+		leftExp = p.parseYieldExpression()
+	case token.AWAIT:
+		leftExp = p.parseAwaitExpression()
+	case token.IMPORT:
+		leftExp = p.parseImportExpression()
 	default:
 		p.noPrefixParseFnError(p.currentToken.Type)
 		return nil
 	}
 
+	// Set once a ?. link is parsed; the chain is wrapped in an OptionalChain
+	// as soon as anything other than a member access or call follows it
+	optional := false
+
 	// Parse infix expressions (operators between expressions)
 	// Continue while the next operator has higher precedence
 	for !p.peekTokenIs(token.SEMICOLON) && precedence < p.getPrecedence(p.peekToken.Type) {
 		switch p.peekToken.Type {
 		case token.PLUS, token.MINUS, token.STAR, token.SLASH,
-			token.EQ, token.NEQ, token.LT, token.GT, token.LTE, token.GTE:
+			token.EQ, token.NEQ, token.LT, token.GT, token.LTE, token.GTE, token.INSTANCEOF:
+			leftExp, optional = closeOptionalChain(leftExp, optional)
 			p.nextToken()
 			leftExp = p.parseInfixExpression(leftExp)
+		case token.QUESTION:
+			leftExp, optional = closeOptionalChain(leftExp, optional)
+			p.nextToken()
+			leftExp = p.parseConditionalExpression(leftExp)
+		case token.COMMA:
+			leftExp, optional = closeOptionalChain(leftExp, optional)
+			p.nextToken()
+			leftExp = p.parseSequenceExpression(leftExp)
 		case token.LPAREN:
 			p.nextToken()
 			leftExp = p.parseCallExpression(leftExp)
@@ -404,22 +607,42 @@ func (p *Parser) parseExpression(precedence int) ast.Expression {
 		case token.LBRACKET:
 			p.nextToken()
 			leftExp = p.parseIndexExpression(leftExp)
+		case token.QUESTION_DOT:
+			p.nextToken()
+			leftExp = p.parseOptionalLink(leftExp)
+			optional = true
 		case token.ASSIGN:
+			leftExp, optional = closeOptionalChain(leftExp, optional)
 			p.nextToken()
 			leftExp = p.parseAssignExpression(leftExp)
 		default:
+			leftExp, _ = closeOptionalChain(leftExp, optional)
 			return leftExp
 		}
 	}
 
+	leftExp, _ = closeOptionalChain(leftExp, optional)
 	return leftExp
 }
 
+// closeOptionalChain wraps a finished chain containing a ?. link in an
+// OptionalChain node, which is where a short-circuit stops
+//
+// Example: "a?.b.c + 1"
+//
+//	After ".c": OptionalChain{a?.b.c}, then the + is parsed with it as the left side
+func closeOptionalChain(exp ast.Expression, optional bool) (ast.Expression, bool) {
+	if !optional || exp == nil {
+		return exp, false
+	}
+	return &ast.OptionalChain{Expression: exp}, false
+}
+
 // parseIdentifier parses an identifier (variable/function name)
 //
 // Example: "myVar" → Identifier{Name: "myVar"}
 func (p *Parser) parseIdentifier() ast.Expression {
-	return &ast.Identifier{Name: p.currentToken.Literal}
+	return &ast.Identifier{Name: p.currentToken.Literal, Pos: p.currentToken.Pos}
 }
 
 // parseNumberLiteral parses a numeric literal
@@ -449,6 +672,32 @@ func (p *Parser) parseStringLiteral() ast.Expression {
 	return &ast.StringLiteral{Value: p.currentToken.Literal}
 }
 
+// parseRegExpLiteral parses a regular expression literal
+// The pattern is compiled here so a bad pattern is an early syntax error,
+// as in JavaScript, rather than a failure when the line runs.
+//
+// Examples:
+//
+//	"/ab+c/gi" → RegExpLiteral{Pattern: "ab+c", Flags: "gi"}
+//	"/(a/"     → error "Invalid regular expression: /(a/: Unterminated group"
+//	"/a/gg"    → error "Invalid regular expression flags"
+func (p *Parser) parseRegExpLiteral() ast.Expression {
+	literal := p.currentToken.Literal
+	end := strings.LastIndex(literal, "/")
+	lit := &ast.RegExpLiteral{Pattern: literal[1:end], Flags: literal[end+1:]}
+
+	flags, ok := regex.ParseFlags(lit.Flags)
+	if !ok {
+		p.errors = append(p.errors, "Invalid regular expression flags")
+		return nil
+	}
+	if _, err := regex.Compile(lit.Pattern, flags); err != nil {
+		p.errors = append(p.errors, fmt.Sprintf("Invalid regular expression: /%s/%s: %s", lit.Pattern, lit.Flags, err))
+		return nil
+	}
+	return lit
+}
+
 // parseBooleanLiteral parses a boolean literal
 //
 // Examples:
@@ -496,21 +745,228 @@ func (p *Parser) parseInfixExpression(left ast.Expression) ast.Expression {
 	return expression
 }
 
-// parseGroupedExpression parses an expression in parentheses
+// parseYieldExpression parses yield inside a generator body
+//
+// Examples:
+//
+//	"yield"          → YieldExpression{}
+//	"yield x + 1"    → YieldExpression{Argument: InfixExpression{...}}
+//	"yield* items"   → YieldExpression{Argument: Identifier{"items"}, Delegate: true}
+func (p *Parser) parseYieldExpression() ast.Expression {
+	if !p.inGenerator {
+		p.errors = append(p.errors, "yield is only valid inside generator functions")
+		return nil
+	}
+
+	exp := &ast.YieldExpression{}
+
+	if p.peekTokenIs(token.STAR) {
+		p.nextToken()
+		exp.Delegate = true
+	}
+
+	// A bare yield ends where the surrounding expression or statement ends
+	if !exp.Delegate && p.peekEndsYield() {
+		return exp
+	}
+
+	p.nextToken()
+	exp.Argument = p.parseAssignmentExpression()
+
+	return exp
+}
+
+// peekEndsYield reports whether the next token can't start a yield operand
+func (p *Parser) peekEndsYield() bool {
+	switch p.peekToken.Type {
+	case token.SEMICOLON, token.RPAREN, token.RBRACKET, token.RBRACE,
+		token.COMMA, token.COLON, token.EOF:
+		return true
+	}
+	return false
+}
+
+// parseAwaitExpression parses await inside an async function or at the top
+// level of a script. It binds like a unary operator.
+//
+// Examples:
+//
+//	"await p"          → AwaitExpression{Argument: Identifier{"p"}}
+//	"await a + await b" → InfixExpression{AwaitExpression{a}, "+", AwaitExpression{b}}
+func (p *Parser) parseAwaitExpression() ast.Expression {
+	if p.inFunction && !p.inAsync {
+		p.errors = append(p.errors, "await is only valid in async functions and the top level of scripts")
+		return nil
+	}
+
+	exp := &ast.AwaitExpression{}
+
+	p.nextToken()
+	exp.Argument = p.parseExpression(PREFIX)
+
+	return exp
+}
+
+// parseConditionalExpression parses "cond ? a : b"; the current token is '?'
+// Both branches may contain assignments, and the alternative may itself be
+// a conditional, so nested ternaries group to the right.
+//
+// Example: "a ? b : c ? d : e" → ConditionalExpression{a, b, ConditionalExpression{c, d, e}}
+func (p *Parser) parseConditionalExpression(condition ast.Expression) ast.Expression {
+	exp := &ast.ConditionalExpression{Condition: condition}
+
+	p.nextToken() // move past '?'
+	exp.Consequence = p.parseAssignmentExpression()
+
+	if !p.expectPeek(token.COLON) {
+		return nil
+	}
+
+	p.nextToken() // move past ':'
+	exp.Alternative = p.parseAssignmentExpression()
+
+	return exp
+}
+
+// parseSequenceExpression parses the comma operator; the current token is ','
+// Consecutive commas extend one sequence instead of nesting.
+//
+// Example: "a, b, c" → SequenceExpression{Expressions: [a, b, c]}
+func (p *Parser) parseSequenceExpression(left ast.Expression) ast.Expression {
+	exp, ok := left.(*ast.SequenceExpression)
+	if !ok {
+		exp = &ast.SequenceExpression{Expressions: []ast.Expression{left}}
+	}
+
+	p.nextToken() // move past ','
+	exp.Expressions = append(exp.Expressions, p.parseAssignmentExpression())
+
+	return exp
+}
+
+// parseAssignmentExpression parses an expression that stops before a
+// top-level comma. It's used wherever commas separate things: arguments,
+// array elements, object properties and assigned values.
+//
+// Example: in "f(a = 1, b)" the first argument is "a = 1", not "a = (1, b)"
+func (p *Parser) parseAssignmentExpression() ast.Expression {
+	return p.parseExpression(COMMA)
+}
+
+// parseGroupedExpression parses an expression in parentheses, or the
+// parameter list of an arrow function when "=>" follows the ')'
 //
-// Example: "(2 + 3)" → InfixExpression{...}
+// Examples:
+//
+//	"(2 + 3)"         → InfixExpression{...}
+//	"(a, b) => a + b" → FunctionLiteral{Parameters: ["a", "b"], Arrow: true}
 func (p *Parser) parseGroupedExpression() ast.Expression {
 	p.nextToken() // move past '('
 
+	// () => ...
+	if p.currentTokenIs(token.RPAREN) && p.peekTokenIs(token.ARROW) {
+		return p.parseArrowFunction([]string{}, false)
+	}
+
 	exp := p.parseExpression(LOWEST)
 
 	if !p.expectPeek(token.RPAREN) {
 		return nil
 	}
 
+	if p.peekTokenIs(token.ARROW) {
+		elements := []ast.Expression{exp}
+		if sequence, ok := exp.(*ast.SequenceExpression); ok {
+			elements = sequence.Expressions
+		}
+		params, ok := p.arrowParameters(elements)
+		if !ok {
+			return nil
+		}
+		return p.parseArrowFunction(params, false)
+	}
+
 	return exp
 }
 
+// parseAsyncExpression parses what follows the contextual word "async"
+// "async" only starts an async function when a function or an arrow
+// parameter list follows; otherwise it's an ordinary identifier.
+//
+// Examples:
+//
+//	"async function() {}" → FunctionLiteral{Async: true}
+//	"async x => x"        → FunctionLiteral{Parameters: ["x"], Async: true, Arrow: true}
+//	"async (a, b) => a"   → FunctionLiteral{Parameters: ["a", "b"], Async: true, Arrow: true}
+//	"async(1)"            → CallExpression{Function: Identifier{"async"}, ...}
+func (p *Parser) parseAsyncExpression() ast.Expression {
+	switch p.peekToken.Type {
+	case token.FUNC:
+		p.nextToken()
+		return p.parseFunctionLiteral(true)
+	case token.IDENT:
+		p.nextToken()
+		return p.parseArrowFunction([]string{p.currentToken.Literal}, true)
+	case token.LPAREN:
+		p.nextToken()
+		args := p.parseCallArguments()
+		if !p.peekTokenIs(token.ARROW) {
+			return &ast.CallExpression{Function: &ast.Identifier{Name: "async"}, Arguments: args}
+		}
+		params, ok := p.arrowParameters(args)
+		if !ok {
+			return nil
+		}
+		return p.parseArrowFunction(params, true)
+	}
+
+	return p.parseIdentifier()
+}
+
+// arrowParameters turns what was parsed as a parenthesized expression or an
+// argument list into arrow function parameters; each must be an identifier
+func (p *Parser) arrowParameters(elements []ast.Expression) ([]string, bool) {
+	params := []string{}
+	for _, element := range elements {
+		ident, ok := element.(*ast.Identifier)
+		if !ok {
+			p.errors = append(p.errors, "invalid arrow function parameter")
+			return nil, false
+		}
+		params = append(params, ident.Name)
+	}
+	return params, true
+}
+
+// parseArrowFunction parses the body of an arrow function; the next token
+// must be "=>". An expression body is returned as if it were a block with a
+// single return statement.
+//
+// Examples:
+//
+//	"x => x * 2"       → FunctionLiteral{Parameters: ["x"], Body: { return x * 2; }, Arrow: true}
+//	"() => { run(); }" → FunctionLiteral{Parameters: [], Body: { run(); }, Arrow: true}
+func (p *Parser) parseArrowFunction(params []string, async bool) ast.Expression {
+	if !p.expectPeek(token.ARROW) {
+		return nil
+	}
+	p.nextToken() // move past '=>'
+
+	lit := &ast.FunctionLiteral{Parameters: params, Async: async, Arrow: true}
+
+	if p.currentTokenIs(token.LBRACE) {
+		lit.Body = p.parseFunctionBody(false, async)
+		return lit
+	}
+
+	restore := p.enterFunction(false, async)
+	value := p.parseAssignmentExpression()
+	restore()
+
+	lit.Body = &ast.BlockStatement{Statements: []ast.Statement{&ast.ReturnStatement{Value: value}}}
+	return lit
+}
+
 // parseFunctionLiteral parses a function definition
 //
 // Syntax: function(param1, param2) { ... }
@@ -522,8 +978,19 @@ func (p *Parser) parseGroupedExpression() ast.Expression {
 //	    Parameters: ["x", "y"],
 //	    Body: BlockStatement{...}
 //	  }
-func (p *Parser) parseFunctionLiteral() ast.Expression {
-	lit := &ast.FunctionLiteral{}
+func (p *Parser) parseFunctionLiteral(async bool) ast.Expression {
+	lit := &ast.FunctionLiteral{Async: async}
+
+	// function* (...) { ... } is a generator
+	if p.peekTokenIs(token.STAR) {
+		p.nextToken()
+		lit.Generator = true
+	}
+
+	if lit.Async && lit.Generator {
+		p.errors = append(p.errors, "async generator functions are not supported")
+		return nil
+	}
 
 	// Expect '(' after 'function'
 	if !p.expectPeek(token.LPAREN) {
@@ -537,11 +1004,32 @@ func (p *Parser) parseFunctionLiteral() ast.Expression {
 		return nil
 	}
 
-	lit.Body = p.parseBlockStatement()
+	lit.Body = p.parseFunctionBody(lit.Generator, lit.Async)
 
 	return lit
 }
 
+// parseFunctionBody parses the block of a function or method
+// yield and await are only allowed directly inside generator and async bodies,
+// not in nested ordinary functions, so the flags are saved and restored
+// around each body.
+func (p *Parser) parseFunctionBody(generator bool, async bool) *ast.BlockStatement {
+	defer p.enterFunction(generator, async)()
+
+	return p.parseBlockStatement()
+}
+
+// enterFunction sets the flags for parsing a function body and returns a
+// function that restores the enclosing ones
+func (p *Parser) enterFunction(generator bool, async bool) (restore func()) {
+	outerGenerator, outerAsync, outerFunction := p.inGenerator, p.inAsync, p.inFunction
+	p.inGenerator, p.inAsync, p.inFunction = generator, async, true
+
+	return func() {
+		p.inGenerator, p.inAsync, p.inFunction = outerGenerator, outerAsync, outerFunction
+	}
+}
+
 // parseFunctionParameters parses the parameter list of a function
 //
 // Example: "(a, b, c)" → ["a", "b", "c"]
@@ -579,7 +1067,7 @@ func (p *Parser) parseFunctionParameters() []string {
 //	"add(5, 3)" → CallExpression{Function: Identifier{"add"}, Arguments: [...]}
 //	"print("hello")" → CallExpression{Function: Identifier{"print"}, Arguments: [...]}
 func (p *Parser) parseCallExpression(function ast.Expression) ast.Expression {
-	exp := &ast.CallExpression{Function: function}
+	exp := &ast.CallExpression{Function: function, Pos: p.currentToken.Pos}
 	exp.Arguments = p.parseCallArguments()
 	return exp
 }
@@ -597,13 +1085,13 @@ func (p *Parser) parseCallArguments() []ast.Expression {
 	}
 
 	p.nextToken() // move to first argument
-	args = append(args, p.parseExpression(LOWEST))
+	args = append(args, p.parseAssignmentExpression())
 
 	// Parse remaining arguments
 	for p.peekTokenIs(token.COMMA) {
 		p.nextToken() // consume comma
 		p.nextToken() // move to next argument
-		args = append(args, p.parseExpression(LOWEST))
+		args = append(args, p.parseAssignmentExpression())
 	}
 
 	if !p.expectPeek(token.RPAREN) {
@@ -614,105 +1102,272 @@ func (p *Parser) parseCallArguments() []ast.Expression {
 }
 
 // parseObjectLiteral parses an object literal
+// A trailing comma before the closing brace is allowed
 //
 // Example:
 //
-//	"{ name: "John", age: 30 }"
+//	"{ name: "John", age, [key]: 1, greet() { ... }, }"
 //	→ ObjectLiteral{
-//	    Pairs: {
-//	      "name": StringLiteral{"John"},
-//	      "age": NumberLiteral{30}
-//	    }
+//	    Properties: [
+//	      ObjectProperty{Key: "name", Value: StringLiteral{"John"}},
+//	      ObjectProperty{Key: "age", Value: Identifier{"age"}, Shorthand: true},
+//	      ObjectProperty{Computed: Identifier{"key"}, Value: NumberLiteral{1}},
+//	      ObjectProperty{Key: "greet", Value: FunctionLiteral{...}, Method: true}
+//	    ]
 //	  }
 func (p *Parser) parseObjectLiteral() ast.Expression {
-	obj := &ast.ObjectLiteral{}
-	obj.Pairs = make(map[string]ast.Expression)
+	obj := &ast.ObjectLiteral{Properties: []*ast.ObjectProperty{}}
 
-	p.nextToken() // move past '{'
+	for !p.peekTokenIs(token.RBRACE) {
+		p.nextToken() // move to the property
 
-	for !p.currentTokenIs(token.RBRACE) && !p.currentTokenIs(token.EOF) {
-		// Parse key (can be identifier or string)
-		var key string
-		if p.currentTokenIs(token.IDENT) || p.currentTokenIs(token.STRING) {
-			key = p.currentToken.Literal
-		} else {
+		prop := p.parseObjectProperty()
+		if prop == nil {
 			return nil
 		}
+		obj.Properties = append(obj.Properties, prop)
 
-		// Expect ':' after key
-		if !p.expectPeek(token.COLON) {
+		// Properties are separated by commas; the last one may have one too
+		if !p.peekTokenIs(token.RBRACE) && !p.expectPeek(token.COMMA) {
 			return nil
 		}
-
-		p.nextToken() // move to value
-		value := p.parseExpression(LOWEST)
-		obj.Pairs[key] = value
-
-		// Check for comma (more properties) or closing brace
-		if p.peekTokenIs(token.COMMA) {
-			p.nextToken() // consume comma
-			p.nextToken() // move to next property
-		} else if p.peekTokenIs(token.RBRACE) {
-			p.nextToken() // consume closing brace
-			break
-		}
 	}
 
+	p.nextToken() // consume closing brace
+
 	return obj
 }
 
-// parseArrayLiteral parses an array literal
+// parseObjectProperty parses one property of an object literal
+// "get" and "set" are only accessor modifiers when followed by a property
+// name, so { get: 1 } and { set() {} } are ordinary properties.
 //
-// Example:
+// Examples:
 //
-//	"[1, 2, 3]"
-//	→ ArrayLiteral{
-//	    Elements: [NumberLiteral{1}, NumberLiteral{2}, NumberLiteral{3}]
-//	  }
-func (p *Parser) parseArrayLiteral() ast.Expression {
-	array := &ast.ArrayLiteral{}
-	array.Elements = []ast.Expression{}
-
-	p.nextToken() // move past '['
-
-	// Handle empty array
-	if p.currentTokenIs(token.RBRACKET) {
-		return array
+//	"a: 1"            → ObjectProperty{Key: "a", Value: NumberLiteral{1}}
+//	"a"               → ObjectProperty{Key: "a", Value: Identifier{"a"}, Shorthand: true}
+//	"1: "one""        → ObjectProperty{Key: "1", Value: StringLiteral{"one"}}
+//	"[k + 1]: v"      → ObjectProperty{Computed: InfixExpression{...}, Value: Identifier{"v"}}
+//	"f(x) { ... }"    → ObjectProperty{Key: "f", Value: FunctionLiteral{...}, Method: true}
+//	"async f() { ... }" → ObjectProperty{Key: "f", Value: FunctionLiteral{Async: true}, Method: true}
+//	"set x(v) { ... }" → ObjectProperty{Kind: PropertySetter, Key: "x", Value: FunctionLiteral{...}}
+func (p *Parser) parseObjectProperty() *ast.ObjectProperty {
+	prop := &ast.ObjectProperty{Kind: ast.PropertyInit}
+
+	// async name() { ... } is an async method
+	async := false
+	if p.currentToken.Literal == "async" && p.currentTokenIs(token.IDENT) &&
+		!p.peekTokenIs(token.LPAREN) && !p.peekTokenIs(token.COLON) &&
+		!p.peekTokenIs(token.COMMA) && !p.peekTokenIs(token.RBRACE) {
+		async = true
+		p.nextToken()
 	}
 
-	array.Elements = append(array.Elements, p.parseExpression(LOWEST))
-
-	// Parse remaining elements
-	for p.peekTokenIs(token.COMMA) {
-		p.nextToken() // consume comma
-		p.nextToken() // move to next element
-		array.Elements = append(array.Elements, p.parseExpression(LOWEST))
+	// *name() { ... } is a generator method
+	generator := false
+	if p.currentTokenIs(token.STAR) {
+		generator = true
+		p.nextToken()
 	}
 
-	if !p.expectPeek(token.RBRACKET) {
+	if async && generator {
+		p.errors = append(p.errors, "async generator methods are not supported")
 		return nil
 	}
 
-	return array
-}
+	if !generator && !async && (p.currentToken.Literal == "get" || p.currentToken.Literal == "set") && p.currentTokenIs(token.IDENT) &&
+		!p.peekTokenIs(token.LPAREN) && !p.peekTokenIs(token.COLON) &&
+		!p.peekTokenIs(token.COMMA) && !p.peekTokenIs(token.RBRACE) {
+		if p.currentToken.Literal == "get" {
+			prop.Kind = ast.PropertyGetter
+		} else {
+			prop.Kind = ast.PropertySetter
+		}
+		p.nextToken()
+	}
 
-// parsePropertyAccess parses object property access
-//
+	keyToken := p.currentToken
+	if p.currentTokenIs(token.LBRACKET) {
+		p.nextToken() // move past '['
+		prop.Computed = p.parseAssignmentExpression()
+		if !p.expectPeek(token.RBRACKET) {
+			return nil
+		}
+	} else {
+		key, ok := p.parsePropertyName()
+		if !ok {
+			return nil
+		}
+		prop.Key = key
+	}
+
+	// Method, getter or setter
+	if p.peekTokenIs(token.LPAREN) {
+		p.nextToken() // move to '('
+		fn := p.parseMethodBody(generator, async)
+		if fn == nil {
+			return nil
+		}
+		prop.Value = fn
+		prop.Method = prop.Kind == ast.PropertyInit
+		return prop
+	}
+
+	if prop.Kind != ast.PropertyInit || generator || async {
+		p.errors = append(p.errors, fmt.Sprintf("expected ( after %s %s in object literal", prop.Kind, prop.Key))
+		return nil
+	}
+
+	if p.peekTokenIs(token.COLON) {
+		p.nextToken() // consume key
+		p.nextToken() // consume ':'
+		prop.Value = p.parseAssignmentExpression()
+		if prop.Value == nil {
+			return nil
+		}
+		return prop
+	}
+
+	// Shorthand {name} only works with plain identifiers
+	if keyToken.Type == token.IDENT && prop.Computed == nil {
+		prop.Value = &ast.Identifier{Name: prop.Key}
+		prop.Shorthand = true
+		return prop
+	}
+
+	p.errors = append(p.errors, fmt.Sprintf("expected : after property key %q, got %s instead", keyToken.Literal, p.peekToken.Type))
+	return nil
+}
+
+// parsePropertyName reads a static property name in an object literal or
+// class body: an identifier, keyword, string or number
+// Numbers are normalized the way JavaScript turns them into keys (1.50 → "1.5").
+func (p *Parser) parsePropertyName() (string, bool) {
+	switch {
+	case isIdentifierName(p.currentToken), p.currentTokenIs(token.STRING):
+		return p.currentToken.Literal, true
+	case p.currentTokenIs(token.NUMBER):
+		num := p.parseNumberLiteral()
+		if num == nil {
+			return "", false
+		}
+		return strconv.FormatFloat(num.(*ast.NumberLiteral).Value, 'f', -1, 64), true
+	default:
+		p.errors = append(p.errors, fmt.Sprintf("unexpected %s, expected a property name", p.currentToken.Type))
+		return "", false
+	}
+}
+
+// parseMethodBody parses the parameters and body of a method definition
+// The current token must be the opening '(' of the parameter list
+//
+// Example: "(a, b) { return a + b; }" → FunctionLiteral{Parameters: ["a", "b"], Body: ...}
+func (p *Parser) parseMethodBody(generator bool, async bool) *ast.FunctionLiteral {
+	fn := &ast.FunctionLiteral{Generator: generator, Async: async}
+	fn.Parameters = p.parseFunctionParameters()
+	if !p.expectPeek(token.LBRACE) {
+		return nil
+	}
+	fn.Body = p.parseFunctionBody(generator, async)
+	return fn
+}
+
+// parseArrayLiteral parses an array literal
+//
 // Example:
 //
+//	"[1, 2, 3]"
+//	→ ArrayLiteral{
+//	    Elements: [NumberLiteral{1}, NumberLiteral{2}, NumberLiteral{3}]
+//	  }
+func (p *Parser) parseArrayLiteral() ast.Expression {
+	array := &ast.ArrayLiteral{}
+	array.Elements = []ast.Expression{}
+
+	p.nextToken() // move past '['
+
+	// Handle empty array
+	if p.currentTokenIs(token.RBRACKET) {
+		return array
+	}
+
+	array.Elements = append(array.Elements, p.parseAssignmentExpression())
+
+	// Parse remaining elements
+	for p.peekTokenIs(token.COMMA) {
+		p.nextToken() // consume comma
+		p.nextToken() // move to next element
+		array.Elements = append(array.Elements, p.parseAssignmentExpression())
+	}
+
+	if !p.expectPeek(token.RBRACKET) {
+		return nil
+	}
+
+	return array
+}
+
+// parsePropertyAccess parses object property access
+// Keywords are valid property names after a dot, and so are #private names
+//
+// Examples:
+//
 //	"person.name" → PropertyAccess{Object: Identifier{"person"}, Property: "name"}
+//	"this.#count" → PropertyAccess{Object: ThisExpression{}, Property: "#count"}
+//	"token.new"   → PropertyAccess{Object: Identifier{"token"}, Property: "new"}
 func (p *Parser) parsePropertyAccess(object ast.Expression) ast.Expression {
 	exp := &ast.PropertyAccess{Object: object}
 
-	if !p.expectPeek(token.IDENT) {
+	if !isIdentifierName(p.peekToken) && !p.peekTokenIs(token.PRIVATE_IDENT) {
+		p.errors = append(p.errors, fmt.Sprintf("expected property name after '.', got %s instead", p.peekToken.Type))
 		return nil
 	}
+	p.nextToken()
 
 	exp.Property = p.currentToken.Literal
+	exp.Pos = p.currentToken.Pos
+
+	return exp
+}
+
+// parseOptionalLink parses what follows "?." in an optional chain
+//
+// Examples:
+//
+//	"a?.b"    → PropertyAccess{Object: Identifier{"a"}, Property: "b", Optional: true}
+//	"a?.[k]"  → IndexExpression{Left: Identifier{"a"}, Index: Identifier{"k"}, Optional: true}
+//	"f?.(1)"  → CallExpression{Function: Identifier{"f"}, Arguments: [1], Optional: true}
+func (p *Parser) parseOptionalLink(object ast.Expression) ast.Expression {
+	switch p.peekToken.Type {
+	case token.LBRACKET:
+		p.nextToken()
+		exp, ok := p.parseIndexExpression(object).(*ast.IndexExpression)
+		if !ok {
+			return nil
+		}
+		exp.Optional = true
+		return exp
+	case token.LPAREN:
+		p.nextToken()
+		exp := p.parseCallExpression(object).(*ast.CallExpression)
+		exp.Optional = true
+		return exp
+	}
 
+	exp, ok := p.parsePropertyAccess(object).(*ast.PropertyAccess)
+	if !ok {
+		return nil
+	}
+	exp.Optional = true
 	return exp
 }
 
+// isIdentifierName reports whether the token is an identifier or a keyword.
+// Both can be used as property names: obj.name, obj.new, obj.class
+func isIdentifierName(tok token.Token) bool {
+	return tok.Literal != "" && token.LookupIdent(tok.Literal) == tok.Type
+}
+
 // parseIndexExpression parses array or object indexing
 //
 // Example:
@@ -720,7 +1375,7 @@ func (p *Parser) parsePropertyAccess(object ast.Expression) ast.Expression {
 //	"arr[0]" → IndexExpression{Left: Identifier{"arr"}, Index: NumberLiteral{0}}
 //	"obj[key]" → IndexExpression{Left: Identifier{"obj"}, Index: Identifier{"key"}}
 func (p *Parser) parseIndexExpression(left ast.Expression) ast.Expression {
-	exp := &ast.IndexExpression{Left: left}
+	exp := &ast.IndexExpression{Left: left, Pos: p.currentToken.Pos}
 
 	p.nextToken()
 	exp.Index = p.parseExpression(LOWEST)
@@ -734,21 +1389,56 @@ func (p *Parser) parseIndexExpression(left ast.Expression) ast.Expression {
 
 // parseAssignExpression parses an assignment expression
 //
-// Example:
+// Examples:
 //
-//	"x = 5" → AssignExpression{Name: "x", Value: NumberLiteral{5}}
+//	"x = 5"      → AssignExpression{Name: "x", Value: NumberLiteral{5}}
+//	"this.x = 5" → MemberAssignExpression{Target: PropertyAccess{...}, Value: NumberLiteral{5}}
 func (p *Parser) parseAssignExpression(left ast.Expression) ast.Expression {
-	// Assignment only works with identifiers on the left
-	ident, ok := left.(*ast.Identifier)
-	if !ok {
+	switch target := left.(type) {
+	case *ast.Identifier:
+		exp := &ast.AssignExpression{Name: target.Name}
+		p.nextToken() // move past '='
+		exp.Value = p.parseAssignmentExpression()
+		return exp
+	case *ast.PropertyAccess, *ast.IndexExpression:
+		exp := &ast.MemberAssignExpression{Target: target}
+		p.nextToken() // move past '='
+		exp.Value = p.parseAssignmentExpression()
+		return exp
+	default:
 		p.errors = append(p.errors, "invalid assignment target")
 		return nil
 	}
+}
+
+// parseNewExpression parses object construction
+// The callee may be a dotted or indexed path, but not a call: in
+// "new a.B(1).run()" the arguments belong to new and .run() is called on the result
+//
+// Examples:
+//
+//	"new Point(1, 2)"   → NewExpression{Callee: Identifier{"Point"}, Arguments: [...]}
+//	"new shapes.Circle" → NewExpression{Callee: PropertyAccess{...}, Arguments: []}
+func (p *Parser) parseNewExpression() ast.Expression {
+	exp := &ast.NewExpression{Arguments: []ast.Expression{}, Pos: p.currentToken.Pos}
 
-	exp := &ast.AssignExpression{Name: ident.Name}
+	p.nextToken() // move past 'new'
+	callee := p.parseExpression(CALL)
+
+	for p.peekTokenIs(token.DOT) || p.peekTokenIs(token.LBRACKET) {
+		p.nextToken()
+		if p.currentTokenIs(token.DOT) {
+			callee = p.parsePropertyAccess(callee)
+		} else {
+			callee = p.parseIndexExpression(callee)
+		}
+	}
+	exp.Callee = callee
 
-	p.nextToken() // move past '='
-	exp.Value = p.parseExpression(LOWEST)
+	if p.peekTokenIs(token.LPAREN) {
+		p.nextToken()
+		exp.Arguments = p.parseCallArguments()
+	}
 
 	return exp
 }
@@ -758,3 +1448,481 @@ func (p *Parser) noPrefixParseFnError(t token.Type) {
 	msg := fmt.Sprintf("no prefix parse function for %s found", t)
 	p.errors = append(p.errors, msg)
 }
+
+// parseClassDeclaration parses a class statement, which binds the class name
+//
+// Example: "class Point { ... }" → ClassDeclaration{Class: ClassLiteral{Name: "Point", ...}}
+func (p *Parser) parseClassDeclaration() *ast.ClassDeclaration {
+	class := p.parseClassLiteral()
+	if class == nil {
+		return nil
+	}
+
+	if class.Name == "" {
+		p.errors = append(p.errors, "class declaration requires a name")
+		return nil
+	}
+
+	return &ast.ClassDeclaration{Class: class}
+}
+
+// parseClassLiteral parses a class definition
+//
+// Syntax: class [Name] [extends <expression>] { <members> }
+//
+// Example:
+//
+//	"class Dog extends Animal { speak() { return "woof"; } }"
+//	→ ClassLiteral{
+//	    Name: "Dog",
+//	    SuperClass: Identifier{"Animal"},
+//	    Members: [ClassMember{Kind: ClassMethod, Key: "speak", ...}]
+//	  }
+func (p *Parser) parseClassLiteral() *ast.ClassLiteral {
+	class := &ast.ClassLiteral{Members: []*ast.ClassMember{}}
+
+	if p.peekTokenIs(token.IDENT) {
+		p.nextToken()
+		class.Name = p.currentToken.Literal
+	}
+
+	if p.peekTokenIs(token.EXTENDS) {
+		p.nextToken() // consume extends
+		p.nextToken() // move to the parent class expression
+		class.ExtendsPos = p.currentToken.Pos
+		class.SuperClass = p.parseExpression(LOWEST)
+	}
+
+	if !p.expectPeek(token.LBRACE) {
+		return nil
+	}
+
+	p.nextToken() // move past '{'
+
+	for !p.currentTokenIs(token.RBRACE) {
+		if p.currentTokenIs(token.EOF) {
+			p.errors = append(p.errors, "unterminated class body")
+			return nil
+		}
+
+		// Stray semicolons between members are allowed
+		if p.currentTokenIs(token.SEMICOLON) {
+			p.nextToken()
+			continue
+		}
+
+		member := p.parseClassMember()
+		if member == nil {
+			return nil
+		}
+
+		if member.Kind == ast.ClassMethod && !member.Static && member.Key == "constructor" {
+			if class.Constructor != nil {
+				p.errors = append(p.errors, "a class may only have one constructor")
+				return nil
+			}
+			class.Constructor = member.Value.(*ast.FunctionLiteral)
+		} else {
+			class.Members = append(class.Members, member)
+		}
+
+		p.nextToken()
+	}
+
+	return class
+}
+
+// parseClassMember parses one member of a class body
+// "static", "get" and "set" are only modifiers when followed by a member name,
+// so methods and fields may still be called static(), get() or set.
+//
+// Examples:
+//
+//	"area() { ... }"      → ClassMember{Kind: ClassMethod, Key: "area"}
+//	"static create() {}"  → ClassMember{Kind: ClassMethod, Key: "create", Static: true}
+//	"async load() {}"     → ClassMember{Kind: ClassMethod, Key: "load", Value: FunctionLiteral{Async: true}}
+//	"get size() { ... }"  → ClassMember{Kind: ClassGetter, Key: "size"}
+//	"#count = 0;"         → ClassMember{Kind: ClassField, Key: "#count", Value: NumberLiteral{0}}
+//	"static { ... }"      → ClassMember{Kind: ClassStaticBlock, Static: true, Body: BlockStatement{...}}
+func (p *Parser) parseClassMember() *ast.ClassMember {
+	member := &ast.ClassMember{Kind: ast.ClassMethod}
+
+	if p.currentToken.Literal == "static" && p.currentTokenIs(token.IDENT) && !p.peekEndsClassMemberName() {
+		member.Static = true
+		p.nextToken()
+
+		if p.currentTokenIs(token.LBRACE) {
+			member.Kind = ast.ClassStaticBlock
+			member.Body = p.parseFunctionBody(false, false)
+			return member
+		}
+	}
+
+	// async name() { ... } is an async method
+	async := false
+	if p.currentToken.Literal == "async" && p.currentTokenIs(token.IDENT) && !p.peekEndsClassMemberName() {
+		async = true
+		p.nextToken()
+	}
+
+	// *name() { ... } is a generator method
+	generator := false
+	if p.currentTokenIs(token.STAR) {
+		generator = true
+		p.nextToken()
+	}
+
+	if async && generator {
+		p.errors = append(p.errors, "async generator methods are not supported")
+		return nil
+	}
+
+	if !generator && !async && (p.currentToken.Literal == "get" || p.currentToken.Literal == "set") &&
+		p.currentTokenIs(token.IDENT) && !p.peekEndsClassMemberName() {
+		if p.currentToken.Literal == "get" {
+			member.Kind = ast.ClassGetter
+		} else {
+			member.Kind = ast.ClassSetter
+		}
+		p.nextToken()
+	}
+
+	if p.currentTokenIs(token.PRIVATE_IDENT) {
+		member.Key = p.currentToken.Literal
+	} else if p.currentTokenIs(token.LBRACKET) {
+		p.nextToken() // move past '['
+		member.Computed = p.parseAssignmentExpression()
+		if !p.expectPeek(token.RBRACKET) {
+			return nil
+		}
+	} else {
+		key, ok := p.parsePropertyName()
+		if !ok {
+			return nil
+		}
+		member.Key = key
+	}
+
+	if p.peekTokenIs(token.LPAREN) {
+		p.nextToken() // move to '('
+		fn := p.parseMethodBody(generator, async)
+		if fn == nil {
+			return nil
+		}
+		member.Value = fn
+		return member
+	}
+
+	if member.Kind != ast.ClassMethod || generator || async {
+		p.errors = append(p.errors, fmt.Sprintf("expected ( after %s %s", member.Kind, member.Key))
+		return nil
+	}
+
+	// Anything else is a field, optionally with an initializer
+	member.Kind = ast.ClassField
+	if p.peekTokenIs(token.ASSIGN) {
+		p.nextToken() // consume the key
+		p.nextToken() // consume '='
+		member.Value = p.parseAssignmentExpression()
+	}
+
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	return member
+}
+
+// peekEndsClassMemberName reports whether the next token shows that the
+// current word is itself a member name rather than a modifier:
+// static() {}, get = 1, set;
+func (p *Parser) peekEndsClassMemberName() bool {
+	return p.peekTokenIs(token.LPAREN) || p.peekTokenIs(token.ASSIGN) ||
+		p.peekTokenIs(token.SEMICOLON) || p.peekTokenIs(token.RBRACE)
+}
+
+// parseModuleItem parses a top-level statement, where import and export
+// declarations are also allowed
+func (p *Parser) parseModuleItem() ast.Statement {
+	switch {
+	case p.currentTokenIs(token.IMPORT) && !p.peekTokenIs(token.LPAREN):
+		return p.parseImportDeclaration()
+	case p.currentTokenIs(token.EXPORT):
+		return p.parseExportDeclaration()
+	default:
+		return p.parseStatement()
+	}
+}
+
+// parseImportDeclaration parses a static import
+//
+// Syntax: import "<source>";
+//
+//	import <default> from "<source>";
+//	import [<default>,] * as <namespace> from "<source>";
+//	import [<default>,] { <name> [as <local>], ... } from "<source>";
+//
+// Example:
+//
+//	"import greet, { a as b } from \"./m.js\";"
+//	→ ImportDeclaration{Source: "./m.js", Default: "greet", Specifiers: [{Imported: "a", Local: "b"}]}
+func (p *Parser) parseImportDeclaration() ast.Statement {
+	decl := &ast.ImportDeclaration{}
+
+	// import "./m.js"; only runs the module
+	if p.peekTokenIs(token.STRING) {
+		p.nextToken()
+		decl.Source = p.currentToken.Literal
+		p.skipSemicolon()
+		return decl
+	}
+
+	if p.peekTokenIs(token.IDENT) && p.peekToken.Literal != "from" {
+		p.nextToken()
+		decl.Default = p.currentToken.Literal
+
+		if p.peekTokenIs(token.COMMA) {
+			p.nextToken()
+			if !p.peekTokenIs(token.STAR) && !p.peekTokenIs(token.LBRACE) {
+				p.errors = append(p.errors, fmt.Sprintf("expected * or { after default import, got %s instead", p.peekToken.Type))
+				return nil
+			}
+		}
+	}
+
+	switch {
+	case p.peekTokenIs(token.STAR):
+		p.nextToken()
+		if !p.expectContextual("as") || !p.expectPeek(token.IDENT) {
+			return nil
+		}
+		decl.Namespace = p.currentToken.Literal
+	case p.peekTokenIs(token.LBRACE):
+		p.nextToken()
+		decl.Specifiers = []*ast.ImportSpecifier{}
+		ok := p.parseModuleSpecifiers(func(name, alias string) bool {
+			if alias == "" {
+				if !isBindingName(name) {
+					p.errors = append(p.errors, fmt.Sprintf("unexpected keyword %q in import list; use { %s as name }", name, name))
+					return false
+				}
+				alias = name
+			}
+			decl.Specifiers = append(decl.Specifiers, &ast.ImportSpecifier{Imported: name, Local: alias})
+			return true
+		})
+		if !ok {
+			return nil
+		}
+	case decl.Default == "":
+		p.errors = append(p.errors, fmt.Sprintf("unexpected %s after import", p.peekToken.Type))
+		return nil
+	}
+
+	source, ok := p.parseFromClause()
+	if !ok {
+		return nil
+	}
+	decl.Source = source
+
+	return decl
+}
+
+// parseExportDeclaration parses an export
+//
+// Syntax: export var|let|class ...;
+//
+//	export default <expression>;
+//	export { <name> [as <exported>], ... } [from "<source>"];
+//	export * [as <namespace>] from "<source>";
+//
+// Examples:
+//
+//	"export var x = 1;"          → ExportDeclaration{Declaration: VarStatement{x}}
+//	"export * from \"./m.js\";" → ExportDeclaration{Source: "./m.js", All: true}
+func (p *Parser) parseExportDeclaration() ast.Statement {
+	switch {
+	case p.peekTokenIs(token.VAR), p.peekTokenIs(token.LET), p.peekTokenIs(token.CLASS):
+		p.nextToken()
+		declaration := p.parseStatement()
+		if declaration == nil {
+			return nil
+		}
+		return &ast.ExportDeclaration{Declaration: declaration}
+
+	case p.peekTokenIs(token.IDENT) && p.peekToken.Literal == "default":
+		p.nextToken()
+		p.nextToken()
+
+		// A named class is a declaration; anything else is an expression
+		if p.currentTokenIs(token.CLASS) && p.peekTokenIs(token.IDENT) {
+			declaration := p.parseClassDeclaration()
+			if declaration == nil {
+				return nil
+			}
+			return &ast.ExportDefaultDeclaration{Declaration: declaration}
+		}
+
+		value := p.parseAssignmentExpression()
+		if value == nil {
+			return nil
+		}
+		p.skipSemicolon()
+		return &ast.ExportDefaultDeclaration{Value: value}
+
+	case p.peekTokenIs(token.STAR):
+		p.nextToken()
+		decl := &ast.ExportDeclaration{All: true}
+
+		if p.peekTokenIs(token.IDENT) && p.peekToken.Literal == "as" {
+			p.nextToken()
+			p.nextToken()
+			if !isIdentifierName(p.currentToken) {
+				p.errors = append(p.errors, fmt.Sprintf("expected a name after 'as', got %s instead", p.currentToken.Type))
+				return nil
+			}
+			decl.Namespace = p.currentToken.Literal
+		}
+
+		source, ok := p.parseFromClause()
+		if !ok {
+			return nil
+		}
+		decl.Source = source
+		return decl
+
+	case p.peekTokenIs(token.LBRACE):
+		p.nextToken()
+		decl := &ast.ExportDeclaration{Specifiers: []*ast.ExportSpecifier{}}
+		ok := p.parseModuleSpecifiers(func(name, alias string) bool {
+			if alias == "" {
+				alias = name
+			}
+			decl.Specifiers = append(decl.Specifiers, &ast.ExportSpecifier{Local: name, Exported: alias})
+			return true
+		})
+		if !ok {
+			return nil
+		}
+
+		if p.peekTokenIs(token.IDENT) && p.peekToken.Literal == "from" {
+			source, ok := p.parseFromClause()
+			if !ok {
+				return nil
+			}
+			decl.Source = source
+			return decl
+		}
+
+		// Without a source, the names refer to bindings of this module
+		for _, spec := range decl.Specifiers {
+			if !isBindingName(spec.Local) {
+				p.errors = append(p.errors, fmt.Sprintf("cannot export keyword %q without a from clause", spec.Local))
+				return nil
+			}
+		}
+		p.skipSemicolon()
+		return decl
+
+	case p.peekTokenIs(token.FUNC):
+		p.errors = append(p.errors, "function declarations are not supported; use export var name = function() { ... }")
+		return nil
+
+	default:
+		p.errors = append(p.errors, fmt.Sprintf("unexpected %s after export", p.peekToken.Type))
+		return nil
+	}
+}
+
+// parseModuleSpecifiers parses a braced list of "name" or "name as alias"
+// entries, calling add for each; alias is empty when there is no "as".
+// Names may be keywords (import { default as x }), so validating them is up to add.
+//
+// Example: "{ a, b as c, }" → add("a", ""), add("b", "c")
+func (p *Parser) parseModuleSpecifiers(add func(name, alias string) bool) bool {
+	for !p.peekTokenIs(token.RBRACE) {
+		p.nextToken()
+		if !isIdentifierName(p.currentToken) {
+			p.errors = append(p.errors, fmt.Sprintf("expected a name in braces, got %s instead", p.currentToken.Type))
+			return false
+		}
+		name, alias := p.currentToken.Literal, ""
+
+		if p.peekTokenIs(token.IDENT) && p.peekToken.Literal == "as" {
+			p.nextToken()
+			p.nextToken()
+			if !isIdentifierName(p.currentToken) {
+				p.errors = append(p.errors, fmt.Sprintf("expected a name after 'as', got %s instead", p.currentToken.Type))
+				return false
+			}
+			alias = p.currentToken.Literal
+		}
+
+		if !add(name, alias) {
+			return false
+		}
+
+		if !p.peekTokenIs(token.RBRACE) && !p.expectPeek(token.COMMA) {
+			return false
+		}
+	}
+
+	p.nextToken() // move to '}'
+	return true
+}
+
+// isBindingName reports whether a name can be a variable: not a keyword,
+// and not "default", which the lexer reads as an identifier
+func isBindingName(name string) bool {
+	return token.LookupIdent(name) == token.IDENT && name != "default"
+}
+
+// parseFromClause parses "from \"<source>\"" and an optional semicolon
+func (p *Parser) parseFromClause() (string, bool) {
+	if !p.expectContextual("from") || !p.expectPeek(token.STRING) {
+		return "", false
+	}
+	source := p.currentToken.Literal
+	p.skipSemicolon()
+	return source, true
+}
+
+// expectContextual is expectPeek for contextual keywords like "from" and
+// "as", which the lexer reads as identifiers
+func (p *Parser) expectContextual(word string) bool {
+	if p.peekTokenIs(token.IDENT) && p.peekToken.Literal == word {
+		p.nextToken()
+		return true
+	}
+	p.errors = append(p.errors, fmt.Sprintf("expected next token to be %s, got %s instead", word, p.peekToken.Type))
+	return false
+}
+
+// skipSemicolon consumes an optional ';' at the end of a statement
+func (p *Parser) skipSemicolon() {
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+}
+
+// parseImportExpression parses a dynamic import, which loads a module at
+// run time
+//
+// Example: "import(\"./m.js\")" → ImportExpression{Source: StringLiteral{"./m.js"}}
+func (p *Parser) parseImportExpression() ast.Expression {
+	if !p.expectPeek(token.LPAREN) {
+		return nil
+	}
+
+	p.nextToken()
+	source := p.parseAssignmentExpression()
+	if source == nil {
+		return nil
+	}
+
+	if !p.expectPeek(token.RPAREN) {
+		return nil
+	}
+
+	return &ast.ImportExpression{Source: source}
+}
@@ -1,7 +1,9 @@
 package parser
 
 import (
+	"fmt"
 	"go-script/ast"
+	"strings"
 	"testing"
 )
 
@@ -498,17 +500,98 @@ func TestObjectLiteralParsing(t *testing.T) {
 			stmt.Value)
 	}
 
-	if len(objLit.Pairs) != 2 {
-		t.Fatalf("object literal has wrong number of pairs. got=%d",
-			len(objLit.Pairs))
+	if len(objLit.Properties) != 2 {
+		t.Fatalf("object literal has wrong number of properties. got=%d",
+			len(objLit.Properties))
 	}
 
-	if _, ok := objLit.Pairs["name"]; !ok {
-		t.Errorf("object literal missing 'name' key")
+	if objLit.Properties[0].Key != "name" {
+		t.Errorf("object literal first key not 'name'. got=%s", objLit.Properties[0].Key)
 	}
 
-	if _, ok := objLit.Pairs["age"]; !ok {
-		t.Errorf("object literal missing 'age' key")
+	if objLit.Properties[1].Key != "age" {
+		t.Errorf("object literal second key not 'age'. got=%s", objLit.Properties[1].Key)
+	}
+}
+
+func TestObjectLiteralPropertyForms(t *testing.T) {
+	input := `var obj = {
+	a,
+	b: 2,
+	"quoted key": 3,
+	1.50: "one and a half",
+	[prefix + "Id"]: 4,
+	greet(name) { return "hi " + name; },
+	get size() { return 1; },
+	set size(v) { },
+	get: "plain property named get",
+	set() { return "method named set"; },
+	new: "keyword key",
+};`
+
+	p := New(input)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	stmt := program.Statements[0].(*ast.VarStatement)
+	objLit, ok := stmt.Value.(*ast.ObjectLiteral)
+	if !ok {
+		t.Fatalf("stmt.Value is not ast.ObjectLiteral. got=%T", stmt.Value)
+	}
+
+	expected := []struct {
+		kind      ast.PropertyKind
+		key       string
+		computed  bool
+		shorthand bool
+		method    bool
+	}{
+		{ast.PropertyInit, "a", false, true, false},
+		{ast.PropertyInit, "b", false, false, false},
+		{ast.PropertyInit, "quoted key", false, false, false},
+		{ast.PropertyInit, "1.5", false, false, false},
+		{ast.PropertyInit, "", true, false, false},
+		{ast.PropertyInit, "greet", false, false, true},
+		{ast.PropertyGetter, "size", false, false, false},
+		{ast.PropertySetter, "size", false, false, false},
+		{ast.PropertyInit, "get", false, false, false},
+		{ast.PropertyInit, "set", false, false, true},
+		{ast.PropertyInit, "new", false, false, false},
+	}
+
+	if len(objLit.Properties) != len(expected) {
+		t.Fatalf("object literal has wrong number of properties. expected=%d, got=%d",
+			len(expected), len(objLit.Properties))
+	}
+
+	for i, tt := range expected {
+		prop := objLit.Properties[i]
+		if prop.Kind != tt.kind || prop.Key != tt.key || (prop.Computed != nil) != tt.computed ||
+			prop.Shorthand != tt.shorthand || prop.Method != tt.method {
+			t.Errorf("properties[%d] wrong. expected=%+v, got kind=%s key=%q computed=%v shorthand=%v method=%v",
+				i, tt, prop.Kind, prop.Key, prop.Computed != nil, prop.Shorthand, prop.Method)
+		}
+	}
+}
+
+func TestObjectLiteralSyntaxErrors(t *testing.T) {
+	tests := []string{
+		`var obj = { a: 1 b: 2 };`,
+		`var obj = { "a" };`,
+		`var obj = { a: };`,
+		`var obj = { [k] };`,
+		`var obj = { get x };`,
+		`var obj = { , };`,
+		`var obj = { a: 1`,
+	}
+
+	for _, input := range tests {
+		p := New(input)
+		p.ParseProgram()
+
+		if len(p.Errors()) == 0 {
+			t.Errorf("expected parser errors for %q, got none", input)
+		}
 	}
 }
 
@@ -670,3 +753,960 @@ func TestIndexExpressionParsing(t *testing.T) {
 		})
 	}
 }
+
+func TestMemberAssignmentParsing(t *testing.T) {
+	tests := []struct {
+		input      string
+		targetType string
+	}{
+		{"this.x = 5;", "*ast.PropertyAccess"},
+		{"obj[key] = 5;", "*ast.IndexExpression"},
+		{"this.#count = 0;", "*ast.PropertyAccess"},
+	}
+
+	for _, tt := range tests {
+		p := New(tt.input)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		stmt := program.Statements[0].(*ast.ExpressionStatement)
+		assign, ok := stmt.Expression.(*ast.MemberAssignExpression)
+		if !ok {
+			t.Fatalf("stmt.Expression is not ast.MemberAssignExpression. got=%T",
+				stmt.Expression)
+		}
+
+		if got := fmt.Sprintf("%T", assign.Target); got != tt.targetType {
+			t.Errorf("For %q: target type wrong. expected=%s, got=%s", tt.input, tt.targetType, got)
+		}
+	}
+}
+
+func TestNewExpressionParsing(t *testing.T) {
+	tests := []struct {
+		input        string
+		argCount     int
+		calleeIsPath bool
+	}{
+		{"new Point(1, 2);", 2, false},
+		{"new Point;", 0, false},
+		{"new shapes.Circle(1);", 1, true},
+	}
+
+	for _, tt := range tests {
+		p := New(tt.input)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		stmt := program.Statements[0].(*ast.ExpressionStatement)
+		exp, ok := stmt.Expression.(*ast.NewExpression)
+		if !ok {
+			t.Fatalf("stmt.Expression is not ast.NewExpression. got=%T", stmt.Expression)
+		}
+
+		if len(exp.Arguments) != tt.argCount {
+			t.Errorf("For %q: wrong number of arguments. expected=%d, got=%d", tt.input, tt.argCount, len(exp.Arguments))
+		}
+
+		if _, isPath := exp.Callee.(*ast.PropertyAccess); isPath != tt.calleeIsPath {
+			t.Errorf("For %q: unexpected callee %T", tt.input, exp.Callee)
+		}
+	}
+}
+
+func TestNewExpressionFollowedByCall(t *testing.T) {
+	p := New("new Counter().increment();")
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	call, ok := stmt.Expression.(*ast.CallExpression)
+	if !ok {
+		t.Fatalf("stmt.Expression is not ast.CallExpression. got=%T", stmt.Expression)
+	}
+
+	access, ok := call.Function.(*ast.PropertyAccess)
+	if !ok {
+		t.Fatalf("call.Function is not ast.PropertyAccess. got=%T", call.Function)
+	}
+
+	if _, ok := access.Object.(*ast.NewExpression); !ok {
+		t.Errorf("access.Object is not ast.NewExpression. got=%T", access.Object)
+	}
+}
+
+func TestClassDeclarationParsing(t *testing.T) {
+	input := `
+class Dog extends Animal {
+	#legs = 4;
+	name;
+	static count = 0;
+	constructor(name) { super(name); }
+	bark() { return "woof"; }
+	get legs() { return this.#legs; }
+	set legs(v) { this.#legs = v; }
+	static create() { return new Dog("rex"); }
+	static { Dog.count = 1; }
+	static() { return "a method called static"; }
+}`
+
+	p := New(input)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	if len(program.Statements) != 1 {
+		t.Fatalf("program.Statements does not contain 1 statement. got=%d",
+			len(program.Statements))
+	}
+
+	decl, ok := program.Statements[0].(*ast.ClassDeclaration)
+	if !ok {
+		t.Fatalf("program.Statements[0] is not ast.ClassDeclaration. got=%T",
+			program.Statements[0])
+	}
+
+	class := decl.Class
+	if class.Name != "Dog" {
+		t.Errorf("class.Name not 'Dog'. got=%s", class.Name)
+	}
+
+	if parent, ok := class.SuperClass.(*ast.Identifier); !ok || parent.Name != "Animal" {
+		t.Errorf("class.SuperClass not Identifier 'Animal'. got=%v", class.SuperClass)
+	}
+
+	if class.Constructor == nil || len(class.Constructor.Parameters) != 1 {
+		t.Fatalf("class.Constructor not parsed correctly. got=%v", class.Constructor)
+	}
+
+	expected := []struct {
+		kind   ast.ClassMemberKind
+		key    string
+		static bool
+	}{
+		{ast.ClassField, "#legs", false},
+		{ast.ClassField, "name", false},
+		{ast.ClassField, "count", true},
+		{ast.ClassMethod, "bark", false},
+		{ast.ClassGetter, "legs", false},
+		{ast.ClassSetter, "legs", false},
+		{ast.ClassMethod, "create", true},
+		{ast.ClassStaticBlock, "", true},
+		{ast.ClassMethod, "static", false},
+	}
+
+	if len(class.Members) != len(expected) {
+		t.Fatalf("class has wrong number of members. expected=%d, got=%d",
+			len(expected), len(class.Members))
+	}
+
+	for i, tt := range expected {
+		member := class.Members[i]
+		if member.Kind != tt.kind || member.Key != tt.key || member.Static != tt.static {
+			t.Errorf("members[%d] wrong. expected=%s %q static=%v, got=%s %q static=%v",
+				i, tt.kind, tt.key, tt.static, member.Kind, member.Key, member.Static)
+		}
+	}
+}
+
+func TestClassExpressionParsing(t *testing.T) {
+	p := New("var Point = class { x = 0 };")
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	stmt := program.Statements[0].(*ast.VarStatement)
+	class, ok := stmt.Value.(*ast.ClassLiteral)
+	if !ok {
+		t.Fatalf("stmt.Value is not ast.ClassLiteral. got=%T", stmt.Value)
+	}
+
+	if class.Name != "" {
+		t.Errorf("anonymous class should have no name. got=%s", class.Name)
+	}
+
+	if len(class.Members) != 1 || class.Members[0].Kind != ast.ClassField {
+		t.Errorf("expected a single field member. got=%v", class.Members)
+	}
+}
+
+func TestKeywordPropertyNames(t *testing.T) {
+	p := New("token.new; obj.class;")
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	for i, name := range []string{"new", "class"} {
+		stmt := program.Statements[i].(*ast.ExpressionStatement)
+		access, ok := stmt.Expression.(*ast.PropertyAccess)
+		if !ok || access.Property != name {
+			t.Errorf("statement %d: expected property %q, got %v", i, name, stmt.Expression)
+		}
+	}
+}
+
+func TestOptionalChainParsing(t *testing.T) {
+	p := New("a?.b.c;")
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	chain, ok := stmt.Expression.(*ast.OptionalChain)
+	if !ok {
+		t.Fatalf("expected *ast.OptionalChain, got %T", stmt.Expression)
+	}
+
+	outer, ok := chain.Expression.(*ast.PropertyAccess)
+	if !ok || outer.Property != "c" || outer.Optional {
+		t.Fatalf("expected non-optional .c access, got %+v", chain.Expression)
+	}
+
+	inner, ok := outer.Object.(*ast.PropertyAccess)
+	if !ok || inner.Property != "b" || !inner.Optional {
+		t.Fatalf("expected optional ?.b access, got %+v", outer.Object)
+	}
+}
+
+func TestOptionalChainLinks(t *testing.T) {
+	p := New("a?.[0]; f?.(1, 2); a?.b + 1;")
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	index, ok := program.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.OptionalChain).Expression.(*ast.IndexExpression)
+	if !ok || !index.Optional {
+		t.Errorf("expected optional index expression, got %+v", program.Statements[0])
+	}
+
+	call, ok := program.Statements[1].(*ast.ExpressionStatement).Expression.(*ast.OptionalChain).Expression.(*ast.CallExpression)
+	if !ok || !call.Optional || len(call.Arguments) != 2 {
+		t.Errorf("expected optional call with 2 arguments, got %+v", program.Statements[1])
+	}
+
+	// The chain ends before the + operator
+	infix, ok := program.Statements[2].(*ast.ExpressionStatement).Expression.(*ast.InfixExpression)
+	if !ok {
+		t.Fatalf("expected *ast.InfixExpression, got %T", program.Statements[2].(*ast.ExpressionStatement).Expression)
+	}
+	if _, ok := infix.Left.(*ast.OptionalChain); !ok {
+		t.Errorf("expected optional chain on the left of +, got %T", infix.Left)
+	}
+}
+
+func TestOptionalChainAssignmentError(t *testing.T) {
+	p := New("a?.b = 1;")
+	p.ParseProgram()
+
+	if len(p.Errors()) == 0 {
+		t.Errorf("expected an error for assignment to an optional chain")
+	}
+}
+
+func TestConditionalExpressionParsing(t *testing.T) {
+	p := New("a ? b : c ? d : e;")
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	exp, ok := stmt.Expression.(*ast.ConditionalExpression)
+	if !ok {
+		t.Fatalf("expected *ast.ConditionalExpression, got %T", stmt.Expression)
+	}
+
+	if _, ok := exp.Consequence.(*ast.Identifier); !ok {
+		t.Errorf("expected identifier consequence, got %T", exp.Consequence)
+	}
+
+	// Nested ternaries group to the right
+	nested, ok := exp.Alternative.(*ast.ConditionalExpression)
+	if !ok {
+		t.Fatalf("expected nested conditional as alternative, got %T", exp.Alternative)
+	}
+	if cond, ok := nested.Condition.(*ast.Identifier); !ok || cond.Name != "c" {
+		t.Errorf("expected nested condition c, got %v", nested.Condition)
+	}
+}
+
+func TestConditionalExpressionPrecedence(t *testing.T) {
+	p := New("x = a == b ? 1 : 2;")
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	assign, ok := program.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.AssignExpression)
+	if !ok {
+		t.Fatalf("expected *ast.AssignExpression, got %T", program.Statements[0].(*ast.ExpressionStatement).Expression)
+	}
+
+	cond, ok := assign.Value.(*ast.ConditionalExpression)
+	if !ok {
+		t.Fatalf("expected conditional as assigned value, got %T", assign.Value)
+	}
+
+	if infix, ok := cond.Condition.(*ast.InfixExpression); !ok || infix.Operator != "==" {
+		t.Errorf("expected == as condition, got %v", cond.Condition)
+	}
+}
+
+func TestSequenceExpressionParsing(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int
+	}{
+		{"a, b;", 2},
+		{"a, b, c;", 3},
+		{"x = 1, y = 2;", 2},
+		{"(a, b, c);", 3},
+	}
+
+	for _, tt := range tests {
+		p := New(tt.input)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		stmt := program.Statements[0].(*ast.ExpressionStatement)
+		seq, ok := stmt.Expression.(*ast.SequenceExpression)
+		if !ok {
+			t.Errorf("For input %q: expected *ast.SequenceExpression, got %T", tt.input, stmt.Expression)
+			continue
+		}
+		if len(seq.Expressions) != tt.expected {
+			t.Errorf("For input %q: expected %d expressions, got %d", tt.input, tt.expected, len(seq.Expressions))
+		}
+	}
+}
+
+func TestCommaSeparatedListsAreNotSequences(t *testing.T) {
+	p := New("f(a, b); [a, b]; var x = { a: 1, b: 2 };")
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	call := program.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.CallExpression)
+	if len(call.Arguments) != 2 {
+		t.Errorf("expected 2 call arguments, got %d", len(call.Arguments))
+	}
+
+	array := program.Statements[1].(*ast.ExpressionStatement).Expression.(*ast.ArrayLiteral)
+	if len(array.Elements) != 2 {
+		t.Errorf("expected 2 array elements, got %d", len(array.Elements))
+	}
+
+	obj := program.Statements[2].(*ast.VarStatement).Value.(*ast.ObjectLiteral)
+	if len(obj.Properties) != 2 {
+		t.Errorf("expected 2 object properties, got %d", len(obj.Properties))
+	}
+}
+
+func TestForOfStatementParsing(t *testing.T) {
+	tests := []struct {
+		input        string
+		expectedKind string
+		expectedName string
+	}{
+		{"for (let x of items) { print(x); }", "let", "x"},
+		{"for (var item of [1, 2]) {}", "var", "item"},
+		{"for (x of items) {}", "", "x"},
+		{"for await (let chunk of stream) {}", "let", "chunk"},
+	}
+
+	for _, tt := range tests {
+		p := New(tt.input)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		stmt, ok := program.Statements[0].(*ast.ForOfStatement)
+		if !ok {
+			t.Fatalf("For input %q: expected *ast.ForOfStatement, got %T", tt.input, program.Statements[0])
+		}
+		if stmt.Kind != tt.expectedKind || stmt.Name != tt.expectedName {
+			t.Errorf("For input %q: expected %q %q, got %q %q", tt.input, tt.expectedKind, tt.expectedName, stmt.Kind, stmt.Name)
+		}
+		if stmt.Iterable == nil || stmt.Body == nil {
+			t.Errorf("For input %q: missing iterable or body", tt.input)
+		}
+		if stmt.Await != strings.HasPrefix(tt.input, "for await") {
+			t.Errorf("For input %q: expected Await to be %v", tt.input, !stmt.Await)
+		}
+	}
+}
+
+func TestForOfStatementErrors(t *testing.T) {
+	p := New("for (let x in items) {}")
+	p.ParseProgram()
+
+	if len(p.Errors()) == 0 {
+		t.Errorf("expected an error for a for statement without 'of'")
+	}
+}
+
+func TestTypeofAndInstanceofParsing(t *testing.T) {
+	p := New("typeof x == \"number\"; a instanceof B == true;")
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	eq := program.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.InfixExpression)
+	if prefix, ok := eq.Left.(*ast.PrefixExpression); !ok || prefix.Operator != "typeof" {
+		t.Errorf("expected typeof to bind tighter than ==, got %T", eq.Left)
+	}
+
+	eq = program.Statements[1].(*ast.ExpressionStatement).Expression.(*ast.InfixExpression)
+	if infix, ok := eq.Left.(*ast.InfixExpression); !ok || infix.Operator != "instanceof" {
+		t.Errorf("expected instanceof to bind tighter than ==, got %T", eq.Left)
+	}
+}
+
+func TestComputedClassMemberParsing(t *testing.T) {
+	p := New("class List { [Symbol.iterator]() {} static [key] = 1; get [name]() {} }")
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	class := program.Statements[0].(*ast.ClassDeclaration).Class
+	if len(class.Members) != 3 {
+		t.Fatalf("expected 3 members, got %d", len(class.Members))
+	}
+
+	expected := []ast.ClassMemberKind{ast.ClassMethod, ast.ClassField, ast.ClassGetter}
+	for i, member := range class.Members {
+		if member.Computed == nil {
+			t.Errorf("member %d: expected a computed key", i)
+		}
+		if member.Kind != expected[i] {
+			t.Errorf("member %d: expected kind %s, got %s", i, expected[i], member.Kind)
+		}
+	}
+
+	if !class.Members[1].Static {
+		t.Errorf("expected the computed field to be static")
+	}
+}
+
+func TestGeneratorFunctionParsing(t *testing.T) {
+	p := New("var gen = function*() { var x = yield 1; yield; yield* other(); };")
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	fn, ok := program.Statements[0].(*ast.VarStatement).Value.(*ast.FunctionLiteral)
+	if !ok {
+		t.Fatalf("expected *ast.FunctionLiteral, got %T", program.Statements[0].(*ast.VarStatement).Value)
+	}
+	if !fn.Generator {
+		t.Fatalf("expected a generator function")
+	}
+
+	statements := fn.Body.Statements
+	if len(statements) != 3 {
+		t.Fatalf("expected 3 statements in the body, got %d", len(statements))
+	}
+
+	first, ok := statements[0].(*ast.VarStatement).Value.(*ast.YieldExpression)
+	if !ok || first.Argument == nil || first.Delegate {
+		t.Errorf("expected 'yield 1' as the initializer, got %#v", statements[0].(*ast.VarStatement).Value)
+	}
+
+	bare, ok := statements[1].(*ast.ExpressionStatement).Expression.(*ast.YieldExpression)
+	if !ok || bare.Argument != nil {
+		t.Errorf("expected a bare yield, got %#v", statements[1].(*ast.ExpressionStatement).Expression)
+	}
+
+	delegate, ok := statements[2].(*ast.ExpressionStatement).Expression.(*ast.YieldExpression)
+	if !ok || !delegate.Delegate {
+		t.Errorf("expected yield*, got %#v", statements[2].(*ast.ExpressionStatement).Expression)
+	}
+}
+
+func TestGeneratorMethodParsing(t *testing.T) {
+	p := New("var obj = { *items() { yield 1; } }; class List { *[Symbol.iterator]() { yield 2; } }")
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	obj := program.Statements[0].(*ast.VarStatement).Value.(*ast.ObjectLiteral)
+	method, ok := obj.Properties[0].Value.(*ast.FunctionLiteral)
+	if !ok || !method.Generator {
+		t.Errorf("expected a generator object method, got %#v", obj.Properties[0].Value)
+	}
+
+	class := program.Statements[1].(*ast.ClassDeclaration).Class
+	if !class.Members[0].Value.(*ast.FunctionLiteral).Generator {
+		t.Errorf("expected a generator class method")
+	}
+}
+
+func TestYieldOutsideGenerator(t *testing.T) {
+	tests := []string{
+		"yield 1;",
+		"var f = function() { yield 1; };",
+		"var gen = function*() { var f = function() { yield 1; }; };",
+	}
+
+	for _, input := range tests {
+		p := New(input)
+		p.ParseProgram()
+
+		if len(p.Errors()) == 0 {
+			t.Errorf("For input %q: expected an error for yield outside a generator", input)
+		}
+	}
+}
+
+func TestThrowStatementParsing(t *testing.T) {
+	p := New(`throw "boom";`)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	stmt, ok := program.Statements[0].(*ast.ThrowStatement)
+	if !ok {
+		t.Fatalf("expected *ast.ThrowStatement, got %T", program.Statements[0])
+	}
+	if _, ok := stmt.Value.(*ast.StringLiteral); !ok {
+		t.Errorf("expected a string value, got %T", stmt.Value)
+	}
+}
+
+func TestTryStatementParsing(t *testing.T) {
+	tests := []struct {
+		input         string
+		expectedParam string
+		hasCatch      bool
+		hasFinally    bool
+	}{
+		{"try { a(); } catch (e) { b(e); }", "e", true, false},
+		{"try { a(); } finally { c(); }", "", false, true},
+		{"try { a(); } catch (err) { b(); } finally { c(); }", "err", true, true},
+		{"try { a(); } catch { b(); }", "", true, false},
+	}
+
+	for _, tt := range tests {
+		p := New(tt.input)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		stmt, ok := program.Statements[0].(*ast.TryStatement)
+		if !ok {
+			t.Fatalf("For input %q: expected *ast.TryStatement, got %T", tt.input, program.Statements[0])
+		}
+		if stmt.CatchParam != tt.expectedParam {
+			t.Errorf("For input %q: expected catch param %q, got %q", tt.input, tt.expectedParam, stmt.CatchParam)
+		}
+		if (stmt.Catch != nil) != tt.hasCatch || (stmt.Finally != nil) != tt.hasFinally {
+			t.Errorf("For input %q: unexpected catch/finally blocks", tt.input)
+		}
+	}
+}
+
+func TestTryStatementErrors(t *testing.T) {
+	p := New("try { a(); }")
+	p.ParseProgram()
+
+	if len(p.Errors()) == 0 {
+		t.Errorf("expected an error for try without catch or finally")
+	}
+}
+
+func TestArrowFunctionParsing(t *testing.T) {
+	tests := []struct {
+		input          string
+		expectedParams []string
+		expectedAsync  bool
+		expressionBody bool
+	}{
+		{"x => x * 2;", []string{"x"}, false, true},
+		{"(a, b) => a + b;", []string{"a", "b"}, false, true},
+		{"() => { run(); };", []string{}, false, false},
+		{"(x) => { return x; };", []string{"x"}, false, false},
+		{"async x => await x;", []string{"x"}, true, true},
+		{"async (a, b) => { await a; };", []string{"a", "b"}, true, false},
+		{"async () => 1;", []string{}, true, true},
+	}
+
+	for _, tt := range tests {
+		p := New(tt.input)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		fn, ok := program.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.FunctionLiteral)
+		if !ok {
+			t.Fatalf("For input %q: expected *ast.FunctionLiteral, got %T", tt.input, program.Statements[0].(*ast.ExpressionStatement).Expression)
+		}
+		if !fn.Arrow || fn.Async != tt.expectedAsync {
+			t.Errorf("For input %q: expected arrow=true async=%v, got arrow=%v async=%v", tt.input, tt.expectedAsync, fn.Arrow, fn.Async)
+		}
+		if fmt.Sprint(fn.Parameters) != fmt.Sprint(tt.expectedParams) {
+			t.Errorf("For input %q: expected parameters %v, got %v", tt.input, tt.expectedParams, fn.Parameters)
+		}
+
+		// Expression bodies become a block with a single return statement
+		if tt.expressionBody {
+			if len(fn.Body.Statements) != 1 {
+				t.Fatalf("For input %q: expected one statement, got %d", tt.input, len(fn.Body.Statements))
+			}
+			if _, ok := fn.Body.Statements[0].(*ast.ReturnStatement); !ok {
+				t.Errorf("For input %q: expected an implicit return, got %T", tt.input, fn.Body.Statements[0])
+			}
+		}
+	}
+}
+
+func TestArrowFunctionAsArgument(t *testing.T) {
+	p := New("items.map(x => x + 1, (a, b) => a);")
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	call := program.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.CallExpression)
+	if len(call.Arguments) != 2 {
+		t.Fatalf("expected 2 arguments, got %d", len(call.Arguments))
+	}
+	for i, arg := range call.Arguments {
+		if fn, ok := arg.(*ast.FunctionLiteral); !ok || !fn.Arrow {
+			t.Errorf("argument %d: expected an arrow function, got %T", i, arg)
+		}
+	}
+}
+
+func TestAsyncFunctionParsing(t *testing.T) {
+	p := New(`var f = async function(a) { return await a; };
+var obj = { async load() { await x; }, async: 1 };
+class Api { async fetch() {} static async create() {} async = 2; }`)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	fn := program.Statements[0].(*ast.VarStatement).Value.(*ast.FunctionLiteral)
+	if !fn.Async || fn.Arrow {
+		t.Errorf("expected an async function expression")
+	}
+	ret := fn.Body.Statements[0].(*ast.ReturnStatement)
+	if _, ok := ret.Value.(*ast.AwaitExpression); !ok {
+		t.Errorf("expected an await expression, got %T", ret.Value)
+	}
+
+	obj := program.Statements[1].(*ast.VarStatement).Value.(*ast.ObjectLiteral)
+	if method := obj.Properties[0].Value.(*ast.FunctionLiteral); !method.Async || obj.Properties[0].Key != "load" {
+		t.Errorf("expected async method load")
+	}
+	if obj.Properties[1].Key != "async" {
+		t.Errorf("expected a property named async, got %q", obj.Properties[1].Key)
+	}
+
+	class := program.Statements[2].(*ast.ClassDeclaration).Class
+	if !class.Members[0].Value.(*ast.FunctionLiteral).Async || class.Members[0].Key != "fetch" {
+		t.Errorf("expected async method fetch")
+	}
+	if !class.Members[1].Static || !class.Members[1].Value.(*ast.FunctionLiteral).Async {
+		t.Errorf("expected static async method create")
+	}
+	if class.Members[2].Kind != ast.ClassField || class.Members[2].Key != "async" {
+		t.Errorf("expected a field named async")
+	}
+}
+
+func TestAsyncAsIdentifier(t *testing.T) {
+	p := New("var async = 1; async(2); async;")
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	call, ok := program.Statements[1].(*ast.ExpressionStatement).Expression.(*ast.CallExpression)
+	if !ok {
+		t.Fatalf("expected a call to async, got %T", program.Statements[1].(*ast.ExpressionStatement).Expression)
+	}
+	if ident, ok := call.Function.(*ast.Identifier); !ok || ident.Name != "async" {
+		t.Errorf("expected the callee to be async, got %v", call.Function)
+	}
+
+	if _, ok := program.Statements[2].(*ast.ExpressionStatement).Expression.(*ast.Identifier); !ok {
+		t.Errorf("expected a plain identifier")
+	}
+}
+
+func TestAwaitParsing(t *testing.T) {
+	p := New("var x = await a + await b;")
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	sum, ok := program.Statements[0].(*ast.VarStatement).Value.(*ast.InfixExpression)
+	if !ok {
+		t.Fatalf("expected await to bind tighter than +, got %T", program.Statements[0].(*ast.VarStatement).Value)
+	}
+	if _, ok := sum.Left.(*ast.AwaitExpression); !ok {
+		t.Errorf("expected await on the left, got %T", sum.Left)
+	}
+	if _, ok := sum.Right.(*ast.AwaitExpression); !ok {
+		t.Errorf("expected await on the right, got %T", sum.Right)
+	}
+}
+
+func TestAwaitOutsideAsyncFunction(t *testing.T) {
+	tests := []string{
+		"var f = function() { await x; };",
+		"var f = () => await x;",
+		"var f = async () => { var g = function() { await x; }; };",
+		"var gen = function*() { await x; };",
+		"var obj = { load() { await x; } };",
+		"var f = function() { for await (let x of xs) {} };",
+	}
+
+	for _, input := range tests {
+		p := New(input)
+		p.ParseProgram()
+
+		if len(p.Errors()) == 0 {
+			t.Errorf("For input %q: expected an error for await outside an async function", input)
+		}
+	}
+}
+
+func TestInvalidArrowParameters(t *testing.T) {
+	tests := []string{
+		"(1) => x;",
+		"(a + b) => x;",
+		"async (a, 2) => x;",
+		"async function*() {};",
+	}
+
+	for _, input := range tests {
+		p := New(input)
+		p.ParseProgram()
+
+		if len(p.Errors()) == 0 {
+			t.Errorf("For input %q: expected a parser error", input)
+		}
+	}
+}
+
+func TestImportDeclarationParsing(t *testing.T) {
+	tests := []struct {
+		input      string
+		source     string
+		def        string
+		namespace  string
+		specifiers []ast.ImportSpecifier
+	}{
+		{`import "./setup.js";`, "./setup.js", "", "", nil},
+		{`import greet from "./greet.js";`, "./greet.js", "greet", "", nil},
+		{`import * as math from './math.js'`, "./math.js", "", "math", nil},
+		{`import { a, b as c, default as d, } from "./m.js";`, "./m.js", "", "",
+			[]ast.ImportSpecifier{{Imported: "a", Local: "a"}, {Imported: "b", Local: "c"}, {Imported: "default", Local: "d"}}},
+		{`import def, { x } from "./m.js";`, "./m.js", "def", "", []ast.ImportSpecifier{{Imported: "x", Local: "x"}}},
+		{`import def, * as ns from "./m.js";`, "./m.js", "def", "ns", nil},
+		{`import {} from "./m.js";`, "./m.js", "", "", []ast.ImportSpecifier{}},
+	}
+
+	for _, tt := range tests {
+		p := New(tt.input)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		if len(program.Statements) != 1 {
+			t.Fatalf("For input %q: expected 1 statement, got %d", tt.input, len(program.Statements))
+		}
+		decl, ok := program.Statements[0].(*ast.ImportDeclaration)
+		if !ok {
+			t.Fatalf("For input %q: expected *ast.ImportDeclaration, got %T", tt.input, program.Statements[0])
+		}
+
+		if decl.Source != tt.source || decl.Default != tt.def || decl.Namespace != tt.namespace {
+			t.Errorf("For input %q: expected source=%q default=%q namespace=%q, got %q %q %q",
+				tt.input, tt.source, tt.def, tt.namespace, decl.Source, decl.Default, decl.Namespace)
+		}
+		if len(decl.Specifiers) != len(tt.specifiers) {
+			t.Fatalf("For input %q: expected %d specifiers, got %d", tt.input, len(tt.specifiers), len(decl.Specifiers))
+		}
+		for i, spec := range tt.specifiers {
+			if *decl.Specifiers[i] != spec {
+				t.Errorf("For input %q: expected specifier %v, got %v", tt.input, spec, *decl.Specifiers[i])
+			}
+		}
+	}
+}
+
+func TestExportDeclarationParsing(t *testing.T) {
+	p := New(`export var x = 1;
+export class Point {}
+export { x as y, Point };
+export { default, a as b } from "./m.js";
+export * from "./all.js";
+export * as ns from "./ns.js";`)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	if len(program.Statements) != 6 {
+		t.Fatalf("expected 6 statements, got %d", len(program.Statements))
+	}
+	decls := make([]*ast.ExportDeclaration, len(program.Statements))
+	for i, stmt := range program.Statements {
+		decl, ok := stmt.(*ast.ExportDeclaration)
+		if !ok {
+			t.Fatalf("statement %d: expected *ast.ExportDeclaration, got %T", i, stmt)
+		}
+		decls[i] = decl
+	}
+
+	if v, ok := decls[0].Declaration.(*ast.VarStatement); !ok || v.Name != "x" {
+		t.Errorf("expected an exported var x, got %#v", decls[0].Declaration)
+	}
+	if c, ok := decls[1].Declaration.(*ast.ClassDeclaration); !ok || c.Class.Name != "Point" {
+		t.Errorf("expected an exported class Point, got %#v", decls[1].Declaration)
+	}
+
+	if len(decls[2].Specifiers) != 2 || *decls[2].Specifiers[0] != (ast.ExportSpecifier{Local: "x", Exported: "y"}) ||
+		*decls[2].Specifiers[1] != (ast.ExportSpecifier{Local: "Point", Exported: "Point"}) || decls[2].Source != "" {
+		t.Errorf("unexpected export list %+v", decls[2])
+	}
+	if decls[3].Source != "./m.js" || len(decls[3].Specifiers) != 2 ||
+		*decls[3].Specifiers[0] != (ast.ExportSpecifier{Local: "default", Exported: "default"}) {
+		t.Errorf("unexpected re-export %+v", decls[3])
+	}
+	if !decls[4].All || decls[4].Source != "./all.js" || decls[4].Namespace != "" {
+		t.Errorf("unexpected star export %+v", decls[4])
+	}
+	if !decls[5].All || decls[5].Source != "./ns.js" || decls[5].Namespace != "ns" {
+		t.Errorf("unexpected namespace export %+v", decls[5])
+	}
+}
+
+func TestExportDefaultParsing(t *testing.T) {
+	tests := []struct {
+		input       string
+		declaration bool
+		valueType   string
+	}{
+		{"export default 42;", false, "*ast.NumberLiteral"},
+		{"export default function(a) { return a; }", false, "*ast.FunctionLiteral"},
+		{"export default (a) => a * 2;", false, "*ast.FunctionLiteral"},
+		{"export default class {}", false, "*ast.ClassLiteral"},
+		{"export default class Point {}", true, ""},
+	}
+
+	for _, tt := range tests {
+		p := New(tt.input)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		decl, ok := program.Statements[0].(*ast.ExportDefaultDeclaration)
+		if !ok {
+			t.Fatalf("For input %q: expected *ast.ExportDefaultDeclaration, got %T", tt.input, program.Statements[0])
+		}
+		if tt.declaration {
+			if _, ok := decl.Declaration.(*ast.ClassDeclaration); !ok || decl.Value != nil {
+				t.Errorf("For input %q: expected a class declaration, got %+v", tt.input, decl)
+			}
+			continue
+		}
+		if got := fmt.Sprintf("%T", decl.Value); got != tt.valueType || decl.Declaration != nil {
+			t.Errorf("For input %q: expected value %s, got %s", tt.input, tt.valueType, got)
+		}
+	}
+}
+
+func TestImportExpressionParsing(t *testing.T) {
+	p := New(`var m = import("./m.js"); import(name).then(f);`)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	exp, ok := program.Statements[0].(*ast.VarStatement).Value.(*ast.ImportExpression)
+	if !ok {
+		t.Fatalf("expected *ast.ImportExpression, got %T", program.Statements[0].(*ast.VarStatement).Value)
+	}
+	if lit, ok := exp.Source.(*ast.StringLiteral); !ok || lit.Value != "./m.js" {
+		t.Errorf("expected source \"./m.js\", got %#v", exp.Source)
+	}
+
+	call := program.Statements[1].(*ast.ExpressionStatement).Expression.(*ast.CallExpression)
+	access := call.Function.(*ast.PropertyAccess)
+	if _, ok := access.Object.(*ast.ImportExpression); !ok {
+		t.Errorf("expected import(name).then, got %T", access.Object)
+	}
+}
+
+func TestModuleDeclarationErrors(t *testing.T) {
+	tests := []string{
+		`if (x) { import a from "./a.js"; }`,
+		`var f = function() { export var x = 1; };`,
+		`import a from;`,
+		`import { a b } from "./a.js";`,
+		`import { default } from "./a.js";`,
+		`import * from "./a.js";`,
+		`export { default };`,
+		`export function f() {}`,
+		`export 42;`,
+		`export * "./a.js";`,
+	}
+
+	for _, input := range tests {
+		p := New(input)
+		p.ParseProgram()
+
+		if len(p.Errors()) == 0 {
+			t.Errorf("For input %q: expected a parser error", input)
+		}
+	}
+}
+
+func TestRegExpLiteralParsing(t *testing.T) {
+	tests := []struct {
+		input   string
+		pattern string
+		flags   string
+	}{
+		{`/ab+c/;`, "ab+c", ""},
+		{`/a\/b[/]/gi;`, `a\/b[/]`, "gi"},
+		{`var r = /(?<y>\d{4})/du;`, `(?<y>\d{4})`, "du"},
+		{`f(/=/);`, "=", ""},
+	}
+
+	for _, tt := range tests {
+		p := New(tt.input)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		var exp ast.Expression
+		switch stmt := program.Statements[0].(type) {
+		case *ast.VarStatement:
+			exp = stmt.Value
+		case *ast.ExpressionStatement:
+			exp = stmt.Expression
+			if call, ok := exp.(*ast.CallExpression); ok {
+				exp = call.Arguments[0]
+			}
+		}
+
+		lit, ok := exp.(*ast.RegExpLiteral)
+		if !ok {
+			t.Errorf("For input %q: expected *ast.RegExpLiteral, got %T", tt.input, exp)
+			continue
+		}
+		if lit.Pattern != tt.pattern || lit.Flags != tt.flags {
+			t.Errorf("For input %q: expected /%s/%s, got /%s/%s", tt.input, tt.pattern, tt.flags, lit.Pattern, lit.Flags)
+		}
+	}
+}
+
+func TestRegExpDivisionAmbiguity(t *testing.T) {
+	p := New("a / b / c; x = (n) / 2 / m;")
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	for _, stmt := range program.Statements {
+		exp := stmt.(*ast.ExpressionStatement).Expression
+		if assign, ok := exp.(*ast.AssignExpression); ok {
+			exp = assign.Value
+		}
+		if infix, ok := exp.(*ast.InfixExpression); !ok || infix.Operator != "/" {
+			t.Errorf("expected a division, got %#v", exp)
+		}
+	}
+}
+
+func TestRegExpLiteralErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`/(a/;`, "Invalid regular expression: /(a/: Unterminated group"},
+		{`/a**/g;`, "Invalid regular expression: /a**/g: Nothing to repeat"},
+		{`/a/gg;`, "Invalid regular expression flags"},
+		{`/a/x;`, "Invalid regular expression flags"},
+	}
+
+	for _, tt := range tests {
+		p := New(tt.input)
+		p.ParseProgram()
+
+		if len(p.Errors()) == 0 || p.Errors()[0] != tt.expected {
+			t.Errorf("For input %q: expected error %q, got %q", tt.input, tt.expected, p.Errors())
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package regex
+
+import (
+	"unicode"
+)
+
+type runeRange struct {
+	low, high rune
+}
+
+// charSet is the set of characters a class like [a-z\d_] matches:
+// ranges of characters plus predefined classes such as \d or \p{L}
+type charSet struct {
+	ranges  []runeRange
+	classes []func(rune) bool
+	negate  bool
+}
+
+// add puts a single character, or the characters of a class escape, in the set
+func (s *charSet) add(ch rune, class *charSet) {
+	if class == nil {
+		s.ranges = append(s.ranges, runeRange{ch, ch})
+		return
+	}
+	s.classes = append(s.classes, class.contains)
+}
+
+// has reports whether ch is one of the set's characters, ignoring negate
+func (s *charSet) has(ch rune) bool {
+	for _, r := range s.ranges {
+		if ch >= r.low && ch <= r.high {
+			return true
+		}
+	}
+	for _, class := range s.classes {
+		if class(ch) {
+			return true
+		}
+	}
+	return false
+}
+
+// contains reports whether the set matches ch, taking negate into account
+func (s *charSet) contains(ch rune) bool {
+	return s.has(ch) != s.negate
+}
+
+// classEscapeSet returns the set for \d \D \w \W \s \S
+func classEscapeSet(escape rune) *charSet {
+	var test func(rune) bool
+	switch unicode.ToLower(escape) {
+	case 'd':
+		test = func(ch rune) bool { return ch >= '0' && ch <= '9' }
+	case 'w':
+		test = isWordChar
+	case 's':
+		test = isSpace
+	}
+	return &charSet{classes: []func(rune) bool{test}, negate: unicode.IsUpper(escape)}
+}
+
+// isWordChar reports whether ch is in \w: [A-Za-z0-9_]
+func isWordChar(ch rune) bool {
+	return ch == '_' || (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') || (ch >= '0' && ch <= '9')
+}
+
+// isSpace reports whether ch is in \s: JavaScript white space and line terminators
+func isSpace(ch rune) bool {
+	switch ch {
+	case '\t', '\n', '\v', '\f', '\r', ' ', 0xA0, 0x1680, 0x2028, 0x2029, 0x202F, 0x205F, 0x3000, 0xFEFF:
+		return true
+	}
+	return ch >= 0x2000 && ch <= 0x200A
+}
+
+// isLineTerminator reports whether ch ends a line for ., ^ and $
+func isLineTerminator(ch rune) bool {
+	return ch == '\n' || ch == '\r' || ch == 0x2028 || ch == 0x2029
+}
+
+func isLetter(ch rune) bool {
+	return unicode.IsLetter(ch)
+}
+
+// generalCategories maps the long names of Unicode general categories to
+// the short names Go's unicode package uses
+var generalCategories = map[string]string{
+	"Letter": "L", "Cased_Letter": "LC", "Uppercase_Letter": "Lu", "Lowercase_Letter": "Ll",
+	"Titlecase_Letter": "Lt", "Modifier_Letter": "Lm", "Other_Letter": "Lo",
+	"Mark": "M", "Nonspacing_Mark": "Mn", "Spacing_Mark": "Mc", "Enclosing_Mark": "Me",
+	"Number": "N", "Decimal_Number": "Nd", "Letter_Number": "Nl", "Other_Number": "No",
+	"Punctuation": "P", "Connector_Punctuation": "Pc", "Dash_Punctuation": "Pd",
+	"Open_Punctuation": "Ps", "Close_Punctuation": "Pe", "Initial_Punctuation": "Pi",
+	"Final_Punctuation": "Pf", "Other_Punctuation": "Po",
+	"Symbol": "S", "Math_Symbol": "Sm", "Currency_Symbol": "Sc", "Modifier_Symbol": "Sk", "Other_Symbol": "So",
+	"Separator": "Z", "Space_Separator": "Zs", "Line_Separator": "Zl", "Paragraph_Separator": "Zp",
+	"Other": "C", "Control": "Cc", "Format": "Cf", "Surrogate": "Cs", "Private_Use": "Co", "Unassigned": "Cn",
+	"digit": "Nd",
+}
+
+// unicodeProperty returns the test for \p{name}
+//
+// Examples:
+//
+//	"L", "Letter", "gc=Lu", "General_Category=Uppercase_Letter"
+//	"Script=Greek", "sc=Latn"
+//	"White_Space", "Alphabetic", "ASCII", "Any"
+func unicodeProperty(name string) (func(rune) bool, bool) {
+	key, value := "", name
+	for i, ch := range name {
+		if ch == '=' {
+			key, value = name[:i], name[i+1:]
+			break
+		}
+	}
+
+	switch key {
+	case "General_Category", "gc":
+		return category(value)
+	case "Script", "sc", "Script_Extensions", "scx":
+		return table(unicode.Scripts[value])
+	case "":
+	default:
+		return nil, false
+	}
+
+	if test, ok := category(value); ok {
+		return test, true
+	}
+
+	switch value {
+	case "Any":
+		return func(rune) bool { return true }, true
+	case "ASCII":
+		return func(ch rune) bool { return ch < 0x80 }, true
+	case "Alphabetic":
+		return func(ch rune) bool {
+			return unicode.IsLetter(ch) || unicode.Is(unicode.Nl, ch) || unicode.Is(unicode.Other_Alphabetic, ch)
+		}, true
+	case "Lowercase":
+		return func(ch rune) bool { return unicode.IsLower(ch) || unicode.Is(unicode.Other_Lowercase, ch) }, true
+	case "Uppercase":
+		return func(ch rune) bool { return unicode.IsUpper(ch) || unicode.Is(unicode.Other_Uppercase, ch) }, true
+	}
+	return table(unicode.Properties[value])
+}
+
+func category(name string) (func(rune) bool, bool) {
+	if short, ok := generalCategories[name]; ok {
+		name = short
+	}
+	if name == "LC" {
+		return func(ch rune) bool { return unicode.In(ch, unicode.Lu, unicode.Ll, unicode.Lt) }, true
+	}
+	if name == "Cn" {
+		return func(ch rune) bool { return !unicode.In(ch, assigned...) }, true
+	}
+	return table(unicode.Categories[name])
+}
+
+// assigned lists the tables of every general category except Cn (unassigned)
+var assigned = []*unicode.RangeTable{unicode.L, unicode.M, unicode.N, unicode.P, unicode.S, unicode.Z, unicode.C}
+
+func table(t *unicode.RangeTable) (func(rune) bool, bool) {
+	if t == nil {
+		return nil, false
+	}
+	return func(ch rune) bool { return unicode.Is(t, ch) }, true
+}
+
+// canonicalize maps a character to the form case-insensitive matching
+// compares. Without the u flag this is the simple uppercase mapping, except
+// that non-ASCII characters never map to ASCII ones (so "ſ" doesn't match
+// "s"). With the u flag characters that fold to each other share a form.
+//
+// Examples (no u flag):
+//
+//	canonicalize('a') → 'A'
+//	canonicalize('ſ') → 'ſ'
+func (re *Regexp) canonicalize(ch rune) rune {
+	if re.flags.Unicode {
+		smallest := ch
+		for f := unicode.SimpleFold(ch); f != ch; f = unicode.SimpleFold(f) {
+			smallest = min(smallest, f)
+		}
+		return smallest
+	}
+
+	upper := unicode.ToUpper(ch)
+	if ch >= 0x80 && upper < 0x80 {
+		return ch
+	}
+	return upper
+}
+
+// inSet reports whether a class matches ch. Under the i flag it matches if
+// any character with the same canonical form is in the set.
+func (re *Regexp) inSet(s *charSet, ch rune) bool {
+	found := s.has(ch)
+	if !found && re.flags.IgnoreCase {
+		canonical := re.canonicalize(ch)
+		for f := unicode.SimpleFold(ch); f != ch; f = unicode.SimpleFold(f) {
+			if re.canonicalize(f) == canonical && s.has(f) {
+				found = true
+				break
+			}
+		}
+	}
+	return found != s.negate
+}
@@ -0,0 +1,354 @@
+package regex
+
+import (
+	"errors"
+	"unicode/utf16"
+)
+
+// ErrStackOverflow is returned when matching needs more nested steps than
+// maxDepth allows. Each repetition of a group that can backtrack, and each
+// item of a sequence, is one more level; without a limit a long enough input
+// would overflow the goroutine's stack and crash the process.
+var ErrStackOverflow = errors.New("Maximum call stack size exceeded")
+
+// maxDepth is how deeply match may recurse before giving up
+const maxDepth = 100000
+
+// matcher runs a compiled pattern over one input
+//
+// Matching is written in continuation-passing style: match(n, pos, back, k)
+// matches node n at pos and calls k with the position after it. If k fails,
+// match tries the node's next alternative (a shorter repetition, the next
+// branch of a|b, ...) before giving up. That is all the backtracking there
+// is, and it gives JavaScript's leftmost, priority-ordered match.
+//
+// back is true inside lookbehinds, which match right to left as the
+// specification requires: sequences run last item first, and characters are
+// read before pos instead of at it.
+type matcher struct {
+	re       *Regexp
+	input    []uint16
+	captures []int // Start and end of every group; -1 when unset
+	depth    int   // calls to match in progress
+}
+
+// char returns the character at pos (or, going backward, the one before it)
+// and its width in code units; width 0 means the input ends there
+func (m *matcher) char(pos int, back bool) (rune, int) {
+	if back {
+		if pos <= 0 {
+			return 0, 0
+		}
+		unit := m.input[pos-1]
+		if m.re.flags.Unicode && isLowSurrogate(unit) && pos >= 2 && isHighSurrogate(m.input[pos-2]) {
+			return utf16.DecodeRune(rune(m.input[pos-2]), rune(unit)), 2
+		}
+		return rune(unit), 1
+	}
+
+	if pos >= len(m.input) {
+		return 0, 0
+	}
+	unit := m.input[pos]
+	if m.re.flags.Unicode && isHighSurrogate(unit) && pos+1 < len(m.input) && isLowSurrogate(m.input[pos+1]) {
+		return utf16.DecodeRune(rune(unit), rune(m.input[pos+1])), 2
+	}
+	return rune(unit), 1
+}
+
+// step moves pos over a character of the given width in the match direction
+func step(pos int, width int, back bool) int {
+	if back {
+		return pos - width
+	}
+	return pos + width
+}
+
+// matchChar matches a node that stands for exactly one character: a
+// literal, a class or the dot. Returns the position after it.
+func (m *matcher) matchChar(n node, pos int, back bool) (int, bool) {
+	ch, width := m.char(pos, back)
+	if width == 0 {
+		return 0, false
+	}
+
+	return step(pos, width, back), m.matchesChar(n, ch)
+}
+
+// matchesChar reports whether ch is one of the characters a single-character
+// node stands for
+func (m *matcher) matchesChar(n node, ch rune) bool {
+	switch n := n.(type) {
+	case *literal:
+		return ch == n.ch || (m.re.flags.IgnoreCase && m.re.canonicalize(ch) == m.re.canonicalize(n.ch))
+	case *charClass:
+		return m.re.inSet(n.set, ch)
+	case anyChar:
+		return m.re.flags.DotAll || !isLineTerminator(ch)
+	case *alternation:
+		for _, alternative := range n.alternatives {
+			if m.matchesChar(alternative, ch) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isSingleChar reports whether n always matches exactly one character
+// An alternation like a|b|[0-9] counts too: every branch consumes the same
+// character, so trying them in order can't change where the match goes on.
+func isSingleChar(n node) bool {
+	switch n := n.(type) {
+	case *literal, *charClass, anyChar:
+		return true
+	case *alternation:
+		for _, alternative := range n.alternatives {
+			if !isSingleChar(alternative) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// match matches node n at pos, then calls k with the position after it
+// Returns true as soon as some way of matching n makes k succeed.
+// Panics with ErrStackOverflow when nested deeper than maxDepth.
+func (m *matcher) match(n node, pos int, back bool, k func(int) bool) bool {
+	m.depth++
+	if m.depth > maxDepth {
+		panic(ErrStackOverflow)
+	}
+	matched := m.matchNode(n, pos, back, k)
+	m.depth--
+	return matched
+}
+
+func (m *matcher) matchNode(n node, pos int, back bool, k func(int) bool) bool {
+	switch n := n.(type) {
+	case *literal, *charClass, anyChar:
+		next, ok := m.matchChar(n, pos, back)
+		return ok && k(next)
+	case *sequence:
+		return m.matchSequence(n.items, pos, back, k)
+	case *alternation:
+		for _, alternative := range n.alternatives {
+			if m.match(alternative, pos, back, k) {
+				return true
+			}
+		}
+		return false
+	case *group:
+		return m.matchGroup(n, pos, back, k)
+	case *backreference:
+		return m.matchBackreference(n, pos, back, k)
+	case *assertion:
+		return m.matchAssertion(n, pos) && k(pos)
+	case *lookaround:
+		return m.matchLookaround(n, pos, k)
+	case *quantifier:
+		if isSingleChar(n.body) {
+			return m.repeatChar(n, pos, back, k)
+		}
+		return m.repeat(n, pos, 0, back, k)
+	}
+	return k(pos) // an empty alternative
+}
+
+// matchSequence matches items one after another (last to first when going
+// backward)
+func (m *matcher) matchSequence(items []node, pos int, back bool, k func(int) bool) bool {
+	if len(items) == 0 {
+		return k(pos)
+	}
+	if back {
+		last := len(items) - 1
+		return m.match(items[last], pos, back, func(next int) bool {
+			return m.matchSequence(items[:last], next, back, k)
+		})
+	}
+	return m.match(items[0], pos, back, func(next int) bool {
+		return m.matchSequence(items[1:], next, back, k)
+	})
+}
+
+// matchGroup records where a capturing group matched, undoing it if the
+// rest of the pattern fails
+func (m *matcher) matchGroup(g *group, pos int, back bool, k func(int) bool) bool {
+	return m.match(g.body, pos, back, func(end int) bool {
+		start, stop := 2*g.index, 2*g.index+1
+		oldStart, oldStop := m.captures[start], m.captures[stop]
+
+		if back {
+			m.captures[start], m.captures[stop] = end, pos
+		} else {
+			m.captures[start], m.captures[stop] = pos, end
+		}
+		if k(end) {
+			return true
+		}
+
+		m.captures[start], m.captures[stop] = oldStart, oldStop
+		return false
+	})
+}
+
+// matchBackreference matches the text a group captured again
+// A group that hasn't matched (yet) matches the empty string.
+func (m *matcher) matchBackreference(ref *backreference, pos int, back bool, k func(int) bool) bool {
+	start, stop := m.captures[2*ref.index], m.captures[2*ref.index+1]
+	if start < 0 || stop < 0 {
+		return k(pos)
+	}
+
+	length := stop - start
+	from := pos
+	if back {
+		from = pos - length
+	}
+	if from < 0 || from+length > len(m.input) {
+		return false
+	}
+
+	for i := 0; i < length; i++ {
+		a, b := rune(m.input[start+i]), rune(m.input[from+i])
+		if a != b && !(m.re.flags.IgnoreCase && m.re.canonicalize(a) == m.re.canonicalize(b)) {
+			return false
+		}
+	}
+
+	if back {
+		return k(from)
+	}
+	return k(from + length)
+}
+
+// matchAssertion checks ^, $, \b or \B at pos
+func (m *matcher) matchAssertion(a *assertion, pos int) bool {
+	switch a.kind {
+	case '^':
+		return pos == 0 || (m.re.flags.Multiline && isLineTerminator(rune(m.input[pos-1])))
+	case '$':
+		return pos == len(m.input) || (m.re.flags.Multiline && isLineTerminator(rune(m.input[pos])))
+	}
+
+	before := pos > 0 && m.isWordAt(pos-1)
+	after := pos < len(m.input) && m.isWordAt(pos)
+	if a.kind == 'b' {
+		return before != after
+	}
+	return before == after
+}
+
+// isWordAt reports whether the code unit at i is a word character for \b
+// With both the u and i flags, "ſ" and the Kelvin sign count too, since they
+// fold to "s" and "k".
+func (m *matcher) isWordAt(i int) bool {
+	ch := rune(m.input[i])
+	if m.re.flags.Unicode && m.re.flags.IgnoreCase && (ch == 0x017F || ch == 0x212A) {
+		return true
+	}
+	return isWordChar(ch)
+}
+
+// matchLookaround checks (?=x), (?!x), (?<=x) or (?<!x) at pos
+// Lookarounds are atomic: once the body has matched, the rest of the
+// pattern can't backtrack into it. Groups captured by a positive
+// lookaround stay set; a negative one never leaves captures behind.
+func (m *matcher) matchLookaround(look *lookaround, pos int, k func(int) bool) bool {
+	saved := append([]int(nil), m.captures...)
+
+	matched := m.match(look.body, pos, look.behind, func(int) bool { return true })
+
+	if look.negate {
+		copy(m.captures, saved)
+		return !matched && k(pos)
+	}
+	if matched && k(pos) {
+		return true
+	}
+	copy(m.captures, saved)
+	return false
+}
+
+// repeat matches a quantifier whose body is more than one character
+// count is the number of repetitions matched so far. An iteration that
+// matches the empty string after the minimum is reached ends the loop, so
+// patterns like (a*)* can't repeat forever.
+func (m *matcher) repeat(q *quantifier, pos int, count int, back bool, k func(int) bool) bool {
+	if q.max != -1 && count >= q.max {
+		return k(pos)
+	}
+
+	iterate := func() bool {
+		saved := append([]int(nil), m.captures[2*q.firstGroup:2*q.lastGroup+2]...)
+		for i := 2 * q.firstGroup; i < 2*q.lastGroup+2; i++ {
+			m.captures[i] = -1
+		}
+
+		if m.match(q.body, pos, back, func(next int) bool {
+			if next == pos && count >= q.min {
+				return false
+			}
+			return m.repeat(q, next, count+1, back, k)
+		}) {
+			return true
+		}
+
+		copy(m.captures[2*q.firstGroup:], saved)
+		return false
+	}
+
+	if count < q.min {
+		return iterate()
+	}
+	if q.greedy {
+		return iterate() || k(pos)
+	}
+	return k(pos) || iterate()
+}
+
+// repeatChar matches a quantifier over a single character like a*, \d+,
+// [^,]{2,5}? or (?:a|b)* with a loop instead of recursion, which keeps long
+// runs cheap
+func (m *matcher) repeatChar(q *quantifier, pos int, back bool, k func(int) bool) bool {
+	positions := []int{pos}
+	extend := func() bool {
+		if q.max != -1 && len(positions)-1 >= q.max {
+			return false
+		}
+		next, ok := m.matchChar(q.body, positions[len(positions)-1], back)
+		if ok {
+			positions = append(positions, next)
+		}
+		return ok
+	}
+
+	for len(positions)-1 < q.min {
+		if !extend() {
+			return false
+		}
+	}
+
+	if !q.greedy {
+		for {
+			if k(positions[len(positions)-1]) {
+				return true
+			}
+			if !extend() {
+				return false
+			}
+		}
+	}
+
+	for extend() {
+	}
+	for i := len(positions) - 1; i >= q.min; i-- {
+		if k(positions[i]) {
+			return true
+		}
+	}
+	return false
+}
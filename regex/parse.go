@@ -0,0 +1,783 @@
+package regex
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"unicode/utf16"
+)
+
+// The pattern is parsed into a tree of these nodes, which the matcher walks
+//
+// Example: `a(b|c)*` becomes
+//
+//	sequence{literal{'a'}, quantifier{min: 0, max: -1, body: group{1, alternation{literal{'b'}, literal{'c'}}}}}
+type node interface{}
+
+type literal struct {
+	ch rune
+}
+
+type anyChar struct{} // .
+
+type charClass struct {
+	set *charSet
+}
+
+type sequence struct {
+	items []node
+}
+
+type alternation struct {
+	alternatives []node
+}
+
+type group struct {
+	index int
+	body  node
+}
+
+type backreference struct {
+	index int
+}
+
+// assertion is ^, $, \b or \B
+type assertion struct {
+	kind rune
+}
+
+type lookaround struct {
+	body   node
+	behind bool
+	negate bool
+}
+
+// quantifier repeats body between min and max times (max -1: no limit)
+// Groups firstGroup..lastGroup are inside the body and are reset to
+// undefined at the start of every repetition.
+type quantifier struct {
+	body       node
+	min, max   int
+	greedy     bool
+	firstGroup int
+	lastGroup  int
+}
+
+type parser struct {
+	src     []rune // Code points with the u flag, UTF-16 code units without
+	pos     int
+	unicode bool
+
+	groupCount int      // Groups opened so far
+	totalCount int      // Groups in the whole pattern, found by prescan
+	names      []string // Group names by index, found by prescan
+}
+
+func newParser(pattern string, flags Flags) *parser {
+	p := &parser{unicode: flags.Unicode}
+	if flags.Unicode {
+		p.src = []rune(pattern)
+	} else {
+		for _, unit := range utf16.Encode([]rune(pattern)) {
+			p.src = append(p.src, rune(unit))
+		}
+	}
+	return p
+}
+
+// prescan counts the capturing groups and collects their names before
+// parsing, because \2 and \k<name> may refer to groups that come later
+//
+// Example: `\k<b>(?<a>x)(?<b>y)` → totalCount 2, names ["", "a", "b"]
+func (p *parser) prescan() error {
+	p.names = []string{""}
+	inClass := false
+
+	for i := 0; i < len(p.src); i++ {
+		switch p.src[i] {
+		case '\\':
+			i++
+		case '[':
+			inClass = true
+		case ']':
+			inClass = false
+		case '(':
+			if inClass {
+				continue
+			}
+			if i+1 < len(p.src) && p.src[i+1] == '?' {
+				if i+2 >= len(p.src) || p.src[i+2] != '<' || (i+3 < len(p.src) && (p.src[i+3] == '=' || p.src[i+3] == '!')) {
+					continue // (?:x), (?=x), (?<=x) and friends don't capture
+				}
+				end := i + 3
+				for end < len(p.src) && p.src[end] != '>' {
+					end++
+				}
+				name := string(p.src[i+3 : min(end, len(p.src))])
+				if !isGroupName(name) {
+					return errors.New("Invalid capture group name")
+				}
+				for _, existing := range p.names {
+					if existing == name {
+						return errors.New("Duplicate capture group name")
+					}
+				}
+				p.names = append(p.names, name)
+			} else {
+				p.names = append(p.names, "")
+			}
+		}
+	}
+
+	p.totalCount = len(p.names) - 1
+	return nil
+}
+
+func (p *parser) hasNamedGroups() bool {
+	for _, name := range p.names {
+		if name != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// parse reads the whole pattern
+func (p *parser) parse() (node, error) {
+	n, err := p.parseDisjunction()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.src) {
+		// parseDisjunction only stops early at a ")" without a "("
+		return nil, errors.New("Unmatched ')'")
+	}
+	return n, nil
+}
+
+func (p *parser) more() bool {
+	return p.pos < len(p.src)
+}
+
+func (p *parser) peek() rune {
+	if p.pos < len(p.src) {
+		return p.src[p.pos]
+	}
+	return -1
+}
+
+func (p *parser) lookingAt(s string) bool {
+	runes := []rune(s)
+	if p.pos+len(runes) > len(p.src) {
+		return false
+	}
+	for i, r := range runes {
+		if p.src[p.pos+i] != r {
+			return false
+		}
+	}
+	return true
+}
+
+// parseDisjunction parses alternatives separated by |
+//
+// Example: "cat|dog" → alternation{sequence{c, a, t}, sequence{d, o, g}}
+func (p *parser) parseDisjunction() (node, error) {
+	alternatives := []node{}
+	for {
+		alternative, err := p.parseAlternative()
+		if err != nil {
+			return nil, err
+		}
+		alternatives = append(alternatives, alternative)
+
+		if p.peek() != '|' {
+			break
+		}
+		p.pos++
+	}
+
+	if len(alternatives) == 1 {
+		return alternatives[0], nil
+	}
+	return &alternation{alternatives: alternatives}, nil
+}
+
+// parseAlternative parses terms up to the next |, ) or the end
+func (p *parser) parseAlternative() (node, error) {
+	items := []node{}
+	for p.more() && p.peek() != '|' && p.peek() != ')' {
+		term, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, term)
+	}
+
+	if len(items) == 1 {
+		return items[0], nil
+	}
+	return &sequence{items: items}, nil
+}
+
+// parseTerm parses an assertion, or an atom with an optional quantifier
+//
+// Examples: "^", "\b", "(?=x)", "a", "a*", "[0-9]{2,4}?"
+func (p *parser) parseTerm() (node, error) {
+	switch {
+	case p.peek() == '^' || p.peek() == '$':
+		p.pos++
+		return &assertion{kind: p.src[p.pos-1]}, nil
+	case p.lookingAt(`\b`) || p.lookingAt(`\B`):
+		p.pos += 2
+		return &assertion{kind: p.src[p.pos-1]}, nil
+	case p.lookingAt("(?=") || p.lookingAt("(?!") || p.lookingAt("(?<=") || p.lookingAt("(?<!"):
+		firstGroup := p.groupCount + 1
+		look := &lookaround{behind: p.src[p.pos+2] == '<'}
+		if look.behind {
+			p.pos++
+		}
+		look.negate = p.src[p.pos+2] == '!'
+		p.pos += 3
+
+		body, err := p.parseDisjunction()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ')' {
+			return nil, errors.New("Unterminated group")
+		}
+		p.pos++
+		look.body = body
+
+		// Lookaheads may be quantified without the u flag (Annex B)
+		if look.behind || p.unicode {
+			if p.atQuantifier() {
+				return nil, errors.New("Invalid quantifier")
+			}
+			return look, nil
+		}
+		return p.parseQuantifier(look, firstGroup)
+	}
+
+	firstGroup := p.groupCount + 1
+	atom, err := p.parseAtom()
+	if err != nil {
+		return nil, err
+	}
+	return p.parseQuantifier(atom, firstGroup)
+}
+
+// atQuantifier reports whether a quantifier starts at the current position
+func (p *parser) atQuantifier() bool {
+	switch p.peek() {
+	case '*', '+', '?':
+		return true
+	case '{':
+		_, _, ok := p.scanBraces()
+		return ok
+	}
+	return false
+}
+
+// parseQuantifier wraps atom in a quantifier if one follows it
+// firstGroup is the index of the first group inside the atom.
+func (p *parser) parseQuantifier(atom node, firstGroup int) (node, error) {
+	q := &quantifier{body: atom, greedy: true, firstGroup: firstGroup, lastGroup: p.groupCount}
+
+	switch p.peek() {
+	case '*':
+		q.min, q.max = 0, -1
+		p.pos++
+	case '+':
+		q.min, q.max = 1, -1
+		p.pos++
+	case '?':
+		q.min, q.max = 0, 1
+		p.pos++
+	case '{':
+		low, high, ok := p.scanBraces()
+		if !ok {
+			if p.unicode {
+				return nil, errors.New("Incomplete quantifier")
+			}
+			return atom, nil // a literal "{" follows the atom (Annex B)
+		}
+		if high != -1 && high < low {
+			return nil, errors.New("numbers out of order in {} quantifier")
+		}
+		q.min, q.max = low, high
+	default:
+		return atom, nil
+	}
+
+	if p.peek() == '?' {
+		q.greedy = false
+		p.pos++
+	}
+	return q, nil
+}
+
+// scanBraces reads {n}, {n,} or {n,m} at the current position, advancing
+// past it only if it is well formed. Returns -1 for a missing maximum.
+func (p *parser) scanBraces() (int, int, bool) {
+	i := p.pos + 1
+	readNumber := func() (int, bool) {
+		start := i
+		for i < len(p.src) && p.src[i] >= '0' && p.src[i] <= '9' {
+			i++
+		}
+		if i == start {
+			return 0, false
+		}
+		n, err := strconv.Atoi(string(p.src[start:i]))
+		if err != nil {
+			return math.MaxInt32, true // too large to ever reach
+		}
+		return min(n, math.MaxInt32), true
+	}
+
+	low, ok := readNumber()
+	if !ok {
+		return 0, 0, false
+	}
+	high := low
+	if i < len(p.src) && p.src[i] == ',' {
+		i++
+		high = -1
+		if i < len(p.src) && p.src[i] != '}' {
+			if high, ok = readNumber(); !ok {
+				return 0, 0, false
+			}
+		}
+	}
+	if i >= len(p.src) || p.src[i] != '}' {
+		return 0, 0, false
+	}
+
+	p.pos = i + 1
+	return low, high, true
+}
+
+// parseAtom parses a single character, class, group or escape
+func (p *parser) parseAtom() (node, error) {
+	ch := p.peek()
+	switch ch {
+	case '.':
+		p.pos++
+		return anyChar{}, nil
+	case '(':
+		return p.parseGroup()
+	case '[':
+		return p.parseClass()
+	case '\\':
+		return p.parseAtomEscape()
+	case '*', '+', '?':
+		return nil, errors.New("Nothing to repeat")
+	case '{':
+		if p.unicode {
+			return nil, errors.New("Lone quantifier brackets")
+		}
+		saved := p.pos
+		if _, _, ok := p.scanBraces(); ok {
+			p.pos = saved
+			return nil, errors.New("Nothing to repeat")
+		}
+	case '}', ']':
+		if p.unicode {
+			return nil, errors.New("Lone quantifier brackets")
+		}
+	}
+
+	p.pos++
+	return &literal{ch: ch}, nil
+}
+
+// parseGroup parses (x), (?:x) and (?<name>x)
+func (p *parser) parseGroup() (node, error) {
+	p.pos++ // (
+
+	index := 0
+	switch {
+	case p.lookingAt("?:"):
+		p.pos += 2
+	case p.lookingAt("?<"):
+		p.pos += 2
+		for p.more() && p.peek() != '>' {
+			p.pos++
+		}
+		p.pos++ // > (the name was checked by prescan)
+		p.groupCount++
+		index = p.groupCount
+	case p.peek() == '?':
+		return nil, errors.New("Invalid group")
+	default:
+		p.groupCount++
+		index = p.groupCount
+	}
+
+	body, err := p.parseDisjunction()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek() != ')' {
+		return nil, errors.New("Unterminated group")
+	}
+	p.pos++
+
+	if index == 0 {
+		return body, nil
+	}
+	return &group{index: index, body: body}, nil
+}
+
+// parseAtomEscape parses an escape outside a character class: a character
+// escape, a class escape like \d, or a backreference
+//
+// Examples: "\n" → literal, "\d" → charClass, "\1" and "\k<year>" → backreference
+func (p *parser) parseAtomEscape() (node, error) {
+	p.pos++ // \
+	if !p.more() {
+		return nil, errors.New("\\ at end of pattern")
+	}
+
+	ch := p.peek()
+	switch {
+	case ch >= '1' && ch <= '9':
+		saved := p.pos
+		n := 0
+		for p.more() && p.peek() >= '0' && p.peek() <= '9' {
+			n = min(n*10+int(p.peek()-'0'), math.MaxInt32)
+			p.pos++
+		}
+		if n <= p.totalCount {
+			return &backreference{index: n}, nil
+		}
+		if p.unicode {
+			return nil, errors.New("Invalid escape")
+		}
+		// No such group: a legacy octal escape, or \8 and \9 matching themselves
+		p.pos = saved
+		if ch >= '8' {
+			p.pos++
+			return &literal{ch: ch}, nil
+		}
+		return &literal{ch: p.parseLegacyOctal()}, nil
+	case ch == 'k' && (p.unicode || p.hasNamedGroups()):
+		p.pos++
+		if p.peek() != '<' {
+			return nil, errors.New("Invalid named reference")
+		}
+		end := p.pos + 1
+		for end < len(p.src) && p.src[end] != '>' {
+			end++
+		}
+		if end >= len(p.src) {
+			return nil, errors.New("Invalid named reference")
+		}
+		name := string(p.src[p.pos+1 : end])
+		p.pos = end + 1
+		for index, groupName := range p.names {
+			if groupName == name && name != "" {
+				return &backreference{index: index}, nil
+			}
+		}
+		return nil, errors.New("Invalid named capture referenced")
+	}
+
+	if set, ok, err := p.parseClassEscape(); ok || err != nil {
+		if err != nil {
+			return nil, err
+		}
+		return &charClass{set: set}, nil
+	}
+
+	c, err := p.parseCharacterEscape(false)
+	if err != nil {
+		return nil, err
+	}
+	return &literal{ch: c}, nil
+}
+
+// parseLegacyOctal reads up to three octal digits, at most \377
+func (p *parser) parseLegacyOctal() rune {
+	value := rune(0)
+	for i := 0; i < 3 && p.more() && p.peek() >= '0' && p.peek() <= '7'; i++ {
+		next := value*8 + (p.peek() - '0')
+		if next > 0377 {
+			break
+		}
+		value = next
+		p.pos++
+	}
+	return value
+}
+
+// parseClassEscape parses \d \D \w \W \s \S, and \p{...} \P{...} with the
+// u flag, just after the backslash. Returns false if there isn't one.
+func (p *parser) parseClassEscape() (*charSet, bool, error) {
+	ch := p.peek()
+	switch ch {
+	case 'd', 'D', 'w', 'W', 's', 'S':
+		p.pos++
+		return classEscapeSet(ch), true, nil
+	case 'p', 'P':
+		if !p.unicode {
+			return nil, false, nil
+		}
+		p.pos++
+		if p.peek() != '{' {
+			return nil, false, errors.New("Invalid property name")
+		}
+		end := p.pos + 1
+		for end < len(p.src) && p.src[end] != '}' {
+			end++
+		}
+		if end >= len(p.src) {
+			return nil, false, errors.New("Invalid property name")
+		}
+		test, ok := unicodeProperty(string(p.src[p.pos+1 : end]))
+		if !ok {
+			return nil, false, errors.New("Invalid property name")
+		}
+		p.pos = end + 1
+		return &charSet{classes: []func(rune) bool{test}, negate: ch == 'P'}, true, nil
+	}
+	return nil, false, nil
+}
+
+// parseCharacterEscape parses an escape that stands for one character, just
+// after the backslash. inClass allows \b (backspace) and \-.
+//
+// Examples: "n" → '\n', "x41" → 'A', "u{1F600}" → '😀' (u flag), "." → '.'
+func (p *parser) parseCharacterEscape(inClass bool) (rune, error) {
+	ch := p.peek()
+	p.pos++
+
+	switch ch {
+	case 't':
+		return '\t', nil
+	case 'n':
+		return '\n', nil
+	case 'v':
+		return '\v', nil
+	case 'f':
+		return '\f', nil
+	case 'r':
+		return '\r', nil
+	case '0':
+		if p.more() && p.peek() >= '0' && p.peek() <= '9' {
+			if p.unicode {
+				return 0, errors.New("Invalid decimal escape")
+			}
+			p.pos--
+			return p.parseLegacyOctal(), nil
+		}
+		return 0, nil
+	case 'c':
+		if letter := p.peek(); (letter >= 'a' && letter <= 'z') || (letter >= 'A' && letter <= 'Z') {
+			p.pos++
+			return letter % 32, nil
+		}
+		if p.unicode {
+			return 0, errors.New("Invalid unicode escape")
+		}
+		p.pos-- // "\c" without a letter is a backslash followed by "c"
+		return '\\', nil
+	case 'x':
+		if value, ok := p.readHex(2); ok {
+			return value, nil
+		}
+		if p.unicode {
+			return 0, errors.New("Invalid escape")
+		}
+		return 'x', nil
+	case 'u':
+		value, ok := p.parseUnicodeEscape()
+		if ok {
+			return value, nil
+		}
+		if p.unicode {
+			return 0, errors.New("Invalid Unicode escape")
+		}
+		return 'u', nil
+	case 'b':
+		if inClass {
+			return '\b', nil
+		}
+	case '-':
+		if inClass {
+			return '-', nil
+		}
+	}
+
+	if p.unicode && !isSyntaxCharacter(ch) && ch != '/' {
+		return 0, errors.New("Invalid escape")
+	}
+	return ch, nil // identity escape: \. \* \/ ...
+}
+
+// parseUnicodeEscape reads the part after \u: four hex digits, or a code
+// point in braces with the u flag. With the u flag an escaped surrogate pair
+// (😀) is one character.
+func (p *parser) parseUnicodeEscape() (rune, bool) {
+	if p.unicode && p.peek() == '{' {
+		end := p.pos + 1
+		for end < len(p.src) && isHexDigit(p.src[end]) {
+			end++
+		}
+		if end == p.pos+1 || end >= len(p.src) || p.src[end] != '}' {
+			return 0, false
+		}
+		value, err := strconv.ParseUint(string(p.src[p.pos+1:end]), 16, 32)
+		if err != nil || value > 0x10FFFF {
+			return 0, false
+		}
+		p.pos = end + 1
+		return rune(value), true
+	}
+
+	value, ok := p.readHex(4)
+	if !ok {
+		return 0, false
+	}
+	if p.unicode && isHighSurrogate(uint16(value)) && p.lookingAt(`\u`) {
+		saved := p.pos
+		p.pos += 2
+		if low, ok := p.readHex(4); ok && isLowSurrogate(uint16(low)) {
+			return utf16.DecodeRune(value, low), true
+		}
+		p.pos = saved
+	}
+	return value, true
+}
+
+// readHex reads exactly n hex digits, advancing only on success
+func (p *parser) readHex(n int) (rune, bool) {
+	if p.pos+n > len(p.src) {
+		return 0, false
+	}
+	value := rune(0)
+	for _, ch := range p.src[p.pos : p.pos+n] {
+		if !isHexDigit(ch) {
+			return 0, false
+		}
+		digit, _ := strconv.ParseUint(string(ch), 16, 8)
+		value = value*16 + rune(digit)
+	}
+	p.pos += n
+	return value, true
+}
+
+// parseClass parses a character class such as [a-z_] or [^\d\s]
+func (p *parser) parseClass() (node, error) {
+	p.pos++ // [
+	set := &charSet{}
+	if p.peek() == '^' {
+		set.negate = true
+		p.pos++
+	}
+
+	for {
+		if !p.more() {
+			return nil, errors.New("Unterminated character class")
+		}
+		if p.peek() == ']' {
+			p.pos++
+			return &charClass{set: set}, nil
+		}
+
+		low, lowSet, err := p.parseClassAtom()
+		if err != nil {
+			return nil, err
+		}
+
+		// A range a-z, unless the "-" is the last character: [a-]
+		if p.peek() == '-' && p.pos+1 < len(p.src) && p.src[p.pos+1] != ']' {
+			p.pos++
+			high, highSet, err := p.parseClassAtom()
+			if err != nil {
+				return nil, err
+			}
+			if lowSet != nil || highSet != nil {
+				if p.unicode {
+					return nil, errors.New("Invalid character class")
+				}
+				// [\d-z] is \d, "-" and "z" (Annex B)
+				set.add(low, lowSet)
+				set.add('-', nil)
+				set.add(high, highSet)
+				continue
+			}
+			if high < low {
+				return nil, errors.New("Range out of order in character class")
+			}
+			set.ranges = append(set.ranges, runeRange{low, high})
+			continue
+		}
+
+		set.add(low, lowSet)
+	}
+}
+
+// parseClassAtom parses one character of a class, or a class escape like
+// \d, which is returned as a set
+func (p *parser) parseClassAtom() (rune, *charSet, error) {
+	ch := p.peek()
+	if ch != '\\' {
+		p.pos++
+		return ch, nil, nil
+	}
+
+	p.pos++
+	if !p.more() {
+		return 0, nil, errors.New("\\ at end of pattern")
+	}
+	if set, ok, err := p.parseClassEscape(); ok || err != nil {
+		return 0, set, err
+	}
+	if !p.unicode && p.peek() >= '1' && p.peek() <= '9' {
+		// No backreferences in classes: \1 is octal, \8 is "8"
+		if p.peek() >= '8' {
+			p.pos++
+			return p.src[p.pos-1], nil, nil
+		}
+		return p.parseLegacyOctal(), nil, nil
+	}
+	c, err := p.parseCharacterEscape(true)
+	return c, nil, err
+}
+
+func isSyntaxCharacter(ch rune) bool {
+	switch ch {
+	case '^', '$', '\\', '.', '*', '+', '?', '(', ')', '[', ']', '{', '}', '|':
+		return true
+	}
+	return false
+}
+
+func isHexDigit(ch rune) bool {
+	return (ch >= '0' && ch <= '9') || (ch >= 'a' && ch <= 'f') || (ch >= 'A' && ch <= 'F')
+}
+
+// isGroupName reports whether a group name is an identifier
+func isGroupName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, ch := range name {
+		if ch == '$' || ch == '_' || isLetter(ch) || (i > 0 && ch >= '0' && ch <= '9') {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+func isHighSurrogate(unit uint16) bool {
+	return unit >= 0xD800 && unit <= 0xDBFF
+}
+
+func isLowSurrogate(unit uint16) bool {
+	return unit >= 0xDC00 && unit <= 0xDFFF
+}
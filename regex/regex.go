@@ -0,0 +1,222 @@
+// Package regex is a backtracking regular expression engine with JavaScript
+// semantics. Go's regexp package guarantees linear time by leaving out
+// backreferences and lookaround, which JavaScript patterns use all the time,
+// so RegExp objects are built on this engine instead.
+//
+// Input is matched as UTF-16 code units, like JavaScript strings, so match
+// positions are the same indices scripts see in lastIndex and match.index.
+// With the u flag the pattern and input are read as code points instead,
+// and surrogate pairs are never split.
+//
+// Supported syntax:
+//
+//	alternatives  a|b
+//	quantifiers   * + ? {n} {n,} {n,m}, lazy with a trailing ?
+//	groups        (x)  (?:x)  (?<name>x)
+//	references    \1  \k<name>
+//	assertions    ^ $ \b \B  (?=x) (?!x)  (?<=x) (?<!x)
+//	classes       . [abc] [^a-z] \d \D \w \W \s \S, and \p{...} \P{...} with u
+//	escapes       \t \n \v \f \r \0 \cX \xHH \uHHHH, and \u{H...} with u
+//
+// Without the u flag the engine follows the web-compatibility rules browsers
+// implement (Annex B of the specification): a "{" that doesn't start a
+// quantifier is a literal, "\8" and "]" match themselves, and "\1" without a
+// group 1 is an octal escape.
+//
+// Example:
+//
+//	re, _ := regex.Compile(`(?<year>\d{4})-(?<month>\d\d)`, regex.Flags{})
+//	input := utf16.Encode([]rune("on 2024-05"))
+//	re.Find(input, 0)  → [3 10 3 7 8 10], nil (start and end of the match, then of each group)
+package regex
+
+// Flags are the options written after a regular expression literal
+//
+// Only IgnoreCase, Multiline, DotAll and Unicode change how the pattern
+// matches. Global, Sticky and HasIndices are kept so RegExp objects can
+// report them; they change how exec() uses the engine.
+type Flags struct {
+	HasIndices bool // d: matches record the start and end of every group
+	Global     bool // g: exec() continues from lastIndex
+	IgnoreCase bool // i: case-insensitive matching
+	Multiline  bool // m: ^ and $ match at line breaks
+	DotAll     bool // s: . matches line breaks too
+	Unicode    bool // u: match code points, enable \p{...} and \u{...}
+	Sticky     bool // y: exec() only matches exactly at lastIndex
+}
+
+// ParseFlags reads a flags string such as "gi"
+// Returns false for unknown or repeated flags.
+//
+// Examples:
+//
+//	ParseFlags("gi") → Flags{Global: true, IgnoreCase: true}, true
+//	ParseFlags("gg") → Flags{}, false
+//	ParseFlags("x")  → Flags{}, false
+func ParseFlags(s string) (Flags, bool) {
+	var flags Flags
+	for _, ch := range s {
+		var flag *bool
+		switch ch {
+		case 'd':
+			flag = &flags.HasIndices
+		case 'g':
+			flag = &flags.Global
+		case 'i':
+			flag = &flags.IgnoreCase
+		case 'm':
+			flag = &flags.Multiline
+		case 's':
+			flag = &flags.DotAll
+		case 'u':
+			flag = &flags.Unicode
+		case 'y':
+			flag = &flags.Sticky
+		default:
+			return Flags{}, false
+		}
+		if *flag {
+			return Flags{}, false
+		}
+		*flag = true
+	}
+	return flags, true
+}
+
+// String returns the flags in canonical order, as RegExp.prototype.flags does
+//
+// Example: ParseFlags("yig") → "giy"
+func (f Flags) String() string {
+	s := ""
+	for _, flag := range []struct {
+		on bool
+		ch string
+	}{
+		{f.HasIndices, "d"}, {f.Global, "g"}, {f.IgnoreCase, "i"}, {f.Multiline, "m"},
+		{f.DotAll, "s"}, {f.Unicode, "u"}, {f.Sticky, "y"},
+	} {
+		if flag.on {
+			s += flag.ch
+		}
+	}
+	return s
+}
+
+// Regexp is a compiled pattern. It keeps no match state, so one Regexp
+// can be used by any number of RegExp objects and matches at once.
+type Regexp struct {
+	program    node
+	groupCount int
+	groupNames []string // Name of each group by index, "" for unnamed groups and group 0
+	flags      Flags
+}
+
+// Compile parses a pattern, returning an error that describes the first
+// syntax error found
+//
+// Examples:
+//
+//	Compile(`a+b`, Flags{})        → matches "ab", "aab", ...
+//	Compile(`(a`, Flags{})         → error "Unterminated group"
+//	Compile(`\p{L}`, Flags{Unicode: true}) → matches any letter
+func Compile(pattern string, flags Flags) (*Regexp, error) {
+	p := newParser(pattern, flags)
+	if err := p.prescan(); err != nil {
+		return nil, err
+	}
+
+	program, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Regexp{
+		program:    program,
+		groupCount: p.groupCount,
+		groupNames: p.names,
+		flags:      flags,
+	}, nil
+}
+
+// NumGroups returns the number of capturing groups, not counting the match itself
+func (re *Regexp) NumGroups() int {
+	return re.groupCount
+}
+
+// GroupNames returns the name of every group by index; unnamed groups and
+// index 0 (the whole match) have the name ""
+//
+// Example: `(a)(?<b>b)` → ["", "", "b"]
+func (re *Regexp) GroupNames() []string {
+	return re.groupNames
+}
+
+// HasNamedGroups reports whether any group has a name
+func (re *Regexp) HasNamedGroups() bool {
+	for _, name := range re.groupNames {
+		if name != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchAt tries to match starting exactly at pos, as the sticky flag does
+// Returns nil if there is no match; otherwise the start and end index of the
+// match followed by those of every group, with -1 for groups that took no
+// part in the match. The error is ErrStackOverflow if the input is too long
+// for the pattern to be matched at all.
+func (re *Regexp) MatchAt(input []uint16, pos int) (captures []int, err error) {
+	if pos < 0 || pos > len(input) {
+		return nil, nil
+	}
+
+	m := &matcher{re: re, input: input, captures: make([]int, 2*(re.groupCount+1))}
+	for i := range m.captures {
+		m.captures[i] = -1
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			if r != ErrStackOverflow {
+				panic(r)
+			}
+			captures, err = nil, ErrStackOverflow
+		}
+	}()
+
+	end := -1
+	if !m.match(re.program, pos, false, func(e int) bool {
+		end = e
+		return true
+	}) {
+		return nil, nil
+	}
+
+	m.captures[0], m.captures[1] = pos, end
+	return m.captures, nil
+}
+
+// Find returns the first match starting at or after pos, in the same form
+// as MatchAt, or nil
+func (re *Regexp) Find(input []uint16, pos int) ([]int, error) {
+	for pos <= len(input) {
+		captures, err := re.MatchAt(input, pos)
+		if captures != nil || err != nil {
+			return captures, err
+		}
+		pos = re.Advance(input, pos)
+	}
+	return nil, nil
+}
+
+// Advance returns the index after the character at pos: the next code unit,
+// or with the u flag the end of a surrogate pair. Searches step through the
+// input this way, so a unicode pattern never starts matching in the middle
+// of a character.
+func (re *Regexp) Advance(input []uint16, pos int) int {
+	if re.flags.Unicode && pos+1 < len(input) && isHighSurrogate(input[pos]) && isLowSurrogate(input[pos+1]) {
+		return pos + 2
+	}
+	return pos + 1
+}
@@ -0,0 +1,277 @@
+package regex
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf16"
+)
+
+func encode(s string) []uint16 {
+	return utf16.Encode([]rune(s))
+}
+
+// find returns the matched text and the text of every group, "<nil>" for
+// groups that didn't participate, or nil if there is no match
+func find(t *testing.T, pattern string, flags string, input string) []string {
+	t.Helper()
+	f, ok := ParseFlags(flags)
+	if !ok {
+		t.Fatalf("invalid flags %q", flags)
+	}
+	re, err := Compile(pattern, f)
+	if err != nil {
+		t.Fatalf("Compile(%q): unexpected error %v", pattern, err)
+	}
+
+	units := encode(input)
+	captures, _ := re.Find(units, 0)
+	if captures == nil {
+		return nil
+	}
+
+	texts := []string{}
+	for i := 0; i < len(captures); i += 2 {
+		if captures[i] < 0 {
+			texts = append(texts, "<nil>")
+			continue
+		}
+		texts = append(texts, string(utf16.Decode(units[captures[i]:captures[i+1]])))
+	}
+	return texts
+}
+
+func TestFind(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		flags    string
+		input    string
+		expected []string
+	}{
+		// Characters, classes and the dot
+		{`abc`, "", "xxabcxx", []string{"abc"}},
+		{`a.c`, "", "abc", []string{"abc"}},
+		{`a.c`, "", "a\nc", nil},
+		{`a.c`, "s", "a\nc", []string{"a\nc"}},
+		{`[a-c]+`, "", "xxbcaz", []string{"bca"}},
+		{`[^a-c]+`, "", "abcxyzabc", []string{"xyz"}},
+		{`\d+\.\d+`, "", "pi is 3.14", []string{"3.14"}},
+		{`\w+`, "", "  hello_1 ", []string{"hello_1"}},
+		{`\s+`, "", "a \t\n b", []string{" \t\n "}},
+		{`[\d-]+`, "", "tel: 555-1234", []string{"555-1234"}},
+		{`[a-]+`, "", "xa-a", []string{"a-a"}},
+		{`\x41B\103`, "", "ABC", []string{"ABC"}},
+		{`\cJ`, "", "a\nb", []string{"\n"}},
+		{`[\b]`, "", "a\bb", []string{"\b"}},
+		{`a{`, "", "a{", []string{"a{"}},
+		{`a{1,x}`, "", "a{1,x}", []string{"a{1,x}"}},
+		{`]`, "", "a]", []string{"]"}},
+
+		// Quantifiers
+		{`a*`, "", "aaab", []string{"aaa"}},
+		{`a+?`, "", "aaa", []string{"a"}},
+		{`a{2}`, "", "aaaa", []string{"aa"}},
+		{`a{2,}`, "", "aaaa", []string{"aaaa"}},
+		{`a{1,3}`, "", "aaaa", []string{"aaa"}},
+		{`a{1,3}?`, "", "aaaa", []string{"a"}},
+		{`<.*>`, "", "<a><b>", []string{"<a><b>"}},
+		{`<.*?>`, "", "<a><b>", []string{"<a>"}},
+		{`(ab)+`, "", "ababab", []string{"ababab", "ab"}},
+		{`(?:ab)+?c`, "", "ababc", []string{"ababc"}},
+		{`(a*)*b`, "", "aab", []string{"aab", "aa"}},
+		{`(a|b)*c`, "", "abac", []string{"abac", "a"}},
+		{`(z)((a+)?(b+)?(c))*`, "", "zaacbbbcac", []string{"zaacbbbcac", "z", "ac", "a", "<nil>", "c"}},
+
+		// Alternation picks the first alternative that works
+		{`cat|category`, "", "category", []string{"cat"}},
+		{`(a|ab)(c|bcd)(d*)`, "", "abcd", []string{"abcd", "a", "bcd", ""}},
+
+		// Groups and backreferences
+		{`(\d{4})-(\d\d)`, "", "on 2024-05", []string{"2024-05", "2024", "05"}},
+		{`(?<year>\d{4})-(?<month>\d\d)`, "", "2024-05", []string{"2024-05", "2024", "05"}},
+		{`(\w)\1`, "", "abccd", []string{"cc", "c"}},
+		{`(?<q>["']).*?\k<q>`, "", `say "it's" ok`, []string{`"it's"`, `"`}},
+		{`\1(a)`, "", "aa", []string{"a", "a"}},
+		{`(a)|\1b`, "", "b", []string{"b", "<nil>"}},
+		{`\1`, "", "\x01", []string{"\x01"}},
+		{`\8`, "", "8", []string{"8"}},
+		{`\k`, "", "k", []string{"k"}},
+
+		// Anchors and word boundaries
+		{`^abc`, "", "xabc", nil},
+		{`^b`, "m", "a\nb", []string{"b"}},
+		{`a$`, "", "a\nb", nil},
+		{`a$`, "m", "a\nb", []string{"a"}},
+		{`\bfoo\b`, "", "a foo b", []string{"foo"}},
+		{`\bfoo\b`, "", "afoob", nil},
+		{`\Boo\B`, "", "foob", []string{"oo"}},
+
+		// Lookaround
+		{`\d+(?=px)`, "", "10em 20px", []string{"20"}},
+		{`\d+(?!px|\d)`, "", "10px 20em", []string{"20"}},
+		{`(?<=\$)\d+`, "", "cost: $42", []string{"42"}},
+		{`(?<!\$)\b\d+`, "", "$42 17", []string{"17"}},
+		{`(?<=(\d+)(\d+))$`, "", "1053", []string{"", "1", "053"}},
+		{`(?<=\1(a))b`, "", "aab", []string{"b", "a"}},
+		{`(?=(a+))a*b\1`, "", "baaabac", []string{"aba", "a"}},
+		{`(?!(a))\1b`, "", "b", []string{"b", "<nil>"}},
+
+		// Case-insensitive matching
+		{`hello`, "i", "HeLLo", []string{"HeLLo"}},
+		{`[a-z]+`, "i", "ABC", []string{"ABC"}},
+		{`(a)\1`, "i", "aA", []string{"aA", "a"}},
+		{`s`, "i", "ſ", nil},
+		{`s`, "iu", "ſ", []string{"ſ"}},
+		{`[^a]`, "i", "A", nil},
+
+		// Unicode
+		{`^.$`, "", "😀", nil},
+		{`^.$`, "u", "😀", []string{"😀"}},
+		{`\u{1F600}`, "u", "a😀", []string{"😀"}},
+		{`😀`, "u", "😀", []string{"😀"}},
+		{`\p{Lu}+`, "u", "abcDEFghi", []string{"DEF"}},
+		{`\p{Script=Greek}+`, "u", "abc αβγ", []string{"αβγ"}},
+		{`\P{L}+`, "u", "abc123def", []string{"123"}},
+		{`[\p{N}]+`, "u", "x٣4y", []string{"٣4"}},
+	}
+
+	for _, tt := range tests {
+		result := find(t, tt.pattern, tt.flags, tt.input)
+		if !reflect.DeepEqual(result, tt.expected) {
+			t.Errorf("/%s/%s on %q: expected %q, got %q", tt.pattern, tt.flags, tt.input, tt.expected, result)
+		}
+	}
+}
+
+func TestMatchAt(t *testing.T) {
+	re, _ := Compile(`\d+`, Flags{Sticky: true})
+	input := encode("ab12cd34")
+
+	if captures, _ := re.MatchAt(input, 0); captures != nil {
+		t.Errorf("expected no match at 0, got %v", captures)
+	}
+	if captures, _ := re.MatchAt(input, 2); !reflect.DeepEqual(captures, []int{2, 4}) {
+		t.Errorf("expected [2 4], got %v", captures)
+	}
+	if captures, _ := re.Find(input, 4); !reflect.DeepEqual(captures, []int{6, 8}) {
+		t.Errorf("expected Find to skip ahead to [6 8], got %v", captures)
+	}
+	if captures, _ := re.MatchAt(input, 9); captures != nil {
+		t.Errorf("expected no match past the end, got %v", captures)
+	}
+}
+
+func TestGroupNames(t *testing.T) {
+	re, err := Compile(`(a)(?<b>b)(?:c)(?<d>d)`, Flags{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if re.NumGroups() != 3 {
+		t.Errorf("expected 3 groups, got %d", re.NumGroups())
+	}
+	if names := re.GroupNames(); !reflect.DeepEqual(names, []string{"", "", "b", "d"}) {
+		t.Errorf("expected names [\"\" \"\" b d], got %q", names)
+	}
+	if !re.HasNamedGroups() {
+		t.Errorf("expected HasNamedGroups to be true")
+	}
+}
+
+func TestCatastrophicPatternsTerminate(t *testing.T) {
+	// Empty iterations end a loop, so these can't spin forever
+	for _, pattern := range []string{`(a*)*$`, `(a?)+?b`, `(|a)*`, `(?:)*x`} {
+		re, err := Compile(pattern, Flags{})
+		if err != nil {
+			t.Fatalf("Compile(%q): %v", pattern, err)
+		}
+		re.Find(encode("aaaaaaaaaaaaaaaa"), 0)
+	}
+
+	// Long runs of a single character don't recurse per character
+	long := make([]uint16, 200000)
+	for i := range long {
+		long[i] = 'a'
+	}
+	re, _ := Compile(`a*b|a+$`, Flags{})
+	if captures, _ := re.Find(long, 0); captures == nil || captures[1] != len(long) {
+		t.Errorf("expected the whole input to match")
+	}
+
+	// Neither do alternatives that are each a single character
+	alternating := encode(strings.Repeat("ab", 500000))
+	re, _ = Compile(`^(?:a|b)*$`, Flags{})
+	if captures, err := re.MatchAt(alternating, 0); err != nil || captures == nil {
+		t.Errorf("expected (?:a|b)* to match the whole input, got %v, %v", captures, err)
+	}
+
+	// A group that has to recurse per repetition runs out of depth with an
+	// error instead of crashing
+	re, _ = Compile(`^(ab)*$`, Flags{})
+	if _, err := re.MatchAt(alternating, 0); err != ErrStackOverflow {
+		t.Errorf("expected ErrStackOverflow, got %v", err)
+	}
+	if _, err := re.Find(alternating, 0); err != ErrStackOverflow {
+		t.Errorf("expected Find to report ErrStackOverflow, got %v", err)
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		flags    Flags
+		expected string
+	}{
+		{`(a`, Flags{}, "Unterminated group"},
+		{`a)`, Flags{}, "Unmatched ')'"},
+		{`*a`, Flags{}, "Nothing to repeat"},
+		{`a**`, Flags{}, "Nothing to repeat"},
+		{`{1}`, Flags{}, "Nothing to repeat"},
+		{`[a`, Flags{}, "Unterminated character class"},
+		{`[z-a]`, Flags{}, "Range out of order in character class"},
+		{`a{3,1}`, Flags{}, "numbers out of order in {} quantifier"},
+		{`a\`, Flags{}, "\\ at end of pattern"},
+		{`(?<1a>x)`, Flags{}, "Invalid capture group name"},
+		{`(?<a>x)(?<a>y)`, Flags{}, "Duplicate capture group name"},
+		{`(?<a>x)\k<b>`, Flags{}, "Invalid named capture referenced"},
+		{`(?x)`, Flags{}, "Invalid group"},
+		{`(?<=a)*`, Flags{}, "Invalid quantifier"},
+		{`\p{Nope}`, Flags{Unicode: true}, "Invalid property name"},
+		{`\2(a)`, Flags{Unicode: true}, "Invalid escape"},
+		{`\q`, Flags{Unicode: true}, "Invalid escape"},
+		{`{`, Flags{Unicode: true}, "Lone quantifier brackets"},
+		{`[\d-z]`, Flags{Unicode: true}, "Invalid character class"},
+		{`\u{110000}`, Flags{Unicode: true}, "Invalid Unicode escape"},
+	}
+
+	for _, tt := range tests {
+		_, err := Compile(tt.pattern, tt.flags)
+		if err == nil {
+			t.Errorf("Compile(%q): expected error %q", tt.pattern, tt.expected)
+			continue
+		}
+		if err.Error() != tt.expected {
+			t.Errorf("Compile(%q): expected error %q, got %q", tt.pattern, tt.expected, err)
+		}
+	}
+}
+
+func TestParseFlags(t *testing.T) {
+	tests := []struct {
+		input    string
+		ok       bool
+		expected string
+	}{
+		{"", true, ""},
+		{"gimsuyd", true, "dgimsuy"},
+		{"yg", true, "gy"},
+		{"gg", false, ""},
+		{"x", false, ""},
+	}
+
+	for _, tt := range tests {
+		flags, ok := ParseFlags(tt.input)
+		if ok != tt.ok || flags.String() != tt.expected {
+			t.Errorf("ParseFlags(%q): expected %q (%v), got %q (%v)", tt.input, tt.expected, tt.ok, flags.String(), ok)
+		}
+	}
+}
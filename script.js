@@ -109,40 +109,55 @@ print("--- /BASICS ---");
 
 print("--- fetch/JSON ---");
 
+// fetch returns a promise; this file runs as a module, so it can await at
+// the top level
+
 print("=== GET List of Posts ===")
-let response = fetch("https://jsonplaceholder.typicode.com/posts")
-print("Status:", response.status)
-print("OK:", response.ok)
-print("Body length:", response.body)
+let response = await fetch("https://jsonplaceholder.typicode.com/posts")
+if (response.error) {
+    print("Error:", response.error)
+} else {
+    print("Status:", response.status)
+    print("OK:", response.ok)
+    print("Body length:", response.body.length)
+}
 print("")
 
 print("=== POST Request with JSON Body ===")
-let response2 = fetch("https://jsonplaceholder.typicode.com/posts", {
+let response2 = await fetch("https://jsonplaceholder.typicode.com/posts", {
     method: "POST",
     headers: {
         "Content-Type": "application/json"
     },
     body: JSON.stringify({"title": "My Post", "body": "This is a test post", "userId": 1})
 })
-print("Status:", response2.status)
-print("OK:", response2.ok)
-print("Body:", response2.body)
+if (response2.error) {
+    print("Error:", response2.error)
+} else {
+    print("Status:", response2.status)
+    print("OK:", response2.ok)
+    print("Body:", response2.body)
+}
 print("")
 
 print("=== Request with Custom Headers ===")
-let response3 = fetch("https://jsonplaceholder.typicode.com/posts/1", {
+let response3 = await fetch("https://jsonplaceholder.typicode.com/posts/1", {
     headers: {
         "Accept": "application/json",
         "User-Agent": "GoScript/1.0"
     }
 })
-print("Status:", response3.status)
-print("OK:", response3.ok)
-print("Response Headers:", response3.headers)
+if (response3.error) {
+    print("Error:", response3.error)
+} else {
+    print("Status:", response3.status)
+    print("OK:", response3.ok)
+    print("Response Headers:", response3.headers)
+}
 print("")
 
 print("=== Error Handling - Invalid URL ===")
-let response4 = fetch("not-a-valid-url")
+let response4 = await fetch("not-a-valid-url")
 if (response4.error) {
     print("Error:", response4.error)
 }
@@ -1,10 +1,34 @@
 package token
 
+import "strconv"
+
 type Type string
 
 type Token struct {
 	Type    Type
 	Literal string
+	Pos     Position // Where the token starts in the source
+}
+
+// Position is a place in the source code; both numbers start at 1, and the
+// column counts characters rather than bytes. The zero Position is unknown.
+//
+// Example: in "var x = y;" the token y is at line 1, column 9
+type Position struct {
+	Line   int
+	Column int
+}
+
+// IsValid reports whether the position is known
+func (p Position) IsValid() bool {
+	return p.Line > 0
+}
+
+// String writes the position as line:column
+//
+// Example: Position{Line: 3, Column: 5} → "3:5"
+func (p Position) String() string {
+	return strconv.Itoa(p.Line) + ":" + strconv.Itoa(p.Column)
 }
 
 const (
@@ -13,9 +37,11 @@ const (
 	ILLEGAL Type = "ILLEGAL"
 
 	// Identifiers and literals
-	IDENT  Type = "IDENT"
-	NUMBER Type = "NUMBER"
-	STRING Type = "STRING"
+	IDENT         Type = "IDENT"
+	PRIVATE_IDENT Type = "PRIVATE_IDENT" // #name inside class bodies
+	NUMBER        Type = "NUMBER"
+	STRING        Type = "STRING"
+	REGEXP        Type = "REGEXP" // /pattern/flags, Literal keeps both slashes and the flags
 
 	// Operators - used for mathematical and logical operations
 	ASSIGN Type = "="
@@ -26,6 +52,10 @@ const (
 	BANG   Type = "!"
 	DOT    Type = "."
 
+	QUESTION     Type = "?"  // conditional: cond ? a : b
+	QUESTION_DOT Type = "?." // optional chaining: a?.b, a?.[k], f?.()
+	ARROW        Type = "=>" // arrow functions: (a, b) => a + b
+
 	// Comparison operators - used for comparing values
 	EQ  Type = "=="
 	NEQ Type = "!="
@@ -46,15 +76,36 @@ const (
 	COLON     Type = ":"
 
 	// Keywords - reserved words with special meaning
-	VAR    Type = "var"
-	LET    Type = "let"
-	FUNC   Type = "function"
-	IF     Type = "if"
-	ELSE   Type = "else"
-	WHILE  Type = "while"
-	RETURN Type = "return"
-	TRUE   Type = "true"
-	FALSE  Type = "false"
+	VAR     Type = "var"
+	LET     Type = "let"
+	FUNC    Type = "function"
+	IF      Type = "if"
+	ELSE    Type = "else"
+	WHILE   Type = "while"
+	RETURN  Type = "return"
+	TRUE    Type = "true"
+	FALSE   Type = "false"
+	CLASS   Type = "class"
+	EXTENDS Type = "extends"
+	NEW     Type = "new"
+	THIS    Type = "this"
+	SUPER   Type = "super"
+	NULL    Type = "null"
+
+	FOR        Type = "for"
+	TYPEOF     Type = "typeof"
+	INSTANCEOF Type = "instanceof"
+
+	YIELD   Type = "yield"
+	THROW   Type = "throw"
+	TRY     Type = "try"
+	CATCH   Type = "catch"
+	FINALLY Type = "finally"
+
+	AWAIT Type = "await"
+
+	IMPORT Type = "import"
+	EXPORT Type = "export"
 )
 
 // Example: When the lexer sees "var", it checks this map and returns TokVar
@@ -70,6 +121,27 @@ var keywords = map[string]Type{
 	"return":   RETURN,
 	"true":     TRUE,
 	"false":    FALSE,
+	"class":    CLASS,
+	"extends":  EXTENDS,
+	"new":      NEW,
+	"this":     THIS,
+	"super":    SUPER,
+	"null":     NULL,
+
+	"for":        FOR,
+	"typeof":     TYPEOF,
+	"instanceof": INSTANCEOF,
+
+	"yield":   YIELD,
+	"throw":   THROW,
+	"try":     TRY,
+	"catch":   CATCH,
+	"finally": FINALLY,
+
+	"await": AWAIT,
+
+	"import": IMPORT,
+	"export": EXPORT,
 }
 
 // LookupIdent checks if an identifier is a keyword.
@@ -12,6 +12,7 @@ func TestTokenTypeConstants(t *testing.T) {
 		{IDENT, "IDENT"},
 		{NUMBER, "NUMBER"},
 		{STRING, "STRING"},
+		{REGEXP, "REGEXP"},
 		{ASSIGN, "="},
 		{PLUS, "+"},
 		{MINUS, "-"},
@@ -80,6 +81,23 @@ func TestLookupIdent_Keywords(t *testing.T) {
 		{"return", RETURN},
 		{"true", TRUE},
 		{"false", FALSE},
+		{"class", CLASS},
+		{"extends", EXTENDS},
+		{"new", NEW},
+		{"this", THIS},
+		{"super", SUPER},
+		{"null", NULL},
+		{"for", FOR},
+		{"typeof", TYPEOF},
+		{"instanceof", INSTANCEOF},
+		{"yield", YIELD},
+		{"throw", THROW},
+		{"try", TRY},
+		{"catch", CATCH},
+		{"finally", FINALLY},
+		{"await", AWAIT},
+		{"import", IMPORT},
+		{"export", EXPORT},
 	}
 
 	for _, tt := range tests {
@@ -137,6 +155,9 @@ func TestLookupIdent_CaseSensitive(t *testing.T) {
 func TestAllKeywordsInMap(t *testing.T) {
 	expectedKeywords := []string{
 		"var", "let", "function", "if", "else", "while", "return", "true", "false",
+		"class", "extends", "new", "this", "super", "null",
+		"for", "typeof", "instanceof",
+		"yield", "throw", "try", "catch", "finally",
 	}
 
 	for _, keyword := range expectedKeywords {
@@ -147,7 +168,7 @@ func TestAllKeywordsInMap(t *testing.T) {
 }
 
 func TestKeywordsMapSize(t *testing.T) {
-	expectedSize := 9 // var, let, function, if, else, while, return, true, false
+	expectedSize := 26 // var, let, function, if, else, while, return, true, false, class, extends, new, this, super, null, for, typeof, instanceof, yield, throw, try, catch, finally, await, import, export
 
 	if len(keywords) != expectedSize {
 		t.Errorf("Expected %d keywords in map, got %d", expectedSize, len(keywords))